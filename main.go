@@ -2,46 +2,124 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
-	"github.com/oracle/oci-go-sdk/v65/common/auth"
+	"oci-resource-dump/pkg/ocidump"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
 )
 
 // Global logger instance
-var logger *Logger
+var logger *ocidump.Logger
+
+// exitStatus is the exit status of a run that succeeded, e.g. --diff-exit-code when compared dumps differ
+var exitStatus int
+
+// logFormat is the record format of loggers created by setLogger (--log-format)
+var logFormat = ocidump.LogFormatText
+
+// setLogger replaces the CLI logger and the logger used by the ocidump package
+func setLogger(level ocidump.LogLevel) {
+	logger = ocidump.NewLogger(level)
+	logger.SetFormat(logFormat)
+	ocidump.SetLogger(logger)
+}
+
+// Discovery, output and diff analysis are implemented in pkg/ocidump
+
+// cliFlags holds the values of the root command flags
+type cliFlags struct {
+	// Basic options
+	timeoutSeconds int
+	logLevelStr    string
+	logFormatStr   string
+	outputFormat   string
+	showProgress   bool
+	noProgress     bool
+	outputFile     string
+	generateConfig bool
+	detail         bool
+	sortBy         string
+	tee            bool
+	summary        bool
+	stream         bool
+	query          string
+	csvDialect     ocidump.CSVDialect
+	daemon         bool
+	watch          time.Duration
+	checkpointFile string
+	resume         bool
+	snapshotName   string
+	mode           string
+	withCost       bool
+	withMetrics    bool
+	withTerraform  bool
+	tfstateFiles   string
+	auditFile      string
+	useCache       bool
+	noCache        bool
+	cacheTTL       int
+	rateLimit      float64
+	adaptive       bool
+	pageSize       int
+
+	// Filter options
+	compartments          string
+	excludeCompartments   string
+	compartmentSubtrees   string
+	resourceTypes         string
+	excludeResourceTypes  string
+	nameFilter            string
+	excludeNameFilter     string
+	nameGlob              string
+	excludeNameGlob       string
+	ignoreCase            bool
+	lifecycleStates       string
+	createdAfter          string
+	createdBefore         string
+	shapes                string
+	filterExpr            string
+	minSizeGB             float64
+	maxSizeGB             float64
+	whereConditions       []string
+	ocidFile              string
+	preset                string
+	skipEmptyCompartments bool
 
-// Output functions moved to output.go
+	// Diff analysis options
+	compareFiles     string
+	diffOutput       string
+	diffFormat       string
+	diffDetailed     bool
+	diffExitCode     int
+	diffIgnoreFields string
+	diffSummary      bool
+	diffTemplate     string
+	diffAgainst      string
+}
 
 func main() {
 	// Variables for CLI arguments
 	var (
-		// Basic options
-		timeoutSeconds int
-		logLevelStr    string
-		outputFormat   string
-		showProgress   bool
-		noProgress     bool
-		outputFile     string
-		generateConfig bool
-
-		// Filter options
-		compartments         string
-		excludeCompartments  string
-		resourceTypes        string
-		excludeResourceTypes string
-		nameFilter           string
-		excludeNameFilter    string
-
-		// Diff analysis options
-		compareFiles string
-		diffOutput   string
-		diffFormat   string
-		diffDetailed bool
+		flags cliFlags
+
+		// Profiling options (all commands)
+		profileConfig ocidump.ProfileConfig
 	)
 
 	var rootCmd = &cobra.Command{
@@ -55,66 +133,202 @@ and discovers various types of resources, outputting their details in JSON, CSV,
 The tool supports filtering by compartments, resource types, and name patterns,
 as well as diff analysis between two resource dumps.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runMainLogic(timeoutSeconds, logLevelStr, outputFormat, showProgress, noProgress,
-				outputFile, generateConfig, compartments, excludeCompartments, resourceTypes,
-				excludeResourceTypes, nameFilter, excludeNameFilter, compareFiles, diffOutput,
-				diffFormat, diffDetailed)
+			return runMainLogic(flags)
 		},
 	}
 
 	// Basic Options
-	rootCmd.Flags().IntVarP(&timeoutSeconds, "timeout", "t", -1, "Timeout in seconds for the entire operation")
-	rootCmd.Flags().StringVarP(&logLevelStr, "log-level", "l", "NOT_SET", "Log level: silent, normal, verbose, debug")
-	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "NOT_SET", "Output format: csv, tsv, or json")
-	rootCmd.Flags().BoolVar(&showProgress, "progress", true, "Show progress bar with real-time statistics (default behavior)")
-	rootCmd.Flags().BoolVar(&noProgress, "no-progress", false, "Disable progress bar")
-	rootCmd.Flags().StringVarP(&outputFile, "output-file", "o", "NOT_SET", "Output file path (default: stdout)")
-	rootCmd.Flags().BoolVar(&generateConfig, "generate-config", false, "Generate default configuration file")
+	rootCmd.Flags().IntVarP(&flags.timeoutSeconds, "timeout", "t", -1, "Timeout in seconds for the entire operation")
+	rootCmd.Flags().StringVarP(&flags.logLevelStr, "log-level", "l", "NOT_SET", "Log level: silent, normal, verbose, debug")
+	rootCmd.Flags().StringVar(&flags.logFormatStr, "log-format", "", "Log format: text or json (one JSON record per line for log ingestion)")
+	rootCmd.Flags().StringVarP(&flags.outputFormat, "format", "f", "NOT_SET", "Output format: csv, tsv, json, ndjson, openmetrics, dot, or mermaid")
+	rootCmd.Flags().BoolVar(&flags.showProgress, "progress", true, "Show progress bar with real-time statistics (default behavior)")
+	rootCmd.Flags().BoolVar(&flags.noProgress, "no-progress", false, "Disable progress bar")
+	rootCmd.Flags().StringVarP(&flags.outputFile, "output-file", "o", "NOT_SET", "Output file path, '-' for stdout (default: stdout)")
+	rootCmd.Flags().BoolVar(&flags.generateConfig, "generate-config", false, "Generate default configuration file")
+	rootCmd.Flags().StringVar(&flags.mode, "mode", "", "Discovery mode: full (per-service API calls) or search (Resource Search, faster with less detail)")
+	rootCmd.Flags().BoolVar(&flags.detail, "detail", false, "Fetch per-resource details that require additional API calls")
+	rootCmd.Flags().BoolVar(&flags.withCost, "with-cost", false, "Attach the cost reported by the Usage API (last 30 days by default) to each resource")
+	rootCmd.Flags().BoolVar(&flags.withMetrics, "with-metrics", false, "Add CPU, memory and storage metrics from the Monitoring API (last 7 days by default)")
+	rootCmd.Flags().BoolVar(&flags.withTerraform, "with-terraform", false, "Mark resources with managed_by=terraform|manual using Resource Manager stack states")
+	rootCmd.Flags().StringVar(&flags.tfstateFiles, "tfstate", "", "Comma-separated local Terraform state files for managed_by (implies --with-terraform)")
+	rootCmd.Flags().BoolVar(&flags.useCache, "cache", false, "Reuse results of each compartment and resource type discovered within the cache TTL")
+	rootCmd.Flags().BoolVar(&flags.noCache, "no-cache", false, "Disable the result and compartment caches enabled in the configuration file")
+	rootCmd.Flags().IntVar(&flags.cacheTTL, "cache-ttl", 0, "Seconds cached results are reused (default: 300)")
+	rootCmd.Flags().Float64Var(&flags.rateLimit, "rate-limit", 0, "Maximum OCI API requests per second per service (default: unlimited)")
+	rootCmd.Flags().IntVar(&flags.pageSize, "page-size", 0, "Items requested per page of OCI list calls (default: 1000, capped at each API's maximum)")
+	rootCmd.Flags().BoolVar(&flags.adaptive, "adaptive-concurrency", false, "Reduce concurrent OCI API requests per service on 429 responses and ramp back up")
+	rootCmd.Flags().StringVar(&flags.auditFile, "audit-file", "", "Append a JSON record of this run (parameters, duration, counts, caller) to this file")
+	rootCmd.Flags().BoolVar(&flags.tee, "tee", false, "Write output to stdout as well as to --output-file")
+	rootCmd.Flags().BoolVar(&flags.stream, "stream", false, "Write json, csv and tsv output while discovery is running to bound memory usage (ndjson is always streamed)")
+	rootCmd.Flags().BoolVar(&flags.summary, "summary", false, "Output resource counts per type, compartment and region instead of the resource list")
+	rootCmd.Flags().StringVar(&flags.query, "query", "", "JMESPath expression applied to the resource array (json format only)")
+	rootCmd.Flags().StringVar(&flags.csvDialect.Delimiter, "csv-delimiter", "", "CSV field delimiter: a single character or 'tab' (default: ,)")
+	rootCmd.Flags().StringVar(&flags.csvDialect.Quote, "csv-quote", "", "CSV quoting style: minimal or all (default: minimal)")
+	rootCmd.Flags().BoolVar(&flags.csvDialect.BOM, "csv-bom", false, "Prepend a UTF-8 byte order mark to CSV output (for Excel)")
+	rootCmd.Flags().BoolVar(&flags.csvDialect.CRLF, "csv-crlf", false, "Use CRLF line endings in CSV output")
+	rootCmd.Flags().BoolVar(&flags.daemon, "daemon", false, "Run discovery on the cron schedule from the daemon section of the configuration file")
+	rootCmd.Flags().DurationVar(&flags.watch, "watch", 0, "Re-run discovery at this interval (e.g. 1h) and report the changes since the previous run")
+	rootCmd.Flags().StringVar(&flags.checkpointFile, "checkpoint-file", "", "Record discovery progress to this file so an interrupted run can be resumed")
+	rootCmd.Flags().BoolVar(&flags.resume, "resume", false, "Continue the discovery recorded in the checkpoint file instead of starting over")
+	rootCmd.Flags().StringVar(&flags.snapshotName, "snapshot-name", "", "Record this run in the history store under the given name")
+	rootCmd.Flags().StringVar(&flags.sortBy, "sort-by", "", "Comma-separated sort keys: resource_type, compartment_name, name, ocid, lifecycle_state, time_created")
 
 	// Filtering Options
-	rootCmd.Flags().StringVar(&compartments, "compartments", "", "Comma-separated list of compartment OCIDs to include")
-	rootCmd.Flags().StringVar(&excludeCompartments, "exclude-compartments", "", "Comma-separated list of compartment OCIDs to exclude")
-	rootCmd.Flags().StringVar(&resourceTypes, "resource-types", "", "Comma-separated list of resource types to include")
-	rootCmd.Flags().StringVar(&excludeResourceTypes, "exclude-resource-types", "", "Comma-separated list of resource types to exclude")
-	rootCmd.Flags().StringVar(&nameFilter, "name-filter", "", "Regex pattern for resource names to include")
-	rootCmd.Flags().StringVar(&excludeNameFilter, "exclude-name-filter", "", "Regex pattern for resource names to exclude")
+	rootCmd.Flags().StringVar(&flags.compartments, "compartments", "", "Comma-separated list of compartment OCIDs, names or glob patterns (e.g. prod-*) to include")
+	rootCmd.Flags().StringVar(&flags.excludeCompartments, "exclude-compartments", "", "Comma-separated list of compartment OCIDs, names or glob patterns to exclude")
+	rootCmd.Flags().StringVar(&flags.compartmentSubtrees, "compartment-subtree", "", "Comma-separated list of compartment OCIDs or names to include with all their descendants")
+	rootCmd.Flags().StringVar(&flags.resourceTypes, "resource-types", "", "Comma-separated list of resource types or groups (compute, network, storage, database, serverless, ...) to include")
+	rootCmd.Flags().StringVar(&flags.excludeResourceTypes, "exclude-resource-types", "", "Comma-separated list of resource types or groups to exclude")
+	rootCmd.Flags().StringVar(&flags.nameFilter, "name-filter", "", "Regex pattern for resource names to include")
+	rootCmd.Flags().StringVar(&flags.excludeNameFilter, "exclude-name-filter", "", "Regex pattern for resource names to exclude")
+	rootCmd.Flags().StringVar(&flags.nameGlob, "name-glob", "", "Glob pattern for resource names to include (e.g. 'prod-*')")
+	rootCmd.Flags().StringVar(&flags.excludeNameGlob, "exclude-name-glob", "", "Glob pattern for resource names to exclude")
+	rootCmd.Flags().BoolVar(&flags.ignoreCase, "ignore-case", false, "Match name filters and globs case-insensitively")
+	rootCmd.Flags().StringVar(&flags.lifecycleStates, "lifecycle-states", "", "Comma-separated list of lifecycle states to include (e.g. AVAILABLE,STOPPED)")
+	rootCmd.Flags().StringVar(&flags.createdAfter, "created-after", "", "Include resources created at or after this time (RFC3339 or YYYY-MM-DD)")
+	rootCmd.Flags().StringVar(&flags.createdBefore, "created-before", "", "Include resources created before this time (RFC3339 or YYYY-MM-DD)")
+	rootCmd.Flags().StringVar(&flags.shapes, "shapes", "", "Comma-separated list of shape patterns to include (e.g. VM.Standard.E4.*,VM.Standard2.*)")
+	rootCmd.Flags().Float64Var(&flags.minSizeGB, "min-size-gb", 0, "Include only storage resources of at least this size in GB (volumes, backups, file systems, autonomous databases)")
+	rootCmd.Flags().Float64Var(&flags.maxSizeGB, "max-size-gb", 0, "Include only storage resources of at most this size in GB")
+	rootCmd.Flags().StringArrayVar(&flags.whereConditions, "where", nil, "Include only resources whose additional info has this key=value (repeatable, e.g. kubernetes_version=v1.27.2)")
+	rootCmd.Flags().StringVar(&flags.ocidFile, "ocid-file", "", "Include only resources whose OCID is listed in this file (one per line)")
+	rootCmd.Flags().BoolVar(&flags.skipEmptyCompartments, "skip-empty-compartments", false, "Show per-compartment progress bars only for compartments with resources")
+	rootCmd.Flags().StringVar(&flags.preset, "preset", "", "Use the named filter preset from the presets section of the configuration file")
+	rootCmd.Flags().StringVar(&flags.filterExpr, "filter-expr", "", "JMESPath expression each resource must satisfy (e.g. \"additional_info.size_in_gbs > `500`\")")
 
 	// Diff Analysis Options
-	rootCmd.Flags().StringVar(&compareFiles, "compare-files", "", "Comma-separated pair of JSON files to compare (old,new)")
-	rootCmd.Flags().StringVar(&diffOutput, "diff-output", "", "Output file for diff analysis (default: stdout)")
-	rootCmd.Flags().StringVar(&diffFormat, "diff-format", "json", "Diff output format: json, text")
-	rootCmd.Flags().BoolVar(&diffDetailed, "diff-detailed", false, "Include unchanged resources in diff output")
+	rootCmd.Flags().StringVar(&flags.compareFiles, "compare-files", "", "Comma-separated pair of dump files (JSON, CSV or TSV) to compare (old,new); exits with --diff-exit-code when they differ")
+	rootCmd.Flags().StringVar(&flags.diffAgainst, "diff-against", "", "Run discovery and compare the results against this baseline dump (JSON, CSV or TSV); exits with --diff-exit-code when they differ")
+	rootCmd.Flags().StringVar(&flags.diffOutput, "diff-output", "", "Output file for diff analysis, '-' for stdout (default: stdout)")
+	rootCmd.Flags().StringVar(&flags.diffFormat, "diff-format", "json", "Diff output format: json, text, html, markdown, csv, tsv")
+	rootCmd.Flags().BoolVar(&flags.diffDetailed, "diff-detailed", false, "Include unchanged resources in diff output")
+	rootCmd.Flags().StringVar(&flags.diffIgnoreFields, "diff-ignore-fields", "", "Comma-separated fields whose changes are ignored (e.g. primary_ip,FreeformTags.*)")
+	rootCmd.Flags().BoolVar(&flags.diffSummary, "diff-summary", false, "Output only the summary counts per resource type, without the resource listings")
+	rootCmd.Flags().StringVar(&flags.diffTemplate, "diff-template", "", "Render the diff with this Go template file instead of --diff-format")
+	rootCmd.Flags().IntVar(&flags.diffExitCode, "diff-exit-code", 2, "Exit status of --compare-files and --diff-against when the resources differ (0 = always exit 0; 1 is reserved for errors)")
 
 	// Configuration Options - separate group
 	// (generateConfig is already defined above)
 
+	// Profiling Options - persistent so long serve and daemon runs can be profiled as well
+	rootCmd.PersistentFlags().StringVar(&profileConfig.PprofAddr, "pprof-addr", "", "Serve pprof endpoints under /debug/pprof/ on this address (e.g. localhost:6060)")
+	rootCmd.PersistentFlags().StringVar(&profileConfig.CPUFile, "profile-cpu", "", "Write a CPU profile of the run to this file")
+	rootCmd.PersistentFlags().StringVar(&profileConfig.MemFile, "profile-mem", "", "Write a heap profile to this file when the run ends")
+
+	var profiler *ocidump.Profiler
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		var err error
+		profiler, err = ocidump.StartProfiling(profileConfig)
+		return err
+	}
+
+	// Subcommands
+	rootCmd.AddCommand(newCompareTfstateCommand())
+	rootCmd.AddCommand(newValidateCommand())
+	rootCmd.AddCommand(newGraphCommand())
+	rootCmd.AddCommand(newQueryCommand())
+	rootCmd.AddCommand(newBrowseCommand())
+	rootCmd.AddCommand(newServeCommand())
+	rootCmd.AddCommand(newHistoryCommand())
+	rootCmd.AddCommand(newDiffCommand())
+	rootCmd.AddCommand(newTypesCommand())
+	rootCmd.AddCommand(newConfigCommand())
+
 	// Group annotations for better help display
 	rootCmd.Flags().SetAnnotation("timeout", "group", []string{"basic"})
 	rootCmd.Flags().SetAnnotation("log-level", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("log-format", "group", []string{"basic"})
 	rootCmd.Flags().SetAnnotation("format", "group", []string{"basic"})
 	rootCmd.Flags().SetAnnotation("progress", "group", []string{"basic"})
 	rootCmd.Flags().SetAnnotation("no-progress", "group", []string{"basic"})
 	rootCmd.Flags().SetAnnotation("output-file", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("mode", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("detail", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("with-cost", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("with-metrics", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("with-terraform", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("tfstate", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("tee", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("summary", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("stream", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("sort-by", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("query", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("csv-delimiter", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("csv-quote", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("csv-bom", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("csv-crlf", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("daemon", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("watch", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("checkpoint-file", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("resume", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("snapshot-name", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("audit-file", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("cache", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("no-cache", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("cache-ttl", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("rate-limit", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("adaptive-concurrency", "group", []string{"basic"})
+	rootCmd.Flags().SetAnnotation("page-size", "group", []string{"basic"})
 
 	rootCmd.Flags().SetAnnotation("compartments", "group", []string{"filtering"})
 	rootCmd.Flags().SetAnnotation("exclude-compartments", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("compartment-subtree", "group", []string{"filtering"})
 	rootCmd.Flags().SetAnnotation("resource-types", "group", []string{"filtering"})
 	rootCmd.Flags().SetAnnotation("exclude-resource-types", "group", []string{"filtering"})
 	rootCmd.Flags().SetAnnotation("name-filter", "group", []string{"filtering"})
 	rootCmd.Flags().SetAnnotation("exclude-name-filter", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("name-glob", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("exclude-name-glob", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("ignore-case", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("lifecycle-states", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("created-after", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("created-before", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("shapes", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("min-size-gb", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("max-size-gb", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("where", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("ocid-file", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("skip-empty-compartments", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("preset", "group", []string{"filtering"})
+	rootCmd.Flags().SetAnnotation("filter-expr", "group", []string{"filtering"})
 
 	rootCmd.Flags().SetAnnotation("compare-files", "group", []string{"diff"})
+	rootCmd.Flags().SetAnnotation("diff-against", "group", []string{"diff"})
 	rootCmd.Flags().SetAnnotation("diff-output", "group", []string{"diff"})
 	rootCmd.Flags().SetAnnotation("diff-format", "group", []string{"diff"})
 	rootCmd.Flags().SetAnnotation("diff-detailed", "group", []string{"diff"})
+	rootCmd.Flags().SetAnnotation("diff-ignore-fields", "group", []string{"diff"})
+	rootCmd.Flags().SetAnnotation("diff-summary", "group", []string{"diff"})
+	rootCmd.Flags().SetAnnotation("diff-template", "group", []string{"diff"})
+	rootCmd.Flags().SetAnnotation("diff-exit-code", "group", []string{"diff"})
 
 	rootCmd.Flags().SetAnnotation("generate-config", "group", []string{"config"})
 
-	// Custom help function to group flags
+	rootCmd.PersistentFlags().SetAnnotation("pprof-addr", "group", []string{"profiling"})
+	rootCmd.PersistentFlags().SetAnnotation("profile-cpu", "group", []string{"profiling"})
+	rootCmd.PersistentFlags().SetAnnotation("profile-mem", "group", []string{"profiling"})
+
+	// Custom help function to group flags; subcommands keep the default help
+	defaultHelpFunc := rootCmd.HelpFunc()
 	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		if cmd != rootCmd {
+			defaultHelpFunc(cmd, args)
+			return
+		}
+
 		fmt.Printf("%s\n\n", cmd.Short)
 		fmt.Printf("%s\n\n", cmd.Long)
-		fmt.Printf("Usage:\n  %s [flags]\n\n", cmd.Use)
+		fmt.Printf("Usage:\n  %s [flags]\n  %s [command]\n\n", cmd.Use, cmd.Use)
+
+		// Commands
+		fmt.Printf("COMMANDS:\n")
+		for _, subCmd := range cmd.Commands() {
+			if subCmd.IsAvailableCommand() {
+				fmt.Printf("  %-26s %s\n", subCmd.Name(), subCmd.Short)
+			}
+		}
+		fmt.Printf("\n")
 
 		// Basic Options
 		fmt.Printf("BASIC OPTIONS:\n")
@@ -164,240 +378,1762 @@ as well as diff analysis between two resource dumps.`,
 			}
 		})
 
+		// Profiling Options
+		fmt.Printf("\nPROFILING OPTIONS:\n")
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if annotations, ok := flag.Annotations["group"]; ok && len(annotations) > 0 && annotations[0] == "profiling" {
+				fmt.Printf("      --%-20s %s\n", flag.Name, flag.Usage)
+			}
+		})
+
 		fmt.Printf("\nEXAMPLES:\n")
 		fmt.Printf("  # Basic usage with CSV output\n")
 		fmt.Printf("  %s --format csv\n\n", cmd.Use)
+		fmt.Printf("  # Fast inventory using the Resource Search service\n")
+		fmt.Printf("  %s --mode search --format csv\n\n", cmd.Use)
 		fmt.Printf("  # Filter specific compartments with progress\n")
-		fmt.Printf("  %s --compartments ocid1.compartment.oc1..prod --progress\n\n", cmd.Use)
+		fmt.Printf("  %s --compartments ocid1.compartment.oc1..prod --progress\n", cmd.Use)
+		fmt.Printf("  %s --compartments 'prod-*' --exclude-compartments prod-sandbox\n\n", cmd.Use)
 		fmt.Printf("  # Compare two resource dumps\n")
 		fmt.Printf("  %s --compare-files old.json,new.json --diff-format text\n\n", cmd.Use)
+		fmt.Printf("  # Find resources not managed by Terraform\n")
+		fmt.Printf("  %s compare-tfstate resources.json terraform.tfstate --diff-format text\n\n", cmd.Use)
+		fmt.Printf("  # Validate a dump file against the published JSON Schema\n")
+		fmt.Printf("  %s validate resources.json\n\n", cmd.Use)
+		fmt.Printf("  # Render a dependency graph of a dump for Graphviz\n")
+		fmt.Printf("  %s graph resources.json --connected-only | dot -Tsvg -o resources.svg\n\n", cmd.Use)
+		fmt.Printf("  # Count instances per shape per compartment with SQL\n")
+		fmt.Printf("  %s query \"SELECT compartment_name, additional_info->>'shape' AS shape, count(*) FROM resources WHERE resource_type = 'ComputeInstance' GROUP BY 1, 2\" resources.json\n\n", cmd.Use)
+		fmt.Printf("  # Browse a dump interactively\n")
+		fmt.Printf("  %s browse resources.json\n\n", cmd.Use)
+		fmt.Printf("  # Serve the inventory over a REST API\n")
+		fmt.Printf("  %s serve --listen :8080\n\n", cmd.Use)
+		fmt.Printf("  # Write a timestamped dump on the configured cron schedule\n")
+		fmt.Printf("  %s --daemon\n\n", cmd.Use)
+		fmt.Printf("  # Re-run discovery every hour and report what changed\n")
+		fmt.Printf("  %s --watch 1h --diff-format text --diff-output changes.txt\n\n", cmd.Use)
+		fmt.Printf("  # Compare the latest run with an earlier snapshot from the history store\n")
+		fmt.Printf("  %s diff --from 3 --to latest --diff-format text\n\n", cmd.Use)
 		fmt.Printf("  # Generate configuration file\n")
 		fmt.Printf("  %s --generate-config\n", cmd.Use)
 	})
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	// Profiles cover the whole run, including runs that failed
+	if stopErr := profiler.Stop(); stopErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", stopErr)
+		err = errors.Join(err, stopErr)
+	}
+	if err != nil {
 		os.Exit(1)
 	}
+	if exitStatus != 0 {
+		os.Exit(exitStatus)
+	}
 }
 
-func runMainLogic(timeoutSeconds int, logLevelStr, outputFormat string, showProgress, noProgress bool,
-	outputFile string, generateConfig bool, compartments, excludeCompartments, resourceTypes,
-	excludeResourceTypes, nameFilter, excludeNameFilter, compareFiles, diffOutput,
-	diffFormat string, diffDetailed bool) error {
+// newCompareTfstateCommand creates the subcommand comparing a resource dump with a Terraform state file
+func newCompareTfstateCommand() *cobra.Command {
+	var (
+		diffOutput   string
+		diffFormat   string
+		diffDetailed bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compare-tfstate <dump.json> <terraform.tfstate>",
+		Short: "Compare a resource dump with a Terraform state file",
+		Long: `Compare a JSON resource dump with a Terraform state file (version 4).
 
-	// Handle configuration file generation
-	if generateConfig {
-		if err := GenerateDefaultConfigFile("oci-resource-dump.yaml"); err != nil {
-			return fmt.Errorf("error generating configuration file: %v", err)
-		}
-		fmt.Println("Default configuration file generated: oci-resource-dump.yaml")
-		return nil
+Resources present in the tenancy but missing from the state are reported as added,
+and resources present in the state but missing from the tenancy as removed.
+Use --diff-detailed to also list resources managed by Terraform.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			setLogger(ocidump.LogLevelNormal)
+
+			diffConfig := ocidump.DiffConfig{
+				Format:     diffFormat,
+				Detailed:   diffDetailed,
+				OutputFile: diffOutput,
+			}
+
+			result, err := ocidump.CompareTerraformState(args[0], args[1], diffConfig)
+			if err != nil {
+				return fmt.Errorf("error comparing Terraform state: %v", err)
+			}
+
+			if err := ocidump.OutputDiffResult(result, diffConfig); err != nil {
+				return fmt.Errorf("error outputting diff results: %v", err)
+			}
+
+			return nil
+		},
 	}
 
-	// Phase 2C: Handle diff analysis mode
-	if compareFiles != "" {
-		// Initialize logger for diff mode
-		logger = NewLogger(LogLevelNormal)
+	cmd.Flags().StringVar(&diffOutput, "diff-output", "", "Output file for diff analysis, '-' for stdout (default: stdout)")
+	cmd.Flags().StringVar(&diffFormat, "diff-format", "json", "Diff output format: json, text, html, markdown, csv, tsv")
+	cmd.Flags().BoolVar(&diffDetailed, "diff-detailed", false, "Include resources managed by Terraform in diff output")
 
-		files := strings.Split(compareFiles, ",")
-		if len(files) != 2 {
-			return fmt.Errorf("--compare-files requires exactly 2 files separated by comma\nExample: --compare-files old.json,new.json")
-		}
+	return cmd
+}
 
-		oldFile := strings.TrimSpace(files[0])
-		newFile := strings.TrimSpace(files[1])
+// newValidateCommand creates the subcommand validating dump files against the JSON Schema
+func newValidateCommand() *cobra.Command {
+	var printSchema bool
 
-		// Configure diff settings
-		diffConfig := DiffConfig{
-			Format:     diffFormat,
-			Detailed:   diffDetailed,
-			OutputFile: diffOutput,
-		}
+	cmd := &cobra.Command{
+		Use:   "validate [file...]",
+		Short: "Validate JSON dump files against the dump schema",
+		Long: `Validate JSON dump files against the published JSON Schema of the dump format.
 
-		// Perform diff analysis
-		result, err := CompareDumps(oldFile, newFile, diffConfig)
-		if err != nil {
-			return fmt.Errorf("error performing diff analysis: %v", err)
-		}
+Each file is reported as valid or with the location of every mismatch.
+Use --print-schema to print the JSON Schema itself.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if printSchema {
+				_, err := os.Stdout.Write(ocidump.DumpSchema())
+				return err
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("validate requires at least one file")
+			}
 
-		// Output results
-		if err := OutputDiffResult(result, diffConfig); err != nil {
-			return fmt.Errorf("error outputting diff results: %v", err)
-		}
+			failed := 0
+			for _, file := range args {
+				if err := ocidump.ValidateDumpFile(file); err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+					failed++
+					continue
+				}
+				fmt.Printf("%s: valid\n", file)
+			}
 
-		return nil
+			if failed > 0 {
+				return fmt.Errorf("%d of %d files failed validation", failed, len(args))
+			}
+			return nil
+		},
 	}
 
-	// Initialize temporary logger for configuration loading
-	logger = NewLogger(LogLevelNormal)
+	cmd.Flags().BoolVar(&printSchema, "print-schema", false, "Print the JSON Schema of the dump format")
 
-	// Load configuration from file
-	appConfig, err := LoadConfig()
-	if err != nil {
-		return fmt.Errorf("error loading configuration: %v", err)
-	}
+	return cmd
+}
 
-	// Create CLI argument pointers to match the expected interface
-	var finalTimeout *int
-	var finalLogLevel *string
-	var finalFormat *string
-	var finalOutputFile *string
+// newGraphCommand creates the subcommand rendering the dependency graph of a dump file
+func newGraphCommand() *cobra.Command {
+	var (
+		graphFormat   string
+		outputFile    string
+		connectedOnly bool
+	)
 
-	if timeoutSeconds != -1 {
-		finalTimeout = &timeoutSeconds
-	}
-	if logLevelStr != "NOT_SET" {
-		finalLogLevel = &logLevelStr
-	}
-	if outputFormat != "NOT_SET" {
-		finalFormat = &outputFormat
-	}
-	if outputFile != "NOT_SET" {
-		finalOutputFile = &outputFile
-	}
+	cmd := &cobra.Command{
+		Use:   "graph <dump.json>",
+		Short: "Render the resource dependency graph of a dump as Graphviz DOT or Mermaid",
+		Long: `Render the resources of a JSON dump as a dependency graph for architecture diagrams.
 
-	// Progress flags handling: only explicit flags override config
-	var finalProgress *bool
-	if noProgress {
-		finalProgress = func() *bool { b := false; return &b }() // explicit --no-progress
-	} else if showProgress {
-		finalProgress = func() *bool { b := true; return &b }() // explicit --progress
-	} else {
-		finalProgress = nil // not specified, don't override config
-	}
+Resources are nodes grouped by compartment, and references between them
+(instance to subnet, subnet to VCN, DB node to DB system, load balancer to subnets, ...)
+are edges. Use --connected-only to leave out resources without any relationship.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			graphFormat = strings.ToLower(graphFormat)
+			if !ocidump.IsGraphFormat(graphFormat) {
+				return fmt.Errorf("invalid graph format '%s'. Valid formats are: %s", graphFormat, strings.Join(ocidump.GraphFormats, ", "))
+			}
 
-	// Merge CLI arguments with configuration file (CLI has higher priority)
-	MergeWithCLIArgs(appConfig, finalTimeout, finalLogLevel, finalFormat, finalProgress, finalOutputFile)
+			resources, err := ocidump.LoadResourcesFromFile(args[0])
+			if err != nil {
+				return err
+			}
 
-	// Phase 2B: Parse and merge filter arguments
-	if compartments != "" {
-		appConfig.Filters.IncludeCompartments = ParseCompartmentList(compartments)
-	}
-	if excludeCompartments != "" {
-		appConfig.Filters.ExcludeCompartments = ParseCompartmentList(excludeCompartments)
-	}
-	if resourceTypes != "" {
-		appConfig.Filters.IncludeResourceTypes = ParseResourceTypeList(resourceTypes)
-	}
-	if excludeResourceTypes != "" {
-		appConfig.Filters.ExcludeResourceTypes = ParseResourceTypeList(excludeResourceTypes)
-	}
-	if nameFilter != "" {
-		appConfig.Filters.NamePattern = nameFilter
-	}
-	if excludeNameFilter != "" {
-		appConfig.Filters.ExcludeNamePattern = excludeNameFilter
+			return ocidump.OutputGraph(resources, graphFormat, outputFile, connectedOnly)
+		},
 	}
 
-	// Validate filter configuration
-	if err := ValidateFilterConfig(appConfig.Filters); err != nil {
-		return fmt.Errorf("invalid filter configuration: %v", err)
-	}
+	cmd.Flags().StringVarP(&graphFormat, "format", "f", "dot", "Graph format: dot, mermaid")
+	cmd.Flags().StringVarP(&outputFile, "output-file", "o", "", "Output file path, '-' for stdout (default: stdout)")
+	cmd.Flags().BoolVar(&connectedOnly, "connected-only", false, "Leave out resources without any relationship")
 
-	// Convert AppConfig to runtime Config
-	config := &Config{}
-	config.Timeout = time.Duration(appConfig.General.Timeout) * time.Second
-	config.OutputFormat = strings.ToLower(appConfig.General.OutputFormat)
-	config.Filters = appConfig.Filters
+	return cmd
+}
 
-	// Parse and validate log level
-	logLevel, err := ParseLogLevel(appConfig.General.LogLevel)
-	if err != nil {
-		return fmt.Errorf("invalid log level: %v", err)
-	}
-	config.LogLevel = logLevel
+// newQueryCommand creates the subcommand running SQL over the resources of dump files
+func newQueryCommand() *cobra.Command {
+	var (
+		resultFormat string
+		outputFile   string
+	)
 
-	// Configure progress bar - from config file or CLI
-	config.ShowProgress = appConfig.General.Progress
-	
-	// CLI flags override config file
-	if showProgress {
-		config.ShowProgress = true
-	}
-	if noProgress {
-		config.ShowProgress = false
+	cmd := &cobra.Command{
+		Use:   "query <sql> <dump.json>...",
+		Short: "Run a SQL SELECT statement over the resources of dump files",
+		Long: fmt.Sprintf(`Run a SQL SELECT statement over the resources of one or more JSON dumps
+for ad-hoc questions such as the number of instances per shape per compartment.
+
+The resources of all dumps are rows of the table %s with the columns:
+
+  %s
+
+dump is the dump file a resource was loaded from. additional_info, freeform_tags,
+defined_tags and cost hold JSON text, read with the ->> operator or json_extract(),
+e.g. additional_info->>'shape' or defined_tags->>'$.Operations.CostCenter'.
+Missing values are NULL, including empty tags and a resource without cost.
+
+The statement is run by SQLite on an in-memory copy of the dumps, so any single
+SELECT statement is supported, including joins, subqueries and window functions.`, ocidump.SQLTableName, strings.Join(ocidump.SQLColumns, ", ")),
+		Args:         cobra.MinimumNArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resultFormat = strings.ToLower(resultFormat)
+			if !ocidump.IsSQLResultFormat(resultFormat) {
+				return fmt.Errorf("invalid result format '%s'. Valid formats are: %s", resultFormat, strings.Join(ocidump.SQLResultFormats, ", "))
+			}
+
+			query, err := ocidump.CompileSQL(args[0])
+			if err != nil {
+				return err
+			}
+
+			var dumps []ocidump.SQLDump
+			for _, filename := range args[1:] {
+				resources, err := ocidump.LoadResourcesFromFile(filename)
+				if err != nil {
+					return fmt.Errorf("error loading %s: %v", filename, err)
+				}
+				dumps = append(dumps, ocidump.SQLDump{Name: filename, Resources: resources})
+			}
+
+			result, err := query.Run(dumps)
+			if err != nil {
+				return fmt.Errorf("error running query: %v", err)
+			}
+			return ocidump.OutputSQLResult(result, resultFormat, outputFile)
+		},
 	}
 
-	// Re-initialize logger with final log level
-	logger = NewLogger(logLevel)
-	config.Logger = logger
+	cmd.Flags().StringVarP(&resultFormat, "format", "f", "table", "Result format: table, csv, json")
+	cmd.Flags().StringVarP(&outputFile, "output-file", "o", "", "Output file path, '-' for stdout (default: stdout)")
 
-	// Progress tracking is now handled directly in discovery.go with uiprogress
+	return cmd
+}
 
-	// Validate output format
-	validFormats := []string{"csv", "tsv", "json"}
-	config.OutputFormat = strings.ToLower(config.OutputFormat)
+// newBrowseCommand creates the subcommand browsing the resources of a dump interactively
+func newBrowseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "browse [dump.json]",
+		Short: "Browse the resources of a dump interactively",
+		Long: `Browse the resources of a JSON dump in the terminal, for quick investigations
+without exporting files.
 
-	isValid := false
-	for _, format := range validFormats {
-		if config.OutputFormat == format {
-			isValid = true
-			break
-		}
+Navigate compartments, resource types and resources, and view all fields of a resource
+including its additional info and tags. Move with the arrow keys (or j/k), open an entry
+with enter, go back with esc, type / to search names, OCIDs and additional info as you
+type, and quit with q.
+
+When stdin or stdout is not a terminal, commands are read a line at a time instead: the
+number of an entry opens it, b goes back, n/p page, /text searches and q quits.
+
+Without a dump file, a discovery is run first with the timeout, filters and detail mode
+of the configuration file.`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resources []ocidump.Resource
+			var err error
+			if len(args) == 1 {
+				resources, err = ocidump.LoadResourcesFromFile(args[0])
+			} else {
+				resources, err = discoverForBrowse()
+			}
+			if err != nil {
+				return err
+			}
+
+			// Use the full-screen UI only when a user is watching the terminal
+			browser := ocidump.NewBrowser(resources, os.Stdin, os.Stdout, false)
+			if isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+				return browser.RunTUI()
+			}
+			return browser.Run()
+		},
 	}
+}
 
-	if !isValid {
-		return fmt.Errorf("invalid output format '%s'. Valid formats are: csv, tsv, json", config.OutputFormat)
+// discoverForBrowse runs a discovery with the settings of the configuration file
+func discoverForBrowse() ([]ocidump.Resource, error) {
+	setLogger(ocidump.LogLevelNormal)
+	appConfig, err := ocidump.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error loading configuration: %v", err)
 	}
+	logLevel, err := ocidump.ParseLogLevel(appConfig.General.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level: %v", err)
+	}
+	setLogger(logLevel)
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(appConfig.General.Timeout)*time.Second)
 	defer cancel()
-
-	// Initialize OCI clients
-	logger.Debug("Initializing OCI clients with instance principal authentication")
-	clients, err := initOCIClients(ctx)
+	resources, err := ocidump.Discover(ctx, ocidump.Options{
+		Filters:                 appConfig.Filters,
+		Detail:                  appConfig.General.Detail,
+		Mode:                    appConfig.General.Mode,
+		PageSize:                appConfig.General.PageSize,
+		CircuitBreakerThreshold: appConfig.General.CircuitBreakerThreshold,
+		ShowProgress:            appConfig.General.Progress,
+		HTTP:                    appConfig.HTTP,
+		RateLimits:              appConfig.RateLimit,
+	})
 	if err != nil {
-		return fmt.Errorf("error initializing OCI clients: %v", err)
+		return nil, fmt.Errorf("error discovering resources: %v", err)
 	}
-	logger.Verbose("OCI clients initialized successfully")
+	return resources, nil
+}
 
-	// Preload compartment names for better performance
-	logger.Debug("Preloading compartment names...")
+// isTerminal reports whether the file is a character device such as a terminal
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
 
-	// Get tenancy ID for preloading
-	provider, err := auth.InstancePrincipalConfigurationProvider()
-	if err != nil {
-		return fmt.Errorf("error getting configuration provider: %v", err)
+// newServeCommand creates the subcommand exposing discovery results over a REST API
+func newServeCommand() *cobra.Command {
+	var (
+		listenAddr     string
+		grpcListenAddr string
+		token          string
+		stateFile      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve discovered resources over a REST API",
+		Long: `Run an HTTP server exposing discovery results so other systems can consume
+the inventory without running the command themselves.
+
+Endpoints:
+  GET  /resources   Resources of the latest discovery (?type=vcns&compartment=<ocid or name>)
+  POST /dump        Run a discovery and store it as the latest snapshot
+  GET  /diff        Differences between the previous and the latest snapshot (?detailed=true)
+  GET  /healthz     Liveness check
+
+Every endpoint except /healthz requires "Authorization: Bearer <token>". Set the token
+with server.token in the configuration file, the OCI_DUMP_SERVER_TOKEN environment
+variable or --token. The server listens on 127.0.0.1:8080 unless --listen or
+server.listen is set.
+
+Only POST /dump runs a discovery. Timeout, filters and detail mode are read from the
+configuration file. Use --state-file to serve an existing dump until the first discovery.
+
+With --grpc-listen or server.grpc_listen, the ResourceDumpService of
+proto/ocidump/v1/ocidump.proto is served as well: Discover, StreamResources and Diff.
+Requests pass the same token as "authorization: Bearer <token>" metadata, and their
+filters, detail mode and timeout replace those of the configuration file. The
+configured timeout is also the longest a request may ask for, and requests list OCIDs
+in ocids rather than ocid_file.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			setLogger(ocidump.LogLevelNormal)
+
+			appConfig, err := ocidump.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("error loading configuration: %v", err)
+			}
+			logLevel, err := ocidump.ParseLogLevel(appConfig.General.LogLevel)
+			if err != nil {
+				return fmt.Errorf("invalid log level: %v", err)
+			}
+			setLogger(logLevel)
+
+			originatorPatterns, err := ocidump.CompileOriginatorPatterns(appConfig.Originators)
+			if err != nil {
+				return fmt.Errorf("invalid originator configuration: %v", err)
+			}
+
+			if listenAddr == "" {
+				listenAddr = appConfig.Server.ListenAddr()
+			}
+			if token == "" {
+				token = appConfig.Server.BearerToken()
+			}
+			if token == "" {
+				return fmt.Errorf("a bearer token is required: set server.token, OCI_DUMP_SERVER_TOKEN or --token")
+			}
+
+			if grpcListenAddr == "" {
+				grpcListenAddr = appConfig.Server.GRPCListen
+			}
+
+			timeout := time.Duration(appConfig.General.Timeout) * time.Second
+			discoveryOptions := ocidump.Options{
+				Filters:                 appConfig.Filters,
+				Detail:                  appConfig.General.Detail,
+				Mode:                    appConfig.General.Mode,
+				PageSize:                appConfig.General.PageSize,
+				CircuitBreakerThreshold: appConfig.General.CircuitBreakerThreshold,
+				Cost:                    appConfig.Cost,
+				HTTP:                    appConfig.HTTP,
+				RateLimits:              appConfig.RateLimit,
+				Utilization:             appConfig.Utilization,
+				Terraform:               appConfig.Terraform,
+			}
+			// discover classifies the originators of the resources, including those streamed to a sink
+			discover := func(ctx context.Context, options ocidump.Options) ([]ocidump.Resource, error) {
+				if sink := options.Sink; sink != nil {
+					options.Sink = func(resources []ocidump.Resource) error {
+						ocidump.ApplyOriginatorClassification(resources, originatorPatterns)
+						return sink(resources)
+					}
+				}
+				resources, err := ocidump.Discover(ctx, options)
+				if err != nil {
+					return nil, err
+				}
+				ocidump.ApplyOriginatorClassification(resources, originatorPatterns)
+				return resources, nil
+			}
+
+			server := ocidump.NewServer(func(ctx context.Context) ([]ocidump.Resource, error) {
+				ctx, cancel := context.WithTimeout(ctx, timeout)
+				defer cancel()
+				return discover(ctx, discoveryOptions)
+			}, token)
+
+			if stateFile != "" {
+				resources, err := ocidump.LoadResourcesFromFile(stateFile)
+				if err != nil {
+					return fmt.Errorf("error loading state file: %v", err)
+				}
+				modTime := time.Now()
+				if info, err := os.Stat(stateFile); err == nil {
+					modTime = info.ModTime()
+				}
+				server.LoadSnapshot(resources, modTime.UTC())
+				logger.Info("Loaded %d resources from %s", len(resources), stateFile)
+			}
+
+			signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stopSignals()
+
+			httpServer := &http.Server{
+				Addr:              listenAddr,
+				Handler:           server.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+				BaseContext:       func(net.Listener) context.Context { return signalCtx },
+			}
+
+			serveErr := make(chan error, 2)
+			go func() {
+				logger.Info("Serving REST API on %s", listenAddr)
+				serveErr <- httpServer.ListenAndServe()
+			}()
+
+			var grpcServer *grpc.Server
+			if grpcListenAddr != "" {
+				listener, err := net.Listen("tcp", grpcListenAddr)
+				if err != nil {
+					return fmt.Errorf("error running gRPC server: %v", err)
+				}
+				grpcServer = ocidump.NewGRPCServer(discover, discoveryOptions, timeout, token).Server()
+				go func() {
+					logger.Info("Serving gRPC on %s", grpcListenAddr)
+					serveErr <- grpcServer.Serve(listener)
+				}()
+			}
+
+			select {
+			case err := <-serveErr:
+				if grpcServer != nil {
+					grpcServer.Stop()
+				}
+				return fmt.Errorf("error running server: %v", err)
+			case <-signalCtx.Done():
+			}
+
+			logger.Info("Shutting down server...")
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if grpcServer != nil {
+				// Streams still running when the shutdown timeout expires are cancelled
+				stopped := make(chan struct{})
+				go func() {
+					grpcServer.GracefulStop()
+					close(stopped)
+				}()
+				select {
+				case <-stopped:
+				case <-shutdownCtx.Done():
+					grpcServer.Stop()
+				}
+			}
+			return httpServer.Shutdown(shutdownCtx)
+		},
 	}
-	tenancyID, err := provider.TenancyOCID()
-	if err != nil {
-		return fmt.Errorf("error getting tenancy ID: %v", err)
+
+	cmd.Flags().StringVar(&listenAddr, "listen", "", "Address to listen on (default: server.listen or 127.0.0.1:8080)")
+	cmd.Flags().StringVar(&grpcListenAddr, "grpc-listen", "", "Also serve the gRPC ResourceDumpService on this address (default: server.grpc_listen)")
+	cmd.Flags().StringVar(&token, "token", "", "Bearer token required by every endpoint except /healthz and every gRPC call (default: server.token or OCI_DUMP_SERVER_TOKEN)")
+	cmd.Flags().StringVar(&stateFile, "state-file", "", "JSON dump to serve until the first discovery")
+
+	return cmd
+}
+
+// newHistoryCommand creates the subcommand listing and showing snapshots in the history store
+func newHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List and show snapshots recorded in the local history store",
+		Long: `List and show snapshots recorded in the local history store.
+
+Runs are recorded when history.enabled is set in the configuration file or
+--snapshot-name is given. Snapshots are referred to by ID, by name, or as
+latest / latest~N (N runs before the latest).`,
 	}
 
-	err = clients.CompartmentCache.PreloadCompartmentNames(ctx, tenancyID)
-	if err != nil {
-		logger.Verbose("Warning: Could not preload all compartment names: %v", err)
-		// Continue execution - individual lookups will still work
-	} else {
-		totalEntries, _ := clients.CompartmentCache.GetCacheStats()
-		logger.Verbose("Preloaded %d compartment names into cache", totalEntries)
+	listCmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List recorded snapshots",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, _, err := openHistoryStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			snapshots, err := store.List()
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(writer, "ID\tNAME\tCREATED\tRESOURCES")
+			for _, entry := range snapshots {
+				fmt.Fprintf(writer, "%d\t%s\t%s\t%d\n", entry.ID, entry.Name, entry.CreatedAt.Local().Format(time.RFC3339), entry.ResourceCount)
+			}
+			return writer.Flush()
+		},
 	}
 
-	// Discover all resources
-	logger.Info("Starting resource discovery with %v timeout...", config.Timeout)
-	logger.Debug("Discovery configuration - Format: %s, Timeout: %v, LogLevel: %s, Progress: %v", config.OutputFormat, config.Timeout, config.LogLevel, config.ShowProgress)
-	resources, err := discoverAllResourcesWithProgress(ctx, clients, config.ShowProgress, config.Filters)
-	if err != nil {
-		return fmt.Errorf("error discovering resources: %v", err)
+	var (
+		showFormat string
+		showOutput string
+	)
+	showCmd := &cobra.Command{
+		Use:          "show <id|name|latest>",
+		Short:        "Print the resources of a snapshot",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, _, err := openHistoryStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			entry, err := store.Resolve(args[0])
+			if err != nil {
+				return err
+			}
+			resources, err := store.Load(entry)
+			if err != nil {
+				return fmt.Errorf("error loading snapshot %d: %v", entry.ID, err)
+			}
+
+			format := strings.ToLower(showFormat)
+			if ocidump.IsStdoutPath(showOutput) {
+				return ocidump.OutputResources(resources, format, ocidump.CSVDialect{})
+			}
+			return ocidump.OutputResourcesToFile(resources, format, showOutput, ocidump.CSVDialect{})
+		},
 	}
+	showCmd.Flags().StringVarP(&showFormat, "format", "f", "json", "Output format: csv, tsv, json, ndjson, openmetrics, dot, or mermaid")
+	showCmd.Flags().StringVarP(&showOutput, "output-file", "o", "", "Output file path, '-' for stdout (default: stdout)")
 
-	// Output resources in the specified format
-	logger.Debug("Outputting %d resources in %s format", len(resources), config.OutputFormat)
+	cmd.AddCommand(listCmd, showCmd)
+	return cmd
+}
 
-	// Handle file output vs stdout
-	if appConfig.Output.File != "" {
-		logger.Info("Writing output to file: %s", appConfig.Output.File)
-		if err := outputResourcesToFile(resources, config.OutputFormat, appConfig.Output.File); err != nil {
-			return fmt.Errorf("error outputting resources to file: %v", err)
-		}
-		logger.Verbose("Resource output completed successfully to file: %s", appConfig.Output.File)
-	} else {
-		if err := outputResources(resources, config.OutputFormat); err != nil {
-			return fmt.Errorf("error outputting resources: %v", err)
-		}
-		logger.Verbose("Resource output completed successfully to stdout")
+// newConfigCommand creates the subcommand checking configuration files
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Check configuration files",
 	}
 
-	return nil
+	validateCmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Validate a configuration file without running discovery",
+		Long: `Validate a configuration file and report every problem found in it, not just the first.
+
+Unknown keys, values of the wrong type, the settings checked when the file is
+loaded, filter presets and the filters section are checked. Without a file,
+the configuration file a run would use is validated (OCI_DUMP_CONFIG_FILE,
+./oci-resource-dump.yaml, ~/.oci-resource-dump.yaml, /etc/oci-resource-dump.yaml).`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := ocidump.FindConfigFile()
+			if len(args) > 0 {
+				file = args[0]
+			}
+			if file == "" {
+				return fmt.Errorf("no configuration file found, pass the file to validate")
+			}
+
+			problems, err := ocidump.ValidateConfigFile(file)
+			if err != nil {
+				return err
+			}
+			if len(problems) > 0 {
+				for _, problem := range problems {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", file, problem)
+				}
+				return fmt.Errorf("%d problems found in %s", len(problems), file)
+			}
+			fmt.Printf("%s: valid\n", file)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(validateCmd)
+	return cmd
+}
+
+// newTypesCommand creates the subcommand listing the supported resource types
+func newTypesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "types",
+		Short: "Show the supported resource types",
+	}
+
+	var format string
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the supported resource types",
+		Long: `List every supported resource type with the name to use in --resource-types,
+its short aliases and groups, whether it is discovered by default and the IAM
+policy verbs and resource types its discovery needs.
+
+Types that are not discovered by default (opt-in) are only discovered when
+they are named in --resource-types or include_resource_types.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			types := ocidump.SupportedResourceTypes()
+			switch format {
+			case "table":
+				writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+				fmt.Fprintln(writer, "TYPE\tNAME\tALIASES\tGROUPS\tDEFAULT\tPERMISSIONS")
+				for _, info := range types {
+					defaultText := "yes"
+					if !info.Default {
+						defaultText = "no (opt-in)"
+					}
+					fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", info.Name, info.FilterName(), listOrDash(info.Aliases),
+						listOrDash(info.Groups), defaultText, listOrDash(info.Permissions))
+				}
+				return writer.Flush()
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(types)
+			default:
+				return fmt.Errorf("unsupported format '%s', must be table or json", format)
+			}
+		},
+	}
+	listCmd.Flags().StringVarP(&format, "format", "f", "table", "Output format: table or json")
+
+	cmd.AddCommand(listCmd)
+	return cmd
+}
+
+// listOrDash joins a list for table output, showing "-" for an empty list
+func listOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
+// newDiffCommand creates the subcommand comparing two snapshots of the history store
+func newDiffCommand() *cobra.Command {
+	var (
+		from         string
+		to           string
+		diffOutput   string
+		diffFormat   string
+		diffDetailed bool
+		diffSummary  bool
+		diffTemplate string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff --from <snapshot> [--to <snapshot>]",
+		Short: "Compare two snapshots from the history store",
+		Long: `Compare two snapshots recorded in the local history store.
+
+Snapshots are referred to by ID, by name, or as latest / latest~N.
+The result has the same format as --compare-files, and the webhooks
+configured in the notify section are notified.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, appConfig, err := openHistoryStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			var entries [2]ocidump.HistoryEntry
+			var resources [2][]ocidump.Resource
+			for i, ref := range []string{from, to} {
+				if entries[i], err = store.Resolve(ref); err != nil {
+					return err
+				}
+				if resources[i], err = store.Load(entries[i]); err != nil {
+					return fmt.Errorf("error loading snapshot %d: %v", entries[i].ID, err)
+				}
+			}
+
+			diffConfig := ocidump.DiffConfig{
+				Format:       diffFormat,
+				Detailed:     diffDetailed,
+				OutputFile:   diffOutput,
+				IgnoreFields: appConfig.Diff.IgnoreFields,
+				SummaryOnly:  diffSummary || appConfig.Diff.SummaryOnly,
+				Template:     appConfig.Diff.Template,
+				Tolerances:   appConfig.Diff.Tolerances,
+			}
+			if diffTemplate != "" {
+				diffConfig.Template = diffTemplate
+			}
+			if err := diffConfig.Validate(); err != nil {
+				return err
+			}
+			result := ocidump.DiffResources(resources[0], resources[1], entries[0].Label(), entries[1].Label(), diffConfig)
+			if err := ocidump.OutputDiffResult(result, diffConfig); err != nil {
+				return fmt.Errorf("error outputting diff results: %v", err)
+			}
+			if err := ocidump.NotifyDiff(context.Background(), appConfig.Notify, result); err != nil {
+				return fmt.Errorf("error sending diff notifications: %v", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Snapshot to compare from (ID, name, latest or latest~N)")
+	cmd.Flags().StringVar(&to, "to", "latest", "Snapshot to compare to (ID, name, latest or latest~N)")
+	cmd.Flags().StringVar(&diffOutput, "diff-output", "", "Output file for diff analysis, '-' for stdout (default: stdout)")
+	cmd.Flags().StringVar(&diffFormat, "diff-format", "json", "Diff output format: json, text, html, markdown, csv, tsv")
+	cmd.Flags().BoolVar(&diffDetailed, "diff-detailed", false, "Include unchanged resources in diff output")
+	cmd.Flags().BoolVar(&diffSummary, "diff-summary", false, "Output only the summary counts per resource type, without the resource listings")
+	cmd.Flags().StringVar(&diffTemplate, "diff-template", "", "Render the diff with this Go template file instead of --diff-format")
+	cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+// openHistoryStore loads the configuration file and opens the history store it configures
+func openHistoryStore() (*ocidump.HistoryStore, *ocidump.AppConfig, error) {
+	setLogger(ocidump.LogLevelNormal)
+	appConfig, err := ocidump.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading configuration: %v", err)
+	}
+	store, err := ocidump.OpenHistoryStore(appConfig.History)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening history store: %v", err)
+	}
+	return store, appConfig, nil
+}
+
+func runMainLogic(flags cliFlags) (runErr error) {
+
+	// Apply --log-format before any logger is created, so diff runs honor it too
+	if flags.logFormatStr != "" {
+		format, err := ocidump.ParseLogFormat(flags.logFormatStr)
+		if err != nil {
+			return err
+		}
+		logFormat = format
+	}
+
+	// Handle configuration file generation
+	if flags.generateConfig {
+		if err := ocidump.GenerateDefaultConfigFile("oci-resource-dump.yaml"); err != nil {
+			return fmt.Errorf("error generating configuration file: %v", err)
+		}
+		fmt.Fprintln(os.Stderr, "Default configuration file generated: oci-resource-dump.yaml")
+		return nil
+	}
+
+	// Phase 2C: Handle diff analysis mode
+	// mergeFilterFlags applies the filter preset and the filter flags to the filters of a configuration
+	mergeFilterFlags := func(appConfig *ocidump.AppConfig) error {
+		// A filter preset replaces the filters section; the filter flags below still override it
+		if flags.preset != "" {
+			if err := appConfig.ApplyFilterPreset(flags.preset); err != nil {
+				return fmt.Errorf("invalid filter configuration: %v", err)
+			}
+		}
+
+		if flags.compartments != "" {
+			appConfig.Filters.IncludeCompartments = ocidump.ParseCompartmentList(flags.compartments)
+		}
+		if flags.excludeCompartments != "" {
+			appConfig.Filters.ExcludeCompartments = ocidump.ParseCompartmentList(flags.excludeCompartments)
+		}
+		if flags.compartmentSubtrees != "" {
+			appConfig.Filters.CompartmentSubtrees = ocidump.ParseCompartmentList(flags.compartmentSubtrees)
+		}
+		if flags.resourceTypes != "" {
+			appConfig.Filters.IncludeResourceTypes = ocidump.ParseResourceTypeList(flags.resourceTypes)
+		}
+		if flags.excludeResourceTypes != "" {
+			appConfig.Filters.ExcludeResourceTypes = ocidump.ParseResourceTypeList(flags.excludeResourceTypes)
+		}
+		if flags.nameFilter != "" {
+			appConfig.Filters.NamePattern = flags.nameFilter
+		}
+		if flags.excludeNameFilter != "" {
+			appConfig.Filters.ExcludeNamePattern = flags.excludeNameFilter
+		}
+		if flags.nameGlob != "" {
+			appConfig.Filters.NameGlob = flags.nameGlob
+		}
+		if flags.excludeNameGlob != "" {
+			appConfig.Filters.ExcludeNameGlob = flags.excludeNameGlob
+		}
+		if flags.ignoreCase {
+			appConfig.Filters.IgnoreCase = true
+		}
+		if flags.lifecycleStates != "" {
+			appConfig.Filters.LifecycleStates = ocidump.ParseLifecycleStateList(flags.lifecycleStates)
+		}
+		if flags.createdAfter != "" {
+			appConfig.Filters.CreatedAfter = flags.createdAfter
+		}
+		if flags.createdBefore != "" {
+			appConfig.Filters.CreatedBefore = flags.createdBefore
+		}
+		if flags.shapes != "" {
+			appConfig.Filters.Shapes = ocidump.ParseShapeList(flags.shapes)
+		}
+		if flags.filterExpr != "" {
+			appConfig.Filters.Expression = flags.filterExpr
+		}
+		if flags.minSizeGB != 0 {
+			appConfig.Filters.MinSizeGB = flags.minSizeGB
+		}
+		if flags.maxSizeGB != 0 {
+			appConfig.Filters.MaxSizeGB = flags.maxSizeGB
+		}
+		if len(flags.whereConditions) > 0 {
+			where, err := ocidump.ParseWhereList(flags.whereConditions)
+			if err != nil {
+				return fmt.Errorf("invalid filter configuration: %v", err)
+			}
+			appConfig.Filters.Where = where
+		}
+		if flags.ocidFile != "" {
+			appConfig.Filters.OCIDFile = flags.ocidFile
+		}
+		if flags.skipEmptyCompartments {
+			appConfig.Filters.SkipEmptyCompartments = true
+		}
+
+		// Validate filter configuration
+		if err := ocidump.ValidateFilterConfig(appConfig.Filters); err != nil {
+			return fmt.Errorf("invalid filter configuration: %v", err)
+		}
+		return nil
+	}
+
+	// newDiffConfig configures diff analysis from the diff flags and the configuration file
+	newDiffConfig := func(appConfig *ocidump.AppConfig) (ocidump.DiffConfig, error) {
+		if flags.diffExitCode < 0 || flags.diffExitCode > 255 {
+			return ocidump.DiffConfig{}, fmt.Errorf("--diff-exit-code must be between 0 and 255, got: %d", flags.diffExitCode)
+		}
+		if flags.diffExitCode == 1 {
+			return ocidump.DiffConfig{}, fmt.Errorf("--diff-exit-code must not be 1, the exit status of errors")
+		}
+		diffConfig := ocidump.DiffConfig{
+			Format:       flags.diffFormat,
+			Detailed:     flags.diffDetailed,
+			OutputFile:   flags.diffOutput,
+			IgnoreFields: appConfig.Diff.IgnoreFields,
+			SummaryOnly:  flags.diffSummary || appConfig.Diff.SummaryOnly,
+			Template:     appConfig.Diff.Template,
+			Tolerances:   appConfig.Diff.Tolerances,
+		}
+		if flags.diffIgnoreFields != "" {
+			diffConfig.IgnoreFields = ocidump.ParseFieldList(flags.diffIgnoreFields)
+		}
+		if flags.diffTemplate != "" {
+			diffConfig.Template = flags.diffTemplate
+		}
+		return diffConfig, diffConfig.Validate()
+	}
+
+	// reportDiff writes the diff result, notifies the configured webhooks and sets the exit status
+	reportDiff := func(appConfig *ocidump.AppConfig, diffConfig ocidump.DiffConfig, result *ocidump.DiffResult) error {
+		if err := ocidump.OutputDiffResult(result, diffConfig); err != nil {
+			return fmt.Errorf("error outputting diff results: %v", err)
+		}
+		if err := ocidump.NotifyDiff(context.Background(), appConfig.Notify, result); err != nil {
+			return fmt.Errorf("error sending diff notifications: %v", err)
+		}
+
+		// Let CI pipelines gate on drift without parsing the report
+		if result.Summary.HasChanges() {
+			exitStatus = flags.diffExitCode
+		}
+		return nil
+	}
+
+	if flags.compareFiles != "" {
+		// Initialize logger for diff mode
+		setLogger(ocidump.LogLevelNormal)
+
+		files := strings.Split(flags.compareFiles, ",")
+		if len(files) != 2 {
+			return fmt.Errorf("--compare-files requires exactly 2 files separated by comma\nExample: --compare-files old.json,new.json")
+		}
+
+		oldFile := strings.TrimSpace(files[0])
+		newFile := strings.TrimSpace(files[1])
+
+		appConfig, err := ocidump.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("error loading configuration: %v", err)
+		}
+
+		// Configure diff settings
+		diffConfig, err := newDiffConfig(appConfig)
+		if err != nil {
+			return err
+		}
+
+		// Scope the comparison with --preset and the filter flags; the filters section of the
+		// configuration file already applied when the dumps were written
+		scope := *appConfig
+		scope.Filters = ocidump.FilterConfig{}
+		if err := mergeFilterFlags(&scope); err != nil {
+			return err
+		}
+		diffConfig.Filters = scope.Filters
+
+		// Perform diff analysis
+		result, err := ocidump.CompareDumps(oldFile, newFile, diffConfig)
+		if err != nil {
+			return fmt.Errorf("error performing diff analysis: %v", err)
+		}
+		return reportDiff(appConfig, diffConfig, result)
+	}
+
+	// Initialize temporary logger for configuration loading
+	setLogger(ocidump.LogLevelNormal)
+
+	// Load configuration from file
+	appConfig, err := ocidump.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %v", err)
+	}
+
+	// Create CLI argument pointers to match the expected interface
+	var finalTimeout *int
+	var finalLogLevel *string
+	var finalFormat *string
+	var finalOutputFile *string
+
+	if flags.timeoutSeconds != -1 {
+		finalTimeout = &flags.timeoutSeconds
+	}
+	if flags.logLevelStr != "NOT_SET" {
+		finalLogLevel = &flags.logLevelStr
+	}
+	if flags.outputFormat != "NOT_SET" {
+		finalFormat = &flags.outputFormat
+	}
+	if flags.outputFile != "NOT_SET" {
+		finalOutputFile = &flags.outputFile
+	}
+
+	// Progress flags handling: only explicit flags override config
+	var finalProgress *bool
+	if flags.noProgress {
+		finalProgress = func() *bool { b := false; return &b }() // explicit --no-progress
+	} else if flags.showProgress {
+		finalProgress = func() *bool { b := true; return &b }() // explicit --progress
+	} else {
+		finalProgress = nil // not specified, don't override config
+	}
+
+	// Merge CLI arguments with configuration file (CLI has higher priority)
+	ocidump.MergeWithCLIArgs(appConfig, finalTimeout, finalLogLevel, finalFormat, finalProgress, finalOutputFile)
+
+	// --detail only enables detail mode; the config file value is kept otherwise
+	if flags.detail {
+		appConfig.General.Detail = true
+	}
+	if flags.logFormatStr != "" {
+		appConfig.General.LogFormat = flags.logFormatStr
+	}
+	if flags.mode != "" {
+		if !slices.Contains(ocidump.DiscoveryModes, flags.mode) {
+			return fmt.Errorf("invalid mode '%s'. Valid modes are: %s", flags.mode, strings.Join(ocidump.DiscoveryModes, ", "))
+		}
+		appConfig.General.Mode = flags.mode
+	}
+	if flags.pageSize != 0 {
+		if flags.pageSize < 0 {
+			return fmt.Errorf("--page-size must not be negative, got: %d", flags.pageSize)
+		}
+		appConfig.General.PageSize = flags.pageSize
+	}
+	if flags.withCost {
+		appConfig.Cost.Enabled = true
+	}
+	if flags.withMetrics {
+		appConfig.Utilization.Enabled = true
+	}
+	if flags.withTerraform {
+		appConfig.Terraform.Enabled = true
+	}
+	if flags.tfstateFiles != "" {
+		appConfig.Terraform.Enabled = true
+		appConfig.Terraform.StateFiles = nil
+		for _, file := range strings.Split(flags.tfstateFiles, ",") {
+			if file = strings.TrimSpace(file); file != "" {
+				appConfig.Terraform.StateFiles = append(appConfig.Terraform.StateFiles, file)
+			}
+		}
+	}
+	if flags.sortBy != "" {
+		appConfig.Output.SortBy = flags.sortBy
+	}
+	if flags.tee {
+		appConfig.Output.Tee = true
+	}
+	if flags.summary {
+		appConfig.Output.Summary = true
+	}
+	if flags.stream {
+		appConfig.Output.Stream = true
+	}
+	if flags.query != "" {
+		appConfig.Output.Query = flags.query
+	}
+	if flags.checkpointFile != "" {
+		appConfig.General.CheckpointFile = flags.checkpointFile
+	}
+	if flags.auditFile != "" {
+		appConfig.Audit.File = flags.auditFile
+	}
+	if flags.useCache {
+		appConfig.Cache.Enabled = true
+	}
+	if flags.noCache {
+		appConfig.Cache.Enabled = false
+		appConfig.Cache.Compartments = false
+	}
+	if flags.cacheTTL != 0 {
+		appConfig.Cache.TTL = flags.cacheTTL
+		if err := appConfig.Cache.Validate(); err != nil {
+			return err
+		}
+	}
+	if flags.rateLimit != 0 || flags.adaptive {
+		if flags.rateLimit != 0 {
+			appConfig.RateLimit.Default = flags.rateLimit
+		}
+		appConfig.RateLimit.Adaptive = appConfig.RateLimit.Adaptive || flags.adaptive
+		if err := appConfig.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+	// A snapshot name records the run even when history is not enabled in the configuration
+	recordRun := appConfig.History.Enabled || flags.snapshotName != ""
+	if flags.csvDialect.Delimiter != "" {
+		appConfig.Output.CSV.Delimiter = flags.csvDialect.Delimiter
+	}
+	if flags.csvDialect.Quote != "" {
+		appConfig.Output.CSV.Quote = flags.csvDialect.Quote
+	}
+	if flags.csvDialect.BOM {
+		appConfig.Output.CSV.BOM = true
+	}
+	if flags.csvDialect.CRLF {
+		appConfig.Output.CSV.CRLF = true
+	}
+	if err := appConfig.Output.CSV.Validate(); err != nil {
+		return err
+	}
+
+	// Phase 2B: Parse and merge filter arguments
+	if err := mergeFilterFlags(appConfig); err != nil {
+		return err
+	}
+
+	// Convert AppConfig to runtime Config
+	config := &ocidump.Config{}
+	config.Timeout = time.Duration(appConfig.General.Timeout) * time.Second
+	config.OutputFormat = strings.ToLower(appConfig.General.OutputFormat)
+	config.Filters = appConfig.Filters
+	config.Detail = appConfig.General.Detail
+	config.SortKeys, err = ocidump.ParseSortKeys(appConfig.Output.SortBy)
+	if err != nil {
+		return fmt.Errorf("invalid sort order: %v", err)
+	}
+
+	// Parse and validate log level
+	logLevel, err := ocidump.ParseLogLevel(appConfig.General.LogLevel)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %v", err)
+	}
+	config.LogLevel = logLevel
+	logFormat, err = ocidump.ParseLogFormat(appConfig.General.LogFormat)
+	if err != nil {
+		return fmt.Errorf("invalid log format: %v", err)
+	}
+
+	// Configure progress bar - from config file or CLI
+	config.ShowProgress = appConfig.General.Progress
+
+	// CLI flags override config file
+	if flags.showProgress {
+		config.ShowProgress = true
+	}
+	if flags.noProgress {
+		config.ShowProgress = false
+	}
+
+	// Re-initialize logger with final log level
+	setLogger(logLevel)
+	config.Logger = logger
+
+	// Progress tracking is now handled directly in discovery.go with uiprogress
+
+	// Validate output format
+	validFormats := []string{"csv", "tsv", "json", "ndjson", "openmetrics", "dot", "mermaid"}
+	config.OutputFormat = strings.ToLower(config.OutputFormat)
+
+	isValid := false
+	for _, format := range validFormats {
+		if config.OutputFormat == format {
+			isValid = true
+			break
+		}
+	}
+
+	if !isValid {
+		return fmt.Errorf("invalid output format '%s'. Valid formats are: csv, tsv, json, ndjson, openmetrics, dot, mermaid", config.OutputFormat)
+	}
+
+	// The summary report replaces the resource list and needs the complete inventory
+	if appConfig.Output.Summary && !slices.Contains(ocidump.SummaryFormats, config.OutputFormat) {
+		return fmt.Errorf("--summary supports the following formats: %s", strings.Join(ocidump.SummaryFormats, ", "))
+	}
+
+	// The query result is arbitrary JSON, so it is only available with the json format
+	var compiledQuery *ocidump.Query
+	if appConfig.Output.Query != "" {
+		if config.OutputFormat != "json" {
+			return fmt.Errorf("--query requires the json output format")
+		}
+		if appConfig.Output.Summary {
+			return fmt.Errorf("--query cannot be combined with --summary")
+		}
+		compiledQuery, err = ocidump.CompileQuery(appConfig.Output.Query)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Streamed output is written while discovery is running, so it cannot depend on the complete inventory
+	streaming := config.OutputFormat == "ndjson"
+	if appConfig.Output.Stream {
+		if !slices.Contains(ocidump.StreamFormats, config.OutputFormat) {
+			return fmt.Errorf("--stream supports the following formats: %s", strings.Join(ocidump.StreamFormats, ", "))
+		}
+		if appConfig.Output.Summary || appConfig.Output.Query != "" {
+			return fmt.Errorf("--stream cannot be combined with --summary or --query")
+		}
+		streaming = true
+	}
+
+	// Daemon runs write timestamped dumps to the configured directory instead of --output-file
+	var daemonSchedule *ocidump.CronSchedule
+	if flags.daemon {
+		if appConfig.Daemon.Schedule == "" {
+			return fmt.Errorf("--daemon requires daemon.schedule in the configuration file")
+		}
+		if appConfig.Output.Summary || appConfig.Output.Query != "" || appConfig.Output.Tee {
+			return fmt.Errorf("--daemon cannot be combined with --summary, --query or --tee")
+		}
+		if appConfig.Daemon.Diff && !ocidump.IsDumpInputFormat(config.OutputFormat) {
+			return fmt.Errorf("daemon.diff requires the %s output format", strings.Join(ocidump.DumpInputFormats, ", "))
+		}
+		daemonSchedule, err = ocidump.ParseCronSchedule(appConfig.Daemon.Schedule)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Watch runs keep the previous discovery in memory and report what changed since
+	var watchDiffConfig ocidump.DiffConfig
+	if flags.watch != 0 {
+		if flags.watch < ocidump.MinWatchInterval {
+			return fmt.Errorf("--watch interval must be at least %v, got: %v", ocidump.MinWatchInterval, flags.watch)
+		}
+		if flags.daemon || streaming || appConfig.Output.Summary || appConfig.Output.Query != "" || flags.diffAgainst != "" {
+			return fmt.Errorf("--watch cannot be combined with --daemon, streamed output, --summary, --query or --diff-against")
+		}
+		if watchDiffConfig, err = newDiffConfig(appConfig); err != nil {
+			return err
+		}
+	}
+
+	// Resuming needs the checkpoint written by the interrupted run
+	if flags.resume {
+		if appConfig.General.CheckpointFile == "" {
+			return fmt.Errorf("--resume requires a checkpoint file (--checkpoint-file or general.checkpoint_file)")
+		}
+		if flags.daemon || flags.watch != 0 {
+			return fmt.Errorf("--resume cannot be combined with --daemon or --watch")
+		}
+	}
+
+	// Tee copies the output file to stdout
+	if appConfig.Output.Tee && ocidump.IsStdoutPath(appConfig.Output.File) {
+		return fmt.Errorf("--tee requires an output file (--output-file)")
+	}
+
+	// Uploads read the written output file
+	if !flags.daemon && appConfig.Upload.S3.Enabled() && ocidump.IsStdoutPath(appConfig.Output.File) {
+		return fmt.Errorf("s3 upload requires an output file (--output-file)")
+	}
+
+	// Streamed output is written in discovery order
+	if streaming && len(config.SortKeys) > 0 {
+		return fmt.Errorf("--sort-by is not supported with streamed output (ndjson format or --stream)")
+	}
+	if streaming && appConfig.Utilization.Enabled {
+		return fmt.Errorf("--with-metrics is not supported with streamed output (ndjson format or --stream)")
+	}
+
+	// The baseline is read before discovery, so a bad file fails the run right away
+	var baseline []ocidump.Resource
+	var baselineDiffConfig ocidump.DiffConfig
+	var baselineTabular bool
+	if flags.diffAgainst != "" {
+		if flags.daemon || streaming || appConfig.Output.Summary || appConfig.Output.Query != "" {
+			return fmt.Errorf("--diff-against cannot be combined with --daemon, streamed output, --summary or --query")
+		}
+		if baselineDiffConfig, err = newDiffConfig(appConfig); err != nil {
+			return err
+		}
+		if baseline, baselineTabular, err = ocidump.LoadDump(flags.diffAgainst); err != nil {
+			return fmt.Errorf("failed to load baseline %s: %v", flags.diffAgainst, err)
+		}
+		// Resources the filters leave out of discovery are not reported as removed; compartment
+		// subtrees are resolved by discovery, so the baseline is scoped to them once it has run
+		scope := config.Filters
+		scope.CompartmentSubtrees = nil
+		if baseline, err = ocidump.FilterResources(baseline, scope); err != nil {
+			return err
+		}
+	}
+
+	// Create context cancelled on SIGINT/SIGTERM so in-flight requests stop on shutdown
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(signalCtx, config.Timeout)
+	defer cancel()
+
+	// Classify resource originators from the created_by enrichment
+	originatorPatterns, err := ocidump.CompileOriginatorPatterns(appConfig.Originators)
+	if err != nil {
+		return fmt.Errorf("invalid originator configuration: %v", err)
+	}
+
+	// Discover all resources
+	logger.Info("Starting resource discovery with %v timeout...", config.Timeout)
+	logger.Debug("Discovery configuration - Format: %s, Timeout: %v, LogLevel: %s, Progress: %v", config.OutputFormat, config.Timeout, config.LogLevel, config.ShowProgress)
+	discoveryOptions := ocidump.Options{
+		Filters:                 config.Filters,
+		Detail:                  config.Detail,
+		ShowProgress:            config.ShowProgress,
+		Mode:                    appConfig.General.Mode,
+		PageSize:                appConfig.General.PageSize,
+		CircuitBreakerThreshold: appConfig.General.CircuitBreakerThreshold,
+		Cost:                    appConfig.Cost,
+		HTTP:                    appConfig.HTTP,
+		RateLimits:              appConfig.RateLimit,
+		Utilization:             appConfig.Utilization,
+		Terraform:               appConfig.Terraform,
+	}
+
+	// Recent results are reused so successive runs with other formats or filters are quick
+	if appConfig.Cache.Enabled {
+		cache, err := ocidump.OpenResultCache(appConfig.Cache, discoveryOptions)
+		if err != nil {
+			return err
+		}
+		discoveryOptions.Cache = cache
+	}
+	// The compartment tree is kept as well, so large tenancies do not list it on every run
+	if appConfig.Cache.Enabled || appConfig.Cache.Compartments {
+		store, err := ocidump.OpenCompartmentTreeCache(appConfig.Cache)
+		if err != nil {
+			return err
+		}
+		discoveryOptions.CompartmentTree = store
+	}
+
+	if flags.daemon {
+		return runDaemon(signalCtx, daemonSchedule, appConfig, config, discoveryOptions, originatorPatterns)
+	}
+	if flags.watch != 0 {
+		return runWatch(signalCtx, flags.watch, appConfig, config, discoveryOptions, originatorPatterns, watchDiffConfig)
+	}
+
+	// Record the run in the audit log and announce its completion whatever its outcome
+	var auditResources []ocidump.Resource
+	if appConfig.Audit.Enabled() || appConfig.Notify.Completion.Enabled() {
+		var stats ocidump.DiscoveryStats
+		discoveryOptions.Stats = &stats
+		record := ocidump.NewAuditRecord("dump", auditParameters(appConfig, config), time.Now())
+		defer func() {
+			record.Finish(ctx, auditResources, stats, runErr, time.Now())
+			if appConfig.Audit.Enabled() {
+				if err := ocidump.WriteAuditRecord(context.Background(), appConfig.Audit, record); err != nil && runErr == nil {
+					runErr = err
+				}
+			}
+			notifyCompletion(appConfig, record)
+		}()
+	}
+
+	// Record progress so a run stopped by the timeout or a signal can be resumed
+	var checkpoint *ocidump.Checkpoint
+	if appConfig.General.CheckpointFile != "" {
+		checkpoint, err = ocidump.OpenCheckpoint(appConfig.General.CheckpointFile, discoveryOptions, flags.resume)
+		if err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+		discoveryOptions.Checkpoint = checkpoint
+	}
+
+	// NDJSON output, and other formats with --stream, is written while discovery is running;
+	// the resources are kept only for the history and the per-type counts of the audit record
+	if streaming {
+		collect := recordRun || appConfig.Audit.Enabled() || appConfig.Notify.Completion.Enabled()
+		resources, err := streamResources(ctx, signalCtx, discoveryOptions, config.OutputFormat, appConfig.Output, originatorPatterns, collect)
+		if err != nil {
+			return err
+		}
+		auditResources = resources
+		if err := finishCheckpoint(ctx, checkpoint); err != nil {
+			return err
+		}
+		if recordRun {
+			if err := recordHistory(appConfig.History, resources, flags.snapshotName); err != nil {
+				return err
+			}
+		}
+		return uploadOutput(signalCtx, appConfig, config.OutputFormat)
+	}
+
+	if flags.diffAgainst != "" && len(config.Filters.CompartmentSubtrees) > 0 && discoveryOptions.Stats == nil {
+		discoveryOptions.Stats = &ocidump.DiscoveryStats{}
+	}
+
+	resources, err := ocidump.Discover(ctx, discoveryOptions)
+	if err != nil {
+		if signalCtx.Err() != nil {
+			return fmt.Errorf("resource discovery interrupted by signal: %v", err)
+		}
+		return fmt.Errorf("error discovering resources: %v", err)
+	}
+	if flags.diffAgainst != "" && len(config.Filters.CompartmentSubtrees) > 0 {
+		baseline = ocidump.FilterResourcesByCompartment(baseline, discoveryOptions.Stats.CompartmentIDs)
+	}
+
+	ocidump.ApplyOriginatorClassification(resources, originatorPatterns)
+	ocidump.SortResources(resources, config.SortKeys)
+	auditResources = resources
+
+	// Output resources in the specified format
+	logger.Debug("Outputting %d resources in %s format", len(resources), config.OutputFormat)
+
+	// Handle file output vs stdout
+	if flags.diffAgainst != "" && ocidump.IsStdoutPath(appConfig.Output.File) && ocidump.IsStdoutPath(flags.diffOutput) {
+		logger.Debug("Writing the diff against the baseline instead of the resource list")
+	} else if appConfig.Output.Summary {
+		logger.Debug("Writing summary report instead of the resource list")
+		if err := ocidump.OutputSummary(resources, config.OutputFormat, appConfig.Output.File, appConfig.Output.CSV); err != nil {
+			return fmt.Errorf("error outputting summary: %v", err)
+		}
+		if appConfig.Output.Tee {
+			if err := ocidump.CopyFileToStdout(appConfig.Output.File); err != nil {
+				return fmt.Errorf("error writing output to stdout: %v", err)
+			}
+		}
+	} else if compiledQuery != nil {
+		logger.Debug("Applying query: %s", compiledQuery)
+		result, err := ocidump.QueryResources(compiledQuery, resources)
+		if err != nil {
+			return fmt.Errorf("error evaluating query: %v", err)
+		}
+		if err := ocidump.OutputQueryResult(result, appConfig.Output.File); err != nil {
+			return fmt.Errorf("error outputting query result: %v", err)
+		}
+		if appConfig.Output.Tee {
+			if err := ocidump.CopyFileToStdout(appConfig.Output.File); err != nil {
+				return fmt.Errorf("error writing output to stdout: %v", err)
+			}
+		}
+	} else if !ocidump.IsStdoutPath(appConfig.Output.File) {
+		logger.Info("Writing output to file: %s", appConfig.Output.File)
+		if err := ocidump.OutputResourcesToFile(resources, config.OutputFormat, appConfig.Output.File, appConfig.Output.CSV); err != nil {
+			return fmt.Errorf("error outputting resources to file: %v", err)
+		}
+		logger.Verbose("Resource output completed successfully to file: %s", appConfig.Output.File)
+
+		if appConfig.Output.Tee {
+			if err := ocidump.CopyFileToStdout(appConfig.Output.File); err != nil {
+				return fmt.Errorf("error writing output to stdout: %v", err)
+			}
+		}
+	} else {
+		if err := ocidump.OutputResources(resources, config.OutputFormat, appConfig.Output.CSV); err != nil {
+			return fmt.Errorf("error outputting resources: %v", err)
+		}
+		logger.Verbose("Resource output completed successfully to stdout")
+	}
+
+	if flags.diffAgainst != "" {
+		// A CSV or TSV baseline lacks most fields of the discovered resources, which would all show up as changes
+		current := resources
+		if baselineTabular {
+			logger.Info("Warning: %s is a CSV or TSV dump, comparing only the fields it keeps", flags.diffAgainst)
+			if current, err = ocidump.ReduceToTabularFields(resources); err != nil {
+				return fmt.Errorf("error performing diff analysis: %v", err)
+			}
+		}
+		result, err := ocidump.DiffAgainstBaseline(baseline, current, flags.diffAgainst, "discovery at "+time.Now().Format(time.RFC3339), baselineDiffConfig)
+		if err != nil {
+			return fmt.Errorf("error performing diff analysis: %v", err)
+		}
+		if err := reportDiff(appConfig, baselineDiffConfig, result); err != nil {
+			return err
+		}
+	}
+
+	if err := finishCheckpoint(ctx, checkpoint); err != nil {
+		return err
+	}
+	if recordRun {
+		if err := recordHistory(appConfig.History, resources, flags.snapshotName); err != nil {
+			return err
+		}
+	}
+	return uploadOutput(signalCtx, appConfig, config.OutputFormat)
+}
+
+// recordHistory stores the resources of a run as a snapshot in the history store
+func recordHistory(historyConfig ocidump.HistoryConfig, resources []ocidump.Resource, name string) error {
+	store, err := ocidump.OpenHistoryStore(historyConfig)
+	if err != nil {
+		return fmt.Errorf("error opening history store: %v", err)
+	}
+	defer store.Close()
+	entry, err := store.Record(resources, name, time.Now())
+	if err != nil {
+		return fmt.Errorf("error recording history snapshot: %v", err)
+	}
+	logger.Info("Recorded snapshot %d in history store %s", entry.ID, store.Path())
+	return nil
+}
+
+// finishCheckpoint removes the checkpoint of a completed run, or keeps it for --resume
+// when the run was cut short by the timeout or a signal
+func finishCheckpoint(ctx context.Context, checkpoint *ocidump.Checkpoint) error {
+	if checkpoint == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		logger.Info("Discovery did not complete; run again with --resume to continue from the checkpoint")
+		return checkpoint.Close()
+	}
+	if err := checkpoint.Remove(); err != nil {
+		return fmt.Errorf("error removing checkpoint: %v", err)
+	}
+	return nil
+}
+
+// runDaemon writes a timestamped dump at every scheduled time until a signal is received
+func runDaemon(ctx context.Context, schedule *ocidump.CronSchedule, appConfig *ocidump.AppConfig, config *ocidump.Config, options ocidump.Options, originatorPatterns *ocidump.CompiledOriginatorPatterns) error {
+	daemonConfig := appConfig.Daemon
+	if daemonConfig.OutputDir != "" {
+		if err := os.MkdirAll(daemonConfig.OutputDir, 0755); err != nil {
+			return fmt.Errorf("error creating daemon output directory: %v", err)
+		}
+	}
+	// The progress bar is meaningless without a terminal watching it
+	options.ShowProgress = false
+
+	logger.Info("Daemon mode: schedule '%s', writing dumps to %s", schedule, filepath.Dir(daemonConfig.DumpPath(config.OutputFormat, time.Now())))
+	err := ocidump.RunSchedule(ctx, schedule, func(ctx context.Context, scheduledAt time.Time) (runErr error) {
+		runCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+
+		// Every scheduled run gets its own audit record and completion notification;
+		// a failed write is logged like other run errors
+		options := options
+		var auditResources []ocidump.Resource
+		if appConfig.Audit.Enabled() || appConfig.Notify.Completion.Enabled() {
+			var stats ocidump.DiscoveryStats
+			options.Stats = &stats
+			record := ocidump.NewAuditRecord("daemon", auditParameters(appConfig, config), time.Now())
+			defer func() {
+				record.Finish(runCtx, auditResources, stats, runErr, time.Now())
+				if appConfig.Audit.Enabled() {
+					if err := ocidump.WriteAuditRecord(context.Background(), appConfig.Audit, record); err != nil {
+						logger.Error("Error writing audit record: %v", err)
+					}
+				}
+				notifyCompletion(appConfig, record)
+			}()
+		}
+
+		logger.Info("Starting scheduled resource discovery with %v timeout...", config.Timeout)
+		resources, err := ocidump.Discover(runCtx, options)
+		if err != nil {
+			return fmt.Errorf("error discovering resources: %v", err)
+		}
+		ocidump.ApplyOriginatorClassification(resources, originatorPatterns)
+		auditResources = resources
+		ocidump.SortResources(resources, config.SortKeys)
+
+		previous, err := daemonConfig.ListDumps(config.OutputFormat)
+		if err != nil {
+			return err
+		}
+
+		dumpPath := daemonConfig.DumpPath(config.OutputFormat, scheduledAt)
+		if err := ocidump.OutputResourcesToFile(resources, config.OutputFormat, dumpPath, appConfig.Output.CSV); err != nil {
+			return fmt.Errorf("error writing dump: %v", err)
+		}
+		logger.Info("Wrote %d resources to %s", len(resources), dumpPath)
+
+		if appConfig.History.Enabled {
+			if err := recordHistory(appConfig.History, resources, ""); err != nil {
+				logger.Error("%v", err)
+			}
+		}
+
+		if daemonConfig.Diff && len(previous) > 0 {
+			diffConfig := appConfig.Diff
+			diffConfig.OutputFile = daemonConfig.DiffPath(dumpPath, diffConfig.Format)
+			result, err := ocidump.CompareDumps(previous[len(previous)-1].Path, dumpPath, diffConfig)
+			if err != nil {
+				return fmt.Errorf("error performing diff analysis: %v", err)
+			}
+			if err := ocidump.OutputDiffResult(result, diffConfig); err != nil {
+				return fmt.Errorf("error outputting diff results: %v", err)
+			}
+			// A failed notification must not stop the dump from being uploaded and pruned
+			if err := ocidump.NotifyDiff(ctx, appConfig.Notify, result); err != nil {
+				logger.Error("Error sending diff notifications: %v", err)
+			}
+		}
+
+		if appConfig.Upload.S3.Enabled() {
+			if err := ocidump.UploadFileToS3(ctx, appConfig.Upload.S3, dumpPath, ocidump.OutputContentType(config.OutputFormat)); err != nil {
+				return fmt.Errorf("error uploading dump: %v", err)
+			}
+		}
+
+		if _, err := daemonConfig.PruneDumps(config.OutputFormat, time.Now()); err != nil {
+			return fmt.Errorf("error pruning old dumps: %v", err)
+		}
+		return nil
+	})
+
+	if errors.Is(err, context.Canceled) {
+		logger.Info("Daemon stopped")
+		return nil
+	}
+	return err
+}
+
+// runWatch re-runs discovery every interval and reports the changes since the previous run
+// The first run only records the baseline. Changes are logged, written to a timestamped
+// diff report next to --diff-output and sent to the diff notification targets.
+func runWatch(ctx context.Context, interval time.Duration, appConfig *ocidump.AppConfig, config *ocidump.Config, options ocidump.Options, originatorPatterns *ocidump.CompiledOriginatorPatterns, diffConfig ocidump.DiffConfig) error {
+	// The progress bar is meaningless without a terminal watching it
+	options.ShowProgress = false
+
+	var previous []ocidump.Resource
+	var previousLabel string
+	logger.Info("Watch mode: running discovery every %v", interval)
+	err := ocidump.RunInterval(ctx, interval, func(ctx context.Context, startedAt time.Time) error {
+		runCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+
+		logger.Info("Starting resource discovery with %v timeout...", config.Timeout)
+		resources, err := ocidump.Discover(runCtx, options)
+		if err != nil {
+			return fmt.Errorf("error discovering resources: %v", err)
+		}
+		ocidump.ApplyOriginatorClassification(resources, originatorPatterns)
+		ocidump.SortResources(resources, config.SortKeys)
+		label := "discovery at " + startedAt.UTC().Format(time.RFC3339)
+
+		if !ocidump.IsStdoutPath(appConfig.Output.File) {
+			if err := ocidump.OutputResourcesToFile(resources, config.OutputFormat, appConfig.Output.File, appConfig.Output.CSV); err != nil {
+				return fmt.Errorf("error writing dump: %v", err)
+			}
+		}
+		if appConfig.History.Enabled {
+			if err := recordHistory(appConfig.History, resources, ""); err != nil {
+				logger.Error("%v", err)
+			}
+		}
+
+		if previous == nil {
+			logger.Info("Recorded baseline of %d resources", len(resources))
+			previous, previousLabel = resources, label
+			return nil
+		}
+		result := ocidump.DiffResources(previous, resources, previousLabel, label, diffConfig)
+		previous, previousLabel = resources, label
+
+		summary := result.Summary
+		if !summary.HasChanges() {
+			logger.Info("No changes since the previous run (%d resources)", summary.TotalNew)
+			return nil
+		}
+		logger.Info("Changes since the previous run: +%d added, -%d removed, ~%d modified, >%d moved", summary.Added, summary.Removed, summary.Modified, summary.Moved)
+
+		runDiffConfig := diffConfig
+		runDiffConfig.OutputFile = ocidump.WatchDiffPath(diffConfig.OutputFile, startedAt)
+		if err := ocidump.OutputDiffResult(result, runDiffConfig); err != nil {
+			return fmt.Errorf("error outputting diff results: %v", err)
+		}
+		if err := ocidump.NotifyDiff(ctx, appConfig.Notify, result); err != nil {
+			logger.Error("Error sending diff notifications: %v", err)
+		}
+		return nil
+	})
+
+	if errors.Is(err, context.Canceled) {
+		logger.Info("Watch stopped")
+		return nil
+	}
+	return err
+}
+
+// notifyCompletion announces a finished run to the configured completion targets
+// Notification failures are logged and do not change the outcome of the run.
+func notifyCompletion(appConfig *ocidump.AppConfig, record *ocidump.AuditRecord) {
+	if !appConfig.Notify.Completion.Enabled() {
+		return
+	}
+	if err := ocidump.NotifyCompletion(context.Background(), appConfig.Notify.Completion, record); err != nil {
+		logger.Error("Error sending completion notifications: %v", err)
+	}
+}
+
+// auditParameters returns the scope of a run as recorded in the audit log
+func auditParameters(appConfig *ocidump.AppConfig, config *ocidump.Config) ocidump.AuditParameters {
+	return ocidump.AuditParameters{
+		Args:         os.Args[1:],
+		OutputFormat: config.OutputFormat,
+		OutputFile:   appConfig.Output.File,
+		Mode:         appConfig.General.Mode,
+		Detail:       config.Detail,
+		Filters:      config.Filters,
+	}
+}
+
+// uploadOutput uploads the output file to the configured upload targets
+func uploadOutput(ctx context.Context, appConfig *ocidump.AppConfig, format string) error {
+	if !appConfig.Upload.S3.Enabled() {
+		return nil
+	}
+	if err := ocidump.UploadFileToS3(ctx, appConfig.Upload.S3, appConfig.Output.File, ocidump.OutputContentType(format)); err != nil {
+		return fmt.Errorf("error uploading output: %v", err)
+	}
+	return nil
+}
+
+// streamResources discovers resources and writes each batch as soon as it is available
+// With collect, the streamed resources are also returned, e.g. to record them in the history store
+func streamResources(ctx, signalCtx context.Context, options ocidump.Options, format string, outputConfig ocidump.OutputConfig, originatorPatterns *ocidump.CompiledOriginatorPatterns, collect bool) ([]ocidump.Resource, error) {
+	out := os.Stdout
+	var target io.Writer = os.Stdout
+	if !ocidump.IsStdoutPath(outputConfig.File) {
+		logger.Info("Streaming output to file: %s", outputConfig.File)
+		file, err := os.Create(outputConfig.File)
+		if err != nil {
+			return nil, fmt.Errorf("error outputting resources to file: failed to create output file: %v", err)
+		}
+		out = file
+		target = file
+		if outputConfig.Tee {
+			target = io.MultiWriter(file, os.Stdout)
+		}
+	}
+
+	writer, err := ocidump.NewResourceWriter(target, format, outputConfig.CSV)
+	if err != nil {
+		if out != os.Stdout {
+			out.Close()
+		}
+		return nil, fmt.Errorf("error outputting resources: %v", err)
+	}
+	sink, waitForWriter := ocidump.NewStreamSink(writer)
+	var collected []ocidump.Resource
+	options.Sink = func(resources []ocidump.Resource) error {
+		ocidump.ApplyOriginatorClassification(resources, originatorPatterns)
+		if collect {
+			collected = append(collected, resources...)
+		}
+		return sink(resources)
+	}
+
+	_, err = ocidump.Discover(ctx, options)
+	if writeErr := waitForWriter(); writeErr != nil && err == nil {
+		err = fmt.Errorf("failed to write streamed resources: %w", writeErr)
+	}
+	if closeErr := writer.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	if out != os.Stdout {
+		if closeErr := ocidump.CloseOutputFile(out); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		if signalCtx.Err() != nil {
+			return nil, fmt.Errorf("resource discovery interrupted by signal: %v", err)
+		}
+		return nil, fmt.Errorf("error discovering resources: %v", err)
+	}
+
+	logger.Verbose("Streamed %d resources in %s format", writer.Count(), format)
+	return collected, nil
 }
@@ -0,0 +1,106 @@
+package ocidump
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// adaptiveLimit limits the requests in flight to a service with additive increase and
+// multiplicative decrease: a 429 response halves the limit, and every successful response
+// raises it by 1/limit, so the limit grows by about one per round trip of requests.
+type adaptiveLimit struct {
+	service string
+	max     int
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	// generation counts decreases; a throttled request started before the last decrease
+	// was sent under the old limit and must not halve the limit again
+	generation int
+	changed    chan struct{}
+}
+
+func newAdaptiveLimit(service string, max int) *adaptiveLimit {
+	return &adaptiveLimit{service: service, max: max, limit: float64(max), changed: make(chan struct{})}
+}
+
+// current returns the number of requests allowed in flight
+func (a *adaptiveLimit) current() int {
+	return max(1, int(a.limit))
+}
+
+// acquire waits for a free slot and returns the generation the request is sent in
+func (a *adaptiveLimit) acquire(ctx context.Context) (int, error) {
+	for {
+		a.mu.Lock()
+		if a.inFlight < a.current() {
+			a.inFlight++
+			generation := a.generation
+			a.mu.Unlock()
+			return generation, nil
+		}
+		changed := a.changed
+		a.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// release frees the slot of a request and adjusts the limit to its response status
+// A status of 0 (no response) and server errors leave the limit unchanged.
+func (a *adaptiveLimit) release(generation, status int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inFlight--
+
+	switch {
+	case status == http.StatusTooManyRequests:
+		if generation == a.generation {
+			a.generation++
+			a.limit = max(1, a.limit/2)
+			logger.Verbose("Throttled by %s, reducing concurrent requests to %d", a.service, a.current())
+		}
+	case status > 0 && status < http.StatusInternalServerError:
+		if a.limit < float64(a.max) {
+			previous := a.current()
+			a.limit = min(float64(a.max), a.limit+1/a.limit)
+			if a.current() > previous {
+				logger.Debug("Raising concurrent %s requests to %d", a.service, a.current())
+			}
+		}
+	}
+
+	// Wake up waiting requests, a slot is free and the limit may have grown
+	close(a.changed)
+	a.changed = make(chan struct{})
+}
+
+// adaptiveDispatcher sends the requests of an SDK client within the adaptive limit of its service
+type adaptiveDispatcher struct {
+	dispatcher common.HTTPRequestDispatcher
+	service    string
+	limiter    *RateLimiter
+}
+
+func (d *adaptiveDispatcher) Do(request *http.Request) (*http.Response, error) {
+	limit := d.limiter.concurrency(d.service)
+	generation, err := limit.acquire(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	response, err := d.dispatcher.Do(request)
+	status := 0
+	if err == nil && response != nil {
+		status = response.StatusCode
+	}
+	limit.release(generation, status)
+	return response, err
+}
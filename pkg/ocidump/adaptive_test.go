@@ -0,0 +1,149 @@
+package ocidump
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+func TestAdaptiveLimit_Feedback(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	limit := newAdaptiveLimit("core", 8)
+
+	// Two requests throttled in the same window halve the limit once
+	first, _ := limit.acquire(context.Background())
+	second, _ := limit.acquire(context.Background())
+	limit.release(first, http.StatusTooManyRequests)
+	limit.release(second, http.StatusTooManyRequests)
+	if got := limit.current(); got != 4 {
+		t.Errorf("limit after one throttled window = %d, expected 4", got)
+	}
+
+	// A request sent under the reduced limit halves it again, down to one
+	generation, _ := limit.acquire(context.Background())
+	limit.release(generation, http.StatusTooManyRequests)
+	for i := 0; i < 3; i++ {
+		generation, _ = limit.acquire(context.Background())
+		limit.release(generation, http.StatusTooManyRequests)
+	}
+	if got := limit.current(); got != 1 {
+		t.Errorf("limit after repeated throttling = %d, expected 1", got)
+	}
+
+	// Errors without a response and server errors leave the limit alone
+	generation, _ = limit.acquire(context.Background())
+	limit.release(generation, 0)
+	generation, _ = limit.acquire(context.Background())
+	limit.release(generation, http.StatusServiceUnavailable)
+	if got := limit.current(); got != 1 {
+		t.Errorf("limit after errors = %d, expected 1", got)
+	}
+
+	// Successful responses ramp back up to the maximum, but not beyond
+	for i := 0; i < 100; i++ {
+		generation, _ = limit.acquire(context.Background())
+		limit.release(generation, http.StatusOK)
+	}
+	if got := limit.current(); got != 8 {
+		t.Errorf("limit after successes = %d, expected 8", got)
+	}
+	if limit.inFlight != 0 {
+		t.Errorf("%d requests still in flight", limit.inFlight)
+	}
+}
+
+func TestAdaptiveLimit_Acquire(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	limit := newAdaptiveLimit("database", 1)
+	generation, err := limit.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second request waits for the slot and gives up with its context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limit.acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("acquire = %v, expected context.DeadlineExceeded", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		if _, err := limit.acquire(context.Background()); err == nil {
+			close(acquired)
+		}
+	}()
+	limit.release(generation, http.StatusOK)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("waiting request was not woken up by release")
+	}
+}
+
+// statusDispatcher answers every request with a fixed status and records the peak concurrency
+type statusDispatcher struct {
+	status int
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (d *statusDispatcher) Do(request *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	d.inFlight++
+	d.peak = max(d.peak, d.inFlight)
+	d.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	d.mu.Lock()
+	d.inFlight--
+	d.mu.Unlock()
+	return &http.Response{StatusCode: d.status, Request: request}, nil
+}
+
+func TestRateLimiter_Adaptive(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	limiter := NewRateLimiter(RateLimitConfig{Adaptive: true, MaxConcurrency: 4})
+	if limiter == nil {
+		t.Fatal("expected a rate limiter with adaptive concurrency")
+	}
+	if got := limiter.workers(5); got != 5 {
+		t.Errorf("workers = %d, expected at least the default 5", got)
+	}
+
+	dispatcher := &statusDispatcher{status: http.StatusTooManyRequests}
+	baseClient := common.BaseClient{HTTPClient: dispatcher}
+	limiter.Limit(&baseClient, "objectstorage")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			request, _ := http.NewRequest(http.MethodGet, "https://objectstorage.example.com", nil)
+			if _, err := baseClient.HTTPClient.Do(request); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if dispatcher.peak > 4 {
+		t.Errorf("peak concurrency = %d, expected at most max_concurrency 4", dispatcher.peak)
+	}
+	if got := limiter.concurrency("objectstorage").current(); got != 1 {
+		t.Errorf("limit after throttling = %d, expected 1", got)
+	}
+
+	var nilLimiter *RateLimiter
+	if got := nilLimiter.workers(5); got != 5 {
+		t.Errorf("nil limiter workers = %d, expected 5", got)
+	}
+}
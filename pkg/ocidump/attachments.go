@@ -0,0 +1,217 @@
+package ocidump
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// volumeAttachment links an attached block or boot volume to its instance
+type volumeAttachment struct {
+	InstanceID string
+	VolumeID   string
+}
+
+// volumeAttachmentResourceTypes lists the resource types enriched by the volume attachment pass
+var volumeAttachmentResourceTypes = []string{"ComputeInstances", "BlockVolumes", "BootVolumes"}
+
+// shouldMapVolumeAttachments checks if any resource type enriched by attachments is selected
+func shouldMapVolumeAttachments(filters FilterConfig) bool {
+	for _, resourceType := range volumeAttachmentResourceTypes {
+		if ApplyResourceTypeFilter(resourceType, filters) {
+			return true
+		}
+	}
+	return false
+}
+
+// attachmentCoverage records the attachment listings that succeeded, so a volume is only
+// reported as unattached when the attachments that could reference it were actually listed
+type attachmentCoverage struct {
+	blockVolumes map[string]bool            // compartment OCID -> block volume attachments listed
+	bootVolumes  map[string]map[string]bool // compartment OCID -> availability domain -> boot volume attachments listed
+}
+
+func newAttachmentCoverage() *attachmentCoverage {
+	return &attachmentCoverage{blockVolumes: make(map[string]bool), bootVolumes: make(map[string]map[string]bool)}
+}
+
+// addBootVolumes records that the boot volume attachments of an availability domain were listed
+func (c *attachmentCoverage) addBootVolumes(compartmentID, availabilityDomain string) {
+	if c.bootVolumes[compartmentID] == nil {
+		c.bootVolumes[compartmentID] = make(map[string]bool)
+	}
+	c.bootVolumes[compartmentID][availabilityDomain] = true
+}
+
+// covers reports whether every attachment listing that could reference the volume succeeded
+func (c *attachmentCoverage) covers(resource ResourceInfo) bool {
+	switch resource.ResourceType {
+	case "BlockVolume":
+		return c.blockVolumes[resource.CompartmentID]
+	case "BootVolume":
+		availabilityDomain, _ := resource.AdditionalInfo["availability_domain"].(string)
+		return c.bootVolumes[resource.CompartmentID][availabilityDomain]
+	}
+	return false
+}
+
+// mapVolumeAttachments lists volume attachments in the given compartments and records
+// them on the discovered instance and volume resources
+// Listings that fail are logged; attachments found elsewhere, or before the failure, are still applied.
+func mapVolumeAttachments(ctx context.Context, clients *OCIClients, compartmentIDs []string, resources []ResourceInfo) {
+	var allAttachments []volumeAttachment
+	coverage := newAttachmentCoverage()
+
+	for _, compartmentID := range compartmentIDs {
+		attachments, err := listBlockVolumeAttachments(ctx, clients, compartmentID)
+		allAttachments = append(allAttachments, attachments...)
+		if err != nil {
+			logger.Verbose("Error listing volume attachments in compartment %s: %v", compartmentID, err)
+		} else {
+			coverage.blockVolumes[compartmentID] = true
+		}
+
+		// Boot volume attachments can only be listed per availability domain
+		availabilityDomains, err := getAvailabilityDomains(ctx, clients, compartmentID)
+		if err != nil {
+			logger.Verbose("Error listing availability domains for boot volume attachments in compartment %s: %v", compartmentID, err)
+			continue
+		}
+		for _, ad := range availabilityDomains {
+			if ad.Name == nil {
+				continue
+			}
+			attachments, err := listBootVolumeAttachments(ctx, clients, compartmentID, *ad.Name)
+			allAttachments = append(allAttachments, attachments...)
+			if err != nil {
+				logger.Verbose("Error listing boot volume attachments in compartment %s, %s: %v", compartmentID, *ad.Name, err)
+				continue
+			}
+			coverage.addBootVolumes(compartmentID, *ad.Name)
+		}
+	}
+
+	applyVolumeAttachments(resources, allAttachments, coverage)
+	logger.Verbose("Mapped %d volume attachments across %d compartments", len(allAttachments), len(compartmentIDs))
+}
+
+// listBlockVolumeAttachments retrieves the attached block volume attachments in a compartment
+// On error, the attachments of the pages read before the failure are returned with it.
+func listBlockVolumeAttachments(ctx context.Context, clients *OCIClients, compartmentID string) ([]volumeAttachment, error) {
+	var attachments []volumeAttachment
+
+	// Implement pagination to get all block volume attachments
+	var page *string
+	for {
+		req := core.ListVolumeAttachmentsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ComputeClient.ListVolumeAttachments(ctx, req)
+		if err != nil {
+			return attachments, err
+		}
+
+		for _, attachment := range resp.Items {
+			if attachment.GetLifecycleState() != core.VolumeAttachmentLifecycleStateAttached {
+				continue
+			}
+			if attachment.GetInstanceId() == nil || attachment.GetVolumeId() == nil {
+				continue
+			}
+			attachments = append(attachments, volumeAttachment{
+				InstanceID: *attachment.GetInstanceId(),
+				VolumeID:   *attachment.GetVolumeId(),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	return attachments, nil
+}
+
+// listBootVolumeAttachments retrieves the attached boot volume attachments in an availability domain of a compartment
+// On error, the attachments of the pages read before the failure are returned with it.
+func listBootVolumeAttachments(ctx context.Context, clients *OCIClients, compartmentID, availabilityDomain string) ([]volumeAttachment, error) {
+	var attachments []volumeAttachment
+
+	var page *string
+	for {
+		req := core.ListBootVolumeAttachmentsRequest{
+			AvailabilityDomain: common.String(availabilityDomain),
+			CompartmentId:      common.String(compartmentID),
+			Page:               page,
+			Limit:              clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ComputeClient.ListBootVolumeAttachments(ctx, req)
+		if err != nil {
+			return attachments, err
+		}
+
+		for _, attachment := range resp.Items {
+			if attachment.LifecycleState != core.BootVolumeAttachmentLifecycleStateAttached {
+				continue
+			}
+			if attachment.InstanceId == nil || attachment.BootVolumeId == nil {
+				continue
+			}
+			attachments = append(attachments, volumeAttachment{
+				InstanceID: *attachment.InstanceId,
+				VolumeID:   *attachment.BootVolumeId,
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	return attachments, nil
+}
+
+// applyVolumeAttachments sets attached_volumes on ComputeInstance resources and attached_instance
+// on BlockVolume / BootVolume resources. Volumes without attachments get an empty attached_instance
+// when coverage shows their attachments were listed, so orphaned volumes can be told apart from
+// volumes that were never checked or whose attachments could not be listed.
+func applyVolumeAttachments(resources []ResourceInfo, attachments []volumeAttachment, coverage *attachmentCoverage) {
+	volumesByInstance := make(map[string][]string)
+	instancesByVolume := make(map[string][]string)
+	for _, attachment := range attachments {
+		volumesByInstance[attachment.InstanceID] = append(volumesByInstance[attachment.InstanceID], attachment.VolumeID)
+		instancesByVolume[attachment.VolumeID] = append(instancesByVolume[attachment.VolumeID], attachment.InstanceID)
+	}
+
+	for _, resource := range resources {
+		if resource.AdditionalInfo == nil {
+			continue
+		}
+
+		switch resource.ResourceType {
+		case "ComputeInstance":
+			if volumes, ok := volumesByInstance[resource.OCID]; ok {
+				sort.Strings(volumes)
+				resource.AdditionalInfo["attached_volumes"] = volumes
+			}
+		case "BlockVolume", "BootVolume":
+			// Shareable block volumes can be attached to several instances
+			instances, ok := instancesByVolume[resource.OCID]
+			if !ok && !coverage.covers(resource) {
+				continue
+			}
+			sort.Strings(instances)
+			resource.AdditionalInfo["attached_instance"] = strings.Join(instances, ",")
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestApplyVolumeAttachments(t *testing.T) {
+	resources := []ResourceInfo{
+		{ResourceType: "ComputeInstance", OCID: "ocid1.instance.oc1..a", AdditionalInfo: map[string]interface{}{}},
+		{ResourceType: "ComputeInstance", OCID: "ocid1.instance.oc1..b", AdditionalInfo: map[string]interface{}{}},
+		{ResourceType: "BootVolume", OCID: "ocid1.bootvolume.oc1..boot", AdditionalInfo: map[string]interface{}{}},
+		{ResourceType: "BlockVolume", OCID: "ocid1.volume.oc1..shared", AdditionalInfo: map[string]interface{}{}},
+		{ResourceType: "BlockVolume", OCID: "ocid1.volume.oc1..orphan", AdditionalInfo: map[string]interface{}{}},
+		{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..vcn", AdditionalInfo: map[string]interface{}{}},
+	}
+
+	attachments := []volumeAttachment{
+		{InstanceID: "ocid1.instance.oc1..a", VolumeID: "ocid1.volume.oc1..shared"},
+		{InstanceID: "ocid1.instance.oc1..a", VolumeID: "ocid1.bootvolume.oc1..boot"},
+		{InstanceID: "ocid1.instance.oc1..b", VolumeID: "ocid1.volume.oc1..shared"},
+	}
+
+	coverage := newAttachmentCoverage()
+	coverage.blockVolumes[""] = true
+	coverage.addBootVolumes("", "")
+	applyVolumeAttachments(resources, attachments, coverage)
+
+	wantVolumes := []string{"ocid1.bootvolume.oc1..boot", "ocid1.volume.oc1..shared"}
+	if got := resources[0].AdditionalInfo["attached_volumes"]; !reflect.DeepEqual(got, wantVolumes) {
+		t.Errorf("instance a attached_volumes = %v, want %v", got, wantVolumes)
+	}
+	if got := resources[2].AdditionalInfo["attached_instance"]; got != "ocid1.instance.oc1..a" {
+		t.Errorf("boot volume attached_instance = %v, want %q", got, "ocid1.instance.oc1..a")
+	}
+	if got := resources[3].AdditionalInfo["attached_instance"]; got != "ocid1.instance.oc1..a,ocid1.instance.oc1..b" {
+		t.Errorf("shared volume attached_instance = %v, want both instances", got)
+	}
+	if got, ok := resources[4].AdditionalInfo["attached_instance"]; !ok || got != "" {
+		t.Errorf("orphaned volume attached_instance = %v (present: %v), want empty string", got, ok)
+	}
+	if _, ok := resources[5].AdditionalInfo["attached_instance"]; ok {
+		t.Error("VCN should not receive attached_instance")
+	}
+}
+
+func TestShouldMapVolumeAttachments(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters FilterConfig
+		want    bool
+	}{
+		{"no filters", FilterConfig{}, true},
+		{"volumes included", FilterConfig{IncludeResourceTypes: []string{"BlockVolumes"}}, true},
+		{"unrelated types only", FilterConfig{IncludeResourceTypes: []string{"VCNs"}}, false},
+		{"all related types excluded", FilterConfig{ExcludeResourceTypes: []string{"ComputeInstances", "BlockVolumes", "BootVolumes"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldMapVolumeAttachments(tt.filters); got != tt.want {
+				t.Errorf("shouldMapVolumeAttachments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapVolumeAttachments_ListingFailures(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const (
+		appCompartment = "ocid1.compartment.oc1..app"
+		dbCompartment  = "ocid1.compartment.oc1..db"
+		ad1            = "AD-1"
+		ad2            = "AD-2"
+	)
+	volumeAttachment := func(compartmentID, instanceID, volumeID string) core.VolumeAttachment {
+		return core.ParavirtualizedVolumeAttachment{
+			CompartmentId:  common.String(compartmentID),
+			InstanceId:     common.String(instanceID),
+			VolumeId:       common.String(volumeID),
+			LifecycleState: core.VolumeAttachmentLifecycleStateAttached,
+		}
+	}
+	compute := &fakeCompute{
+		volumeAttachments: []core.VolumeAttachment{
+			volumeAttachment(dbCompartment, "ocid1.instance.oc1..db", "ocid1.volume.oc1..app-shared"),
+			volumeAttachment(dbCompartment, "ocid1.instance.oc1..db", "ocid1.volume.oc1..db-data"),
+		},
+		bootVolumeAttachments: []core.BootVolumeAttachment{
+			{CompartmentId: common.String(dbCompartment), AvailabilityDomain: common.String(ad1), InstanceId: common.String("ocid1.instance.oc1..db"),
+				BootVolumeId: common.String("ocid1.bootvolume.oc1..db"), LifecycleState: core.BootVolumeAttachmentLifecycleStateAttached},
+		},
+		volumeAttachmentErrs:     map[string]error{appCompartment: errors.New("service unavailable")},
+		bootVolumeAttachmentErrs: map[string]error{ad2: errors.New("service unavailable")},
+	}
+	clients := newFakeOCIClients(&fakeIdentity{availabilityDomains: []string{ad1, ad2}}, &fakeVirtualNetwork{}, compute)
+
+	volume := func(resourceType, ocid, compartmentID, availabilityDomain string) ResourceInfo {
+		info := map[string]interface{}{}
+		if availabilityDomain != "" {
+			info["availability_domain"] = availabilityDomain
+		}
+		return ResourceInfo{ResourceType: resourceType, OCID: ocid, CompartmentID: compartmentID, AdditionalInfo: info}
+	}
+	resources := []ResourceInfo{
+		{ResourceType: "ComputeInstance", OCID: "ocid1.instance.oc1..db", CompartmentID: dbCompartment, AdditionalInfo: map[string]interface{}{}},
+		volume("BlockVolume", "ocid1.volume.oc1..app-shared", appCompartment, ad1),
+		volume("BlockVolume", "ocid1.volume.oc1..app-unknown", appCompartment, ad1),
+		volume("BlockVolume", "ocid1.volume.oc1..db-data", dbCompartment, ad1),
+		volume("BlockVolume", "ocid1.volume.oc1..db-orphan", dbCompartment, ad1),
+		volume("BootVolume", "ocid1.bootvolume.oc1..db", dbCompartment, ad1),
+		volume("BootVolume", "ocid1.bootvolume.oc1..app-ad1", appCompartment, ad1),
+		volume("BootVolume", "ocid1.bootvolume.oc1..app-ad2", appCompartment, ad2),
+	}
+
+	mapVolumeAttachments(context.Background(), clients, []string{appCompartment, dbCompartment}, resources)
+
+	want := map[string]interface{}{
+		"ocid1.volume.oc1..app-shared":  "ocid1.instance.oc1..db", // found in another compartment despite the failure
+		"ocid1.volume.oc1..app-unknown": nil,                      // block volume attachments of app could not be listed
+		"ocid1.volume.oc1..db-data":     "ocid1.instance.oc1..db",
+		"ocid1.volume.oc1..db-orphan":   "",
+		"ocid1.bootvolume.oc1..db":      "ocid1.instance.oc1..db",
+		"ocid1.bootvolume.oc1..app-ad1": "",  // AD-1 was listed although block volumes failed
+		"ocid1.bootvolume.oc1..app-ad2": nil, // AD-2 boot volume attachments could not be listed
+	}
+	for _, resource := range resources[1:] {
+		if got := resource.AdditionalInfo["attached_instance"]; got != want[resource.OCID] {
+			t.Errorf("%s attached_instance = %#v, want %#v", resource.OCID, got, want[resource.OCID])
+		}
+	}
+	wantVolumes := []string{"ocid1.bootvolume.oc1..db", "ocid1.volume.oc1..app-shared", "ocid1.volume.oc1..db-data"}
+	if got := resources[0].AdditionalInfo["attached_volumes"]; !reflect.DeepEqual(got, wantVolumes) {
+		t.Errorf("instance attached_volumes = %v, want %v", got, wantVolumes)
+	}
+}
@@ -0,0 +1,181 @@
+package ocidump
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path"
+	"time"
+)
+
+// Audit record statuses
+const (
+	AuditStatusSuccess     = "success"
+	AuditStatusFailed      = "failed"
+	AuditStatusInterrupted = "interrupted"
+)
+
+// AuditConfig holds the destinations of the run audit log
+type AuditConfig struct {
+	File string         `yaml:"file"` // Append one JSON line per run to this file
+	S3   S3UploadConfig `yaml:"s3"`   // Upload each record as an object; key is the object key prefix
+}
+
+// Enabled reports whether an audit destination is configured
+func (c AuditConfig) Enabled() bool {
+	return c.File != "" || c.S3.Enabled()
+}
+
+// Validate checks the audit destinations
+func (c AuditConfig) Validate() error {
+	var problems []error
+	for _, err := range splitErrors(c.S3.Validate()) {
+		problems = append(problems, fmt.Errorf("audit: %w", err))
+	}
+	return errors.Join(problems...)
+}
+
+// AuditRecord describes a single run of the tool
+type AuditRecord struct {
+	RunID           string          `json:"run_id"`
+	Command         string          `json:"command"` // dump, daemon or serve
+	StartedAt       time.Time       `json:"started_at"`
+	FinishedAt      time.Time       `json:"finished_at"`
+	DurationSeconds float64         `json:"duration_seconds"`
+	Status          string          `json:"status"` // success, failed or interrupted
+	Error           string          `json:"error,omitempty"`
+	Caller          AuditCaller     `json:"caller"`
+	Parameters      AuditParameters `json:"parameters"`
+
+	Compartments    int            `json:"compartments"`
+	ResourceCount   int            `json:"resource_count"`
+	ResourceCounts  map[string]int `json:"resource_counts,omitempty"` // Resources per resource type
+	DiscoveryErrors int            `json:"discovery_errors"`          // Resource type discoveries that failed after retries
+}
+
+// AuditCaller identifies who ran the tool
+type AuditCaller struct {
+	Hostname       string `json:"hostname,omitempty"`
+	User           string `json:"user,omitempty"`
+	Authentication string `json:"authentication"`
+	TenancyID      string `json:"tenancy_id,omitempty"`
+}
+
+// AuditParameters records the scope of a run
+type AuditParameters struct {
+	Args         []string     `json:"args"` // Command-line arguments
+	OutputFormat string       `json:"output_format,omitempty"`
+	OutputFile   string       `json:"output_file,omitempty"`
+	Mode         string       `json:"mode,omitempty"`
+	Detail       bool         `json:"detail"`
+	Filters      FilterConfig `json:"filters"`
+}
+
+// NewAuditRecord starts the audit record of a run with the local caller identity
+func NewAuditRecord(command string, parameters AuditParameters, startedAt time.Time) *AuditRecord {
+	record := &AuditRecord{
+		RunID:      newRunID(),
+		Command:    command,
+		StartedAt:  startedAt.UTC(),
+		Parameters: parameters,
+		Caller:     AuditCaller{Authentication: "instance_principal"},
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		record.Caller.Hostname = hostname
+	}
+	if current, err := user.Current(); err == nil {
+		record.Caller.User = current.Username
+	}
+	return record
+}
+
+// newRunID returns a random identifier for a run
+func newRunID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// Finish completes the record with the outcome of the run
+// ctx tells an interrupted run (cancelled or timed out) from a failed one
+func (r *AuditRecord) Finish(ctx context.Context, resources []ResourceInfo, stats DiscoveryStats, err error, finishedAt time.Time) {
+	r.FinishedAt = finishedAt.UTC()
+	r.DurationSeconds = r.FinishedAt.Sub(r.StartedAt).Round(time.Millisecond).Seconds()
+	r.Caller.TenancyID = stats.TenancyID
+	r.Compartments = stats.Compartments
+	r.ResourceCount = stats.Resources
+	r.DiscoveryErrors = stats.Errors
+
+	if resources != nil {
+		r.ResourceCount = len(resources)
+		r.ResourceCounts = make(map[string]int)
+		for _, resource := range resources {
+			r.ResourceCounts[resource.ResourceType]++
+		}
+	}
+
+	switch {
+	case err == nil:
+		r.Status = AuditStatusSuccess
+	case ctx.Err() != nil:
+		r.Status = AuditStatusInterrupted
+		r.Error = err.Error()
+	default:
+		r.Status = AuditStatusFailed
+		r.Error = err.Error()
+	}
+}
+
+// objectKey returns the key of the record object under the configured prefix
+func (r *AuditRecord) objectKey(prefix string) string {
+	return path.Join(prefix, fmt.Sprintf("%s-%s.json", r.StartedAt.Format(dumpTimestampLayout), r.RunID))
+}
+
+// WriteAuditRecord appends the record to the audit file and uploads it to the audit bucket
+// Both destinations are attempted; their errors are joined
+func WriteAuditRecord(ctx context.Context, config AuditConfig, record *AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+
+	var errs []error
+	if config.File != "" {
+		if err := appendAuditLine(config.File, data); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write audit file: %w", err))
+		}
+	}
+	if config.S3.Enabled() {
+		if err := putS3Object(ctx, config.S3, record.objectKey(config.S3.Key), bytes.NewReader(data), "application/json"); err != nil {
+			errs = append(errs, fmt.Errorf("failed to upload audit record: %w", err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	logger.Verbose("Recorded run %s in the audit log", record.RunID)
+	return nil
+}
+
+// appendAuditLine appends a JSON line to the audit file, creating it if needed
+// The file is only ever appended to and is synced so a record survives a crash right after the run
+func appendAuditLine(filename string, data []byte) error {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return CloseOutputFile(file)
+}
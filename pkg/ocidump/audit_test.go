@@ -0,0 +1,106 @@
+package ocidump
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditRecord_Finish(t *testing.T) {
+	startedAt := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	stats := DiscoveryStats{TenancyID: "ocid1.tenancy.oc1..t", Compartments: 3, Resources: 2, Errors: 1}
+	resources := []ResourceInfo{
+		{ResourceType: "ComputeInstance", OCID: "ocid1.instance.oc1..a"},
+		{ResourceType: "ComputeInstance", OCID: "ocid1.instance.oc1..b"},
+		{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..c"},
+	}
+
+	record := NewAuditRecord("dump", AuditParameters{Args: []string{"-f", "json"}}, startedAt)
+	record.Finish(context.Background(), resources, stats, nil, startedAt.Add(90*time.Second))
+	if record.Status != AuditStatusSuccess || record.Error != "" {
+		t.Errorf("status = %s, error = %q, expected success", record.Status, record.Error)
+	}
+	if record.DurationSeconds != 90 {
+		t.Errorf("duration = %v, expected 90", record.DurationSeconds)
+	}
+	if record.ResourceCount != 3 || record.ResourceCounts["ComputeInstance"] != 2 || record.ResourceCounts["VCN"] != 1 {
+		t.Errorf("counts = %d %v", record.ResourceCount, record.ResourceCounts)
+	}
+	if record.Compartments != 3 || record.DiscoveryErrors != 1 || record.Caller.TenancyID != stats.TenancyID {
+		t.Errorf("stats not recorded: %+v", record)
+	}
+	if record.RunID == "" || record.Caller.Authentication != "instance_principal" {
+		t.Errorf("run ID or caller missing: %+v", record)
+	}
+
+	// Without resources, e.g. streamed output, the discovered count is used
+	streamed := NewAuditRecord("dump", AuditParameters{}, startedAt)
+	streamed.Finish(context.Background(), nil, stats, errors.New("upload failed"), startedAt)
+	if streamed.Status != AuditStatusFailed || streamed.Error != "upload failed" || streamed.ResourceCount != 2 {
+		t.Errorf("failed run recorded as %+v", streamed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	interrupted := NewAuditRecord("dump", AuditParameters{}, startedAt)
+	interrupted.Finish(ctx, nil, DiscoveryStats{}, context.Canceled, startedAt)
+	if interrupted.Status != AuditStatusInterrupted {
+		t.Errorf("status = %s, expected interrupted", interrupted.Status)
+	}
+}
+
+func TestAuditRecord_ObjectKey(t *testing.T) {
+	record := &AuditRecord{RunID: "0123abcd", StartedAt: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	if key := record.objectKey("audit/"); key != "audit/20250310T090000Z-0123abcd.json" {
+		t.Errorf("objectKey = %s", key)
+	}
+	if key := record.objectKey(""); key != "20250310T090000Z-0123abcd.json" {
+		t.Errorf("objectKey without prefix = %s", key)
+	}
+}
+
+func TestWriteAuditRecord_AppendsToFile(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	filename := filepath.Join(t.TempDir(), "audit.jsonl")
+	config := AuditConfig{File: filename}
+
+	for _, command := range []string{"dump", "daemon"} {
+		record := NewAuditRecord(command, AuditParameters{}, time.Now())
+		record.Finish(context.Background(), nil, DiscoveryStats{}, nil, time.Now())
+		if err := WriteAuditRecord(context.Background(), config, record); err != nil {
+			t.Fatalf("WriteAuditRecord failed: %v", err)
+		}
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var commands []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		commands = append(commands, record.Command)
+	}
+	if strings.Join(commands, ",") != "dump,daemon" {
+		t.Errorf("audit file records = %v, expected both runs in order", commands)
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("audit file mode = %v, expected 0600", info.Mode().Perm())
+	}
+}
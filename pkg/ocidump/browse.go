@@ -0,0 +1,301 @@
+package ocidump
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// browsePageSize is the number of entries shown per page of a list
+const browsePageSize = 20
+
+// Browser is an interactive browser over the resources of a dump
+//
+// It navigates compartments → resource types → resources → resource details and searches
+// across all resources. RunTUI shows the views full-screen with cursor navigation; Run reads
+// input a line at a time, so it works over pipes and in terminals without cursor support:
+// a number opens an entry, "b" goes back, "/text" searches, "n"/"p" page and "q" quits.
+// Each command updates the view stack and the current view is then rendered again.
+type Browser struct {
+	resources []ResourceInfo
+	input     io.Reader
+	in        *bufio.Scanner
+	out       io.Writer
+	clear     bool // clear the screen before each view (interactive terminals)
+
+	stack   []*browseView
+	message string // shown once below the next view, e.g. for invalid input
+}
+
+// browseView is a screen of the browser: a paged list of entries or the details of a resource
+type browseView struct {
+	title    string
+	entries  []browseEntry
+	page     int
+	resource *ResourceInfo // set for the details view
+
+	cursor int // selected entry, or first detail line shown, in the full-screen UI
+	scroll int // first entry shown in the full-screen UI
+}
+
+// browseEntry is a selectable line of a list view
+type browseEntry struct {
+	label string
+	open  func() *browseView
+}
+
+// NewBrowser returns a browser over resources reading commands from in and rendering to out
+// With clear, the screen is cleared with ANSI escape sequences before each view.
+func NewBrowser(resources []ResourceInfo, in io.Reader, out io.Writer, clear bool) *Browser {
+	b := &Browser{resources: resources, input: in, in: bufio.NewScanner(in), out: out, clear: clear}
+	b.stack = []*browseView{b.compartmentsView()}
+	return b
+}
+
+// Run shows the views until the user quits or the input ends
+func (b *Browser) Run() error {
+	for {
+		if err := b.render(); err != nil {
+			return err
+		}
+		if !b.in.Scan() {
+			return b.in.Err()
+		}
+		if quit := b.update(strings.TrimSpace(b.in.Text())); quit {
+			return nil
+		}
+	}
+}
+
+// current returns the view on top of the stack
+func (b *Browser) current() *browseView {
+	return b.stack[len(b.stack)-1]
+}
+
+// update applies a command to the view stack and reports whether to quit
+func (b *Browser) update(command string) bool {
+	view := b.current()
+	switch {
+	case command == "":
+	case command == "q" || command == "quit":
+		return true
+	case command == "b" || command == "..":
+		if len(b.stack) > 1 {
+			b.stack = b.stack[:len(b.stack)-1]
+		}
+	case command == "n":
+		if (view.page+1)*browsePageSize < len(view.entries) {
+			view.page++
+		}
+	case command == "p":
+		if view.page > 0 {
+			view.page--
+		}
+	case command == "?" || command == "h":
+		b.message = "Commands: <number> open, b back, n/p next/previous page, /text search, q quit"
+	case strings.HasPrefix(command, "/"):
+		query := strings.TrimSpace(command[1:])
+		if query == "" {
+			b.message = "Usage: /text searches names, OCIDs, types, compartments and additional info"
+			break
+		}
+		b.stack = append(b.stack, b.searchView(query))
+	default:
+		index, err := strconv.Atoi(command)
+		if err != nil || index < 1 || index > len(view.entries) {
+			b.message = fmt.Sprintf("Unknown command %q; enter ? for help", command)
+			break
+		}
+		if next := view.entries[index-1].open; next != nil {
+			b.stack = append(b.stack, next())
+		}
+	}
+	return false
+}
+
+// render writes the current view
+func (b *Browser) render() error {
+	var sb strings.Builder
+	if b.clear {
+		sb.WriteString("\033[H\033[2J")
+	}
+
+	view := b.current()
+	var path []string
+	for _, v := range b.stack {
+		path = append(path, v.title)
+	}
+	sb.WriteString(strings.Join(path, " > ") + "\n\n")
+
+	if view.resource != nil {
+		writeResourceDetails(&sb, *view.resource)
+		sb.WriteString("\n  b back, q quit\n")
+	} else if len(view.entries) == 0 {
+		sb.WriteString("  (no resources)\n")
+	} else {
+		start := view.page * browsePageSize
+		end := min(start+browsePageSize, len(view.entries))
+		width := len(strconv.Itoa(len(view.entries)))
+		for i := start; i < end; i++ {
+			fmt.Fprintf(&sb, "  %*d  %s\n", width, i+1, view.entries[i].label)
+		}
+		if len(view.entries) > browsePageSize {
+			pages := (len(view.entries) + browsePageSize - 1) / browsePageSize
+			fmt.Fprintf(&sb, "\n  page %d/%d (n/p)\n", view.page+1, pages)
+		}
+	}
+
+	if b.message != "" {
+		sb.WriteString("\n" + b.message + "\n")
+		b.message = ""
+	}
+	sb.WriteString("\n> ")
+	_, err := io.WriteString(b.out, sb.String())
+	return err
+}
+
+// compartmentsView lists the compartments with their resource counts
+func (b *Browser) compartmentsView() *browseView {
+	counts := make(map[string]int)
+	for _, resource := range b.resources {
+		counts[resource.CompartmentName]++
+	}
+	names := sortedKeys(counts)
+
+	view := &browseView{title: fmt.Sprintf("Compartments (%d resources)", len(b.resources))}
+	for _, name := range names {
+		view.entries = append(view.entries, browseEntry{
+			label: fmt.Sprintf("%-40s %6d", displayName(name), counts[name]),
+			open:  func() *browseView { return b.resourceTypesView(name) },
+		})
+	}
+	return view
+}
+
+// resourceTypesView lists the resource types of a compartment with their counts
+func (b *Browser) resourceTypesView(compartment string) *browseView {
+	counts := make(map[string]int)
+	for _, resource := range b.resources {
+		if resource.CompartmentName == compartment {
+			counts[resource.ResourceType]++
+		}
+	}
+
+	view := &browseView{title: displayName(compartment)}
+	for _, resourceType := range sortedKeys(counts) {
+		view.entries = append(view.entries, browseEntry{
+			label: fmt.Sprintf("%-40s %6d", resourceType, counts[resourceType]),
+			open: func() *browseView {
+				return b.resourcesView(resourceType, false, func(r ResourceInfo) bool {
+					return r.CompartmentName == compartment && r.ResourceType == resourceType
+				})
+			},
+		})
+	}
+	return view
+}
+
+// resourcesView lists the resources matching a predicate, sorted by name
+// With withType, the resource type is shown as well because the list mixes types.
+func (b *Browser) resourcesView(title string, withType bool, match func(ResourceInfo) bool) *browseView {
+	var matched []int
+	for i, resource := range b.resources {
+		if match(resource) {
+			matched = append(matched, i)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return b.resources[matched[i]].ResourceName < b.resources[matched[j]].ResourceName
+	})
+
+	view := &browseView{title: title}
+	for _, i := range matched {
+		resource := &b.resources[i]
+		label := fmt.Sprintf("%-40s %-12s %s", displayName(resource.ResourceName), resource.LifecycleState, resource.OCID)
+		if withType {
+			label = fmt.Sprintf("%-24s %s", resource.ResourceType, label)
+		}
+		view.entries = append(view.entries, browseEntry{
+			label: label,
+			open:  func() *browseView { return &browseView{title: displayName(resource.ResourceName), resource: resource} },
+		})
+	}
+	return view
+}
+
+// searchView lists the resources containing the query, case-insensitively
+func (b *Browser) searchView(query string) *browseView {
+	query = strings.ToLower(query)
+	return b.resourcesView(fmt.Sprintf("Search %q", query), true, func(r ResourceInfo) bool {
+		return strings.Contains(strings.ToLower(browseSearchText(r)), query)
+	})
+}
+
+// browseSearchText returns the text a resource is searched in
+func browseSearchText(resource ResourceInfo) string {
+	parts := []string{resource.ResourceName, resource.OCID, resource.ResourceType, resource.CompartmentName, resource.LifecycleState}
+	for key, value := range resource.AdditionalInfo {
+		parts = append(parts, key, fmt.Sprint(value))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// writeResourceDetails writes all fields of a resource, additional info keys sorted
+func writeResourceDetails(sb *strings.Builder, resource ResourceInfo) {
+	fields := [][2]string{
+		{"Type", resource.ResourceType},
+		{"Name", resource.ResourceName},
+		{"OCID", resource.OCID},
+		{"Compartment", resource.CompartmentName},
+		{"Compartment ID", resource.CompartmentID},
+		{"Lifecycle state", resource.LifecycleState},
+		{"Created", resource.TimeCreated},
+	}
+	for _, field := range fields {
+		if field[1] != "" {
+			fmt.Fprintf(sb, "  %-16s %s\n", field[0]+":", field[1])
+		}
+	}
+
+	if len(resource.AdditionalInfo) > 0 {
+		sb.WriteString("\n  Additional info:\n")
+		for _, key := range sortedKeys(resource.AdditionalInfo) {
+			fmt.Fprintf(sb, "    %-30s %v\n", key, resource.AdditionalInfo[key])
+		}
+	}
+	if len(resource.FreeformTags) > 0 {
+		sb.WriteString("\n  Freeform tags:\n")
+		for _, key := range sortedKeys(resource.FreeformTags) {
+			fmt.Fprintf(sb, "    %-30s %s\n", key, resource.FreeformTags[key])
+		}
+	}
+	if len(resource.DefinedTags) > 0 {
+		sb.WriteString("\n  Defined tags:\n")
+		for _, namespace := range sortedKeys(resource.DefinedTags) {
+			for _, key := range sortedKeys(resource.DefinedTags[namespace]) {
+				fmt.Fprintf(sb, "    %-30s %v\n", namespace+"."+key, resource.DefinedTags[namespace][key])
+			}
+		}
+	}
+}
+
+// displayName returns the name shown for an empty name
+func displayName(name string) string {
+	if name == "" {
+		return "(unnamed)"
+	}
+	return name
+}
+
+// sortedKeys returns the keys of a map in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,95 @@
+package ocidump
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func browseTestResources() []ResourceInfo {
+	return []ResourceInfo{
+		{ResourceType: "VCN", ResourceName: "prod-vcn", OCID: "ocid1.vcn.oc1..prod", CompartmentName: "prod", LifecycleState: "AVAILABLE",
+			AdditionalInfo: map[string]interface{}{"cidr_blocks": []string{"10.0.0.0/16"}, "dns_label": "prodvcn"}},
+		{ResourceType: "ComputeInstance", ResourceName: "web-2", OCID: "ocid1.instance.oc1..web2", CompartmentName: "prod", LifecycleState: "RUNNING"},
+		{ResourceType: "ComputeInstance", ResourceName: "web-1", OCID: "ocid1.instance.oc1..web1", CompartmentName: "prod", LifecycleState: "RUNNING",
+			AdditionalInfo: map[string]interface{}{"primary_ip": "10.0.0.10"}, FreeformTags: map[string]string{"team": "web"}},
+		{ResourceType: "VCN", ResourceName: "dev-vcn", OCID: "ocid1.vcn.oc1..dev", CompartmentName: "dev", LifecycleState: "AVAILABLE"},
+	}
+}
+
+// runBrowser feeds the commands to a browser and returns the rendered views
+func runBrowser(t *testing.T, resources []ResourceInfo, commands ...string) []string {
+	t.Helper()
+	var out strings.Builder
+	browser := NewBrowser(resources, strings.NewReader(strings.Join(commands, "\n")+"\n"), &out, false)
+	if err := browser.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return strings.Split(out.String(), "\n> ")
+}
+
+func TestBrowser_Navigation(t *testing.T) {
+	views := runBrowser(t, browseTestResources(), "2", "1", "1", "b", "b", "b", "q")
+	if len(views) != 8 {
+		t.Fatalf("rendered %d views, want 8", len(views))
+	}
+
+	if !strings.HasPrefix(views[0], "Compartments (4 resources)") || !strings.Contains(views[0], "1  dev") || !strings.Contains(views[0], "2  prod") {
+		t.Errorf("compartments view:\n%s", views[0])
+	}
+	if !strings.Contains(views[1], "1  ComputeInstance") || !strings.Contains(views[1], "2  VCN") {
+		t.Errorf("resource types view:\n%s", views[1])
+	}
+	if !strings.HasPrefix(views[2], "Compartments (4 resources) > prod > ComputeInstance") || strings.Index(views[2], "web-1") > strings.Index(views[2], "web-2") {
+		t.Errorf("resources view should list the instances by name:\n%s", views[2])
+	}
+	for _, want := range []string{"ocid1.instance.oc1..web1", "primary_ip", "10.0.0.10", "team"} {
+		if !strings.Contains(views[3], want) {
+			t.Errorf("details view misses %q:\n%s", want, views[3])
+		}
+	}
+	if views[6] != views[0] {
+		t.Errorf("going back three times should return to the compartments view:\n%s", views[6])
+	}
+}
+
+func TestBrowser_Search(t *testing.T) {
+	views := runBrowser(t, browseTestResources(), "/PRODVCN", "1", "b", "b", "/10.0.0", "/nomatch", "/", "x", "q")
+
+	if !strings.Contains(views[1], `Search "prodvcn"`) || !strings.Contains(views[1], "VCN") || !strings.Contains(views[1], "prod-vcn") || strings.Contains(views[1], "dev-vcn") {
+		t.Errorf("search by additional info value:\n%s", views[1])
+	}
+	if !strings.Contains(views[2], "dns_label") {
+		t.Errorf("opening a search result should show its details:\n%s", views[2])
+	}
+	if !strings.Contains(views[5], "prod-vcn") || !strings.Contains(views[5], "web-1") || strings.Contains(views[5], "web-2") {
+		t.Errorf("search across resource types:\n%s", views[5])
+	}
+	if !strings.Contains(views[6], "(no resources)") {
+		t.Errorf("empty search result:\n%s", views[6])
+	}
+	if !strings.Contains(views[7], "Usage: /text") || !strings.Contains(views[8], `Unknown command "x"`) {
+		t.Errorf("invalid input messages:\n%s\n%s", views[7], views[8])
+	}
+}
+
+func TestBrowser_Paging(t *testing.T) {
+	var resources []ResourceInfo
+	for i := 0; i < 45; i++ {
+		resources = append(resources, ResourceInfo{ResourceType: "VCN", ResourceName: fmt.Sprintf("vcn-%02d", i), CompartmentName: "prod"})
+	}
+	views := runBrowser(t, resources, "1", "1", "n", "n", "n", "p", "42", "q")
+
+	if !strings.Contains(views[2], "vcn-00") || strings.Contains(views[2], "vcn-20") || !strings.Contains(views[2], "page 1/3") {
+		t.Errorf("first page:\n%s", views[2])
+	}
+	if !strings.Contains(views[4], "vcn-44") || !strings.Contains(views[4], "page 3/3") || views[5] != views[4] {
+		t.Errorf("paging past the last page should stay on it:\n%s", views[5])
+	}
+	if !strings.Contains(views[6], "page 2/3") {
+		t.Errorf("previous page:\n%s", views[6])
+	}
+	if !strings.Contains(views[7], "Name:            vcn-41") {
+		t.Errorf("entries keep their numbers across pages:\n%s", views[7])
+	}
+}
@@ -0,0 +1,228 @@
+package ocidump
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// browserModel is the full-screen terminal UI of a Browser
+// It shares the view stack of the line mode; the cursor and scroll position are kept per view,
+// so going back returns to the entry that was opened.
+type browserModel struct {
+	browser *Browser
+	width   int
+	height  int
+
+	searching bool   // the search prompt has the keyboard
+	query     string // search text, applied on every keystroke
+}
+
+// browseChromeLines is the number of lines around the entries: the path, a blank line,
+// a blank line and the status line
+const browseChromeLines = 4
+
+// RunTUI shows the views full-screen until the user quits
+// The input and output of the browser must be a terminal; use Run for pipes.
+func (b *Browser) RunTUI() error {
+	program := tea.NewProgram(&browserModel{browser: b}, tea.WithInput(b.input), tea.WithOutput(b.out), tea.WithAltScreen())
+	_, err := program.Run()
+	return err
+}
+
+// Init implements tea.Model
+func (m *browserModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *browserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.follow()
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		if m.searching {
+			m.updateSearch(msg)
+			return m, nil
+		}
+		return m, m.updateKey(msg)
+	}
+	return m, nil
+}
+
+// updateKey moves the cursor or changes the view for a key pressed outside the search prompt
+func (m *browserModel) updateKey(msg tea.KeyMsg) tea.Cmd {
+	b := m.browser
+	view := b.current()
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup", "ctrl+b":
+		m.move(-m.pageHeight())
+	case "pgdown", "ctrl+f", " ":
+		m.move(m.pageHeight())
+	case "home", "g":
+		m.move(-m.lineCount(view))
+	case "end", "G":
+		m.move(m.lineCount(view))
+	case "enter", "right", "l":
+		if view.resource == nil && view.cursor < len(view.entries) {
+			if next := view.entries[view.cursor].open; next != nil {
+				b.stack = append(b.stack, next())
+			}
+		}
+	case "esc", "backspace", "left", "h", "b":
+		if len(b.stack) > 1 {
+			b.stack = b.stack[:len(b.stack)-1]
+			m.follow()
+		}
+	case "/":
+		m.searching = true
+		m.query = ""
+		b.stack = append(b.stack, b.searchView(""))
+	}
+	return nil
+}
+
+// updateSearch edits the search text and replaces the search results as the user types
+// Enter keeps the results for navigation, Esc closes them.
+func (m *browserModel) updateSearch(msg tea.KeyMsg) {
+	b := m.browser
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+		return
+	case tea.KeyEsc:
+		m.searching = false
+		b.stack = b.stack[:len(b.stack)-1]
+		m.follow()
+		return
+	case tea.KeyBackspace:
+		runes := []rune(m.query)
+		if len(runes) == 0 {
+			return
+		}
+		m.query = string(runes[:len(runes)-1])
+	case tea.KeySpace:
+		m.query += " "
+	case tea.KeyRunes:
+		m.query += string(msg.Runes)
+	default:
+		return
+	}
+	b.stack[len(b.stack)-1] = b.searchView(strings.TrimSpace(m.query))
+}
+
+// move moves the cursor of the current view by delta lines, keeping it in range
+// In the details view, the cursor is the first line shown, so moving it scrolls.
+func (m *browserModel) move(delta int) {
+	view := m.browser.current()
+	last := m.lineCount(view) - 1
+	if view.resource != nil {
+		last -= m.pageHeight() - 1
+	}
+	view.cursor = max(0, min(view.cursor+delta, last))
+	m.follow()
+}
+
+// follow scrolls the current list so that its cursor is visible
+func (m *browserModel) follow() {
+	view := m.browser.current()
+	height := m.pageHeight()
+	if view.cursor < view.scroll {
+		view.scroll = view.cursor
+	}
+	if view.cursor >= view.scroll+height {
+		view.scroll = view.cursor - height + 1
+	}
+}
+
+// pageHeight returns the number of entries that fit on the screen
+func (m *browserModel) pageHeight() int {
+	if m.height == 0 {
+		return browsePageSize
+	}
+	return max(1, m.height-browseChromeLines)
+}
+
+// lineCount returns the number of entries of a list, or of lines of the details view
+func (m *browserModel) lineCount(view *browseView) int {
+	if view.resource != nil {
+		return len(browseDetailLines(view))
+	}
+	return len(view.entries)
+}
+
+// View implements tea.Model
+func (m *browserModel) View() string {
+	b := m.browser
+	view := b.current()
+	height := m.pageHeight()
+
+	var path []string
+	for _, v := range b.stack {
+		path = append(path, v.title)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(m.fit(strings.Join(path, " > ")) + "\n\n")
+
+	var position string
+	if view.resource != nil {
+		lines := browseDetailLines(view)
+		end := min(view.cursor+height, len(lines))
+		for _, line := range lines[view.cursor:end] {
+			sb.WriteString(m.fit(line) + "\n")
+		}
+		position = fmt.Sprintf("lines %d-%d of %d", view.cursor+1, end, len(lines))
+	} else if len(view.entries) == 0 {
+		sb.WriteString("  (no resources)\n")
+	} else {
+		end := min(view.scroll+height, len(view.entries))
+		for i := view.scroll; i < end; i++ {
+			if i == view.cursor {
+				// Reverse video marks the selected entry
+				sb.WriteString("\033[7m" + m.fit("> "+view.entries[i].label) + "\033[0m\n")
+			} else {
+				sb.WriteString(m.fit("  "+view.entries[i].label) + "\n")
+			}
+		}
+		position = fmt.Sprintf("%d/%d", view.cursor+1, len(view.entries))
+	}
+
+	sb.WriteString("\n")
+	if m.searching {
+		sb.WriteString(m.fit("/" + m.query + "█   enter keep results, esc cancel"))
+	} else {
+		sb.WriteString(m.fit(fmt.Sprintf("↑/↓ move  pgup/pgdn page  enter open  esc back  / search  q quit   %s", position)))
+	}
+	return sb.String()
+}
+
+// fit cuts a line to the width of the terminal so that it does not wrap
+func (m *browserModel) fit(line string) string {
+	if m.width == 0 {
+		return line
+	}
+	runes := []rune(line)
+	if len(runes) <= m.width {
+		return line
+	}
+	return string(runes[:m.width])
+}
+
+// browseDetailLines returns the lines of the details view of a resource
+func browseDetailLines(view *browseView) []string {
+	var sb strings.Builder
+	writeResourceDetails(&sb, *view.resource)
+	return strings.Split(strings.TrimRight(sb.String(), "\n"), "\n")
+}
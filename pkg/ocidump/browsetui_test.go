@@ -0,0 +1,122 @@
+package ocidump
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pressKeys sends key presses to the full-screen browser and returns the rendered view
+// Named keys are given by their tea.KeyType; everything else is typed as runes.
+func pressKeys(m *browserModel, keys ...interface{}) string {
+	for _, key := range keys {
+		switch k := key.(type) {
+		case tea.KeyType:
+			m.Update(tea.KeyMsg{Type: k})
+		case string:
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+	return m.View()
+}
+
+func TestBrowserModel_Navigation(t *testing.T) {
+	m := &browserModel{browser: NewBrowser(browseTestResources(), strings.NewReader(""), &strings.Builder{}, false)}
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 24})
+
+	view := m.View()
+	if !strings.Contains(view, "> dev") || !strings.Contains(view, "1/2") {
+		t.Errorf("initial view should select the first compartment:\n%s", view)
+	}
+
+	view = pressKeys(m, tea.KeyDown, tea.KeyEnter)
+	if !strings.HasPrefix(view, "Compartments (4 resources) > prod") || !strings.Contains(view, "> ComputeInstance") {
+		t.Errorf("opening prod should list its resource types:\n%s", view)
+	}
+
+	view = pressKeys(m, tea.KeyEnter, "j", tea.KeyEnter)
+	if !strings.Contains(view, "ocid1.instance.oc1..web2") {
+		t.Errorf("details view of web-2 expected:\n%s", view)
+	}
+
+	// Going back restores the selected entry of each view
+	view = pressKeys(m, tea.KeyEsc)
+	if !strings.Contains(view, "> web-2") {
+		t.Errorf("back should keep web-2 selected:\n%s", view)
+	}
+	view = pressKeys(m, tea.KeyEsc, tea.KeyEsc, tea.KeyEsc)
+	if !strings.Contains(view, "> prod") || !strings.Contains(view, "2/2") {
+		t.Errorf("back at the top should stay on the compartments:\n%s", view)
+	}
+
+	// The cursor stays within the list
+	view = pressKeys(m, tea.KeyDown, tea.KeyDown, tea.KeyUp, tea.KeyUp, tea.KeyUp)
+	if !strings.Contains(view, "> dev") {
+		t.Errorf("cursor should stop at the first entry:\n%s", view)
+	}
+
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd == nil {
+		t.Errorf("q should quit")
+	}
+}
+
+func TestBrowserModel_LiveSearch(t *testing.T) {
+	m := &browserModel{browser: NewBrowser(browseTestResources(), strings.NewReader(""), &strings.Builder{}, false)}
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 24})
+
+	view := pressKeys(m, "/", "w", "e")
+	if !strings.Contains(view, "web-1") || !strings.Contains(view, "web-2") || strings.Contains(view, "dev-vcn") || !strings.Contains(view, "/we") {
+		t.Errorf("search results should update while typing:\n%s", view)
+	}
+
+	view = pressKeys(m, "b", "1")
+	if !strings.Contains(view, "web-1") || strings.Contains(view, "web-2") {
+		t.Errorf("search for web1 should match only web-1:\n%s", view)
+	}
+
+	view = pressKeys(m, tea.KeyBackspace, "2", tea.KeyEnter, tea.KeyEnter)
+	if !strings.Contains(view, "ocid1.instance.oc1..web2") {
+		t.Errorf("enter should keep the results and open the selected one:\n%s", view)
+	}
+
+	view = pressKeys(m, tea.KeyEsc, tea.KeyEsc)
+	if !strings.HasPrefix(view, "Compartments (4 resources)\n") {
+		t.Errorf("esc should close the search results:\n%s", view)
+	}
+
+	view = pressKeys(m, "/", "x", tea.KeyEsc)
+	if !strings.HasPrefix(view, "Compartments (4 resources)\n") {
+		t.Errorf("esc in the prompt should cancel the search:\n%s", view)
+	}
+}
+
+func TestBrowserModel_Scrolling(t *testing.T) {
+	var resources []ResourceInfo
+	for i := 0; i < 45; i++ {
+		resources = append(resources, ResourceInfo{ResourceType: "VCN", ResourceName: fmt.Sprintf("vcn-%02d", i), CompartmentName: "prod"})
+	}
+	m := &browserModel{browser: NewBrowser(resources, strings.NewReader(""), &strings.Builder{}, false)}
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 14}) // 10 entries per screen
+
+	view := pressKeys(m, tea.KeyEnter, tea.KeyEnter)
+	if !strings.Contains(view, "vcn-09") || strings.Contains(view, "vcn-10") {
+		t.Errorf("first screen should show 10 entries:\n%s", view)
+	}
+
+	view = pressKeys(m, tea.KeyPgDown, tea.KeyDown)
+	if !strings.Contains(view, "> vcn-11") || strings.Contains(view, "vcn-01") || !strings.Contains(view, "12/45") {
+		t.Errorf("list should scroll with the cursor:\n%s", view)
+	}
+
+	view = pressKeys(m, tea.KeyEnd)
+	if !strings.Contains(view, "> vcn-44") || !strings.Contains(view, "vcn-35") || strings.Contains(view, "vcn-34") {
+		t.Errorf("end should show the last screen:\n%s", view)
+	}
+
+	view = pressKeys(m, tea.KeyHome)
+	if !strings.Contains(view, "> vcn-00") || strings.Contains(view, "vcn-10") {
+		t.Errorf("home should show the first screen:\n%s", view)
+	}
+}
@@ -0,0 +1,177 @@
+package ocidump
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultCacheTTL is how long cached results are reused when cache.ttl is not set
+const defaultCacheTTL = 5 * time.Minute
+
+// CacheConfig holds the settings of the discovery result cache
+type CacheConfig struct {
+	Enabled bool   `yaml:"enabled"` // Reuse recent results of each compartment and resource type
+	Dir     string `yaml:"dir"`     // Cache directory (default: ~/.oci-resource-dump/cache)
+	TTL     int    `yaml:"ttl"`     // Seconds a cached result is reused (default: 300)
+
+	Compartments   bool `yaml:"compartments"`    // Keep the compartment tree on disk, also without enabled
+	CompartmentTTL int  `yaml:"compartment_ttl"` // Seconds the stored compartment tree is reused (default: 3600)
+}
+
+// Validate checks the cache settings
+func (c CacheConfig) Validate() error {
+	var problems []error
+	if c.TTL < 0 {
+		problems = append(problems, fmt.Errorf("cache ttl must not be negative, got: %d", c.TTL))
+	}
+	if c.CompartmentTTL < 0 {
+		problems = append(problems, fmt.Errorf("cache compartment_ttl must not be negative, got: %d", c.CompartmentTTL))
+	}
+	return errors.Join(problems...)
+}
+
+// ttl returns the configured time to live, defaulting to 5 minutes
+func (c CacheConfig) ttl() time.Duration {
+	if c.TTL == 0 {
+		return defaultCacheTTL
+	}
+	return time.Duration(c.TTL) * time.Second
+}
+
+// ResultCache keeps the discovered resources of each compartment and resource type on disk,
+// so runs shortly after each other with different output formats or filters do not call
+// every OCI API again
+//
+//	<dir>/<mode>[-detail]/<compartment OCID>/<resource type>.json
+//
+// Results are cached before the name and lifecycle filters are applied, so a cached result
+// can be reused with any filters. Results of detail mode and of each discovery mode are kept
+// apart because they contain different fields.
+type ResultCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cacheEntry is the content of a cache file
+type cacheEntry struct {
+	CachedAt  time.Time      `json:"cached_at"`
+	Resources []ResourceInfo `json:"resources"`
+}
+
+// DefaultCacheDir returns ~/.oci-resource-dump/cache
+func DefaultCacheDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory for result cache: %w", err)
+	}
+	return filepath.Join(homeDir, ".oci-resource-dump", "cache"), nil
+}
+
+// OpenResultCache opens the result cache configured by config for runs with the given options
+func OpenResultCache(config CacheConfig, opts Options) (*ResultCache, error) {
+	dir := config.Dir
+	if dir == "" {
+		var err error
+		if dir, err = DefaultCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+
+	variant := opts.Mode
+	if variant == "" {
+		variant = DiscoveryModeFull
+	}
+	if opts.Detail {
+		variant += "-detail"
+	}
+	dir = filepath.Join(dir, variant)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create result cache: %w", err)
+	}
+	return &ResultCache{dir: dir, ttl: config.ttl(), now: time.Now}, nil
+}
+
+// path returns the cache file of a resource type of a compartment
+func (c *ResultCache) path(compartmentID, resourceType string) string {
+	return filepath.Join(c.dir, url.PathEscape(compartmentID), url.PathEscape(resourceType)+".json")
+}
+
+// Get returns the cached resources of a resource type of a compartment
+// It reports false when there is no entry younger than the TTL; unreadable entries count as missing.
+func (c *ResultCache) Get(compartmentID, resourceType string) ([]ResourceInfo, bool) {
+	data, err := os.ReadFile(c.path(compartmentID, resourceType))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Debug("Ignoring unreadable cache entry for %s in %s: %v", resourceType, compartmentID, err)
+		}
+		return nil, false
+	}
+
+	// Numbers are kept as written so cached values are output exactly like discovered ones
+	var entry cacheEntry
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&entry); err != nil {
+		logger.Debug("Ignoring invalid cache entry for %s in %s: %v", resourceType, compartmentID, err)
+		return nil, false
+	}
+	if c.now().Sub(entry.CachedAt) > c.ttl {
+		return nil, false
+	}
+	return entry.Resources, true
+}
+
+// Put stores the resources of a resource type of a compartment
+func (c *ResultCache) Put(compartmentID, resourceType string, resources []ResourceInfo) error {
+	path := c.path(compartmentID, resourceType)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create result cache: %w", err)
+	}
+	if resources == nil {
+		resources = []ResourceInfo{}
+	}
+	if err := writeJSONFile(path, cacheEntry{CachedAt: c.now().UTC(), Resources: resources}, false); err != nil {
+		return fmt.Errorf("failed to write result cache: %w", err)
+	}
+	return nil
+}
+
+// writeJSONFile writes value as JSON through a temporary file renamed into place
+func writeJSONFile(path string, value interface{}, indent bool) error {
+	var data []byte
+	var err error
+	if indent {
+		data, err = json.MarshalIndent(value, "", "  ")
+	} else {
+		data, err = json.Marshal(value)
+	}
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
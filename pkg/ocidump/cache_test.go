@@ -0,0 +1,106 @@
+package ocidump
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestResultCache_PutGet(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	dir := t.TempDir()
+	cache, err := OpenResultCache(CacheConfig{Enabled: true, Dir: dir, TTL: 60}, Options{})
+	if err != nil {
+		t.Fatalf("OpenResultCache failed: %v", err)
+	}
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	if _, ok := cache.Get("ocid1.compartment.oc1..a", "VCNs"); ok {
+		t.Fatal("empty cache reported a hit")
+	}
+
+	resources := []ResourceInfo{{
+		ResourceType:   "VCN",
+		ResourceName:   "prod-vcn",
+		OCID:           "ocid1.vcn.oc1..v",
+		AdditionalInfo: map[string]interface{}{"size_in_gbs": int64(1000000)},
+	}}
+	if err := cache.Put("ocid1.compartment.oc1..a", "VCNs", resources); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := cache.Put("ocid1.compartment.oc1..a", "Subnets", nil); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	cached, ok := cache.Get("ocid1.compartment.oc1..a", "VCNs")
+	if !ok || len(cached) != 1 || cached[0].OCID != "ocid1.vcn.oc1..v" {
+		t.Fatalf("Get = %v, %v", cached, ok)
+	}
+	if size := fmt.Sprint(cached[0].AdditionalInfo["size_in_gbs"]); size != "1000000" {
+		t.Errorf("cached number printed as %s, expected 1000000", size)
+	}
+	// An empty result is a hit as well, so the API is not called again
+	if cached, ok := cache.Get("ocid1.compartment.oc1..a", "Subnets"); !ok || len(cached) != 0 {
+		t.Errorf("empty result Get = %v, %v", cached, ok)
+	}
+	if _, ok := cache.Get("ocid1.compartment.oc1..b", "VCNs"); ok {
+		t.Error("other compartment reported a hit")
+	}
+
+	now = now.Add(61 * time.Second)
+	if _, ok := cache.Get("ocid1.compartment.oc1..a", "VCNs"); ok {
+		t.Error("expired entry reported a hit")
+	}
+}
+
+func TestResultCache_Variants(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	dir := t.TempDir()
+	full, err := OpenResultCache(CacheConfig{Dir: dir}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail, err := OpenResultCache(CacheConfig{Dir: dir}, Options{Detail: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.ttl != defaultCacheTTL {
+		t.Errorf("ttl = %v, expected default %v", full.ttl, defaultCacheTTL)
+	}
+
+	if err := full.Put("ocid1.compartment.oc1..a", "VCNs", []ResourceInfo{{OCID: "ocid1.vcn.oc1..v"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := detail.Get("ocid1.compartment.oc1..a", "VCNs"); ok {
+		t.Error("detail mode reused a result cached without detail")
+	}
+}
+
+func TestResultCache_InvalidEntry(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	cache, err := OpenResultCache(CacheConfig{Dir: t.TempDir()}, Options{Mode: DiscoveryModeSearch})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := cache.path("ocid1.compartment.oc1..a", "VCNs")
+	if err := cache.Put("ocid1.compartment.oc1..a", "VCNs", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{truncated"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("ocid1.compartment.oc1..a", "VCNs"); ok {
+		t.Error("invalid entry reported a hit")
+	}
+}
+
+func TestCacheConfig_Validate(t *testing.T) {
+	if err := (CacheConfig{TTL: -1}).Validate(); err == nil {
+		t.Error("expected error for negative ttl")
+	}
+	if err := (CacheConfig{Enabled: true, TTL: 600}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
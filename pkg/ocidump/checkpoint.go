@@ -0,0 +1,205 @@
+package ocidump
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Checkpoint records which resource types of which compartments have been discovered,
+// together with their resources, so an interrupted run can be resumed
+//
+// The file is a JSON Lines journal: a header describing the run followed by one entry per
+// completed compartment/resource type. Entries are appended as they complete, so a run
+// killed mid-write loses at most the entry being written.
+type Checkpoint struct {
+	path string
+
+	mu        sync.Mutex
+	file      *os.File
+	completed map[string]bool // compartmentID + "/" + resourceType
+	restored  []ResourceInfo
+
+	partialLine bool // the restored file ends without a newline
+}
+
+// checkpointVersion is bumped when the journal format changes incompatibly
+const checkpointVersion = 1
+
+// checkpointHeader is the first line of a checkpoint file
+type checkpointHeader struct {
+	Version   int          `json:"version"`
+	StartedAt string       `json:"started_at"`
+	Filters   FilterConfig `json:"filters"`
+	Detail    bool         `json:"detail"`
+}
+
+// checkpointEntry records a completed resource type of a compartment
+type checkpointEntry struct {
+	CompartmentID string         `json:"compartment_id"`
+	ResourceType  string         `json:"resource_type"`
+	Resources     []ResourceInfo `json:"resources"`
+}
+
+// OpenCheckpoint opens the checkpoint file for a run with the given options
+// With resume, the completed work recorded in an existing file is restored; the file must
+// have been written with the same filters and detail setting. Otherwise the file is started afresh.
+func OpenCheckpoint(path string, opts Options, resume bool) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{path: path, completed: make(map[string]bool)}
+	header := checkpointHeader{
+		Version:   checkpointVersion,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+		Filters:   opts.Filters,
+		Detail:    opts.Detail,
+	}
+
+	if resume {
+		restored, err := checkpoint.restore(header)
+		if err != nil {
+			return nil, err
+		}
+		if restored {
+			file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open checkpoint file: %w", err)
+			}
+			checkpoint.file = file
+			if checkpoint.partialLine {
+				// Terminate the cut-off line so the next entry starts on its own line
+				if _, err := file.Write([]byte("\n")); err != nil {
+					file.Close()
+					return nil, fmt.Errorf("failed to write checkpoint file: %w", err)
+				}
+			}
+			logger.Info("Resuming from checkpoint %s: %d resource types already discovered (%d resources)",
+				path, len(checkpoint.completed), len(checkpoint.restored))
+			return checkpoint, nil
+		}
+		logger.Info("No checkpoint found at %s, starting a new discovery", path)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint file: %w", err)
+	}
+	checkpoint.file = file
+	if err := checkpoint.append(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// restore reads an existing checkpoint file; it reports false when there is none
+func (c *Checkpoint) restore(expected checkpointHeader) (bool, error) {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	c.partialLine = len(data) > 0 && data[len(data)-1] != '\n'
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	if !scanner.Scan() {
+		return false, nil
+	}
+
+	var header checkpointHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return false, fmt.Errorf("invalid checkpoint file %s: %w", c.path, err)
+	}
+	if header.Version != checkpointVersion {
+		return false, fmt.Errorf("checkpoint file %s has unsupported version %d", c.path, header.Version)
+	}
+	got, _ := json.Marshal(checkpointHeader{Filters: header.Filters, Detail: header.Detail})
+	want, _ := json.Marshal(checkpointHeader{Filters: expected.Filters, Detail: expected.Detail})
+	if !bytes.Equal(got, want) {
+		return false, fmt.Errorf("checkpoint file %s was written with different filters or detail setting; run without --resume to start over", c.path)
+	}
+
+	for scanner.Scan() {
+		var entry checkpointEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// The last line may be cut short by the interruption; the resource type is discovered again
+			logger.Verbose("Ignoring incomplete checkpoint entry: %v", err)
+			continue
+		}
+		c.completed[checkpointKey(entry.CompartmentID, entry.ResourceType)] = true
+		c.restored = append(c.restored, entry.Resources...)
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+	return true, nil
+}
+
+// checkpointKey identifies a resource type of a compartment
+func checkpointKey(compartmentID, resourceType string) string {
+	return compartmentID + "/" + resourceType
+}
+
+// Completed reports whether the resource type of the compartment was discovered by an earlier run
+func (c *Checkpoint) Completed(compartmentID, resourceType string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.completed[checkpointKey(compartmentID, resourceType)]
+}
+
+// Restored returns the resources recorded by earlier runs
+func (c *Checkpoint) Restored() []ResourceInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.restored
+}
+
+// Record appends a completed resource type of a compartment to the checkpoint file
+func (c *Checkpoint) Record(compartmentID, resourceType string, resources []ResourceInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.append(checkpointEntry{CompartmentID: compartmentID, ResourceType: resourceType, Resources: resources}); err != nil {
+		return err
+	}
+	c.completed[checkpointKey(compartmentID, resourceType)] = true
+	return nil
+}
+
+// append writes a single JSON line; callers hold mu (or own the checkpoint exclusively)
+func (c *Checkpoint) append(value interface{}) error {
+	line, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint entry: %w", err)
+	}
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// Close closes the checkpoint file and keeps it for a later --resume
+func (c *Checkpoint) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return nil
+	}
+	err := c.file.Close()
+	c.file = nil
+	return err
+}
+
+// Remove closes and deletes the checkpoint file once a run has completed
+func (c *Checkpoint) Remove() error {
+	closeErr := c.Close()
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return errors.Join(closeErr, fmt.Errorf("failed to remove checkpoint file: %w", err))
+	}
+	return closeErr
+}
@@ -0,0 +1,125 @@
+package ocidump
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpoint_Resume(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	path := filepath.Join(t.TempDir(), "discovery.checkpoint")
+	opts := Options{Filters: FilterConfig{IncludeResourceTypes: []string{"vcns", "subnets"}}}
+
+	checkpoint, err := OpenCheckpoint(path, opts, false)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() error = %v", err)
+	}
+	vcn := ResourceInfo{ResourceType: "VCN", ResourceName: "main", OCID: "ocid1.vcn.oc1..a", CompartmentID: "ocid1.compartment.oc1..a"}
+	if err := checkpoint.Record("ocid1.compartment.oc1..a", "VCNs", []ResourceInfo{vcn}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := checkpoint.Record("ocid1.compartment.oc1..a", "Subnets", nil); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := checkpoint.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	resumed, err := OpenCheckpoint(path, opts, true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint(resume) error = %v", err)
+	}
+	defer resumed.Close()
+
+	if !resumed.Completed("ocid1.compartment.oc1..a", "VCNs") || !resumed.Completed("ocid1.compartment.oc1..a", "Subnets") {
+		t.Errorf("Completed() = false for recorded resource types")
+	}
+	if resumed.Completed("ocid1.compartment.oc1..b", "VCNs") {
+		t.Errorf("Completed() = true for a compartment that was not recorded")
+	}
+	restored := resumed.Restored()
+	if len(restored) != 1 || restored[0].OCID != vcn.OCID {
+		t.Errorf("Restored() = %v, expected the recorded VCN", restored)
+	}
+}
+
+func TestCheckpoint_ResumeTruncatedEntry(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	path := filepath.Join(t.TempDir(), "discovery.checkpoint")
+
+	checkpoint, err := OpenCheckpoint(path, Options{}, false)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() error = %v", err)
+	}
+	if err := checkpoint.Record("c1", "VCNs", []ResourceInfo{{OCID: "ocid1.vcn.oc1..a"}}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	checkpoint.Close()
+
+	// Simulate a run killed while writing an entry
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"compartment_id":"c1","resource_type":"Subnets","resour`)
+	file.Close()
+
+	resumed, err := OpenCheckpoint(path, Options{}, true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint(resume) error = %v", err)
+	}
+	if resumed.Completed("c1", "Subnets") {
+		t.Errorf("truncated entry was treated as completed")
+	}
+	if err := resumed.Record("c1", "Subnets", nil); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	resumed.Close()
+
+	again, err := OpenCheckpoint(path, Options{}, true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint(resume) error = %v", err)
+	}
+	defer again.Close()
+	if !again.Completed("c1", "VCNs") || !again.Completed("c1", "Subnets") {
+		t.Errorf("entries recorded after a truncated line were not restored")
+	}
+}
+
+func TestCheckpoint_ResumeWithDifferentFilters(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	path := filepath.Join(t.TempDir(), "discovery.checkpoint")
+
+	checkpoint, err := OpenCheckpoint(path, Options{Filters: FilterConfig{NamePattern: "^prod-"}}, false)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() error = %v", err)
+	}
+	checkpoint.Close()
+
+	if _, err := OpenCheckpoint(path, Options{Filters: FilterConfig{NamePattern: "^dev-"}}, true); err == nil {
+		t.Errorf("OpenCheckpoint(resume) expected error for different filters")
+	}
+	if _, err := OpenCheckpoint(path, Options{Filters: FilterConfig{NamePattern: "^prod-"}, Detail: true}, true); err == nil {
+		t.Errorf("OpenCheckpoint(resume) expected error for different detail setting")
+	}
+}
+
+func TestCheckpoint_ResumeWithoutFile(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	path := filepath.Join(t.TempDir(), "discovery.checkpoint")
+
+	checkpoint, err := OpenCheckpoint(path, Options{}, true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint(resume) error = %v", err)
+	}
+	if len(checkpoint.Restored()) != 0 {
+		t.Errorf("Restored() = %v, expected none", checkpoint.Restored())
+	}
+	if err := checkpoint.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("checkpoint file still exists after Remove()")
+	}
+}
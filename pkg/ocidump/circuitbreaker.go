@@ -0,0 +1,69 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultCircuitBreakerThreshold is the number of discoveries in a row a service may fail
+// before its resource types are skipped in the remaining compartments
+const DefaultCircuitBreakerThreshold = 5
+
+// circuitBreaker stops discovering resource types of services that keep failing
+// A service that errors everywhere, e.g. in a region that is not subscribed or without a policy
+// for it, fails for each of its resource types in every compartment. The breaker is kept per
+// service, named like the rate limiter names services, so once the resource types of a service
+// have failed threshold times in a row, its breaker opens and all of them are skipped for the
+// rest of the run instead of each being retried in every compartment.
+// A success in between resets the count, so errors limited to some compartments keep it closed.
+type circuitBreaker struct {
+	threshold int
+
+	mu       sync.Mutex
+	failures map[string]int
+	open     map[string]bool
+}
+
+// newCircuitBreaker returns a circuit breaker for the threshold (0 = DefaultCircuitBreakerThreshold),
+// or nil when it is disabled with a negative threshold
+func newCircuitBreaker(threshold int) *circuitBreaker {
+	if threshold < 0 {
+		return nil
+	}
+	if threshold == 0 {
+		threshold = DefaultCircuitBreakerThreshold
+	}
+	return &circuitBreaker{threshold: threshold, failures: make(map[string]int), open: make(map[string]bool)}
+}
+
+// allow reports whether a resource type should still be discovered
+func (b *circuitBreaker) allow(resourceType string) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.open[resourceTypeService(resourceType)]
+}
+
+// record counts the outcome of discovering a resource type in a compartment against its service
+// and reports whether the failure opened the breaker. Cancellations are not failures of the service.
+func (b *circuitBreaker) record(resourceType string, err error) bool {
+	if b == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	service := resourceTypeService(resourceType)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures[service] = 0
+		return false
+	}
+	b.failures[service]++
+	if b.open[service] || b.failures[service] < b.threshold {
+		return false
+	}
+	b.open[service] = true
+	return true
+}
@@ -0,0 +1,119 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := newCircuitBreaker(3)
+	failure := errors.New("NotAuthorizedOrNotFound")
+
+	// A success in between resets the count
+	breaker.record("VCNs", failure)
+	breaker.record("VCNs", failure)
+	breaker.record("VCNs", nil)
+	breaker.record("VCNs", failure)
+	breaker.record("VCNs", failure)
+	if !breaker.allow("VCNs") {
+		t.Fatal("breaker opened without 3 failures in a row")
+	}
+
+	// Cancellations do not count
+	breaker.record("VCNs", context.Canceled)
+	if !breaker.allow("VCNs") {
+		t.Fatal("breaker opened on a cancellation")
+	}
+
+	if !breaker.record("VCNs", failure) {
+		t.Error("the third failure in a row should open the breaker")
+	}
+	if breaker.allow("VCNs") {
+		t.Error("open breaker allows discovery")
+	}
+	if breaker.record("VCNs", failure) {
+		t.Error("an open breaker must be reported once")
+	}
+	if breaker.allow("Subnets") {
+		t.Error("resource types of the same service must be skipped with it")
+	}
+	if !breaker.allow("Streams") {
+		t.Error("breakers of other services must stay closed")
+	}
+
+	disabled := newCircuitBreaker(-1)
+	for i := 0; i < 10; i++ {
+		disabled.record("VCNs", failure)
+	}
+	if !disabled.allow("VCNs") {
+		t.Error("disabled breaker must allow discovery")
+	}
+	if newCircuitBreaker(0).threshold != DefaultCircuitBreakerThreshold {
+		t.Error("threshold 0 should use the default")
+	}
+}
+
+func TestCircuitBreaker_PerService(t *testing.T) {
+	breaker := newCircuitBreaker(3)
+	failure := errors.New("NotAuthorizedOrNotFound")
+
+	// Failures of different resource types of one service count together
+	breaker.record("DatabaseSystems", failure)
+	breaker.record("AutonomousDatabases", failure)
+	if !breaker.record("VmClusters", failure) {
+		t.Fatal("the third failure of the database service should open the breaker")
+	}
+	for _, resourceType := range []string{"DatabaseSystems", "DbHomes", "ExadataDbServers"} {
+		if breaker.allow(resourceType) {
+			t.Errorf("%s must be skipped once the database service breaker is open", resourceType)
+		}
+	}
+	if !breaker.allow("ManagedDatabases") {
+		t.Error("resource types of the database management service must stay allowed")
+	}
+
+	// Resource types without a known service have a breaker of their own
+	breaker.record("Widgets", failure)
+	breaker.record("Gadgets", failure)
+	breaker.record("Widgets", failure)
+	if !breaker.allow("Gadgets") || !breaker.allow("Widgets") {
+		t.Error("registered resource types must not share a breaker")
+	}
+}
+
+func TestResourceTypeServices(t *testing.T) {
+	for name := range builtinDiscoverers {
+		if _, ok := resourceTypeServices[name]; !ok {
+			t.Errorf("built-in resource type %s has no service", name)
+		}
+	}
+}
+
+func TestDiscoverAllResourcesWithProgress_CircuitBreaker(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	var compartments []identity.Compartment
+	for i := 0; i < 20; i++ {
+		compartments = append(compartments, fakeCompartment(fmt.Sprintf("ocid1.compartment.oc1..%d", i), fmt.Sprintf("c%d", i)))
+	}
+	network := &fakeVirtualNetwork{listErr: errors.New("NotAuthorizedOrNotFound: Authorization failed")}
+	clients := newFakeOCIClients(&fakeIdentity{compartments: compartments}, network, &fakeCompute{})
+	clients.Options.CircuitBreakerThreshold = 3
+
+	var stats DiscoveryStats
+	filters := FilterConfig{IncludeResourceTypes: []string{"vcns"}}
+	if _, err := discoverAllResourcesWithProgress(context.Background(), clients, false, filters, nil, nil, nil, &stats); err != nil {
+		t.Fatalf("discoverAllResourcesWithProgress() error = %v", err)
+	}
+
+	// At most the compartments in flight when the breaker opens still call the service
+	if calls := network.count("ListVcns"); calls > 3+clients.RateLimiter.workers(5)-1 {
+		t.Errorf("ListVcns called %d times in 21 compartments, expected the breaker to stop it", calls)
+	}
+	if stats.Errors != 21 {
+		t.Errorf("stats.Errors = %d, expected every compartment to count as failed or skipped", stats.Errors)
+	}
+}
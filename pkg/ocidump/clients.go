@@ -0,0 +1,594 @@
+package ocidump
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+
+	"github.com/oracle/oci-go-sdk/v65/apigateway"
+	"github.com/oracle/oci-go-sdk/v65/blockchain"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/common/auth"
+	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/oracle/oci-go-sdk/v65/databasemanagement"
+	"github.com/oracle/oci-go-sdk/v65/filestorage"
+	"github.com/oracle/oci-go-sdk/v65/functions"
+	"github.com/oracle/oci-go-sdk/v65/healthchecks"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/identitydomains"
+	"github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/opensearch"
+	"github.com/oracle/oci-go-sdk/v65/opsi"
+	"github.com/oracle/oci-go-sdk/v65/osmanagementhub"
+	"github.com/oracle/oci-go-sdk/v65/streaming"
+)
+
+// InitOCIClients initializes all required OCI service clients with context support
+func InitOCIClients(ctx context.Context) (*OCIClients, error) {
+	// Check if context is already cancelled
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Use instance principal authentication with timeout control
+	type configProviderResult struct {
+		provider common.ConfigurationProvider
+		err      error
+	}
+	configProviderChan := make(chan configProviderResult, 1)
+
+	go func() {
+		provider, err := auth.InstancePrincipalConfigurationProvider()
+		configProviderChan <- configProviderResult{provider: provider, err: err}
+	}()
+
+	var configProvider common.ConfigurationProvider
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-configProviderChan:
+		if result.err != nil {
+			return nil, fmt.Errorf("failed to create instance principal config provider: %w", result.err)
+		}
+		configProvider = result.provider
+	}
+
+	clients := &OCIClients{ConfigProvider: configProvider}
+
+	// Helper function to initialize client with timeout
+	initClientWithTimeout := func(clientName string, initFunc func() (interface{}, error)) (interface{}, error) {
+		type clientResult struct {
+			client interface{}
+			err    error
+		}
+		clientChan := make(chan clientResult, 1)
+
+		go func() {
+			client, err := initFunc()
+			clientChan <- clientResult{client: client, err: err}
+		}()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result := <-clientChan:
+			if result.err != nil {
+				return nil, fmt.Errorf("failed to create %s client: %w", clientName, result.err)
+			}
+			return result.client, nil
+		}
+	}
+
+	// Initialize Compute client
+	computeInterface, err := initClientWithTimeout("compute", func() (interface{}, error) {
+		return core.NewComputeClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.ComputeClient = computeInterface.(core.ComputeClient)
+
+	// Initialize VirtualNetwork client
+	vnInterface, err := initClientWithTimeout("virtual network", func() (interface{}, error) {
+		return core.NewVirtualNetworkClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.VirtualNetworkClient = vnInterface.(core.VirtualNetworkClient)
+
+	// Initialize BlockStorage client
+	bsInterface, err := initClientWithTimeout("block storage", func() (interface{}, error) {
+		return core.NewBlockstorageClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.BlockStorageClient = bsInterface.(core.BlockstorageClient)
+
+	// Initialize Identity client
+	identityInterface, err := initClientWithTimeout("identity", func() (interface{}, error) {
+		return identity.NewIdentityClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.IdentityClient = identityInterface.(identity.IdentityClient)
+
+	// Initialize Object Storage client
+	osInterface, err := initClientWithTimeout("object storage", func() (interface{}, error) {
+		return objectstorage.NewObjectStorageClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.ObjectStorageClient = osInterface.(objectstorage.ObjectStorageClient)
+
+	// Initialize Container Engine client (OKE)
+	ceInterface, err := initClientWithTimeout("container engine", func() (interface{}, error) {
+		return containerengine.NewContainerEngineClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.ContainerEngineClient = ceInterface.(containerengine.ContainerEngineClient)
+
+	// Initialize Load Balancer client
+	lbInterface, err := initClientWithTimeout("load balancer", func() (interface{}, error) {
+		return loadbalancer.NewLoadBalancerClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.LoadBalancerClient = lbInterface.(loadbalancer.LoadBalancerClient)
+
+	// Initialize Database client
+	dbInterface, err := initClientWithTimeout("database", func() (interface{}, error) {
+		return database.NewDatabaseClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.DatabaseClient = dbInterface.(database.DatabaseClient)
+
+	// Initialize API Gateway client
+	apiGatewayInterface, err := initClientWithTimeout("api gateway", func() (interface{}, error) {
+		return apigateway.NewGatewayClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.APIGatewayClient = apiGatewayInterface.(apigateway.GatewayClient)
+
+	// Initialize Functions client
+	functionsInterface, err := initClientWithTimeout("functions", func() (interface{}, error) {
+		return functions.NewFunctionsManagementClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.FunctionsClient = functionsInterface.(functions.FunctionsManagementClient)
+
+	// Initialize File Storage client
+	fileStorageInterface, err := initClientWithTimeout("file storage", func() (interface{}, error) {
+		return filestorage.NewFileStorageClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.FileStorageClient = fileStorageInterface.(filestorage.FileStorageClient)
+
+	// Initialize Network Load Balancer client
+	nlbInterface, err := initClientWithTimeout("network load balancer", func() (interface{}, error) {
+		return networkloadbalancer.NewNetworkLoadBalancerClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.NetworkLoadBalancerClient = nlbInterface.(networkloadbalancer.NetworkLoadBalancerClient)
+
+	// Initialize Streaming client
+	streamingInterface, err := initClientWithTimeout("streaming", func() (interface{}, error) {
+		return streaming.NewStreamAdminClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.StreamingClient = streamingInterface.(streaming.StreamAdminClient)
+
+	// Initialize OpenSearch client
+	openSearchInterface, err := initClientWithTimeout("opensearch", func() (interface{}, error) {
+		return opensearch.NewOpensearchClusterClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.OpenSearchClient = openSearchInterface.(opensearch.OpensearchClusterClient)
+
+	// Initialize OpenSearch backup client
+	openSearchBackupInterface, err := initClientWithTimeout("opensearch backup", func() (interface{}, error) {
+		return opensearch.NewOpensearchClusterBackupClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.OpenSearchBackupClient = openSearchBackupInterface.(opensearch.OpensearchClusterBackupClient)
+
+	// Initialize Quotas client
+	quotasInterface, err := initClientWithTimeout("quotas", func() (interface{}, error) {
+		return limits.NewQuotasClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.QuotasClient = quotasInterface.(limits.QuotasClient)
+
+	// Initialize Compute Management client
+	computeManagementInterface, err := initClientWithTimeout("compute management", func() (interface{}, error) {
+		return core.NewComputeManagementClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.ComputeManagementClient = computeManagementInterface.(core.ComputeManagementClient)
+
+	// Initialize Health Checks client
+	healthChecksInterface, err := initClientWithTimeout("health checks", func() (interface{}, error) {
+		return healthchecks.NewHealthChecksClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.HealthChecksClient = healthChecksInterface.(healthchecks.HealthChecksClient)
+
+	// Initialize OS Management Hub managed instance client
+	managedInstanceInterface, err := initClientWithTimeout("os management hub managed instance", func() (interface{}, error) {
+		return osmanagementhub.NewManagedInstanceClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.ManagedInstanceClient = managedInstanceInterface.(osmanagementhub.ManagedInstanceClient)
+
+	// Initialize OS Management Hub managed instance group client
+	managedInstanceGroupInterface, err := initClientWithTimeout("os management hub managed instance group", func() (interface{}, error) {
+		return osmanagementhub.NewManagedInstanceGroupClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.ManagedInstanceGroupClient = managedInstanceGroupInterface.(osmanagementhub.ManagedInstanceGroupClient)
+
+	// Initialize Operations Insights client
+	operationsInsightsInterface, err := initClientWithTimeout("operations insights", func() (interface{}, error) {
+		return opsi.NewOperationsInsightsClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.OperationsInsightsClient = operationsInsightsInterface.(opsi.OperationsInsightsClient)
+
+	// Initialize Database Management client
+	dbManagementInterface, err := initClientWithTimeout("database management", func() (interface{}, error) {
+		return databasemanagement.NewDbManagementClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.DbManagementClient = dbManagementInterface.(databasemanagement.DbManagementClient)
+
+	// Initialize Blockchain Platform client
+	blockchainPlatformInterface, err := initClientWithTimeout("blockchain platform", func() (interface{}, error) {
+		return blockchain.NewBlockchainPlatformClientWithConfigurationProvider(configProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+	clients.BlockchainPlatformClient = blockchainPlatformInterface.(blockchain.BlockchainPlatformClient)
+
+	// Identity domain clients are created on demand, one per domain endpoint
+	clients.IdentityDomainsClient = func(domainURL string) (IdentityDomainsAPI, error) {
+		client, err := identitydomains.NewIdentityDomainsClientWithConfigurationProvider(configProvider, domainURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create identity domains client: %w", err)
+		}
+		clients.Transport.Apply(&client.BaseClient)
+		clients.RateLimiter.Limit(&client.BaseClient, "identitydomains")
+		return client, nil
+	}
+
+	// Initialize Compartment Name Cache
+	clients.CompartmentCache = NewResourceNameCache(clients.IdentityClient)
+
+	// Final context check
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	return clients, nil
+}
+
+// getCompartments retrieves all accessible compartments in the tenancy with aggressive timeout control
+func getCompartments(ctx context.Context, clients *OCIClients) ([]identity.Compartment, error) {
+	return listCompartments(ctx, clients, false)
+}
+
+// listCompartments retrieves the accessible compartments directly under the tenancy, or all nested
+// compartments of the tenancy with inSubtree, along with the root compartment
+func listCompartments(ctx context.Context, clients *OCIClients, inSubtree bool) ([]identity.Compartment, error) {
+	// Check context before starting
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Get tenancy ID from the provider the clients were created with, or from the instance principal
+	configProvider := clients.ConfigProvider
+	if configProvider == nil {
+		type configResult struct {
+			provider common.ConfigurationProvider
+			err      error
+		}
+		configChan := make(chan configResult, 1)
+
+		go func() {
+			provider, err := auth.InstancePrincipalConfigurationProvider()
+			configChan <- configResult{provider: provider, err: err}
+		}()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result := <-configChan:
+			if result.err != nil {
+				return nil, result.err
+			}
+			configProvider = result.provider
+		}
+	}
+
+	// Check context after config provider setup
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Get tenancy ID with timeout channel
+	type tenancyResult struct {
+		tenancyID string
+		err       error
+	}
+	tenancyChan := make(chan tenancyResult, 1)
+
+	go func() {
+		tenancyID, err := configProvider.TenancyOCID()
+		tenancyChan <- tenancyResult{tenancyID: tenancyID, err: err}
+	}()
+
+	var tenancyID string
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-tenancyChan:
+		if result.err != nil {
+			return nil, result.err
+		}
+		tenancyID = result.tenancyID
+	}
+
+	// Check context before API call
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// List compartments with explicit context deadline
+	req := identity.ListCompartmentsRequest{
+		CompartmentId: common.String(tenancyID),
+		AccessLevel:   identity.ListCompartmentsAccessLevelAccessible,
+		Limit:         clients.pageLimit(maxPageSize),
+	}
+	if inSubtree {
+		req.CompartmentIdInSubtree = common.Bool(true)
+	}
+
+	// Execute API calls with timeout channel for aggressive control, following all pages
+	type compartmentResult struct {
+		resp identity.ListCompartmentsResponse
+		err  error
+	}
+	var compartments []identity.Compartment
+	for {
+		compartmentChan := make(chan compartmentResult, 1)
+
+		go func(req identity.ListCompartmentsRequest) {
+			resp, err := clients.IdentityClient.ListCompartments(ctx, req)
+			compartmentChan <- compartmentResult{resp: resp, err: err}
+		}(req)
+
+		var resp identity.ListCompartmentsResponse
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result := <-compartmentChan:
+			if result.err != nil {
+				return nil, result.err
+			}
+			resp = result.resp
+		}
+
+		compartments = append(compartments, resp.Items...)
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+
+	// Final context check
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Include root compartment
+	rootCompartment := identity.Compartment{
+		Id:             common.String(tenancyID),
+		Name:           common.String("root"),
+		CompartmentId:  common.String(tenancyID),
+		LifecycleState: identity.CompartmentLifecycleStateActive,
+	}
+	compartments = append([]identity.Compartment{rootCompartment}, compartments...)
+
+	return compartments, nil
+}
+
+// pageLimit returns the page size to request from a list operation that returns at most max items per page
+func (c *OCIClients) pageLimit(max int) *int {
+	size := c.Options.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	return common.Int(min(size, max))
+}
+
+// pageLimit64 is pageLimit for list operations with an int64 limit
+func (c *OCIClients) pageLimit64(max int) *int64 {
+	return common.Int64(int64(*c.pageLimit(max)))
+}
+
+// baseClients returns the SDK base clients of the OCI service clients
+// Clients that are not SDK clients, such as test fakes, and unset clients are skipped
+func (c *OCIClients) baseClients() []*common.BaseClient {
+	services := []interface{}{
+		c.ComputeClient,
+		c.VirtualNetworkClient,
+		c.BlockStorageClient,
+		c.IdentityClient,
+		c.ObjectStorageClient,
+		c.ContainerEngineClient,
+		c.LoadBalancerClient,
+		c.DatabaseClient,
+		c.APIGatewayClient,
+		c.FunctionsClient,
+		c.FileStorageClient,
+		c.NetworkLoadBalancerClient,
+		c.StreamingClient,
+		c.OpenSearchClient,
+		c.OpenSearchBackupClient,
+		c.QuotasClient,
+		c.ComputeManagementClient,
+		c.HealthChecksClient,
+		c.ManagedInstanceClient,
+		c.ManagedInstanceGroupClient,
+		c.OperationsInsightsClient,
+		c.DbManagementClient,
+		c.BlockchainPlatformClient,
+	}
+
+	var baseClients []*common.BaseClient
+	for _, service := range services {
+		if baseClient := sdkBaseClient(service); baseClient != nil {
+			baseClients = append(baseClients, baseClient)
+		}
+	}
+	return baseClients
+}
+
+// sdkBaseClient returns the base client embedded in an SDK service client, or nil for other values
+// The copy shares the HTTP dispatcher of the client, which is all Close needs
+func sdkBaseClient(client interface{}) *common.BaseClient {
+	value := reflect.ValueOf(client)
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	field := value.FieldByName("BaseClient")
+	if !field.IsValid() {
+		return nil
+	}
+	baseClient, ok := field.Interface().(common.BaseClient)
+	if !ok {
+		return nil
+	}
+	return &baseClient
+}
+
+// updateBaseClients calls update with the base client and service of each SDK service client
+// The clients are stored by value, so each is replaced by a copy with the updated base client.
+// The service of a client is the name of its SDK package.
+func (c *OCIClients) updateBaseClients(update func(baseClient *common.BaseClient, service string)) {
+	fields := reflect.ValueOf(c).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		if field.Kind() != reflect.Interface || field.IsNil() || !field.CanSet() {
+			continue
+		}
+		client := field.Elem()
+		if client.Kind() != reflect.Struct || !client.FieldByName("BaseClient").IsValid() {
+			continue
+		}
+		updated := reflect.New(client.Type()).Elem()
+		updated.Set(client)
+		baseClient, ok := updated.FieldByName("BaseClient").Addr().Interface().(*common.BaseClient)
+		if !ok {
+			continue
+		}
+		update(baseClient, path.Base(client.Type().PkgPath()))
+		field.Set(updated)
+	}
+}
+
+// Close releases resources held by the OCI clients by closing idle HTTP connections.
+// It is safe to call on partially initialized clients and more than once.
+func (c *OCIClients) Close() {
+	if c == nil {
+		return
+	}
+
+	closed := 0
+	for _, baseClient := range c.baseClients() {
+		if closeIdleConnections(baseClient.HTTPClient) {
+			closed++
+		}
+	}
+
+	logger.Debug("Closed idle connections for %d OCI clients", closed)
+}
+
+// closeIdleConnections closes idle connections of an SDK HTTP dispatcher when supported
+func closeIdleConnections(dispatcher common.HTTPRequestDispatcher) bool {
+	httpClient, ok := dispatcher.(*http.Client)
+	if !ok || httpClient == nil {
+		return false
+	}
+
+	transport := httpClient.Transport
+	for transport != nil {
+		if closer, ok := transport.(interface{ CloseIdleConnections() }); ok {
+			closer.CloseIdleConnections()
+			return true
+		}
+		// The SDK wraps the real transport to support certificate refresh, and the shared
+		// HTTP client wraps that to record Retry-After headers
+		wrapper, ok := transport.(interface{ Delegate() http.RoundTripper })
+		if !ok {
+			break
+		}
+		transport = wrapper.Delegate()
+	}
+	return false
+}
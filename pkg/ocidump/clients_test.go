@@ -0,0 +1,108 @@
+package ocidump
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+// closeTrackingTransport records CloseIdleConnections calls
+type closeTrackingTransport struct {
+	http.RoundTripper
+	closed int
+}
+
+func (t *closeTrackingTransport) CloseIdleConnections() {
+	t.closed++
+}
+
+// delegatingTransport mimics the SDK transport wrapper that exposes its delegate
+type delegatingTransport struct {
+	delegate http.RoundTripper
+}
+
+func (t *delegatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.delegate.RoundTrip(req)
+}
+
+func (t *delegatingTransport) Delegate() http.RoundTripper {
+	return t.delegate
+}
+
+func TestCloseIdleConnections(t *testing.T) {
+	direct := &closeTrackingTransport{}
+	if !closeIdleConnections(&http.Client{Transport: direct}) {
+		t.Error("closeIdleConnections() should close a transport supporting CloseIdleConnections")
+	}
+	if direct.closed != 1 {
+		t.Errorf("direct transport closed %d times, want 1", direct.closed)
+	}
+
+	wrapped := &closeTrackingTransport{}
+	if !closeIdleConnections(&http.Client{Transport: &delegatingTransport{delegate: wrapped}}) {
+		t.Error("closeIdleConnections() should close the delegate of a wrapped transport")
+	}
+	if wrapped.closed != 1 {
+		t.Errorf("wrapped transport closed %d times, want 1", wrapped.closed)
+	}
+
+	nested := &closeTrackingTransport{}
+	if !closeIdleConnections(&http.Client{Transport: &retryAfterTransport{transport: &delegatingTransport{delegate: nested}}}) {
+		t.Error("closeIdleConnections() should close the delegate of nested wrappers")
+	}
+	if nested.closed != 1 {
+		t.Errorf("nested transport closed %d times, want 1", nested.closed)
+	}
+
+	if closeIdleConnections(nil) {
+		t.Error("closeIdleConnections(nil) should return false")
+	}
+}
+
+func TestOCIClients_Close(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+
+	// Close must be safe on nil and zero-valued clients
+	var nilClients *OCIClients
+	nilClients.Close()
+
+	clients := &OCIClients{}
+	clients.Close()
+
+	transport := &closeTrackingTransport{}
+	clients.IdentityClient = identity.IdentityClient{BaseClient: common.BaseClient{HTTPClient: &http.Client{Transport: transport}}}
+	clients.Close()
+	clients.Close()
+
+	if transport.closed != 2 {
+		t.Errorf("identity client transport closed %d times, want 2", transport.closed)
+	}
+}
+
+func TestOCIClients_PageLimit(t *testing.T) {
+	tests := []struct {
+		pageSize int
+		max      int
+		expected int
+	}{
+		{0, maxPageSize, DefaultPageSize},
+		{0, 50, 50},
+		{200, maxPageSize, 200},
+		{200, 100, 100},
+		{5000, maxPageSize, maxPageSize},
+	}
+
+	for _, tt := range tests {
+		clients := &OCIClients{Options: DiscoveryOptions{PageSize: tt.pageSize}}
+		if got := *clients.pageLimit(tt.max); got != tt.expected {
+			t.Errorf("pageLimit(%d) with page size %d = %d, expected %d", tt.max, tt.pageSize, got, tt.expected)
+		}
+	}
+
+	clients := &OCIClients{}
+	if got := *clients.pageLimit64(maxPageSize); got != int64(DefaultPageSize) {
+		t.Errorf("pageLimit64 = %d, expected %d", got, DefaultPageSize)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package ocidump
 
 import (
 	"context"
@@ -11,16 +11,23 @@ import (
 	"github.com/oracle/oci-go-sdk/v65/identity"
 )
 
-// NewCompartmentNameCache creates a new compartment name cache instance
-func NewCompartmentNameCache(identityClient identity.IdentityClient) *CompartmentNameCache {
-	return &CompartmentNameCache{
+// NewResourceNameCache creates a new resource name cache instance
+func NewResourceNameCache(identityClient IdentityAPI) *ResourceNameCache {
+	return &ResourceNameCache{
 		cache:  make(map[string]string),
 		client: identityClient,
 	}
 }
 
+// NewCompartmentNameCache creates a new resource name cache instance
+//
+// Deprecated: use NewResourceNameCache
+func NewCompartmentNameCache(identityClient IdentityAPI) *ResourceNameCache {
+	return NewResourceNameCache(identityClient)
+}
+
 // GetCompartmentName retrieves the compartment name for a given OCID with optimized caching
-func (c *CompartmentNameCache) GetCompartmentName(ctx context.Context, compartmentOCID string) string {
+func (c *ResourceNameCache) GetCompartmentName(ctx context.Context, compartmentOCID string) string {
 	// Fast path: check cache with read lock
 	c.mu.RLock()
 	if name, exists := c.cache[compartmentOCID]; exists {
@@ -45,15 +52,25 @@ func (c *CompartmentNameCache) GetCompartmentName(ctx context.Context, compartme
 	name := c.fetchCompartmentName(ctxWithTimeout, compartmentOCID)
 	c.cache[compartmentOCID] = name
 
+	// A compartment missing from a stored tree, e.g. one created since, means the tree is stale
+	if c.fromStore && isCompartmentOCID(compartmentOCID) {
+		logger.Debug("Compartment %s is not in the stored compartment tree", compartmentOCID)
+		c.store.Invalidate(c.tenancyID)
+		c.fromStore = false
+	}
+
 	return name
 }
 
 // fetchCompartmentName retrieves compartment name from OCI API
-func (c *CompartmentNameCache) fetchCompartmentName(ctx context.Context, compartmentOCID string) string {
+func (c *ResourceNameCache) fetchCompartmentName(ctx context.Context, compartmentOCID string) string {
 	// Handle root compartment (tenancy)
 	if compartmentOCID == "" {
 		return "root"
 	}
+	if c.client == nil {
+		return c.formatShortOCID(compartmentOCID)
+	}
 
 	request := identity.GetCompartmentRequest{
 		CompartmentId: common.String(compartmentOCID),
@@ -75,7 +92,7 @@ func (c *CompartmentNameCache) fetchCompartmentName(ctx context.Context, compart
 }
 
 // formatShortOCID creates a short, readable version of an OCID for fallback display
-func (c *CompartmentNameCache) formatShortOCID(ocid string) string {
+func (c *ResourceNameCache) formatShortOCID(ocid string) string {
 	if len(ocid) <= 8 {
 		return ocid
 	}
@@ -87,10 +104,22 @@ func (c *CompartmentNameCache) formatShortOCID(ocid string) string {
 
 // PreloadCompartmentNames fetches compartment names with optimized concurrent processing
 // This dramatically improves performance by reducing API calls during resource discovery
-func (c *CompartmentNameCache) PreloadCompartmentNames(ctx context.Context, tenancyOCID string) error {
+func (c *ResourceNameCache) PreloadCompartmentNames(ctx context.Context, tenancyOCID string) error {
 	logger.Debug("Preloading compartment names for tenancy: %s", tenancyOCID)
 	startTime := time.Now()
 
+	// Reuse the tree stored by a recent run
+	if c.store != nil && c.tenancyID == tenancyOCID {
+		if compartments, ok := c.store.Get(tenancyOCID); ok {
+			err := c.simplePreloadCompartments(compartments, tenancyOCID)
+			c.mu.Lock()
+			c.fromStore = true
+			c.mu.Unlock()
+			logger.Verbose("Loaded %d compartment names from the compartment cache in %v", len(compartments), time.Since(startTime))
+			return err
+		}
+	}
+
 	// Get all compartments in the tenancy with timeout
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -100,6 +129,12 @@ func (c *CompartmentNameCache) PreloadCompartmentNames(ctx context.Context, tena
 		return fmt.Errorf("failed to get compartments: %w", err)
 	}
 
+	if c.store != nil && c.tenancyID == tenancyOCID {
+		if err := c.store.Put(tenancyOCID, compartments); err != nil {
+			logger.Verbose("Warning: Could not update compartment cache: %v", err)
+		}
+	}
+
 	logger.Debug("Found %d compartments to preload", len(compartments))
 
 	// Use batch processing only for very large tenancies where overhead is justified
@@ -130,13 +165,17 @@ func (c *CompartmentNameCache) PreloadCompartmentNames(ctx context.Context, tena
 }
 
 // getAllCompartments recursively retrieves all compartments in the tenancy
-func (c *CompartmentNameCache) getAllCompartments(ctx context.Context, compartmentOCID string) ([]identity.Compartment, error) {
+func (c *ResourceNameCache) getAllCompartments(ctx context.Context, compartmentOCID string) ([]identity.Compartment, error) {
 	var allCompartments []identity.Compartment
+	if c.client == nil {
+		return nil, fmt.Errorf("no identity client")
+	}
 
 	request := identity.ListCompartmentsRequest{
 		CompartmentId:          common.String(compartmentOCID),
 		AccessLevel:            identity.ListCompartmentsAccessLevelAccessible,
 		CompartmentIdInSubtree: common.Bool(true),
+		Limit:                  c.pageLimit,
 	}
 
 	response, err := c.client.ListCompartments(ctx, request)
@@ -160,7 +199,7 @@ func (c *CompartmentNameCache) getAllCompartments(ctx context.Context, compartme
 }
 
 // GetCacheStats returns statistics about the compartment name cache
-func (c *CompartmentNameCache) GetCacheStats() (totalEntries int, cacheHitRate float64) {
+func (c *ResourceNameCache) GetCacheStats() (totalEntries int, cacheHitRate float64) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -173,7 +212,7 @@ func (c *CompartmentNameCache) GetCacheStats() (totalEntries int, cacheHitRate f
 }
 
 // batchPreloadCompartments handles concurrent preloading for large tenancies
-func (c *CompartmentNameCache) batchPreloadCompartments(compartments []identity.Compartment, tenancyOCID string) error {
+func (c *ResourceNameCache) batchPreloadCompartments(compartments []identity.Compartment, tenancyOCID string) error {
 	logger.Debug("Using batch preload for %d compartments", len(compartments))
 
 	// Process in batches of 20 compartments with 3 concurrent workers
@@ -240,7 +279,7 @@ func (c *CompartmentNameCache) batchPreloadCompartments(compartments []identity.
 }
 
 // simplePreloadCompartments handles sequential preloading for small tenancies
-func (c *CompartmentNameCache) simplePreloadCompartments(compartments []identity.Compartment, tenancyOCID string) error {
+func (c *ResourceNameCache) simplePreloadCompartments(compartments []identity.Compartment, tenancyOCID string) error {
 	logger.Debug("Using simple preload for %d compartments", len(compartments))
 
 	c.mu.Lock()
@@ -258,8 +297,17 @@ func (c *CompartmentNameCache) simplePreloadCompartments(compartments []identity
 	return nil
 }
 
+// SetStore persists the compartment tree of a tenancy in store between runs
+// PreloadCompartmentNames then loads the tree from store while it is fresh and stores it otherwise.
+func (c *ResourceNameCache) SetStore(store *CompartmentTreeCache, tenancyID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store = store
+	c.tenancyID = tenancyID
+}
+
 // ClearCache clears all cached compartment names
-func (c *CompartmentNameCache) ClearCache() {
+func (c *ResourceNameCache) ClearCache() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
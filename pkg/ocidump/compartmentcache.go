@@ -0,0 +1,135 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+// defaultCompartmentTTL is how long a stored compartment tree is reused when cache.compartment_ttl is not set
+const defaultCompartmentTTL = time.Hour
+
+// compartmentTTL returns the configured lifetime of the compartment tree, defaulting to an hour
+func (c CacheConfig) compartmentTTL() time.Duration {
+	if c.CompartmentTTL == 0 {
+		return defaultCompartmentTTL
+	}
+	return time.Duration(c.CompartmentTTL) * time.Second
+}
+
+// CompartmentTreeCache keeps the compartment tree of each tenancy on disk, so the names of all
+// compartments are not listed again on every run
+//
+//	<dir>/compartments/<tenancy OCID>.json
+//
+// The stored tree is used until it expires or a lookup misses a compartment that is not in it,
+// e.g. one created since, which removes it so the next run lists the tree again.
+type CompartmentTreeCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// compartmentTree is the content of a compartment tree file
+type compartmentTree struct {
+	CachedAt     time.Time              `json:"cached_at"`
+	Compartments []compartmentTreeEntry `json:"compartments"`
+}
+
+// compartmentTreeEntry is a compartment and its parent in the tree
+type compartmentTreeEntry struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"`
+}
+
+// OpenCompartmentTreeCache opens the compartment tree cache in the configured cache directory
+func OpenCompartmentTreeCache(config CacheConfig) (*CompartmentTreeCache, error) {
+	dir := config.Dir
+	if dir == "" {
+		var err error
+		if dir, err = DefaultCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+	dir = filepath.Join(dir, "compartments")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create compartment cache: %w", err)
+	}
+	return &CompartmentTreeCache{dir: dir, ttl: config.compartmentTTL(), now: time.Now}, nil
+}
+
+// path returns the compartment tree file of a tenancy
+func (c *CompartmentTreeCache) path(tenancyID string) string {
+	return filepath.Join(c.dir, url.PathEscape(tenancyID)+".json")
+}
+
+// Get returns the stored compartments of a tenancy
+// It reports false when there is no tree younger than the TTL; unreadable trees count as missing.
+func (c *CompartmentTreeCache) Get(tenancyID string) ([]identity.Compartment, bool) {
+	data, err := os.ReadFile(c.path(tenancyID))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Debug("Ignoring unreadable compartment cache for %s: %v", tenancyID, err)
+		}
+		return nil, false
+	}
+	var tree compartmentTree
+	if err := json.Unmarshal(data, &tree); err != nil {
+		logger.Debug("Ignoring invalid compartment cache for %s: %v", tenancyID, err)
+		return nil, false
+	}
+	if c.now().Sub(tree.CachedAt) > c.ttl {
+		return nil, false
+	}
+
+	compartments := make([]identity.Compartment, 0, len(tree.Compartments))
+	for _, entry := range tree.Compartments {
+		compartment := identity.Compartment{Id: common.String(entry.ID), Name: common.String(entry.Name)}
+		if entry.ParentID != "" {
+			compartment.CompartmentId = common.String(entry.ParentID)
+		}
+		compartments = append(compartments, compartment)
+	}
+	return compartments, true
+}
+
+// Put stores the compartments of a tenancy
+func (c *CompartmentTreeCache) Put(tenancyID string, compartments []identity.Compartment) error {
+	tree := compartmentTree{CachedAt: c.now().UTC(), Compartments: make([]compartmentTreeEntry, 0, len(compartments))}
+	for _, compartment := range compartments {
+		if compartment.Id == nil || compartment.Name == nil {
+			continue
+		}
+		tree.Compartments = append(tree.Compartments, compartmentTreeEntry{
+			ID:       *compartment.Id,
+			Name:     *compartment.Name,
+			ParentID: stringValue(compartment.CompartmentId),
+		})
+	}
+	if err := writeJSONFile(c.path(tenancyID), tree, false); err != nil {
+		return fmt.Errorf("failed to write compartment cache: %w", err)
+	}
+	return nil
+}
+
+// Invalidate removes the stored tree of a tenancy
+func (c *CompartmentTreeCache) Invalidate(tenancyID string) {
+	if err := os.Remove(c.path(tenancyID)); err != nil && !os.IsNotExist(err) {
+		logger.Verbose("Warning: Could not remove compartment cache: %v", err)
+		return
+	}
+	logger.Debug("Removed the compartment cache of %s", tenancyID)
+}
+
+// isCompartmentOCID reports whether an OCID names a compartment or the tenancy (root compartment)
+func isCompartmentOCID(ocid string) bool {
+	return strings.HasPrefix(ocid, "ocid1.compartment.") || strings.HasPrefix(ocid, "ocid1.tenancy.")
+}
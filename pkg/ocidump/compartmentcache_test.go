@@ -0,0 +1,100 @@
+package ocidump
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+func TestCompartmentTreeCache_PutGet(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	store, err := OpenCompartmentTreeCache(CacheConfig{Dir: t.TempDir(), CompartmentTTL: 60})
+	if err != nil {
+		t.Fatalf("OpenCompartmentTreeCache failed: %v", err)
+	}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	if _, ok := store.Get(fakeTenancyID); ok {
+		t.Fatal("empty cache should miss")
+	}
+	compartments := []identity.Compartment{
+		fakeCompartment("ocid1.compartment.oc1..prod", "prod"),
+		fakeCompartment("ocid1.compartment.oc1..app", "app"),
+	}
+	compartments[1].CompartmentId = compartments[0].Id
+	if err := store.Put(fakeTenancyID, compartments); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	got, ok := store.Get(fakeTenancyID)
+	if !ok || len(got) != 2 || *got[1].Name != "app" || *got[1].CompartmentId != "ocid1.compartment.oc1..prod" {
+		t.Fatalf("Get = %v, %v, want both compartments with their parents", got, ok)
+	}
+	if _, ok := store.Get("ocid1.tenancy.oc1..other"); ok {
+		t.Error("trees of other tenancies must be kept apart")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := store.Get(fakeTenancyID); ok {
+		t.Error("tree older than the TTL should miss")
+	}
+
+	store.Invalidate(fakeTenancyID)
+	if _, err := os.Stat(store.path(fakeTenancyID)); !os.IsNotExist(err) {
+		t.Errorf("Invalidate should remove the tree, stat error = %v", err)
+	}
+}
+
+func TestResourceNameCache_Store(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const newCompartment = "ocid1.compartment.oc1..new"
+	identityFake := &fakeIdentity{compartments: []identity.Compartment{
+		fakeCompartment("ocid1.compartment.oc1..a", "a"),
+		fakeCompartment("ocid1.compartment.oc1..b", "b"),
+		fakeCompartment("ocid1.compartment.oc1..c", "c"),
+	}}
+	store, err := OpenCompartmentTreeCache(CacheConfig{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first run lists the tree and stores it
+	first := NewResourceNameCache(identityFake)
+	first.SetStore(store, fakeTenancyID)
+	if err := first.PreloadCompartmentNames(context.Background(), fakeTenancyID); err != nil {
+		t.Fatal(err)
+	}
+	listed := identityFake.count("ListCompartments")
+	if listed == 0 {
+		t.Fatal("first preload should list the compartments")
+	}
+
+	// The next run loads it without listing
+	identityFake.compartments = append(identityFake.compartments, fakeCompartment(newCompartment, "new"))
+	second := NewResourceNameCache(identityFake)
+	second.SetStore(store, fakeTenancyID)
+	if err := second.PreloadCompartmentNames(context.Background(), fakeTenancyID); err != nil {
+		t.Fatal(err)
+	}
+	if calls := identityFake.count("ListCompartments"); calls != listed {
+		t.Errorf("ListCompartments called %d times, want the stored tree to be used", calls-listed)
+	}
+	if name := second.GetCompartmentName(context.Background(), "ocid1.compartment.oc1..b"); name != "b" {
+		t.Errorf("stored name = %s, want b", name)
+	}
+	if name := second.GetCompartmentName(context.Background(), fakeTenancyID); name != "root" {
+		t.Errorf("tenancy name = %s, want root", name)
+	}
+
+	// A compartment created since is fetched and the stale tree removed
+	if name := second.GetCompartmentName(context.Background(), newCompartment); name != "new" {
+		t.Errorf("new compartment name = %s, want new", name)
+	}
+	if _, ok := store.Get(fakeTenancyID); ok {
+		t.Error("a miss should invalidate the stored tree")
+	}
+}
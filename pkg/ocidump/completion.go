@@ -0,0 +1,308 @@
+package ocidump
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/common/auth"
+	"github.com/oracle/oci-go-sdk/v65/ons"
+)
+
+// Completion notification triggers
+const (
+	NotifyWhenAlways  = "always"  // Every run (default)
+	NotifyWhenSuccess = "success" // Successful runs
+	NotifyWhenFailure = "failure" // Failed or interrupted runs
+)
+
+// notifyWhenValues lists the supported completion triggers
+var notifyWhenValues = []string{NotifyWhenAlways, NotifyWhenSuccess, NotifyWhenFailure}
+
+// defaultSMTPPort is the submission port used when email.smtp_port is not set
+const defaultSMTPPort = 587
+
+// completionTopTypes is the number of resource types listed in a completion notification
+const completionTopTypes = 10
+
+// CompletionNotifyConfig holds the targets notified when a run completes
+type CompletionNotifyConfig struct {
+	When      string      `yaml:"when"`       // always (default), success or failure
+	Slack     []string    `yaml:"slack"`      // Slack incoming webhook URLs
+	Email     EmailConfig `yaml:"email"`      // SMTP delivery
+	ONSTopics []string    `yaml:"ons_topics"` // OCI Notifications topic OCIDs, published with the instance principal
+}
+
+// EmailConfig describes SMTP delivery of completion notifications
+type EmailConfig struct {
+	SMTPHost string   `yaml:"smtp_host"`
+	SMTPPort int      `yaml:"smtp_port"` // Default: 587; STARTTLS is used when the server offers it
+	Username string   `yaml:"username"`  // Empty for servers without authentication
+	Password string   `yaml:"password"`  // Default: OCI_DUMP_SMTP_PASSWORD environment variable
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// Enabled reports whether any completion target is configured
+func (c CompletionNotifyConfig) Enabled() bool {
+	return len(c.Slack) > 0 || c.Email.Enabled() || len(c.ONSTopics) > 0
+}
+
+// Enabled reports whether email delivery is configured
+func (c EmailConfig) Enabled() bool {
+	return c.SMTPHost != ""
+}
+
+// Validate checks the completion targets
+func (c CompletionNotifyConfig) Validate() error {
+	var problems []error
+	if c.When != "" && !contains(notifyWhenValues, c.When) {
+		problems = append(problems, fmt.Errorf("invalid completion notification when '%s', must be one of: %v", c.When, notifyWhenValues))
+	}
+	for i, rawURL := range c.Slack {
+		target, err := url.Parse(rawURL)
+		if err != nil || target.Host == "" || (target.Scheme != "https" && target.Scheme != "http") {
+			problems = append(problems, fmt.Errorf("invalid slack url at notify.completion.slack[%d], must be an http(s) URL", i))
+		}
+	}
+	for _, topic := range c.ONSTopics {
+		if !strings.HasPrefix(topic, "ocid1.onstopic.") {
+			problems = append(problems, fmt.Errorf("invalid ONS topic '%s', must be a topic OCID", topic))
+		}
+	}
+	if c.Email.Enabled() {
+		if c.Email.From == "" || len(c.Email.To) == 0 {
+			problems = append(problems, fmt.Errorf("completion email requires from and to addresses"))
+		}
+		if c.Email.SMTPPort < 0 || c.Email.SMTPPort > 65535 {
+			problems = append(problems, fmt.Errorf("invalid smtp_port: %d", c.Email.SMTPPort))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// notifies reports whether a run with the given status is notified
+func (c CompletionNotifyConfig) notifies(status string) bool {
+	switch c.When {
+	case NotifyWhenSuccess:
+		return status == AuditStatusSuccess
+	case NotifyWhenFailure:
+		return status != AuditStatusSuccess
+	default:
+		return true
+	}
+}
+
+// onsPublisher is the subset of ons.NotificationDataPlaneClient used to publish notifications
+type onsPublisher interface {
+	PublishMessage(ctx context.Context, request ons.PublishMessageRequest) (ons.PublishMessageResponse, error)
+}
+
+// NotifyCompletion announces the outcome of a finished run to every configured target
+// All targets are attempted; failures are returned together
+func NotifyCompletion(ctx context.Context, config CompletionNotifyConfig, record *AuditRecord) error {
+	return notifyCompletion(ctx, config, record, newONSPublisher)
+}
+
+// notifyCompletion sends the notifications, creating the ONS client only when topics are configured
+func notifyCompletion(ctx context.Context, config CompletionNotifyConfig, record *AuditRecord, newPublisher func() (onsPublisher, error)) error {
+	if !config.notifies(record.Status) {
+		logger.Debug("Run %s is %s, completion notifications are sent on %s", record.RunID, record.Status, config.When)
+		return nil
+	}
+
+	title := completionTitle(record)
+	var errs []error
+	for _, slackURL := range config.Slack {
+		logger.Info("Sending completion notification to %s", redactURL(slackURL))
+		text := fmt.Sprintf("*%s*\n%s", title, completionText(record))
+		if err := postJSON(ctx, slackURL, map[string]interface{}{"text": text}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if config.Email.Enabled() {
+		logger.Info("Sending completion email to %s", strings.Join(config.Email.To, ", "))
+		if err := sendEmail(ctx, config.Email, title, completionText(record)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send completion email: %w", err))
+		}
+	}
+
+	if len(config.ONSTopics) > 0 {
+		publisher, err := newPublisher()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create notifications client: %w", err))
+		} else {
+			for _, topic := range config.ONSTopics {
+				logger.Info("Publishing completion notification to topic %s", topic)
+				_, err := publisher.PublishMessage(ctx, ons.PublishMessageRequest{
+					TopicId: common.String(topic),
+					MessageDetails: ons.MessageDetails{
+						Title: common.String(title),
+						Body:  common.String(completionText(record)),
+					},
+				})
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to publish to topic %s: %w", topic, err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newONSPublisher creates a notifications client with the instance principal
+// Topics must be in the region of the instance
+func newONSPublisher() (onsPublisher, error) {
+	provider, err := auth.InstancePrincipalConfigurationProvider()
+	if err != nil {
+		return nil, err
+	}
+	return ons.NewNotificationDataPlaneClientWithConfigurationProvider(provider)
+}
+
+// completionTitle returns the one-line outcome of a run
+func completionTitle(record *AuditRecord) string {
+	switch record.Status {
+	case AuditStatusSuccess:
+		return fmt.Sprintf("OCI resource dump completed: %d resources", record.ResourceCount)
+	case AuditStatusInterrupted:
+		return "OCI resource dump interrupted"
+	default:
+		return "OCI resource dump failed"
+	}
+}
+
+// completionText renders the summary of a run as plain text
+func completionText(record *AuditRecord) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Status: %s\n", record.Status)
+	if record.Error != "" {
+		fmt.Fprintf(&b, "Error: %s\n", record.Error)
+	}
+	fmt.Fprintf(&b, "Started: %s (%s)\n", record.StartedAt.Format(time.RFC3339), time.Duration(record.DurationSeconds*float64(time.Second)).Round(time.Second))
+	fmt.Fprintf(&b, "Resources: %d in %d compartments\n", record.ResourceCount, record.Compartments)
+	fmt.Fprintf(&b, "Discovery errors: %d\n", record.DiscoveryErrors)
+	if record.Parameters.OutputFile != "" && !IsStdoutPath(record.Parameters.OutputFile) {
+		fmt.Fprintf(&b, "Output: %s\n", record.Parameters.OutputFile)
+	}
+	fmt.Fprintf(&b, "Run: %s (%s on %s)\n", record.RunID, record.Command, record.Caller.Hostname)
+
+	// The most common resource types, largest first
+	resourceTypes := make([]string, 0, len(record.ResourceCounts))
+	for resourceType := range record.ResourceCounts {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		a, b := resourceTypes[i], resourceTypes[j]
+		if record.ResourceCounts[a] != record.ResourceCounts[b] {
+			return record.ResourceCounts[a] > record.ResourceCounts[b]
+		}
+		return a < b
+	})
+	for i, resourceType := range resourceTypes {
+		if i == completionTopTypes {
+			fmt.Fprintf(&b, "• ... and %d more resource types\n", len(resourceTypes)-completionTopTypes)
+			break
+		}
+		fmt.Fprintf(&b, "• %s: %d\n", resourceType, record.ResourceCounts[resourceType])
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// port returns the configured SMTP port, defaulting to the submission port
+func (c EmailConfig) port() int {
+	if c.SMTPPort == 0 {
+		return defaultSMTPPort
+	}
+	return c.SMTPPort
+}
+
+// password returns the configured password or the one from the environment
+func (c EmailConfig) password() string {
+	if c.Password != "" {
+		return c.Password
+	}
+	return os.Getenv("OCI_DUMP_SMTP_PASSWORD")
+}
+
+// sendEmail delivers a plain text message over SMTP
+// STARTTLS is used when offered; credentials are only sent over TLS or to localhost.
+func sendEmail(ctx context.Context, config EmailConfig, subject, body string) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(config.SMTPHost, strconv.Itoa(config.port())))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, config.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: config.SMTPHost}); err != nil {
+			return err
+		}
+	}
+	if config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", config.Username, config.password(), config.SMTPHost)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(config.From); err != nil {
+		return err
+	}
+	for _, to := range config.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(emailMessage(config, subject, body, time.Now())); err != nil {
+		writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// emailMessage builds the RFC 5322 message of a notification
+func emailMessage(config EmailConfig, subject, body string, date time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(config.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,246 @@
+package ocidump
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/ons"
+)
+
+func TestCompletionNotifyConfig_Validate(t *testing.T) {
+	email := EmailConfig{SMTPHost: "smtp.example.com", From: "dump@example.com", To: []string{"ops@example.com"}}
+	tests := []struct {
+		name    string
+		config  CompletionNotifyConfig
+		wantErr bool
+	}{
+		{"empty", CompletionNotifyConfig{}, false},
+		{"all targets", CompletionNotifyConfig{When: NotifyWhenFailure, Slack: []string{"https://hooks.slack.com/services/T/B/X"}, Email: email, ONSTopics: []string{"ocid1.onstopic.oc1..t"}}, false},
+		{"unknown when", CompletionNotifyConfig{When: "sometimes"}, true},
+		{"invalid slack url", CompletionNotifyConfig{Slack: []string{"hooks.slack.com"}}, true},
+		{"invalid topic", CompletionNotifyConfig{ONSTopics: []string{"my-topic"}}, true},
+		{"email without recipients", CompletionNotifyConfig{Email: EmailConfig{SMTPHost: "smtp.example.com", From: "dump@example.com"}}, true},
+		{"invalid smtp port", CompletionNotifyConfig{Email: EmailConfig{SMTPHost: "smtp.example.com", SMTPPort: 70000, From: "a@example.com", To: []string{"b@example.com"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompletionNotifyConfig_Notifies(t *testing.T) {
+	tests := []struct {
+		when     string
+		status   string
+		expected bool
+	}{
+		{"", AuditStatusFailed, true},
+		{NotifyWhenAlways, AuditStatusSuccess, true},
+		{NotifyWhenSuccess, AuditStatusSuccess, true},
+		{NotifyWhenSuccess, AuditStatusFailed, false},
+		{NotifyWhenFailure, AuditStatusSuccess, false},
+		{NotifyWhenFailure, AuditStatusInterrupted, true},
+	}
+
+	for _, tt := range tests {
+		if got := (CompletionNotifyConfig{When: tt.when}).notifies(tt.status); got != tt.expected {
+			t.Errorf("notifies(%q) with when %q = %v, expected %v", tt.status, tt.when, got, tt.expected)
+		}
+	}
+}
+
+// fakeONSPublisher records published messages
+type fakeONSPublisher struct {
+	requests []ons.PublishMessageRequest
+	err      error
+}
+
+func (f *fakeONSPublisher) PublishMessage(ctx context.Context, request ons.PublishMessageRequest) (ons.PublishMessageResponse, error) {
+	f.requests = append(f.requests, request)
+	return ons.PublishMessageResponse{}, f.err
+}
+
+func testCompletionRecord() *AuditRecord {
+	return &AuditRecord{
+		RunID:           "run-1",
+		Command:         "dump",
+		StartedAt:       time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+		DurationSeconds: 125,
+		Status:          AuditStatusSuccess,
+		Caller:          AuditCaller{Hostname: "bastion"},
+		Parameters:      AuditParameters{OutputFile: "dump.json"},
+		Compartments:    3,
+		ResourceCount:   12,
+		ResourceCounts:  map[string]int{"VCN": 2, "ComputeInstance": 10},
+		DiscoveryErrors: 1,
+	}
+}
+
+func TestNotifyCompletion(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+
+	var slackText string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		slackText = payload["text"]
+	}))
+	defer server.Close()
+
+	publisher := &fakeONSPublisher{}
+	config := CompletionNotifyConfig{Slack: []string{server.URL}, ONSTopics: []string{"ocid1.onstopic.oc1..t"}}
+	err := notifyCompletion(context.Background(), config, testCompletionRecord(), func() (onsPublisher, error) { return publisher, nil })
+	if err != nil {
+		t.Fatalf("notifyCompletion failed: %v", err)
+	}
+
+	for _, want := range []string{"*OCI resource dump completed: 12 resources*", "Resources: 12 in 3 compartments", "Discovery errors: 1", "Output: dump.json", "(2m5s)"} {
+		if !strings.Contains(slackText, want) {
+			t.Errorf("slack text missing %q:\n%s", want, slackText)
+		}
+	}
+	if strings.Index(slackText, "ComputeInstance: 10") > strings.Index(slackText, "VCN: 2") {
+		t.Errorf("resource types not sorted by count:\n%s", slackText)
+	}
+
+	if len(publisher.requests) != 1 {
+		t.Fatalf("published %d messages, expected 1", len(publisher.requests))
+	}
+	request := publisher.requests[0]
+	if *request.TopicId != "ocid1.onstopic.oc1..t" || *request.MessageDetails.Title != "OCI resource dump completed: 12 resources" {
+		t.Errorf("unexpected publish request: %s / %s", *request.TopicId, *request.MessageDetails.Title)
+	}
+}
+
+func TestNotifyCompletion_Errors(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	// Failures of one target do not stop the others
+	publisher := &fakeONSPublisher{err: errors.New("topic not found")}
+	config := CompletionNotifyConfig{When: NotifyWhenFailure, Slack: []string{server.URL}, ONSTopics: []string{"ocid1.onstopic.oc1..a", "ocid1.onstopic.oc1..b"}}
+	record := testCompletionRecord()
+	record.Status = AuditStatusFailed
+	record.Error = "error discovering resources: timeout"
+
+	err := notifyCompletion(context.Background(), config, record, func() (onsPublisher, error) { return publisher, nil })
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "ocid1.onstopic.oc1..b") {
+		t.Errorf("expected errors of all targets, got: %v", err)
+	}
+	if len(publisher.requests) != 2 || !strings.Contains(*publisher.requests[0].MessageDetails.Body, "Error: error discovering resources: timeout") {
+		t.Errorf("unexpected publish requests: %+v", publisher.requests)
+	}
+
+	// Successful runs are not announced with when: failure
+	record.Status = AuditStatusSuccess
+	publisher.requests = nil
+	if err := notifyCompletion(context.Background(), config, record, func() (onsPublisher, error) { return publisher, nil }); err != nil || len(publisher.requests) != 0 {
+		t.Errorf("successful run notified: %v, %d messages", err, len(publisher.requests))
+	}
+}
+
+// fakeSMTPServer accepts one message on a local port and sends the commands and data it received
+func fakeSMTPServer(t *testing.T) (string, int, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var transcript strings.Builder
+		reader := bufio.NewReader(conn)
+		fmt.Fprint(conn, "220 localhost ESMTP\r\n")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			transcript.WriteString(line)
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"):
+				fmt.Fprint(conn, "250 localhost\r\n")
+			case command == "DATA":
+				fmt.Fprint(conn, "354 go ahead\r\n")
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					transcript.WriteString(line)
+				}
+				fmt.Fprint(conn, "250 queued\r\n")
+			case command == "QUIT":
+				fmt.Fprint(conn, "221 bye\r\n")
+				received <- transcript.String()
+				return
+			default:
+				fmt.Fprint(conn, "250 ok\r\n")
+			}
+		}
+		received <- transcript.String()
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, received
+}
+
+func TestSendEmail(t *testing.T) {
+	host, port, received := fakeSMTPServer(t)
+	config := EmailConfig{SMTPHost: host, SMTPPort: port, From: "dump@example.com", To: []string{"ops@example.com", "oncall@example.com"}}
+	if err := sendEmail(context.Background(), config, "OCI resource dump failed", "Status: failed\nError: timeout"); err != nil {
+		t.Fatalf("sendEmail failed: %v", err)
+	}
+
+	transcript := <-received
+	for _, want := range []string{"MAIL FROM:<dump@example.com>", "RCPT TO:<ops@example.com>", "RCPT TO:<oncall@example.com>", "Subject: OCI resource dump failed\r\n", "Status: failed\r\nError: timeout\r\n"} {
+		if !strings.Contains(transcript, want) {
+			t.Errorf("transcript missing %q:\n%s", want, transcript)
+		}
+	}
+}
+
+func TestEmailMessage(t *testing.T) {
+	config := EmailConfig{From: "dump@example.com", To: []string{"a@example.com", "b@example.com"}}
+	message := string(emailMessage(config, "Dump — done", "line 1\nline 2", time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)))
+
+	headers, body, found := strings.Cut(message, "\r\n\r\n")
+	if !found {
+		t.Fatalf("no header separator in:\n%s", message)
+	}
+	for _, want := range []string{"To: a@example.com, b@example.com", "Subject: =?utf-8?q?", "Date: Mon, 10 Mar 2025 09:00:00 +0000", "Content-Type: text/plain; charset=utf-8"} {
+		if !strings.Contains(headers, want) {
+			t.Errorf("headers missing %q:\n%s", want, headers)
+		}
+	}
+	if body != "line 1\r\nline 2\r\n" {
+		t.Errorf("body = %q", body)
+	}
+}
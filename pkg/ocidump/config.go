@@ -0,0 +1,393 @@
+package ocidump
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AppConfig represents the YAML configuration structure
+// Phase 2C: Configuration with filtering and diff support
+type AppConfig struct {
+	Version string                  `yaml:"version"`
+	General GeneralConfig           `yaml:"general"`
+	Output  OutputConfig            `yaml:"output"`
+	Filters FilterConfig            `yaml:"filters"`
+	Presets map[string]FilterConfig `yaml:"presets"` // Named filter sets selected with --preset
+	Diff    DiffConfig              `yaml:"diff"`
+	Upload  UploadConfig            `yaml:"upload"`
+	Daemon  DaemonConfig            `yaml:"daemon"`
+	Notify  NotifyConfig            `yaml:"notify"`
+	History HistoryConfig           `yaml:"history"`
+	Cost    CostConfig              `yaml:"cost"`
+	Audit   AuditConfig             `yaml:"audit"`
+	Cache   CacheConfig             `yaml:"cache"`
+
+	Utilization UtilizationConfig `yaml:"utilization"`
+	Terraform   TerraformConfig   `yaml:"terraform"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	HTTP        HTTPConfig        `yaml:"http"`
+	Server      ServerConfig      `yaml:"server"`
+
+	Originators OriginatorConfig `yaml:"originators"`
+}
+
+// GeneralConfig holds general execution settings
+type GeneralConfig struct {
+	Timeout      int    `yaml:"timeout"`       // Timeout in seconds
+	LogLevel     string `yaml:"log_level"`     // Log level: silent, normal, verbose, debug
+	LogFormat    string `yaml:"log_format"`    // Log format: text (default) or json
+	OutputFormat string `yaml:"output_format"` // Output format: json, ndjson, csv, tsv, openmetrics, dot, mermaid
+	Progress     bool   `yaml:"progress"`      // Progress bar display
+	Detail       bool   `yaml:"detail"`        // Fetch per-resource details (additional API calls)
+	Mode         string `yaml:"mode"`          // Discovery mode: full (default) or search
+	PageSize     int    `yaml:"page_size"`     // Items requested per page of list calls (0 = 1000, capped per API)
+
+	CircuitBreakerThreshold int `yaml:"circuit_breaker_threshold"` // Skip the resource types of a service after they failed this many times in a row (0 = 5, -1 = never)
+
+	CheckpointFile string `yaml:"checkpoint_file"` // Record discovery progress here so --resume can continue an interrupted run
+}
+
+// OutputConfig holds output-related settings
+type OutputConfig struct {
+	File    string `yaml:"file"`    // Output file path (empty = stdout)
+	Tee     bool   `yaml:"tee"`     // Also write to stdout when writing to a file
+	SortBy  string `yaml:"sort_by"` // Comma-separated sort keys (empty = discovery order)
+	Summary bool   `yaml:"summary"` // Output resource counts instead of the resource list
+	Stream  bool   `yaml:"stream"`  // Write json, csv and tsv output while discovery is running (ndjson always is)
+	Query   string `yaml:"query"`   // JMESPath expression applied to the resource array (json only)
+
+	CSV CSVDialect `yaml:"csv"` // CSV dialect (delimiter, quoting, BOM, line endings)
+}
+
+// Default configuration values
+func getDefaultConfig() *AppConfig {
+	return &AppConfig{
+		Version: "1.0",
+		General: GeneralConfig{
+			Timeout:      300, // 5 minutes default
+			LogLevel:     "normal",
+			OutputFormat: "json",
+			Progress:     true,
+		},
+		Output: OutputConfig{
+			File: "", // stdout by default
+		},
+		Filters: FilterConfig{
+			IncludeCompartments:  []string{},
+			ExcludeCompartments:  []string{},
+			IncludeResourceTypes: []string{},
+			ExcludeResourceTypes: []string{},
+			NamePattern:          "",
+			ExcludeNamePattern:   "",
+		},
+		Diff: DiffConfig{
+			Format:     "json",
+			Detailed:   false,
+			OutputFile: "",
+		},
+		Originators: getDefaultOriginatorConfig(),
+	}
+}
+
+// Configuration file search paths in priority order
+func getConfigPaths() []string {
+	paths := []string{}
+
+	// 1. Environment variable
+	if configFile := os.Getenv("OCI_DUMP_CONFIG_FILE"); configFile != "" {
+		paths = append(paths, configFile)
+	}
+
+	// 2. Current directory
+	paths = append(paths, "./oci-resource-dump.yaml")
+
+	// 3. Home directory
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(homeDir, ".oci-resource-dump.yaml"))
+	}
+
+	// 4. System directory
+	paths = append(paths, "/etc/oci-resource-dump.yaml")
+
+	return paths
+}
+
+// LoadConfig loads configuration from YAML file with fallback to defaults
+func LoadConfig() (*AppConfig, error) {
+	// Start with default configuration
+	config := getDefaultConfig()
+
+	// Try to find and load configuration file
+	for _, path := range getConfigPaths() {
+		if data, err := os.ReadFile(path); err == nil {
+			if err := yaml.Unmarshal(data, config); err != nil {
+				return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+			}
+
+			break // Use first found configuration file
+		}
+	}
+
+	// Validate configuration
+	if err := validateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// validateConfig validates the loaded configuration and returns its first problem
+func validateConfig(config *AppConfig) error {
+	if problems := configProblems(config); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// splitErrors returns the errors joined in err by errors.Join, err itself otherwise, or nil for nil
+// Section validators join all their problems so configProblems can report each one.
+func splitErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// configProblems runs every configuration check and returns all problems found
+func configProblems(config *AppConfig) []error {
+	var problems []error
+	check := func(err error) {
+		problems = append(problems, splitErrors(err)...)
+	}
+
+	// Validate log level
+	validLogLevels := []string{"silent", "normal", "verbose", "debug"}
+	if !contains(validLogLevels, config.General.LogLevel) {
+		check(fmt.Errorf("invalid log_level '%s', must be one of: %v", config.General.LogLevel, validLogLevels))
+	}
+
+	// Validate log format
+	if _, err := ParseLogFormat(config.General.LogFormat); err != nil {
+		check(fmt.Errorf("invalid log_format '%s', must be one of: [text json]", config.General.LogFormat))
+	}
+
+	// Validate output format
+	validFormats := []string{"json", "ndjson", "csv", "tsv", "openmetrics", "dot", "mermaid"}
+	if !contains(validFormats, config.General.OutputFormat) {
+		check(fmt.Errorf("invalid output_format '%s', must be one of: %v", config.General.OutputFormat, validFormats))
+	}
+
+	// Validate discovery mode
+	if config.General.Mode != "" && !contains(DiscoveryModes, config.General.Mode) {
+		check(fmt.Errorf("invalid mode '%s', must be one of: %v", config.General.Mode, DiscoveryModes))
+	}
+
+	// Validate sort keys
+	_, err := ParseSortKeys(config.Output.SortBy)
+	check(err)
+
+	// Validate CSV dialect
+	check(config.Output.CSV.Validate())
+
+	// Validate query
+	if config.Output.Query != "" {
+		_, err := CompileQuery(config.Output.Query)
+		check(err)
+	}
+
+	// Validate timeout
+	if config.General.Timeout <= 0 {
+		check(fmt.Errorf("timeout must be positive, got: %d", config.General.Timeout))
+	}
+
+	// Validate page size
+	if config.General.PageSize < 0 {
+		check(fmt.Errorf("page_size must not be negative, got: %d", config.General.PageSize))
+	}
+
+	// Validate circuit breaker threshold
+	if config.General.CircuitBreakerThreshold < -1 {
+		check(fmt.Errorf("circuit_breaker_threshold must be -1 (disabled) or more, got: %d", config.General.CircuitBreakerThreshold))
+	}
+
+	// Validate diff settings
+	check(config.Diff.Validate())
+
+	// Validate upload targets
+	check(config.Upload.S3.Validate())
+
+	// Validate daemon schedule and retention
+	check(config.Daemon.Validate())
+
+	// Validate webhook notifications
+	check(config.Notify.Validate())
+
+	// Validate history store settings
+	check(config.History.Validate())
+
+	// Validate cost enrichment settings
+	check(config.Cost.Validate())
+
+	// Validate utilization enrichment settings
+	check(config.Utilization.Validate())
+
+	// Validate result cache settings
+	check(config.Cache.Validate())
+
+	// Validate audit log destinations
+	check(config.Audit.Validate())
+
+	// Validate API request rates
+	check(config.RateLimit.Validate())
+
+	// Validate HTTP client settings
+	check(config.HTTP.Validate())
+
+	// Validate originator patterns
+	_, err = CompileOriginatorPatterns(config.Originators)
+	check(err)
+
+	// Validate filter presets
+	for _, name := range config.presetNames() {
+		for _, err := range splitErrors(ValidateFilterConfig(config.Presets[name])) {
+			check(fmt.Errorf("invalid filter preset '%s': %w", name, err))
+		}
+	}
+
+	return problems
+}
+
+// FindConfigFile returns the configuration file LoadConfig reads, or "" when there is none
+func FindConfigFile() string {
+	for _, path := range getConfigPaths() {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// ValidateConfigFile loads a configuration file and reports every problem found in it
+// Besides the checks run when the configuration is loaded, unknown keys, values of the wrong
+// type and the filters section are checked. The error is set when the file cannot be read or
+// is not valid YAML.
+func ValidateConfigFile(path string) ([]error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var problems []error
+	config := getDefaultConfig()
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		// Type errors leave the rest of the file decoded, so the other checks still run
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+		}
+		for _, message := range typeErr.Errors {
+			problems = append(problems, errors.New(message))
+		}
+	}
+
+	problems = append(problems, configProblems(config)...)
+	for _, err := range splitErrors(ValidateFilterConfig(config.Filters)) {
+		problems = append(problems, fmt.Errorf("invalid filter configuration: %w", err))
+	}
+	return problems, nil
+}
+
+// ApplyFilterPreset replaces the filters with the named preset from the presets section
+func (c *AppConfig) ApplyFilterPreset(name string) error {
+	preset, ok := c.Presets[name]
+	if !ok {
+		if len(c.Presets) == 0 {
+			return fmt.Errorf("unknown filter preset '%s', the configuration file defines no presets", name)
+		}
+		return fmt.Errorf("unknown filter preset '%s', available presets: %v", name, c.presetNames())
+	}
+	c.Filters = preset
+	return nil
+}
+
+// presetNames returns the names of the filter presets in sorted order
+func (c *AppConfig) presetNames() []string {
+	names := make([]string, 0, len(c.Presets))
+	for name := range c.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// contains checks if a string slice contains a specific string
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveConfig saves the current configuration to a YAML file
+func SaveConfig(config *AppConfig, filename string) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+
+	return nil
+}
+
+// GenerateDefaultConfigFile creates a default configuration file
+func GenerateDefaultConfigFile(filename string) error {
+	config := getDefaultConfig()
+	return SaveConfig(config, filename)
+}
+
+// MergeWithCLIArgs merges configuration file settings with CLI arguments
+// CLI arguments have higher priority than configuration file, but only when explicitly set
+func MergeWithCLIArgs(config *AppConfig, cliTimeout *int, cliLogLevel *string, cliFormat *string, cliProgress *bool, cliOutputFile *string) {
+	// CLI timeout overrides config only if explicitly set (not -1)
+	if cliTimeout != nil && *cliTimeout != -1 {
+		config.General.Timeout = *cliTimeout
+	}
+
+	// CLI log level overrides config only if explicitly set (not "NOT_SET")
+	if cliLogLevel != nil && *cliLogLevel != "NOT_SET" {
+		config.General.LogLevel = *cliLogLevel
+	}
+
+	// CLI format overrides config only if explicitly set (not "NOT_SET")
+	if cliFormat != nil && *cliFormat != "NOT_SET" {
+		config.General.OutputFormat = *cliFormat
+	}
+
+	// CLI progress overrides config only when explicitly set (not nil)
+	// nil means no explicit flag was provided, so keep config file value
+	if cliProgress != nil {
+		config.General.Progress = *cliProgress
+	}
+
+	// CLI output file overrides config only if explicitly set (not "NOT_SET")
+	if cliOutputFile != nil && *cliOutputFile != "NOT_SET" {
+		config.Output.File = *cliOutputFile
+	}
+}
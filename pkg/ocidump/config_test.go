@@ -1,10 +1,13 @@
-package main
+package ocidump
 
 import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestGetDefaultConfig(t *testing.T) {
@@ -86,6 +89,16 @@ func TestValidateConfig_InvalidTimeout(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_InvalidPageSize(t *testing.T) {
+	config := getDefaultConfig()
+	config.General.PageSize = -1
+
+	err := validateConfig(config)
+	if err == nil {
+		t.Error("validateConfig() error = nil, want error for negative page size")
+	}
+}
+
 func TestValidateConfig_InvalidLogLevel(t *testing.T) {
 	config := getDefaultConfig()
 	config.General.LogLevel = "invalid"
@@ -106,6 +119,140 @@ func TestValidateConfig_InvalidOutputFormat(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_InvalidPreset(t *testing.T) {
+	config := getDefaultConfig()
+	config.Presets = map[string]FilterConfig{
+		"db-audit": {IncludeResourceTypes: []string{"no_such_type"}},
+	}
+
+	err := validateConfig(config)
+	if err == nil || !strings.Contains(err.Error(), "db-audit") {
+		t.Errorf("validateConfig() error = %v, want error naming preset db-audit", err)
+	}
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	valid := write("valid.yaml", "general:\n  timeout: 600\nfilters:\n  include_resource_types: [\"network\"]\n")
+	if problems, err := ValidateConfigFile(valid); err != nil || len(problems) != 0 {
+		t.Errorf("ValidateConfigFile(valid) = %v, %v, want no problems", problems, err)
+	}
+
+	invalid := write("invalid.yaml", `general:
+  timeout: -1
+  log_level: loud
+  colour: true
+filters:
+  include_resource_types: ["widgets"]
+`)
+	problems, err := ValidateConfigFile(invalid)
+	if err != nil {
+		t.Fatalf("ValidateConfigFile(invalid) error = %v", err)
+	}
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	joined := strings.Join(messages, "\n")
+	for _, want := range []string{"field colour not found", "invalid log_level 'loud'", "timeout must be positive", "unknown resource type 'widgets'"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("problems do not mention %q:\n%s", want, joined)
+		}
+	}
+	if len(problems) != 4 {
+		t.Errorf("got %d problems, want 4:\n%s", len(problems), joined)
+	}
+
+	if _, err := ValidateConfigFile(write("broken.yaml", "general: [\n")); err == nil {
+		t.Error("ValidateConfigFile() accepted malformed YAML")
+	}
+}
+
+func TestValidateConfigFile_SeveralProblemsPerSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `filters:
+  include_resource_types: ["widgets"]
+  created_after: "yesterday"
+daemon:
+  file_prefix: "dumps/daily"
+  retention:
+    keep_last: -1
+presets:
+  broken:
+    name_pattern: "("
+    shapes: ["["]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ValidateConfigFile(path)
+	if err != nil {
+		t.Fatalf("ValidateConfigFile() error = %v", err)
+	}
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	joined := strings.Join(messages, "\n")
+	for _, want := range []string{
+		"unknown resource type 'widgets'", "invalid created_after 'yesterday'",
+		"invalid daemon file_prefix", "daemon retention keep_last",
+		"invalid filter preset 'broken': invalid regex pattern", "invalid filter preset 'broken': invalid shape pattern",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("problems do not mention %q:\n%s", want, joined)
+		}
+	}
+	if len(problems) != 6 {
+		t.Errorf("got %d problems, want 6, one per line:\n%s", len(problems), joined)
+	}
+}
+
+func TestApplyFilterPreset(t *testing.T) {
+	configContent := `filters:
+  name_pattern: "base-*"
+presets:
+  prod-network:
+    include_compartments: ["prod-*"]
+    include_resource_types: ["network"]
+  db-audit:
+    include_resource_types: ["database"]
+    lifecycle_states: ["AVAILABLE"]
+`
+	config := getDefaultConfig()
+	if err := yaml.Unmarshal([]byte(configContent), config); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if err := validateConfig(config); err != nil {
+		t.Fatalf("validateConfig() error = %v, want nil", err)
+	}
+
+	if err := config.ApplyFilterPreset("prod-network"); err != nil {
+		t.Fatalf("ApplyFilterPreset() error = %v, want nil", err)
+	}
+	want := FilterConfig{
+		IncludeCompartments:  []string{"prod-*"},
+		IncludeResourceTypes: []string{"network"},
+	}
+	if !reflect.DeepEqual(config.Filters, want) {
+		t.Errorf("ApplyFilterPreset() Filters = %+v, want %+v", config.Filters, want)
+	}
+
+	err := config.ApplyFilterPreset("missing")
+	if err == nil || !strings.Contains(err.Error(), "[db-audit prod-network]") {
+		t.Errorf("ApplyFilterPreset() error = %v, want error listing available presets", err)
+	}
+}
+
 func TestLoadConfig_NoFile(t *testing.T) {
 	// 一時ディレクトリを作成してカレントディレクトリを変更
 	tempDir, err := os.MkdirTemp("", "config_test")
@@ -0,0 +1,175 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/usageapi"
+)
+
+// Cost grouping
+const (
+	CostGroupByResource    = "resource"    // Cost of each resource (default)
+	CostGroupByCompartment = "compartment" // Total cost of the compartment containing each resource
+)
+
+// defaultCostDays is the length of the cost period when cost.days is not set
+const defaultCostDays = 30
+
+// CostConfig holds the settings of the Usage API cost enrichment
+type CostConfig struct {
+	Enabled bool   `yaml:"enabled"`  // Attach the cost reported by the Usage API to each resource
+	GroupBy string `yaml:"group_by"` // resource (default) or compartment
+	Days    int    `yaml:"days"`     // Length of the cost period in days, ending today (default: 30)
+}
+
+// ResourceCost is the cost attached to a resource by the cost enrichment
+type ResourceCost struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency,omitempty"`
+	Days     int     `json:"days"`  // Length of the period, ending at the start of the day of the run (UTC)
+	Scope    string  `json:"scope"` // "resource", or "compartment" when the amount is the compartment total
+}
+
+// Validate checks the cost settings
+func (c CostConfig) Validate() error {
+	var problems []error
+	if c.GroupBy != "" && c.GroupBy != CostGroupByResource && c.GroupBy != CostGroupByCompartment {
+		problems = append(problems, fmt.Errorf("invalid cost group_by '%s', must be one of: %s, %s", c.GroupBy, CostGroupByResource, CostGroupByCompartment))
+	}
+	if c.Days < 0 || c.Days > 365 {
+		problems = append(problems, fmt.Errorf("cost days must be between 1 and 365, got: %d", c.Days))
+	}
+	return errors.Join(problems...)
+}
+
+// groupBy returns the configured grouping, defaulting to per-resource costs
+func (c CostConfig) groupBy() string {
+	if c.GroupBy == "" {
+		return CostGroupByResource
+	}
+	return c.GroupBy
+}
+
+// days returns the configured period length, defaulting to 30 days
+func (c CostConfig) days() int {
+	if c.Days == 0 {
+		return defaultCostDays
+	}
+	return c.Days
+}
+
+// costPeriod returns the cost period of the given length ending at the start of the current UTC day
+// The Usage API requires daily queries to be aligned to midnight UTC
+func costPeriod(now time.Time, days int) (time.Time, time.Time) {
+	end := now.UTC().Truncate(24 * time.Hour)
+	return end.AddDate(0, 0, -days), end
+}
+
+// costTable maps a resource or compartment OCID to its cost in the queried period
+type costTable map[string]ResourceCost
+
+// fetchCosts queries the Usage API for the cost of every resource or compartment in the tenancy
+func fetchCosts(ctx context.Context, clients *OCIClients, config CostConfig) (costTable, error) {
+	tenancyID, err := clients.ConfigProvider.TenancyOCID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenancy ID: %w", err)
+	}
+	client, err := usageapi.NewUsageapiClientWithConfigurationProvider(clients.ConfigProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create usage API client: %w", err)
+	}
+	clients.Transport.Apply(&client.BaseClient)
+	clients.RateLimiter.Limit(&client.BaseClient, "usageapi")
+
+	groupKey := "resourceId"
+	if config.groupBy() == CostGroupByCompartment {
+		groupKey = "compartmentId"
+	}
+	started, ended := costPeriod(time.Now(), config.days())
+	details := usageapi.RequestSummarizedUsagesDetails{
+		TenantId:          common.String(tenancyID),
+		TimeUsageStarted:  &common.SDKTime{Time: started},
+		TimeUsageEnded:    &common.SDKTime{Time: ended},
+		Granularity:       usageapi.RequestSummarizedUsagesDetailsGranularityDaily,
+		IsAggregateByTime: common.Bool(true),
+		QueryType:         usageapi.RequestSummarizedUsagesDetailsQueryTypeCost,
+		GroupBy:           []string{groupKey},
+	}
+	logger.Debug("Querying usage API for costs from %s to %s grouped by %s", started.Format(time.DateOnly), ended.Format(time.DateOnly), groupKey)
+
+	var items []usageapi.UsageSummary
+	var page *string
+	for {
+		var resp usageapi.RequestSummarizedUsagesResponse
+		operation := func() error {
+			var err error
+			resp, err = client.RequestSummarizedUsages(ctx, usageapi.RequestSummarizedUsagesRequest{
+				RequestSummarizedUsagesDetails: details,
+				Page:                           page,
+				Limit:                          clients.pageLimit(maxPageSize),
+			})
+			return err
+		}
+		if err := withRetryAndProgress(ctx, operation, 3, "usage API", nil); err != nil {
+			return nil, fmt.Errorf("usage API query failed: %w", err)
+		}
+
+		items = append(items, resp.Items...)
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	costs := buildCostTable(items, config)
+	logger.Verbose("Usage API reported costs for %d %ss", len(costs), config.groupBy())
+	return costs, nil
+}
+
+// buildCostTable sums the usage summaries per resource or compartment OCID
+func buildCostTable(items []usageapi.UsageSummary, config CostConfig) costTable {
+	costs := make(costTable)
+	for _, item := range items {
+		id := stringValue(item.ResourceId)
+		if config.groupBy() == CostGroupByCompartment {
+			id = stringValue(item.CompartmentId)
+		}
+		if id == "" || item.ComputedAmount == nil {
+			continue
+		}
+
+		cost := costs[id]
+		cost.Amount += float64(*item.ComputedAmount)
+		if cost.Currency == "" {
+			cost.Currency = stringValue(item.Currency)
+		}
+		cost.Days = config.days()
+		cost.Scope = config.groupBy()
+		costs[id] = cost
+	}
+
+	for id, cost := range costs {
+		cost.Amount = math.Round(cost.Amount*10000) / 10000
+		costs[id] = cost
+	}
+	return costs
+}
+
+// apply attaches the cost of each resource, or of its compartment, to the resources
+// Resources without reported usage are left without cost
+func (t costTable) apply(resources []ResourceInfo, groupBy string) {
+	for i := range resources {
+		id := resources[i].OCID
+		if groupBy == CostGroupByCompartment {
+			id = resources[i].CompartmentID
+		}
+		if cost, ok := t[id]; ok {
+			resources[i].Cost = &cost
+		}
+	}
+}
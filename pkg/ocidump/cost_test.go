@@ -0,0 +1,76 @@
+package ocidump
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/usageapi"
+)
+
+func TestCostConfig_Validate(t *testing.T) {
+	tests := []struct {
+		config  CostConfig
+		wantErr bool
+	}{
+		{CostConfig{}, false},
+		{CostConfig{Enabled: true, GroupBy: "compartment", Days: 7}, false},
+		{CostConfig{GroupBy: "service"}, true},
+		{CostConfig{Days: -1}, true},
+		{CostConfig{Days: 400}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.config, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCostPeriod(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 4, 5, 0, time.FixedZone("JST", 9*60*60))
+	start, end := costPeriod(now, 30)
+	if !end.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("end = %v, expected midnight UTC of the current day", end)
+	}
+	if !start.Equal(time.Date(2025, 2, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("start = %v, expected 30 days before end", start)
+	}
+}
+
+func TestCostTable_Apply(t *testing.T) {
+	items := []usageapi.UsageSummary{
+		{ResourceId: common.String("ocid1.instance.oc1..a"), CompartmentId: common.String("ocid1.compartment.oc1..prod"), ComputedAmount: common.Float32(10.5), Currency: common.String("USD")},
+		{ResourceId: common.String("ocid1.instance.oc1..a"), CompartmentId: common.String("ocid1.compartment.oc1..prod"), ComputedAmount: common.Float32(0.25), Currency: common.String("USD")},
+		{ResourceId: common.String("ocid1.volume.oc1..b"), CompartmentId: common.String("ocid1.compartment.oc1..prod"), ComputedAmount: common.Float32(2)},
+		{ResourceId: common.String(""), CompartmentId: common.String("ocid1.compartment.oc1..prod"), ComputedAmount: common.Float32(1)},
+	}
+	resources := []ResourceInfo{
+		{OCID: "ocid1.instance.oc1..a", CompartmentID: "ocid1.compartment.oc1..prod"},
+		{OCID: "ocid1.vcn.oc1..c", CompartmentID: "ocid1.compartment.oc1..prod"},
+	}
+
+	byResource := CostConfig{Enabled: true}
+	buildCostTable(items, byResource).apply(resources, byResource.groupBy())
+	if cost := resources[0].Cost; cost == nil || *cost != (ResourceCost{Amount: 10.75, Currency: "USD", Days: 30, Scope: "resource"}) {
+		t.Errorf("instance cost = %+v", cost)
+	}
+	if resources[1].Cost != nil {
+		t.Errorf("resource without usage got cost %+v", resources[1].Cost)
+	}
+
+	byCompartment := CostConfig{Enabled: true, GroupBy: CostGroupByCompartment, Days: 7}
+	buildCostTable(items, byCompartment).apply(resources, byCompartment.groupBy())
+	for _, resource := range resources {
+		if cost := resource.Cost; cost == nil || *cost != (ResourceCost{Amount: 13.75, Currency: "USD", Days: 7, Scope: "compartment"}) {
+			t.Errorf("%s compartment cost = %+v", resource.OCID, cost)
+		}
+	}
+
+	// Nothing is applied when costs could not be fetched
+	var missing costTable
+	unpriced := []ResourceInfo{{OCID: "ocid1.instance.oc1..a"}}
+	missing.apply(unpriced, CostGroupByResource)
+	if unpriced[0].Cost != nil {
+		t.Errorf("nil cost table applied cost %+v", unpriced[0].Cost)
+	}
+}
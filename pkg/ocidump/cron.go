@@ -0,0 +1,191 @@
+package ocidump
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed five-field cron expression (minute hour day-of-month month day-of-week)
+type CronSchedule struct {
+	expression string
+	minutes    [60]bool
+	hours      [24]bool
+	days       [32]bool // 1-31
+	months     [13]bool // 1-12
+	weekdays   [7]bool  // 0-6, Sunday = 0
+
+	// Standard cron semantics: when both day fields are restricted, either may match
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+// cronMacros maps the supported @-shortcuts to their five-field equivalents
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// cronSearchLimit bounds the search for the next run so impossible schedules (e.g. Feb 30) terminate
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// ParseCronSchedule parses a cron expression such as "0 */6 * * *" or "@daily"
+// Fields support '*', lists (1,15), ranges (1-5), steps (*/10, 0-30/5) and month/weekday names
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) == 1 {
+		if macro, ok := cronMacros[strings.ToLower(fields[0])]; ok {
+			fields = strings.Fields(macro)
+		}
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 fields (minute hour day-of-month month day-of-week)", expression)
+	}
+
+	schedule := &CronSchedule{expression: expression}
+	var err error
+	if _, err = parseCronField(fields[0], 0, 59, nil, schedule.minutes[:]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': minute: %w", expression, err)
+	}
+	if _, err = parseCronField(fields[1], 0, 23, nil, schedule.hours[:]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': hour: %w", expression, err)
+	}
+	if schedule.daysRestricted, err = parseCronField(fields[2], 1, 31, nil, schedule.days[:]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': day of month: %w", expression, err)
+	}
+	if _, err = parseCronField(fields[3], 1, 12, cronMonthNames, schedule.months[:]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': month: %w", expression, err)
+	}
+
+	// Day of week accepts 0-7 where both 0 and 7 are Sunday
+	var weekdays [8]bool
+	if schedule.weekdaysRestricted, err = parseCronField(fields[4], 0, 7, cronWeekdayNames, weekdays[:]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': day of week: %w", expression, err)
+	}
+	copy(schedule.weekdays[:], weekdays[:7])
+	schedule.weekdays[0] = schedule.weekdays[0] || weekdays[7]
+
+	return schedule, nil
+}
+
+// parseCronField sets the allowed values of a field and reports whether the field is restricted (not '*')
+func parseCronField(field string, min, max int, names map[string]int, allowed []bool) (bool, error) {
+	restricted := true
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if rangePart, stepPart, found := strings.Cut(part, "/"); found {
+			value, err := strconv.Atoi(stepPart)
+			if err != nil || value <= 0 {
+				return false, fmt.Errorf("invalid step '%s'", stepPart)
+			}
+			part, step = rangePart, value
+		}
+
+		start, end := min, max
+		switch {
+		case part == "*":
+			if step == 1 {
+				restricted = false
+			}
+		case strings.Contains(part, "-"):
+			startPart, endPart, _ := strings.Cut(part, "-")
+			var err error
+			if start, err = parseCronValue(startPart, min, max, names); err != nil {
+				return false, err
+			}
+			if end, err = parseCronValue(endPart, min, max, names); err != nil {
+				return false, err
+			}
+			if start > end {
+				return false, fmt.Errorf("invalid range '%s'", part)
+			}
+		default:
+			value, err := parseCronValue(part, min, max, names)
+			if err != nil {
+				return false, err
+			}
+			start, end = value, value
+			if step > 1 {
+				end = max // "5/15" means every 15 starting at 5
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			allowed[value] = true
+		}
+	}
+	return restricted, nil
+}
+
+// parseCronValue parses a single number or name within the allowed bounds
+func parseCronValue(value string, min, max int, names map[string]int) (int, error) {
+	if number, ok := names[strings.ToLower(value)]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", value)
+	}
+	if number < min || number > max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", number, min, max)
+	}
+	return number, nil
+}
+
+// String returns the source expression
+func (s *CronSchedule) String() string {
+	return s.expression
+}
+
+// Next returns the first matching time strictly after t, in t's location
+// A zero time is returned when the schedule never matches (e.g. "0 0 30 2 *")
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for next.Before(limit) {
+		if !s.months[next.Month()] {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.hours[next.Hour()] {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.minutes[next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+// matchesDay applies the day-of-month and day-of-week fields
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayMatch := s.days[t.Day()]
+	weekdayMatch := s.weekdays[t.Weekday()]
+	if s.daysRestricted && s.weekdaysRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
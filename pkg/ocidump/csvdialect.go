@@ -0,0 +1,137 @@
+package ocidump
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// CSVDialect controls how CSV output is written
+// The zero value writes RFC 4180 style CSV (comma, minimal quoting, LF, no BOM)
+type CSVDialect struct {
+	Delimiter string `yaml:"delimiter"` // Field delimiter: a single character or "tab" (default: ",")
+	Quote     string `yaml:"quote"`     // Quoting style: minimal or all (default: minimal)
+	BOM       bool   `yaml:"bom"`       // Prepend a UTF-8 byte order mark so Excel detects the encoding
+	CRLF      bool   `yaml:"crlf"`      // Use CRLF line endings
+}
+
+// csvQuoteStyles lists the supported quoting styles
+var csvQuoteStyles = []string{"minimal", "all"}
+
+// utf8BOM is the UTF-8 byte order mark
+const utf8BOM = "\ufeff"
+
+// Validate checks the delimiter and quoting style
+func (d CSVDialect) Validate() error {
+	var problems []error
+	if _, err := d.delimiterRune(); err != nil {
+		problems = append(problems, err)
+	}
+	if d.Quote != "" && !contains(csvQuoteStyles, d.Quote) {
+		problems = append(problems, fmt.Errorf("invalid csv quote style '%s', must be one of: %v", d.Quote, csvQuoteStyles))
+	}
+	return errors.Join(problems...)
+}
+
+// delimiterRune resolves the configured delimiter
+func (d CSVDialect) delimiterRune() (rune, error) {
+	switch d.Delimiter {
+	case "":
+		return ',', nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+
+	delimiter, size := utf8.DecodeRuneInString(d.Delimiter)
+	if size != len(d.Delimiter) || delimiter == utf8.RuneError {
+		return 0, fmt.Errorf("invalid csv delimiter '%s', must be a single character or \"tab\"", d.Delimiter)
+	}
+	if delimiter == '"' || delimiter == '\r' || delimiter == '\n' {
+		return 0, fmt.Errorf("invalid csv delimiter %q", d.Delimiter)
+	}
+	return delimiter, nil
+}
+
+// csvRecordWriter writes CSV records using a CSVDialect
+// encoding/csv is not used because it cannot quote every field
+type csvRecordWriter struct {
+	w          *bufio.Writer
+	delimiter  rune
+	quoteAll   bool
+	lineEnding string
+}
+
+// newCSVRecordWriter creates a CSV writer and writes the byte order mark when enabled
+func newCSVRecordWriter(w io.Writer, dialect CSVDialect) (*csvRecordWriter, error) {
+	if err := dialect.Validate(); err != nil {
+		return nil, err
+	}
+	delimiter, _ := dialect.delimiterRune()
+
+	writer := &csvRecordWriter{
+		w:          bufio.NewWriter(w),
+		delimiter:  delimiter,
+		quoteAll:   dialect.Quote == "all",
+		lineEnding: "\n",
+	}
+	if dialect.CRLF {
+		writer.lineEnding = "\r\n"
+	}
+	if dialect.BOM {
+		if _, err := writer.w.WriteString(utf8BOM); err != nil {
+			return nil, err
+		}
+	}
+	return writer, nil
+}
+
+// Write writes a single record
+func (cw *csvRecordWriter) Write(record []string) error {
+	for i, field := range record {
+		if i > 0 {
+			if _, err := cw.w.WriteRune(cw.delimiter); err != nil {
+				return err
+			}
+		}
+		if !cw.quoteAll && !cw.fieldNeedsQuotes(field) {
+			if _, err := cw.w.WriteString(field); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := cw.w.WriteString(`"` + strings.ReplaceAll(field, `"`, `""`) + `"`); err != nil {
+			return err
+		}
+	}
+	_, err := cw.w.WriteString(cw.lineEnding)
+	return err
+}
+
+// WriteAll writes all records and flushes the output
+func (cw *csvRecordWriter) WriteAll(records [][]string) error {
+	for _, record := range records {
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
+
+// Flush writes any buffered data to the underlying writer
+func (cw *csvRecordWriter) Flush() error {
+	return cw.w.Flush()
+}
+
+// fieldNeedsQuotes follows the encoding/csv rules for minimal quoting
+func (cw *csvRecordWriter) fieldNeedsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, cw.delimiter) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	return field[0] == ' ' || field[0] == '\t'
+}
@@ -0,0 +1,87 @@
+package ocidump
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVDialect_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect CSVDialect
+		wantErr bool
+	}{
+		{"default", CSVDialect{}, false},
+		{"semicolon", CSVDialect{Delimiter: ";"}, false},
+		{"tab keyword", CSVDialect{Delimiter: "tab"}, false},
+		{"quote all", CSVDialect{Quote: "all"}, false},
+		{"multi-character delimiter", CSVDialect{Delimiter: ";;"}, true},
+		{"quote delimiter", CSVDialect{Delimiter: `"`}, true},
+		{"unknown quote style", CSVDialect{Quote: "none"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dialect.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteCSV_Dialect(t *testing.T) {
+	resources := []ResourceInfo{
+		{
+			ResourceType:    "VCN",
+			CompartmentName: "本番環境",
+			ResourceName:    "vcn; \"main\"",
+			OCID:            "ocid1.vcn.oc1..test",
+			CompartmentID:   "ocid1.compartment.oc1..test",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		dialect  CSVDialect
+		expected string
+	}{
+		{
+			name:    "default",
+			dialect: CSVDialect{},
+			expected: "ResourceType,CompartmentName,ResourceName,OCID,CompartmentID,TimeCreated,LifecycleState,AdditionalInfo,FreeformTags,DefinedTags\n" +
+				"VCN,本番環境,\"vcn; \"\"main\"\"\",ocid1.vcn.oc1..test,ocid1.compartment.oc1..test,,,,,\n",
+		},
+		{
+			name:    "excel",
+			dialect: CSVDialect{Delimiter: ";", Quote: "all", BOM: true, CRLF: true},
+			expected: "\ufeff\"ResourceType\";\"CompartmentName\";\"ResourceName\";\"OCID\";\"CompartmentID\";\"TimeCreated\";\"LifecycleState\";\"AdditionalInfo\";\"FreeformTags\";\"DefinedTags\"\r\n" +
+				"\"VCN\";\"本番環境\";\"vcn; \"\"main\"\"\";\"ocid1.vcn.oc1..test\";\"ocid1.compartment.oc1..test\";\"\";\"\";\"\";\"\";\"\"\r\n",
+		},
+		{
+			name:    "tab delimiter",
+			dialect: CSVDialect{Delimiter: "tab"},
+			expected: "ResourceType\tCompartmentName\tResourceName\tOCID\tCompartmentID\tTimeCreated\tLifecycleState\tAdditionalInfo\tFreeformTags\tDefinedTags\n" +
+				"VCN\t本番環境\t\"vcn; \"\"main\"\"\"\tocid1.vcn.oc1..test\tocid1.compartment.oc1..test\t\t\t\t\t\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := writeCSV(resources, &out, tt.dialect); err != nil {
+				t.Fatalf("writeCSV() error = %v, want nil", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("writeCSV() = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteCSV_InvalidDialect(t *testing.T) {
+	var out strings.Builder
+	if err := writeCSV(nil, &out, CSVDialect{Quote: "never"}); err == nil {
+		t.Error("writeCSV() with invalid dialect error = nil, want error")
+	}
+}
@@ -0,0 +1,196 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DaemonConfig holds the settings for scheduled runs (--daemon)
+type DaemonConfig struct {
+	Schedule   string          `yaml:"schedule"`    // Cron expression, e.g. "0 */6 * * *" or "@daily"
+	OutputDir  string          `yaml:"output_dir"`  // Directory receiving the timestamped dumps (default: current directory)
+	FilePrefix string          `yaml:"file_prefix"` // Dump file name prefix (default: oci-resource-dump)
+	Retention  RetentionConfig `yaml:"retention"`   // Pruning policy for old dumps
+	Diff       bool            `yaml:"diff"`        // Diff each dump against the previous one (json, csv or tsv)
+}
+
+// RetentionConfig limits how many daemon dumps are kept; zero values disable a limit
+type RetentionConfig struct {
+	KeepLast   int `yaml:"keep_last"`    // Keep at most this many dumps
+	MaxAgeDays int `yaml:"max_age_days"` // Remove dumps older than this many days
+}
+
+// DumpFile is a timestamped dump written by the daemon
+type DumpFile struct {
+	Path string
+	Time time.Time
+}
+
+// defaultDumpFilePrefix is used when no file prefix is configured
+const defaultDumpFilePrefix = "oci-resource-dump"
+
+// dumpTimestampLayout is the UTC timestamp embedded in dump file names
+const dumpTimestampLayout = "20060102T150405Z"
+
+// Validate checks the schedule and retention settings
+func (c DaemonConfig) Validate() error {
+	var problems []error
+	if c.Schedule != "" {
+		if _, err := ParseCronSchedule(c.Schedule); err != nil {
+			problems = append(problems, fmt.Errorf("invalid daemon schedule: %w", err))
+		}
+	}
+	if strings.ContainsAny(c.FilePrefix, `/\`) {
+		problems = append(problems, fmt.Errorf("invalid daemon file_prefix '%s': must not contain path separators", c.FilePrefix))
+	}
+	if c.Retention.KeepLast < 0 {
+		problems = append(problems, fmt.Errorf("daemon retention keep_last must not be negative, got: %d", c.Retention.KeepLast))
+	}
+	if c.Retention.MaxAgeDays < 0 {
+		problems = append(problems, fmt.Errorf("daemon retention max_age_days must not be negative, got: %d", c.Retention.MaxAgeDays))
+	}
+	return errors.Join(problems...)
+}
+
+// prefix returns the configured file prefix or the default
+func (c DaemonConfig) prefix() string {
+	if c.FilePrefix == "" {
+		return defaultDumpFilePrefix
+	}
+	return c.FilePrefix
+}
+
+// dir returns the configured output directory or the current directory
+func (c DaemonConfig) dir() string {
+	if c.OutputDir == "" {
+		return "."
+	}
+	return c.OutputDir
+}
+
+// DumpPath returns the path of the dump written at t, e.g. dumps/oci-resource-dump-20250101T060000Z.json
+func (c DaemonConfig) DumpPath(format string, t time.Time) string {
+	name := fmt.Sprintf("%s-%s.%s", c.prefix(), t.UTC().Format(dumpTimestampLayout), dumpFileExtension(format))
+	return filepath.Join(c.dir(), name)
+}
+
+// DiffPath returns the path of the diff written next to a dump, e.g. oci-resource-dump-20250101T060000Z.diff.json
+func (c DaemonConfig) DiffPath(dumpPath, diffFormat string) string {
+	extension := "json"
+	switch strings.ToLower(diffFormat) {
+	case "text":
+		extension = "txt"
+	case "html":
+		extension = "html"
+	case "markdown", "md":
+		extension = "md"
+	case "csv", "tsv":
+		extension = strings.ToLower(diffFormat)
+	}
+	return strings.TrimSuffix(dumpPath, filepath.Ext(dumpPath)) + ".diff." + extension
+}
+
+// ListDumps returns the dumps in the output directory written in the given format, oldest first
+func (c DaemonConfig) ListDumps(format string) ([]DumpFile, error) {
+	entries, err := os.ReadDir(c.dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read dump directory %s: %w", c.dir(), err)
+	}
+
+	namePrefix := c.prefix() + "-"
+	nameSuffix := "." + dumpFileExtension(format)
+	var dumps []DumpFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, namePrefix) || !strings.HasSuffix(name, nameSuffix) {
+			continue
+		}
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, namePrefix), nameSuffix)
+		t, err := time.Parse(dumpTimestampLayout, timestamp)
+		if err != nil {
+			continue // not a daemon dump, e.g. a diff file or an unrelated file
+		}
+		dumps = append(dumps, DumpFile{Path: filepath.Join(c.dir(), name), Time: t})
+	}
+
+	sort.Slice(dumps, func(i, j int) bool { return dumps[i].Time.Before(dumps[j].Time) })
+	return dumps, nil
+}
+
+// PruneDumps removes dumps (and their diff files) that fall outside the retention policy
+// and returns the removed dump paths
+func (c DaemonConfig) PruneDumps(format string, now time.Time) ([]string, error) {
+	dumps, err := c.ListDumps(format)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for i, dump := range dumps {
+		tooMany := c.Retention.KeepLast > 0 && len(dumps)-i > c.Retention.KeepLast
+		tooOld := c.Retention.MaxAgeDays > 0 && now.Sub(dump.Time) > time.Duration(c.Retention.MaxAgeDays)*24*time.Hour
+		if !tooMany && !tooOld {
+			continue
+		}
+
+		if err := os.Remove(dump.Path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove old dump %s: %w", dump.Path, err)
+		}
+		for _, diffFormat := range []string{"json", "text", "html", "markdown", "csv", "tsv"} {
+			if err := os.Remove(c.DiffPath(dump.Path, diffFormat)); err != nil && !os.IsNotExist(err) {
+				logger.Verbose("Warning: Could not remove diff file for %s: %v", dump.Path, err)
+			}
+		}
+		logger.Verbose("Pruned old dump: %s", dump.Path)
+		removed = append(removed, dump.Path)
+	}
+	return removed, nil
+}
+
+// dumpFileExtension returns the file extension used for an output format
+func dumpFileExtension(format string) string {
+	switch format {
+	case "openmetrics":
+		return "prom"
+	case "mermaid":
+		return "mmd"
+	default:
+		return format
+	}
+}
+
+// RunSchedule calls run at every time matching the schedule until ctx is cancelled
+// Errors returned by run are logged and the next run is still scheduled
+func RunSchedule(ctx context.Context, schedule *CronSchedule, run func(ctx context.Context, scheduledAt time.Time) error) error {
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			return fmt.Errorf("cron schedule '%s' has no upcoming run", schedule)
+		}
+		logger.Info("Next scheduled run at %s", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if err := run(ctx, next); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Error("Scheduled run failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package ocidump
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDaemonConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  DaemonConfig
+		wantErr bool
+	}{
+		{"empty", DaemonConfig{}, false},
+		{"valid", DaemonConfig{Schedule: "0 */6 * * *", Retention: RetentionConfig{KeepLast: 10, MaxAgeDays: 30}}, false},
+		{"invalid schedule", DaemonConfig{Schedule: "every hour"}, true},
+		{"prefix with separator", DaemonConfig{FilePrefix: "../dump"}, true},
+		{"negative keep_last", DaemonConfig{Retention: RetentionConfig{KeepLast: -1}}, true},
+		{"negative max_age_days", DaemonConfig{Retention: RetentionConfig{MaxAgeDays: -1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDaemonConfig_Paths(t *testing.T) {
+	config := DaemonConfig{OutputDir: "dumps"}
+	at := time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)
+
+	dumpPath := config.DumpPath("json", at)
+	if expected := filepath.Join("dumps", "oci-resource-dump-20250115T060000Z.json"); dumpPath != expected {
+		t.Errorf("DumpPath() = %s, expected %s", dumpPath, expected)
+	}
+	if got := config.DumpPath("openmetrics", at); filepath.Ext(got) != ".prom" {
+		t.Errorf("DumpPath(openmetrics) = %s, expected .prom extension", got)
+	}
+	if got, expected := config.DiffPath(dumpPath, "text"), filepath.Join("dumps", "oci-resource-dump-20250115T060000Z.diff.txt"); got != expected {
+		t.Errorf("DiffPath() = %s, expected %s", got, expected)
+	}
+	if got := config.DiffPath(dumpPath, "html"); filepath.Ext(got) != ".html" {
+		t.Errorf("DiffPath(html) = %s, expected .html extension", got)
+	}
+}
+
+func TestDaemonConfig_PruneDumps(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	dir := t.TempDir()
+	config := DaemonConfig{OutputDir: dir, Retention: RetentionConfig{KeepLast: 3, MaxAgeDays: 7}}
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	var paths []string
+	for _, daysAgo := range []int{10, 5, 3, 2, 1} {
+		path := config.DumpPath("json", now.AddDate(0, 0, -daysAgo))
+		paths = append(paths, path)
+		if err := os.WriteFile(path, []byte("[]"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(config.DiffPath(path, "json"), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Files that are not daemon dumps are left alone
+	unrelated := filepath.Join(dir, "oci-resource-dump-latest.json")
+	if err := os.WriteFile(unrelated, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := config.PruneDumps("json", now)
+	if err != nil {
+		t.Fatalf("PruneDumps() error = %v", err)
+	}
+	if len(removed) != 2 || removed[0] != paths[0] || removed[1] != paths[1] {
+		t.Errorf("PruneDumps() removed %v, expected %v", removed, paths[:2])
+	}
+
+	remaining, err := config.ListDumps("json")
+	if err != nil {
+		t.Fatalf("ListDumps() error = %v", err)
+	}
+	if len(remaining) != 3 || remaining[0].Path != paths[2] || remaining[2].Path != paths[4] {
+		t.Errorf("ListDumps() = %v, expected the 3 newest dumps oldest first", remaining)
+	}
+	if _, err := os.Stat(config.DiffPath(paths[0], "json")); !os.IsNotExist(err) {
+		t.Errorf("diff file of a pruned dump still exists")
+	}
+	if _, err := os.Stat(unrelated); err != nil {
+		t.Errorf("unrelated file was removed: %v", err)
+	}
+}
+
+func TestDaemonConfig_ListDumpsMissingDir(t *testing.T) {
+	config := DaemonConfig{OutputDir: filepath.Join(t.TempDir(), "missing")}
+	dumps, err := config.ListDumps("json")
+	if err != nil || len(dumps) != 0 {
+		t.Errorf("ListDumps() = %v, %v; expected no dumps and no error", dumps, err)
+	}
+}
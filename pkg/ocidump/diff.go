@@ -0,0 +1,1317 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiffConfig represents the diff analysis configuration
+type DiffConfig struct {
+	Format     string `yaml:"format"`      // "json", "text", "html", "markdown", "csv" or "tsv"
+	Detailed   bool   `yaml:"detailed"`    // include unchanged resources
+	OutputFile string `yaml:"output_file"` // output file path
+
+	IgnoreFields []string `yaml:"ignore_fields"` // fields whose changes are ignored, e.g. primary_ip or FreeformTags.*
+	SummaryOnly  bool     `yaml:"summary_only"`  // output only the summary counts, not the resources
+	Template     string   `yaml:"template"`      // Go text/template file rendering the result instead of the format
+
+	Tolerances []DiffTolerance `yaml:"tolerances"` // volatile fields whose small numeric changes are ignored
+
+	Filters FilterConfig `yaml:"-"` // limits the comparison to matching resources of both dumps
+}
+
+// DiffResult represents the comparison result between two resource dumps
+type DiffResult struct {
+	Summary   DiffSummary        `json:"summary"`
+	Added     []ResourceInfo     `json:"added"`
+	Removed   []ResourceInfo     `json:"removed"`
+	Modified  []ModifiedResource `json:"modified"`
+	Moved     []MovedResource    `json:"moved,omitempty"`
+	Unchanged []ResourceInfo     `json:"unchanged,omitempty"`
+	// Unverified holds resources of a Terraform state that the compared dump does not cover
+	Unverified []ResourceInfo `json:"unverified,omitempty"`
+	Timestamp  string         `json:"timestamp"`
+	OldFile    string         `json:"old_file"`
+	NewFile    string         `json:"new_file"`
+}
+
+// DiffSummary provides statistical overview of the differences
+type DiffSummary struct {
+	TotalOld       int                  `json:"total_old"`
+	TotalNew       int                  `json:"total_new"`
+	Added          int                  `json:"added"`
+	Removed        int                  `json:"removed"`
+	Modified       int                  `json:"modified"`
+	Moved          int                  `json:"moved"`
+	Unchanged      int                  `json:"unchanged"`
+	Unverified     int                  `json:"unverified,omitempty"`
+	ByResourceType map[string]DiffStats `json:"by_resource_type"`
+	ByOriginator   map[string]DiffStats `json:"by_originator,omitempty"`
+}
+
+// HasChanges reports whether any resource was added, removed, modified or moved
+func (s DiffSummary) HasChanges() bool {
+	return s.Added+s.Removed+s.Modified+s.Moved > 0
+}
+
+// DiffStats holds statistics for a specific resource type
+type DiffStats struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Modified  int `json:"modified"`
+	Moved     int `json:"moved"`
+	Unchanged int `json:"unchanged"`
+}
+
+// ModifiedResource represents a resource that has been changed
+type ModifiedResource struct {
+	ResourceInfo ResourceInfo  `json:"resource_info"`
+	Changes      []FieldChange `json:"changes"`
+}
+
+// MovedResource represents a resource that has been moved to another compartment
+// Changes holds the changes besides the compartment, if any.
+type MovedResource struct {
+	ResourceInfo        ResourceInfo  `json:"resource_info"`
+	FromCompartmentID   string        `json:"from_compartment_id"`
+	FromCompartmentName string        `json:"from_compartment_name,omitempty"`
+	ToCompartmentID     string        `json:"to_compartment_id"`
+	ToCompartmentName   string        `json:"to_compartment_name,omitempty"`
+	Changes             []FieldChange `json:"changes,omitempty"`
+}
+
+// From returns the source compartment as "name (OCID)", or the OCID when the name is unknown
+func (m MovedResource) From() string {
+	return compartmentLabel(m.FromCompartmentName, m.FromCompartmentID)
+}
+
+// To returns the target compartment as "name (OCID)", or the OCID when the name is unknown
+func (m MovedResource) To() string {
+	return compartmentLabel(m.ToCompartmentName, m.ToCompartmentID)
+}
+
+func compartmentLabel(name, id string) string {
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", name, id)
+}
+
+// FieldChange represents a specific field modification
+type FieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+// CompareDumps performs diff analysis between two JSON dump files
+func CompareDumps(oldFile, newFile string, config DiffConfig) (*DiffResult, error) {
+	logger.Info("Starting diff analysis: %s vs %s", oldFile, newFile)
+
+	// Validate input files
+	if err := validateDiffFiles(oldFile, newFile); err != nil {
+		return nil, err
+	}
+
+	// Load resources from both files
+	oldResources, oldTabular, err := LoadDump(oldFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load old file %s: %w", oldFile, err)
+	}
+
+	newResources, newTabular, err := LoadDump(newFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load new file %s: %w", newFile, err)
+	}
+
+	logger.Verbose("Loaded %d resources from old file, %d from new file", len(oldResources), len(newResources))
+
+	// A CSV or TSV dump lacks most fields of a JSON dump, which would all show up as changes
+	switch {
+	case oldTabular && !newTabular:
+		logger.Info("Warning: %s is a CSV or TSV dump, comparing only the fields it keeps", oldFile)
+		newResources, err = ReduceToTabularFields(newResources)
+	case newTabular && !oldTabular:
+		logger.Info("Warning: %s is a CSV or TSV dump, comparing only the fields it keeps", newFile)
+		oldResources, err = ReduceToTabularFields(oldResources)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if oldResources, err = FilterResources(oldResources, config.Filters); err != nil {
+		return nil, err
+	}
+	if newResources, err = FilterResources(newResources, config.Filters); err != nil {
+		return nil, err
+	}
+
+	return DiffResources(oldResources, newResources, oldFile, newFile, config), nil
+}
+
+// DiffResources compares two resource lists; the labels identify them in the result
+func DiffResources(oldResources, newResources []ResourceInfo, oldLabel, newLabel string, config DiffConfig) *DiffResult {
+	// Create resource maps for efficient comparison
+	oldMap := CreateResourceMap(oldResources)
+	newMap := CreateResourceMap(newResources)
+
+	// Perform diff analysis
+	added := FindAddedResources(oldMap, newMap)
+	removed := FindRemovedResources(oldMap, newMap)
+	modified := FindModifiedResources(oldMap, newMap)
+	unchanged := FindUnchangedResources(oldMap, newMap)
+	if len(config.IgnoreFields) > 0 {
+		modified, unchanged = dropDiffChanges(modified, unchanged, func(_ ResourceInfo, change FieldChange) bool {
+			return isIgnoredDiffField(change.Field, config.IgnoreFields)
+		})
+	}
+	if len(config.Tolerances) > 0 {
+		modified, unchanged = dropDiffChanges(modified, unchanged, func(resource ResourceInfo, change FieldChange) bool {
+			return withinDiffTolerance(resource, change, config.Tolerances)
+		})
+	}
+	modified, moved := FindMovedResources(oldMap, modified)
+
+	// Build result
+	result := BuildDiffResult(added, removed, modified, unchanged, oldLabel, newLabel, config.Detailed)
+	result.addMoved(moved)
+
+	logger.Info("Diff analysis complete: +%d, -%d, ~%d, >%d resources", len(added), len(removed), len(modified), len(moved))
+	return result
+}
+
+// DiffAgainstBaseline compares freshly discovered resources against a baseline read from a dump
+// The discovered resources are passed through JSON first, so values compare as they would after
+// being written to a dump, e.g. integers as JSON numbers.
+func DiffAgainstBaseline(baseline, current []ResourceInfo, baselineLabel, currentLabel string, config DiffConfig) (*DiffResult, error) {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode discovered resources: %w", err)
+	}
+	var normalized []ResourceInfo
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to decode discovered resources: %w", err)
+	}
+	return DiffResources(baseline, normalized, baselineLabel, currentLabel, config), nil
+}
+
+// FilterResourcesByCompartment keeps the resources located in one of the given compartments
+// It scopes a baseline to the compartments a discovery actually processed, e.g. the resolved
+// --compartment-subtree selection, which the baseline alone cannot be filtered by.
+func FilterResourcesByCompartment(resources []ResourceInfo, compartmentIDs []string) []ResourceInfo {
+	keep := make(map[string]bool, len(compartmentIDs))
+	for _, id := range compartmentIDs {
+		keep[id] = true
+	}
+	filtered := make([]ResourceInfo, 0, len(resources))
+	for _, resource := range resources {
+		if keep[resource.CompartmentID] {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+// DumpInputFormats lists the output formats LoadResourcesFromFile can read back
+var DumpInputFormats = []string{"json", "csv", "tsv"}
+
+// IsDumpInputFormat reports whether dumps written in format can be compared
+func IsDumpInputFormat(format string) bool {
+	return contains(DumpInputFormats, format)
+}
+
+// LoadResourcesFromFile loads ResourceInfo array from a JSON, CSV or TSV dump
+// JSON files are validated against the dump schema first to report precise errors
+func LoadResourcesFromFile(filename string) ([]ResourceInfo, error) {
+	resources, _, err := LoadDump(filename)
+	return resources, err
+}
+
+// LoadDump loads the resources of a JSON, CSV or TSV dump and reports whether it was a CSV or
+// TSV dump, whose resources carry only the fields ReduceToTabularFields keeps
+func LoadDump(filename string) (resources []ResourceInfo, tabular bool, err error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	// CSV and TSV dumps start with their header instead of a JSON array
+	if delimiter, ok := tabularDumpDelimiter(data); ok {
+		resources, err := parseTabularDump(data, delimiter)
+		return resources, true, err
+	}
+
+	if err := ValidateDump(data); err != nil {
+		return nil, false, err
+	}
+
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return nil, false, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	return resources, false, nil
+}
+
+// CreateResourceMap creates a map with OCID as key for efficient lookups
+func CreateResourceMap(resources []ResourceInfo) map[string]ResourceInfo {
+	resourceMap := make(map[string]ResourceInfo, len(resources))
+	for _, resource := range resources {
+		if resource.OCID != "" {
+			resourceMap[resource.OCID] = resource
+		} else {
+			// Fallback key for resources without OCID
+			fallbackKey := fmt.Sprintf("%s:%s:%s", resource.CompartmentID, resource.ResourceType, resource.ResourceName)
+			resourceMap[fallbackKey] = resource
+		}
+	}
+	return resourceMap
+}
+
+// FindAddedResources identifies resources present in new but not in old
+func FindAddedResources(oldMap, newMap map[string]ResourceInfo) []ResourceInfo {
+	var added []ResourceInfo
+	for ocid, resource := range newMap {
+		if _, exists := oldMap[ocid]; !exists {
+			added = append(added, resource)
+		}
+	}
+
+	// Sort for consistent output
+	sort.Slice(added, func(i, j int) bool {
+		if added[i].ResourceType != added[j].ResourceType {
+			return added[i].ResourceType < added[j].ResourceType
+		}
+		return added[i].ResourceName < added[j].ResourceName
+	})
+
+	return added
+}
+
+// FindRemovedResources identifies resources present in old but not in new
+func FindRemovedResources(oldMap, newMap map[string]ResourceInfo) []ResourceInfo {
+	var removed []ResourceInfo
+	for ocid, resource := range oldMap {
+		if _, exists := newMap[ocid]; !exists {
+			removed = append(removed, resource)
+		}
+	}
+
+	// Sort for consistent output
+	sort.Slice(removed, func(i, j int) bool {
+		if removed[i].ResourceType != removed[j].ResourceType {
+			return removed[i].ResourceType < removed[j].ResourceType
+		}
+		return removed[i].ResourceName < removed[j].ResourceName
+	})
+
+	return removed
+}
+
+// FindModifiedResources identifies resources that exist in both but with differences
+func FindModifiedResources(oldMap, newMap map[string]ResourceInfo) []ModifiedResource {
+	var modified []ModifiedResource
+
+	for ocid, oldResource := range oldMap {
+		if newResource, exists := newMap[ocid]; exists {
+			changes := CompareResourceDetails(oldResource, newResource)
+			if len(changes) > 0 {
+				modified = append(modified, ModifiedResource{
+					ResourceInfo: newResource,
+					Changes:      changes,
+				})
+			}
+		}
+	}
+
+	// Sort for consistent output
+	sort.Slice(modified, func(i, j int) bool {
+		if modified[i].ResourceInfo.ResourceType != modified[j].ResourceInfo.ResourceType {
+			return modified[i].ResourceInfo.ResourceType < modified[j].ResourceInfo.ResourceType
+		}
+		return modified[i].ResourceInfo.ResourceName < modified[j].ResourceInfo.ResourceName
+	})
+
+	return modified
+}
+
+// FindMovedResources separates the modified resources whose compartment changed
+// A move is reported on its own with the source and target compartment; the remaining
+// changes of a moved resource are kept with it.
+func FindMovedResources(oldMap map[string]ResourceInfo, modified []ModifiedResource) ([]ModifiedResource, []MovedResource) {
+	var kept []ModifiedResource
+	var moved []MovedResource
+	for _, resource := range modified {
+		var changes []FieldChange
+		isMoved := false
+		for _, change := range resource.Changes {
+			if change.Field == "CompartmentID" {
+				isMoved = true
+			} else {
+				changes = append(changes, change)
+			}
+		}
+		if !isMoved {
+			kept = append(kept, resource)
+			continue
+		}
+		// Only resources with an OCID can move, the fallback key includes the compartment
+		old := oldMap[resource.ResourceInfo.OCID]
+		moved = append(moved, MovedResource{
+			ResourceInfo:        resource.ResourceInfo,
+			FromCompartmentID:   old.CompartmentID,
+			FromCompartmentName: old.CompartmentName,
+			ToCompartmentID:     resource.ResourceInfo.CompartmentID,
+			ToCompartmentName:   resource.ResourceInfo.CompartmentName,
+			Changes:             changes,
+		})
+	}
+	return kept, moved
+}
+
+// Validate checks the ignored field patterns, the tolerance rules and the template
+func (c DiffConfig) Validate() error {
+	var problems []error
+	for _, pattern := range c.IgnoreFields {
+		if _, err := path.Match(pattern, ""); err != nil {
+			problems = append(problems, fmt.Errorf("invalid diff ignore field pattern '%s': %v", pattern, err))
+		}
+	}
+	for i, tolerance := range c.Tolerances {
+		for _, err := range splitErrors(tolerance.Validate()) {
+			problems = append(problems, fmt.Errorf("invalid diff tolerance at diff.tolerances[%d]: %w", i, err))
+		}
+	}
+	if c.Template != "" {
+		if _, err := ParseDiffTemplate(c.Template); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// ParseFieldList parses a comma-separated list of diff field names
+func ParseFieldList(input string) []string {
+	var fields []string
+	for _, field := range strings.Split(input, ",") {
+		if trimmed := strings.TrimSpace(field); trimmed != "" {
+			fields = append(fields, trimmed)
+		}
+	}
+	return fields
+}
+
+// dropDiffChanges drops the changes of the modified resources for which drop returns true
+// Resources left without changes are moved to the unchanged resources.
+func dropDiffChanges(modified []ModifiedResource, unchanged []ResourceInfo, drop func(resource ResourceInfo, change FieldChange) bool) ([]ModifiedResource, []ResourceInfo) {
+	var kept []ModifiedResource
+	for _, resource := range modified {
+		var changes []FieldChange
+		for _, change := range resource.Changes {
+			if !drop(resource.ResourceInfo, change) {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) > 0 {
+			resource.Changes = changes
+			kept = append(kept, resource)
+		} else {
+			unchanged = append(unchanged, resource.ResourceInfo)
+		}
+	}
+
+	// Keep the order of FindUnchangedResources
+	sort.Slice(unchanged, func(i, j int) bool {
+		if unchanged[i].ResourceType != unchanged[j].ResourceType {
+			return unchanged[i].ResourceType < unchanged[j].ResourceType
+		}
+		return unchanged[i].ResourceName < unchanged[j].ResourceName
+	})
+	return kept, unchanged
+}
+
+// isIgnoredDiffField reports whether a changed field matches one of the ignored field names
+// A field is ignored when one of the names matches it, either in full (e.g. "FreeformTags.env"
+// or "LifecycleState") or, for additional info, by its key alone (e.g. "primary_ip"); names may
+// be glob patterns.
+func isIgnoredDiffField(field string, ignoreFields []string) bool {
+	key := strings.TrimPrefix(field, "AdditionalInfo.")
+	for _, pattern := range ignoreFields {
+		if matched, _ := path.Match(pattern, field); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FindUnchangedResources identifies resources that are identical in both dumps
+func FindUnchangedResources(oldMap, newMap map[string]ResourceInfo) []ResourceInfo {
+	var unchanged []ResourceInfo
+
+	for ocid, oldResource := range oldMap {
+		if newResource, exists := newMap[ocid]; exists {
+			changes := CompareResourceDetails(oldResource, newResource)
+			if len(changes) == 0 {
+				unchanged = append(unchanged, newResource)
+			}
+		}
+	}
+
+	// Sort for consistent output
+	sort.Slice(unchanged, func(i, j int) bool {
+		if unchanged[i].ResourceType != unchanged[j].ResourceType {
+			return unchanged[i].ResourceType < unchanged[j].ResourceType
+		}
+		return unchanged[i].ResourceName < unchanged[j].ResourceName
+	})
+
+	return unchanged
+}
+
+// CompareResourceDetails compares two ResourceInfo objects and returns list of changes
+func CompareResourceDetails(old, new ResourceInfo) []FieldChange {
+	var changes []FieldChange
+
+	// Compare basic fields
+	if strings.TrimSpace(old.ResourceName) != strings.TrimSpace(new.ResourceName) {
+		changes = append(changes, FieldChange{
+			Field:    "ResourceName",
+			OldValue: old.ResourceName,
+			NewValue: new.ResourceName,
+		})
+	}
+
+	if old.CompartmentID != new.CompartmentID {
+		changes = append(changes, FieldChange{
+			Field:    "CompartmentID",
+			OldValue: old.CompartmentID,
+			NewValue: new.CompartmentID,
+		})
+	}
+
+	if strings.TrimSpace(old.LifecycleState) != strings.TrimSpace(new.LifecycleState) {
+		changes = append(changes, FieldChange{
+			Field:    "LifecycleState",
+			OldValue: old.LifecycleState,
+			NewValue: new.LifecycleState,
+		})
+	}
+
+	// Compare AdditionalInfo maps
+	changes = append(changes, compareAdditionalInfo(old.AdditionalInfo, new.AdditionalInfo)...)
+
+	// Compare tags
+	changes = append(changes, compareMapFields("FreeformTags", flattenFreeformTags(old.FreeformTags), flattenFreeformTags(new.FreeformTags))...)
+	changes = append(changes, compareMapFields("DefinedTags", flattenDefinedTags(old.DefinedTags), flattenDefinedTags(new.DefinedTags))...)
+
+	return changes
+}
+
+// flattenFreeformTags converts freeform tags into a map comparable with compareMapFields
+func flattenFreeformTags(tags map[string]string) map[string]interface{} {
+	flattened := make(map[string]interface{}, len(tags))
+	for key, value := range tags {
+		flattened[key] = value
+	}
+	return flattened
+}
+
+// flattenDefinedTags converts defined tags into a map keyed by "namespace.key"
+func flattenDefinedTags(tags map[string]map[string]interface{}) map[string]interface{} {
+	flattened := make(map[string]interface{})
+	for namespace, namespaceTags := range tags {
+		for key, value := range namespaceTags {
+			flattened[namespace+"."+key] = value
+		}
+	}
+	return flattened
+}
+
+// compareAdditionalInfo compares two AdditionalInfo maps and returns field changes
+func compareAdditionalInfo(oldInfo, newInfo map[string]interface{}) []FieldChange {
+	return compareMapFields("AdditionalInfo", oldInfo, newInfo)
+}
+
+// compareMapFields compares two maps and returns field changes named "<prefix>.<key>"
+func compareMapFields(prefix string, oldInfo, newInfo map[string]interface{}) []FieldChange {
+	var changes []FieldChange
+
+	// Get all unique keys from both maps
+	allKeys := getAllKeys(oldInfo, newInfo)
+
+	for _, key := range allKeys {
+		oldVal, oldExists := oldInfo[key]
+		newVal, newExists := newInfo[key]
+
+		if !oldExists && newExists {
+			// Field added
+			changes = append(changes, FieldChange{
+				Field:    fmt.Sprintf("%s.%s", prefix, key),
+				OldValue: nil,
+				NewValue: newVal,
+			})
+		} else if oldExists && !newExists {
+			// Field removed
+			changes = append(changes, FieldChange{
+				Field:    fmt.Sprintf("%s.%s", prefix, key),
+				OldValue: oldVal,
+				NewValue: nil,
+			})
+		} else if oldExists && newExists && !reflect.DeepEqual(normalizeDiffValue(oldVal), normalizeDiffValue(newVal)) {
+			// Field modified
+			changes = append(changes, FieldChange{
+				Field:    fmt.Sprintf("%s.%s", prefix, key),
+				OldValue: oldVal,
+				NewValue: newVal,
+			})
+		}
+	}
+
+	// Sort changes by field name for consistent output
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+// normalizeDiffValue converts a value to a canonical form before it is compared
+// Values read from a dump and values just discovered differ in representation only: numbers
+// decode from JSON as float64 but are discovered as ints, list order is not stable between API
+// calls (e.g. ip_addresses or cidr_blocks) and strings may carry stray whitespace. Numbers become
+// float64, strings are trimmed and lists are sorted, recursively.
+func normalizeDiffValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		if number, err := v.Float64(); err == nil {
+			return number
+		}
+		return v.String()
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeDiffValue(item)
+		}
+		return normalized
+	case []interface{}:
+		return normalizeDiffList(v)
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return normalizeDiffList(items)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+		return normalizeDiffList(items)
+	}
+	return value
+}
+
+// normalizeDiffList normalizes the items of a list and sorts them by their printed form
+func normalizeDiffList(items []interface{}) []interface{} {
+	normalized := make([]interface{}, len(items))
+	for i, item := range items {
+		normalized[i] = normalizeDiffValue(item)
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return fmt.Sprint(normalized[i]) < fmt.Sprint(normalized[j])
+	})
+	return normalized
+}
+
+// getAllKeys returns all unique keys from two maps
+func getAllKeys(map1, map2 map[string]interface{}) []string {
+	keySet := make(map[string]bool)
+
+	for key := range map1 {
+		keySet[key] = true
+	}
+	for key := range map2 {
+		keySet[key] = true
+	}
+
+	var keys []string
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// BuildDiffResult constructs the final DiffResult object
+func BuildDiffResult(added, removed []ResourceInfo, modified []ModifiedResource, unchanged []ResourceInfo, oldFile, newFile string, includeUnchanged bool) *DiffResult {
+	result := &DiffResult{
+		Added:     added,
+		Removed:   removed,
+		Modified:  modified,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		OldFile:   oldFile,
+		NewFile:   newFile,
+	}
+
+	if includeUnchanged {
+		result.Unchanged = unchanged
+	}
+
+	// Build summary statistics
+	result.Summary = DiffSummary{
+		TotalOld:       len(removed) + len(modified) + len(unchanged),
+		TotalNew:       len(added) + len(modified) + len(unchanged),
+		Added:          len(added),
+		Removed:        len(removed),
+		Modified:       len(modified),
+		Unchanged:      len(unchanged),
+		ByResourceType: buildResourceTypeStats(added, removed, modified, unchanged),
+		ByOriginator:   buildOriginatorStats(added, removed, modified, nil),
+	}
+
+	return result
+}
+
+// addMoved adds the moved resources to the result and its summary statistics
+func (r *DiffResult) addMoved(moved []MovedResource) {
+	if len(moved) == 0 {
+		return
+	}
+	r.Moved = moved
+	r.Summary.TotalOld += len(moved)
+	r.Summary.TotalNew += len(moved)
+	r.Summary.Moved = len(moved)
+
+	for _, resource := range moved {
+		stat := r.Summary.ByResourceType[resource.ResourceInfo.ResourceType]
+		stat.Moved++
+		r.Summary.ByResourceType[resource.ResourceInfo.ResourceType] = stat
+	}
+	r.Summary.ByOriginator = buildOriginatorStats(r.Added, r.Removed, r.Modified, moved)
+}
+
+// addUnverified adds the resources that could not be verified to the result and its summary
+// They are not changes, so they are left out of the per-type statistics
+func (r *DiffResult) addUnverified(unverified []ResourceInfo) {
+	if len(unverified) == 0 {
+		return
+	}
+	r.Unverified = unverified
+	r.Summary.TotalOld += len(unverified)
+	r.Summary.Unverified = len(unverified)
+}
+
+// buildOriginatorStats groups changes by originator class (human/automation/terraform)
+// Returns nil when none of the changed resources carry an originator classification
+func buildOriginatorStats(added, removed []ResourceInfo, modified []ModifiedResource, moved []MovedResource) map[string]DiffStats {
+	stats := make(map[string]DiffStats)
+	classified := false
+
+	for _, resource := range added {
+		class := getOriginatorClass(resource)
+		classified = classified || class != OriginatorUnknown
+		stat := stats[class]
+		stat.Added++
+		stats[class] = stat
+	}
+
+	for _, resource := range removed {
+		class := getOriginatorClass(resource)
+		classified = classified || class != OriginatorUnknown
+		stat := stats[class]
+		stat.Removed++
+		stats[class] = stat
+	}
+
+	for _, resource := range modified {
+		class := getOriginatorClass(resource.ResourceInfo)
+		classified = classified || class != OriginatorUnknown
+		stat := stats[class]
+		stat.Modified++
+		stats[class] = stat
+	}
+
+	for _, resource := range moved {
+		class := getOriginatorClass(resource.ResourceInfo)
+		classified = classified || class != OriginatorUnknown
+		stat := stats[class]
+		stat.Moved++
+		stats[class] = stat
+	}
+
+	if !classified {
+		return nil
+	}
+
+	return stats
+}
+
+// buildResourceTypeStats creates per-resource-type statistics
+func buildResourceTypeStats(added, removed []ResourceInfo, modified []ModifiedResource, unchanged []ResourceInfo) map[string]DiffStats {
+	stats := make(map[string]DiffStats)
+
+	// Count added resources
+	for _, resource := range added {
+		stat := stats[resource.ResourceType]
+		stat.Added++
+		stats[resource.ResourceType] = stat
+	}
+
+	// Count removed resources
+	for _, resource := range removed {
+		stat := stats[resource.ResourceType]
+		stat.Removed++
+		stats[resource.ResourceType] = stat
+	}
+
+	// Count modified resources
+	for _, resource := range modified {
+		stat := stats[resource.ResourceInfo.ResourceType]
+		stat.Modified++
+		stats[resource.ResourceInfo.ResourceType] = stat
+	}
+
+	// Count unchanged resources
+	for _, resource := range unchanged {
+		stat := stats[resource.ResourceType]
+		stat.Unchanged++
+		stats[resource.ResourceType] = stat
+	}
+
+	return stats
+}
+
+// OutputDiffResult outputs the diff result in the specified format
+func OutputDiffResult(result *DiffResult, config DiffConfig) error {
+	if IsStdoutPath(config.OutputFile) {
+		return writeDiffResult(result, config, os.Stdout)
+	}
+
+	file, err := os.Create(config.OutputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file %s: %w", config.OutputFile, err)
+	}
+	logger.Info("Writing diff result to file: %s", config.OutputFile)
+
+	if err := writeDiffResult(result, config, file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return CloseOutputFile(file)
+}
+
+// writeDiffResult writes the diff result to the writer in the configured format
+func writeDiffResult(result *DiffResult, config DiffConfig, writer io.Writer) error {
+	if config.SummaryOnly {
+		return writeDiffSummary(result, config, writer)
+	}
+	if config.Template != "" {
+		return OutputDiffTemplate(result, config.Template, writer)
+	}
+	switch strings.ToLower(config.Format) {
+	case "json":
+		return OutputDiffJSON(result, writer)
+	case "text":
+		return OutputDiffText(result, writer)
+	case "html":
+		return OutputDiffHTML(result, writer)
+	case "markdown", "md":
+		return OutputDiffMarkdown(result, writer)
+	case "csv":
+		return OutputDiffCSV(result, writer)
+	case "tsv":
+		return OutputDiffTSV(result, writer)
+	default:
+		return fmt.Errorf("unsupported diff format: %s", config.Format)
+	}
+}
+
+// writeDiffSummary writes only the summary counts of the diff result in the configured format
+// JSON and the tabular formats get the summary on its own; the report formats and templates
+// are written without their resource listings.
+func writeDiffSummary(result *DiffResult, config DiffConfig, writer io.Writer) error {
+	format := strings.ToLower(config.Format)
+	if config.Template != "" {
+		format = "template"
+	}
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result.Summary)
+	case "csv":
+		csvWriter, err := newCSVRecordWriter(writer, CSVDialect{})
+		if err != nil {
+			return err
+		}
+		return csvWriter.WriteAll(diffSummaryRecords(result.Summary))
+	case "tsv":
+		for _, record := range diffSummaryRecords(result.Summary) {
+			if _, err := fmt.Fprintln(writer, strings.Join(record, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	summary := *result
+	summary.Added, summary.Removed, summary.Modified, summary.Moved, summary.Unchanged, summary.Unverified = nil, nil, nil, nil, nil, nil
+	config.SummaryOnly = false
+	return writeDiffResult(&summary, config, writer)
+}
+
+// OutputDiffJSON outputs the diff result in JSON format
+func OutputDiffJSON(result *DiffResult, writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// OutputDiffText outputs the diff result in human-readable text format
+func OutputDiffText(result *DiffResult, writer io.Writer) error {
+	fmt.Fprintf(writer, "OCI Resource Dump Comparison Report\n")
+	fmt.Fprintf(writer, "===================================\n\n")
+
+	fmt.Fprintf(writer, "Files Compared:\n")
+	fmt.Fprintf(writer, "  Old: %s (%d resources)\n", result.OldFile, result.Summary.TotalOld)
+	fmt.Fprintf(writer, "  New: %s (%d resources)\n", result.NewFile, result.Summary.TotalNew)
+	fmt.Fprintf(writer, "\nGenerated: %s\n\n", result.Timestamp)
+
+	// Summary section
+	fmt.Fprintf(writer, "SUMMARY\n")
+	fmt.Fprintf(writer, "-------\n")
+	totalChanges := result.Summary.Added + result.Summary.Removed + result.Summary.Modified + result.Summary.Moved
+	fmt.Fprintf(writer, "Total Changes: %d resources affected\n", totalChanges)
+	fmt.Fprintf(writer, "  Added:     %d resources\n", result.Summary.Added)
+	fmt.Fprintf(writer, "  Removed:   %d resources\n", result.Summary.Removed)
+	fmt.Fprintf(writer, "  Modified:  %d resources\n", result.Summary.Modified)
+	fmt.Fprintf(writer, "  Moved:     %d resources\n", result.Summary.Moved)
+	fmt.Fprintf(writer, "  Unchanged: %d resources\n", result.Summary.Unchanged)
+	if result.Summary.Unverified > 0 {
+		fmt.Fprintf(writer, "  Unverified: %d resources\n", result.Summary.Unverified)
+	}
+	fmt.Fprintf(writer, "\n")
+
+	// Resource type breakdown
+	if len(result.Summary.ByResourceType) > 0 {
+		fmt.Fprintf(writer, "CHANGES BY RESOURCE TYPE\n")
+		fmt.Fprintf(writer, "------------------------\n")
+
+		var resourceTypes []string
+		for resourceType := range result.Summary.ByResourceType {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+		sort.Strings(resourceTypes)
+
+		for _, resourceType := range resourceTypes {
+			stats := result.Summary.ByResourceType[resourceType]
+			total := stats.Added + stats.Removed + stats.Modified + stats.Moved + stats.Unchanged
+			fmt.Fprintf(writer, "%s: +%d, -%d, ~%d, >%d (%d total)\n",
+				resourceType, stats.Added, stats.Removed, stats.Modified, stats.Moved, total)
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	// Originator breakdown
+	if len(result.Summary.ByOriginator) > 0 {
+		fmt.Fprintf(writer, "CHANGES BY ORIGINATOR\n")
+		fmt.Fprintf(writer, "---------------------\n")
+
+		var originators []string
+		for originator := range result.Summary.ByOriginator {
+			originators = append(originators, originator)
+		}
+		sort.Strings(originators)
+
+		for _, originator := range originators {
+			stats := result.Summary.ByOriginator[originator]
+			fmt.Fprintf(writer, "%s: +%d, -%d, ~%d, >%d\n", originator, stats.Added, stats.Removed, stats.Modified, stats.Moved)
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	// Added resources
+	if len(result.Added) > 0 {
+		fmt.Fprintf(writer, "ADDED RESOURCES (%d)\n", len(result.Added))
+		fmt.Fprintf(writer, "-------------------\n")
+		for _, resource := range result.Added {
+			fmt.Fprintf(writer, "+ %s: %s (%s)\n", resource.ResourceType, resource.ResourceName, resource.OCID)
+			fmt.Fprintf(writer, "  Compartment: %s\n", resource.CompartmentID)
+			if len(resource.AdditionalInfo) > 0 {
+				fmt.Fprintf(writer, "  %s\n", formatAdditionalInfo(resource.AdditionalInfo))
+			}
+			fmt.Fprintf(writer, "\n")
+		}
+	}
+
+	// Removed resources
+	if len(result.Removed) > 0 {
+		fmt.Fprintf(writer, "REMOVED RESOURCES (%d)\n", len(result.Removed))
+		fmt.Fprintf(writer, "---------------------\n")
+		for _, resource := range result.Removed {
+			fmt.Fprintf(writer, "- %s: %s (%s)\n", resource.ResourceType, resource.ResourceName, resource.OCID)
+			fmt.Fprintf(writer, "  Compartment: %s\n", resource.CompartmentID)
+			if len(resource.AdditionalInfo) > 0 {
+				fmt.Fprintf(writer, "  %s\n", formatAdditionalInfo(resource.AdditionalInfo))
+			}
+			fmt.Fprintf(writer, "\n")
+		}
+	}
+
+	// Modified resources
+	if len(result.Modified) > 0 {
+		fmt.Fprintf(writer, "MODIFIED RESOURCES (%d)\n", len(result.Modified))
+		fmt.Fprintf(writer, "-----------------------\n")
+		for _, modified := range result.Modified {
+			resource := modified.ResourceInfo
+			fmt.Fprintf(writer, "~ %s: %s (%s)\n", resource.ResourceType, resource.ResourceName, resource.OCID)
+			fmt.Fprintf(writer, "  Compartment: %s\n", resource.CompartmentID)
+			fmt.Fprintf(writer, "  Changes:\n")
+			for _, change := range modified.Changes {
+				fmt.Fprintf(writer, "    - %s: %v → %v\n",
+					strings.TrimPrefix(change.Field, "AdditionalInfo."),
+					formatValue(change.OldValue),
+					formatValue(change.NewValue))
+			}
+			fmt.Fprintf(writer, "\n")
+		}
+	}
+
+	// Moved resources
+	if len(result.Moved) > 0 {
+		fmt.Fprintf(writer, "MOVED RESOURCES (%d)\n", len(result.Moved))
+		fmt.Fprintf(writer, "--------------------\n")
+		for _, moved := range result.Moved {
+			resource := moved.ResourceInfo
+			fmt.Fprintf(writer, "> %s: %s (%s)\n", resource.ResourceType, resource.ResourceName, resource.OCID)
+			fmt.Fprintf(writer, "  From: %s\n", moved.From())
+			fmt.Fprintf(writer, "  To:   %s\n", moved.To())
+			if len(moved.Changes) > 0 {
+				fmt.Fprintf(writer, "  Changes:\n")
+				for _, change := range moved.Changes {
+					fmt.Fprintf(writer, "    - %s: %v → %v\n",
+						strings.TrimPrefix(change.Field, "AdditionalInfo."),
+						formatValue(change.OldValue),
+						formatValue(change.NewValue))
+				}
+			}
+			fmt.Fprintf(writer, "\n")
+		}
+	}
+
+	// Unverified resources
+	if len(result.Unverified) > 0 {
+		fmt.Fprintf(writer, "UNVERIFIED RESOURCES (%d)\n", len(result.Unverified))
+		fmt.Fprintf(writer, "------------------------\n")
+		for _, resource := range result.Unverified {
+			fmt.Fprintf(writer, "? %s: %s (%s)\n", resource.ResourceType, resource.ResourceName, resource.OCID)
+			fmt.Fprintf(writer, "  Compartment: %s\n", resource.CompartmentID)
+			if len(resource.AdditionalInfo) > 0 {
+				fmt.Fprintf(writer, "  %s\n", formatAdditionalInfo(resource.AdditionalInfo))
+			}
+			fmt.Fprintf(writer, "\n")
+		}
+	}
+
+	// Unchanged resources (if detailed mode)
+	if result.Unchanged != nil && len(result.Unchanged) > 0 {
+		fmt.Fprintf(writer, "UNCHANGED RESOURCES (%d)\n", len(result.Unchanged))
+		fmt.Fprintf(writer, "-----------------------\n")
+		for _, resource := range result.Unchanged {
+			fmt.Fprintf(writer, "= %s: %s (%s)\n", resource.ResourceType, resource.ResourceName, resource.OCID)
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	return nil
+}
+
+// OutputDiffMarkdown outputs the diff result as Markdown sections and tables, e.g. for PR or chat comments
+func OutputDiffMarkdown(result *DiffResult, writer io.Writer) error {
+	fmt.Fprintf(writer, "## OCI Resource Dump Comparison\n\n")
+	fmt.Fprintf(writer, "- **Old:** `%s` (%d resources)\n", result.OldFile, result.Summary.TotalOld)
+	fmt.Fprintf(writer, "- **New:** `%s` (%d resources)\n", result.NewFile, result.Summary.TotalNew)
+	fmt.Fprintf(writer, "- **Generated:** %s\n\n", result.Timestamp)
+
+	fmt.Fprintf(writer, "| Added | Removed | Modified | Moved | Unchanged |\n")
+	fmt.Fprintf(writer, "|------:|--------:|---------:|------:|----------:|\n")
+	fmt.Fprintf(writer, "| %d | %d | %d | %d | %d |\n\n",
+		result.Summary.Added, result.Summary.Removed, result.Summary.Modified, result.Summary.Moved, result.Summary.Unchanged)
+
+	if len(result.Summary.ByResourceType) > 0 {
+		fmt.Fprintf(writer, "### Changes by Resource Type\n\n")
+		fmt.Fprintf(writer, "| Resource Type | Added | Removed | Modified | Moved | Unchanged |\n")
+		fmt.Fprintf(writer, "|---------------|------:|--------:|---------:|------:|----------:|\n")
+		for _, row := range sortedDiffStats(result.Summary.ByResourceType) {
+			fmt.Fprintf(writer, "| %s | %d | %d | %d | %d | %d |\n",
+				markdownCell(row.Name), row.Added, row.Removed, row.Modified, row.Moved, row.Unchanged)
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	if len(result.Summary.ByOriginator) > 0 {
+		fmt.Fprintf(writer, "### Changes by Originator\n\n")
+		fmt.Fprintf(writer, "| Originator | Added | Removed | Modified | Moved |\n")
+		fmt.Fprintf(writer, "|------------|------:|--------:|---------:|------:|\n")
+		for _, row := range sortedDiffStats(result.Summary.ByOriginator) {
+			fmt.Fprintf(writer, "| %s | %d | %d | %d | %d |\n", markdownCell(row.Name), row.Added, row.Removed, row.Modified, row.Moved)
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	writeResources := func(title string, resources []ResourceInfo) {
+		if len(resources) == 0 {
+			return
+		}
+		fmt.Fprintf(writer, "### %s (%d)\n\n", title, len(resources))
+		fmt.Fprintf(writer, "| Resource Type | Name | OCID | Compartment |\n")
+		fmt.Fprintf(writer, "|---------------|------|------|-------------|\n")
+		for _, resource := range resources {
+			fmt.Fprintf(writer, "| %s | %s | `%s` | `%s` |\n", markdownCell(resource.ResourceType),
+				markdownCell(resource.ResourceName), resource.OCID, resource.CompartmentID)
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+	writeResources("Added Resources", result.Added)
+	writeResources("Removed Resources", result.Removed)
+	writeResources("Unverified Resources", result.Unverified)
+
+	if len(result.Modified) > 0 {
+		fmt.Fprintf(writer, "### Modified Resources (%d)\n\n", len(result.Modified))
+		fmt.Fprintf(writer, "| Resource Type | Name | Field | Old | New |\n")
+		fmt.Fprintf(writer, "|---------------|------|-------|-----|-----|\n")
+		for _, modified := range result.Modified {
+			resource := modified.ResourceInfo
+			for _, change := range modified.Changes {
+				fmt.Fprintf(writer, "| %s | %s | %s | %s | %s |\n",
+					markdownCell(resource.ResourceType), markdownCell(resource.ResourceName),
+					markdownCell(strings.TrimPrefix(change.Field, "AdditionalInfo.")),
+					markdownCell(formatValue(change.OldValue)), markdownCell(formatValue(change.NewValue)))
+			}
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	if len(result.Moved) > 0 {
+		fmt.Fprintf(writer, "### Moved Resources (%d)\n\n", len(result.Moved))
+		fmt.Fprintf(writer, "| Resource Type | Name | From | To | Other Changes |\n")
+		fmt.Fprintf(writer, "|---------------|------|------|----|---------------|\n")
+		for _, moved := range result.Moved {
+			var changes []string
+			for _, change := range moved.Changes {
+				changes = append(changes, fmt.Sprintf("%s: %s → %s", strings.TrimPrefix(change.Field, "AdditionalInfo."),
+					formatValue(change.OldValue), formatValue(change.NewValue)))
+			}
+			fmt.Fprintf(writer, "| %s | %s | %s | %s | %s |\n",
+				markdownCell(moved.ResourceInfo.ResourceType), markdownCell(moved.ResourceInfo.ResourceName),
+				markdownCell(moved.From()), markdownCell(moved.To()), markdownCell(strings.Join(changes, "; ")))
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+
+	// Unchanged resources (if detailed mode) are folded away, as they are rarely of interest in a comment
+	if len(result.Unchanged) > 0 {
+		fmt.Fprintf(writer, "<details>\n<summary>Unchanged Resources (%d)</summary>\n\n", len(result.Unchanged))
+		for _, resource := range result.Unchanged {
+			fmt.Fprintf(writer, "- %s: %s (`%s`)\n", markdownCell(resource.ResourceType), markdownCell(resource.ResourceName), resource.OCID)
+		}
+		fmt.Fprintf(writer, "\n</details>\n")
+	}
+
+	return nil
+}
+
+// diffRecordHeader is the header of the tabular (CSV and TSV) diff output
+var diffRecordHeader = []string{"change_type", "resource_type", "resource_name", "ocid", "compartment_id", "field", "old", "new"}
+
+// diffRecords flattens the diff result into one row per change
+// Modified resources get a row per changed field; added, removed, unverified and unchanged resources get a
+// single row with empty field, old and new columns. Moved resources get a CompartmentID row with
+// the source and target compartment, followed by a row per other changed field.
+func diffRecords(result *DiffResult) [][]string {
+	records := [][]string{diffRecordHeader}
+	resourceRecord := func(changeType string, resource ResourceInfo, field, oldValue, newValue string) []string {
+		return []string{changeType, resource.ResourceType, resource.ResourceName, resource.OCID, resource.CompartmentID, field, oldValue, newValue}
+	}
+
+	for _, resource := range result.Added {
+		records = append(records, resourceRecord("added", resource, "", "", ""))
+	}
+	for _, resource := range result.Removed {
+		records = append(records, resourceRecord("removed", resource, "", "", ""))
+	}
+	for _, modified := range result.Modified {
+		for _, change := range modified.Changes {
+			records = append(records, resourceRecord("modified", modified.ResourceInfo,
+				strings.TrimPrefix(change.Field, "AdditionalInfo."), formatValue(change.OldValue), formatValue(change.NewValue)))
+		}
+	}
+	for _, moved := range result.Moved {
+		records = append(records, resourceRecord("moved", moved.ResourceInfo, "CompartmentID", moved.From(), moved.To()))
+		for _, change := range moved.Changes {
+			records = append(records, resourceRecord("moved", moved.ResourceInfo,
+				strings.TrimPrefix(change.Field, "AdditionalInfo."), formatValue(change.OldValue), formatValue(change.NewValue)))
+		}
+	}
+	for _, resource := range result.Unverified {
+		records = append(records, resourceRecord("unverified", resource, "", "", ""))
+	}
+	for _, resource := range result.Unchanged {
+		records = append(records, resourceRecord("unchanged", resource, "", "", ""))
+	}
+	return records
+}
+
+// diffSummaryRecords flattens the diff summary into one row per resource type and a total row
+func diffSummaryRecords(summary DiffSummary) [][]string {
+	records := [][]string{{"resource_type", "added", "removed", "modified", "moved", "unchanged"}}
+	statsRecord := func(name string, stats DiffStats) []string {
+		return []string{name, strconv.Itoa(stats.Added), strconv.Itoa(stats.Removed), strconv.Itoa(stats.Modified), strconv.Itoa(stats.Moved), strconv.Itoa(stats.Unchanged)}
+	}
+
+	for _, row := range sortedDiffStats(summary.ByResourceType) {
+		records = append(records, statsRecord(row.Name, row.DiffStats))
+	}
+	return append(records, statsRecord("total", DiffStats{
+		Added:     summary.Added,
+		Removed:   summary.Removed,
+		Modified:  summary.Modified,
+		Moved:     summary.Moved,
+		Unchanged: summary.Unchanged,
+	}))
+}
+
+// OutputDiffCSV outputs the diff result as CSV with one row per change
+func OutputDiffCSV(result *DiffResult, writer io.Writer) error {
+	csvWriter, err := newCSVRecordWriter(writer, CSVDialect{})
+	if err != nil {
+		return err
+	}
+	return csvWriter.WriteAll(diffRecords(result))
+}
+
+// OutputDiffTSV outputs the diff result as TSV with one row per change
+func OutputDiffTSV(result *DiffResult, writer io.Writer) error {
+	for _, record := range diffRecords(result) {
+		fields := make([]string, len(record))
+		for i, field := range record {
+			fields[i] = escapeTSVField(field)
+		}
+		if _, err := fmt.Fprintln(writer, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markdownCell escapes a value for a Markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	value = strings.ReplaceAll(value, "\r\n", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// formatAdditionalInfo formats additional info for text output
+// The priority fields and the names resolved for *_id and *_ids fields are always written; up to
+// 3 other fields follow.
+func formatAdditionalInfo(info map[string]interface{}) string {
+	var parts []string
+
+	// Prioritize important fields
+	priorityFields := []string{"shape", "primary_ip", "cidr_block", "size_gb", "performance_tier"}
+
+	for _, field := range priorityFields {
+		if value, exists := info[field]; exists {
+			parts = append(parts, fmt.Sprintf("%s: %v", field, formatValue(value)))
+		}
+	}
+
+	// Add the remaining fields in key order, so the same info is always written the same way
+	keys := make([]string, 0, len(info))
+	for key := range info {
+		if !slices.Contains(priorityFields, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	// Names of related resources come first, as they are what makes the other OCIDs readable
+	var others []string
+	for _, key := range keys {
+		if isRelationshipName(key, info) {
+			parts = append(parts, fmt.Sprintf("%s: %v", key, formatValue(info[key])))
+		} else {
+			others = append(others, key)
+		}
+	}
+	for _, key := range others[:min(len(others), 3)] {
+		parts = append(parts, fmt.Sprintf("%s: %v", key, formatValue(info[key])))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// isRelationshipName reports whether key is a name added by resolveRelationshipNames,
+// e.g. vcn_name next to vcn_id or subnet_names next to subnet_ids
+func isRelationshipName(key string, info map[string]interface{}) bool {
+	if prefix, ok := strings.CutSuffix(key, "_names"); ok {
+		_, exists := info[prefix+"_ids"]
+		return exists
+	}
+	if prefix, ok := strings.CutSuffix(key, "_name"); ok {
+		_, exists := info[prefix+"_id"]
+		return exists
+	}
+	return false
+}
+
+// formatValue formats a value for display
+func formatValue(value interface{}) string {
+	if value == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// validateDiffFiles validates that both input files exist and are readable
+func validateDiffFiles(oldFile, newFile string) error {
+	if _, err := os.Stat(oldFile); os.IsNotExist(err) {
+		return fmt.Errorf("old file not found: %s", oldFile)
+	}
+
+	if _, err := os.Stat(newFile); os.IsNotExist(err) {
+		return fmt.Errorf("new file not found: %s", newFile)
+	}
+
+	if oldFile == newFile {
+		return fmt.Errorf("old and new files cannot be the same: %s", oldFile)
+	}
+
+	return nil
+}
@@ -0,0 +1,836 @@
+package ocidump
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadResourcesFromFile_ValidFile(t *testing.T) {
+	// テストデータ作成
+	resources := []ResourceInfo{
+		{
+			ResourceType:   "ComputeInstance",
+			ResourceName:   "test-instance-01",
+			OCID:           "ocid1.instance.oc1..test1",
+			CompartmentID:  "ocid1.compartment.oc1..test",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard2.1"},
+		},
+		{
+			ResourceType:   "VCN",
+			ResourceName:   "test-vcn",
+			OCID:           "ocid1.vcn.oc1..test1",
+			CompartmentID:  "ocid1.compartment.oc1..test",
+			AdditionalInfo: map[string]interface{}{"cidr_block": "10.0.0.0/16"},
+		},
+	}
+
+	// 一時ファイル作成
+	tempDir, err := os.MkdirTemp("", "diff_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "test_resources.json")
+	data, err := json.Marshal(resources)
+	if err != nil {
+		t.Fatalf("Failed to marshal test data: %v", err)
+	}
+
+	err = os.WriteFile(filePath, data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	// テスト実行
+	result, err := LoadResourcesFromFile(filePath)
+	if err != nil {
+		t.Errorf("LoadResourcesFromFile() error = %v, want nil", err)
+	}
+
+	if !reflect.DeepEqual(result, resources) {
+		t.Errorf("LoadResourcesFromFile() = %v, want %v", result, resources)
+	}
+}
+
+func TestLoadResourcesFromFile_NonExistentFile(t *testing.T) {
+	_, err := LoadResourcesFromFile("/nonexistent/file.json")
+	if err == nil {
+		t.Error("LoadResourcesFromFile() error = nil, want error for non-existent file")
+	}
+}
+
+func TestLoadResourcesFromFile_InvalidJSON(t *testing.T) {
+	// 一時ファイル作成
+	tempDir, err := os.MkdirTemp("", "diff_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "invalid.json")
+	err = os.WriteFile(filePath, []byte("invalid json content"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	_, err = LoadResourcesFromFile(filePath)
+	if err == nil {
+		t.Error("LoadResourcesFromFile() error = nil, want error for invalid JSON")
+	}
+}
+
+func TestCreateResourceMap(t *testing.T) {
+	resources := []ResourceInfo{
+		{
+			OCID:         "ocid1.instance.oc1..test1",
+			ResourceName: "instance-1",
+		},
+		{
+			OCID:         "ocid1.vcn.oc1..test1",
+			ResourceName: "vcn-1",
+		},
+	}
+
+	resourceMap := CreateResourceMap(resources)
+
+	if len(resourceMap) != 2 {
+		t.Errorf("CreateResourceMap() map length = %d, want 2", len(resourceMap))
+	}
+
+	if resource, exists := resourceMap["ocid1.instance.oc1..test1"]; !exists {
+		t.Error("CreateResourceMap() missing instance resource")
+	} else if resource.ResourceName != "instance-1" {
+		t.Errorf("CreateResourceMap() instance name = %s, want instance-1", resource.ResourceName)
+	}
+
+	if resource, exists := resourceMap["ocid1.vcn.oc1..test1"]; !exists {
+		t.Error("CreateResourceMap() missing VCN resource")
+	} else if resource.ResourceName != "vcn-1" {
+		t.Errorf("CreateResourceMap() VCN name = %s, want vcn-1", resource.ResourceName)
+	}
+}
+
+func TestFindAddedResources(t *testing.T) {
+	oldMap := map[string]ResourceInfo{
+		"ocid1.instance.oc1..test1": {OCID: "ocid1.instance.oc1..test1", ResourceName: "instance-1"},
+	}
+
+	newMap := map[string]ResourceInfo{
+		"ocid1.instance.oc1..test1": {OCID: "ocid1.instance.oc1..test1", ResourceName: "instance-1"},
+		"ocid1.vcn.oc1..test1":      {OCID: "ocid1.vcn.oc1..test1", ResourceName: "vcn-1"},
+	}
+
+	added := FindAddedResources(oldMap, newMap)
+
+	if len(added) != 1 {
+		t.Errorf("FindAddedResources() length = %d, want 1", len(added))
+	}
+
+	if added[0].OCID != "ocid1.vcn.oc1..test1" {
+		t.Errorf("FindAddedResources() OCID = %s, want ocid1.vcn.oc1..test1", added[0].OCID)
+	}
+}
+
+func TestFindRemovedResources(t *testing.T) {
+	oldMap := map[string]ResourceInfo{
+		"ocid1.instance.oc1..test1": {OCID: "ocid1.instance.oc1..test1", ResourceName: "instance-1"},
+		"ocid1.vcn.oc1..test1":      {OCID: "ocid1.vcn.oc1..test1", ResourceName: "vcn-1"},
+	}
+
+	newMap := map[string]ResourceInfo{
+		"ocid1.instance.oc1..test1": {OCID: "ocid1.instance.oc1..test1", ResourceName: "instance-1"},
+	}
+
+	removed := FindRemovedResources(oldMap, newMap)
+
+	if len(removed) != 1 {
+		t.Errorf("FindRemovedResources() length = %d, want 1", len(removed))
+	}
+
+	if removed[0].OCID != "ocid1.vcn.oc1..test1" {
+		t.Errorf("FindRemovedResources() OCID = %s, want ocid1.vcn.oc1..test1", removed[0].OCID)
+	}
+}
+
+func TestFindModifiedResources(t *testing.T) {
+	oldMap := map[string]ResourceInfo{
+		"ocid1.instance.oc1..test1": {
+			OCID:           "ocid1.instance.oc1..test1",
+			ResourceName:   "instance-1",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard2.1"},
+		},
+	}
+
+	newMap := map[string]ResourceInfo{
+		"ocid1.instance.oc1..test1": {
+			OCID:           "ocid1.instance.oc1..test1",
+			ResourceName:   "instance-1-renamed",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard2.2"},
+		},
+	}
+
+	modified := FindModifiedResources(oldMap, newMap)
+
+	if len(modified) != 1 {
+		t.Errorf("FindModifiedResources() length = %d, want 1", len(modified))
+	}
+
+	if modified[0].ResourceInfo.OCID != "ocid1.instance.oc1..test1" {
+		t.Errorf("FindModifiedResources() ResourceInfo.OCID = %s, want ocid1.instance.oc1..test1", modified[0].ResourceInfo.OCID)
+	}
+
+	// Changes should contain field modifications
+	if len(modified[0].Changes) == 0 {
+		t.Error("FindModifiedResources() should detect changes")
+	}
+}
+
+func TestCompareResourceDetails(t *testing.T) {
+	old := ResourceInfo{
+		ResourceName:  "instance-1",
+		ResourceType:  "ComputeInstance",
+		OCID:          "ocid1.instance.oc1..test1",
+		CompartmentID: "ocid1.compartment.oc1..test",
+		AdditionalInfo: map[string]interface{}{
+			"shape":      "VM.Standard2.1",
+			"primary_ip": "10.0.1.10",
+		},
+	}
+
+	new := ResourceInfo{
+		ResourceName:  "instance-1-renamed",
+		ResourceType:  "ComputeInstance",
+		OCID:          "ocid1.instance.oc1..test1",
+		CompartmentID: "ocid1.compartment.oc1..test",
+		AdditionalInfo: map[string]interface{}{
+			"shape":      "VM.Standard2.2",
+			"primary_ip": "10.0.1.10",
+		},
+	}
+
+	changes := CompareResourceDetails(old, new)
+
+	// 変更が検出されることを確認
+	if len(changes) == 0 {
+		t.Error("CompareResourceDetails() should detect changes")
+	}
+
+	// ResourceNameの変更が検出されることを確認
+	foundNameChange := false
+	foundShapeChange := false
+	for _, change := range changes {
+		if change.Field == "ResourceName" {
+			foundNameChange = true
+			if change.OldValue != "instance-1" || change.NewValue != "instance-1-renamed" {
+				t.Errorf("CompareResourceDetails() ResourceName change: old=%v, new=%v", change.OldValue, change.NewValue)
+			}
+		}
+		if change.Field == "shape" {
+			foundShapeChange = true
+			if change.OldValue != "VM.Standard2.1" || change.NewValue != "VM.Standard2.2" {
+				t.Errorf("CompareResourceDetails() shape change: old=%v, new=%v", change.OldValue, change.NewValue)
+			}
+		}
+	}
+
+	if !foundNameChange {
+		t.Error("CompareResourceDetails() should detect ResourceName change")
+	}
+	if !foundShapeChange {
+		t.Error("CompareResourceDetails() should detect shape change")
+	}
+}
+
+func TestCompareResourceDetails_Tags(t *testing.T) {
+	old := ResourceInfo{
+		OCID:         "ocid1.instance.oc1..test1",
+		FreeformTags: map[string]string{"team": "platform", "env": "dev"},
+		DefinedTags: map[string]map[string]interface{}{
+			"Finance": {"CostCenter": "1234"},
+		},
+	}
+	new := ResourceInfo{
+		OCID:         "ocid1.instance.oc1..test1",
+		FreeformTags: map[string]string{"team": "platform", "env": "prod"},
+		DefinedTags: map[string]map[string]interface{}{
+			"Finance":    {"CostCenter": "1234"},
+			"Operations": {"Owner": "sre"},
+		},
+	}
+
+	changes := CompareResourceDetails(old, new)
+	if len(changes) != 2 {
+		t.Fatalf("CompareResourceDetails() returned %d changes, want 2: %+v", len(changes), changes)
+	}
+
+	if changes[0].Field != "FreeformTags.env" || changes[0].OldValue != "dev" || changes[0].NewValue != "prod" {
+		t.Errorf("Unexpected freeform tag change: %+v", changes[0])
+	}
+	if changes[1].Field != "DefinedTags.Operations.Owner" || changes[1].OldValue != nil || changes[1].NewValue != "sre" {
+		t.Errorf("Unexpected defined tag change: %+v", changes[1])
+	}
+}
+
+func TestCompareResourceDetails_NormalizedValues(t *testing.T) {
+	old := ResourceInfo{
+		ResourceName: "vcn-1",
+		AdditionalInfo: map[string]interface{}{
+			"size_gb":      float64(50),
+			"cidr_blocks":  []interface{}{"10.0.0.0/16", "10.1.0.0/16"},
+			"ip_addresses": []interface{}{"10.0.1.10", "10.0.1.11"},
+			"display":      "app ",
+		},
+	}
+	new := ResourceInfo{
+		ResourceName: " vcn-1",
+		AdditionalInfo: map[string]interface{}{
+			"size_gb":      int64(50),
+			"cidr_blocks":  []string{"10.1.0.0/16", "10.0.0.0/16"},
+			"ip_addresses": []interface{}{"10.0.1.11", "10.0.1.10"},
+			"display":      "app",
+		},
+	}
+	if changes := CompareResourceDetails(old, new); len(changes) != 0 {
+		t.Errorf("CompareResourceDetails() = %+v, want no changes", changes)
+	}
+
+	new.AdditionalInfo["ip_addresses"] = []interface{}{"10.0.1.12", "10.0.1.10"}
+	new.AdditionalInfo["size_gb"] = 60
+	changes := CompareResourceDetails(old, new)
+	if len(changes) != 2 || changes[0].Field != "AdditionalInfo.ip_addresses" || changes[1].Field != "AdditionalInfo.size_gb" {
+		t.Errorf("CompareResourceDetails() = %+v, want ip_addresses and size_gb changes", changes)
+	}
+}
+
+func TestBuildDiffResult(t *testing.T) {
+	added := []ResourceInfo{
+		{OCID: "ocid1.vcn.oc1..test1", ResourceName: "vcn-1"},
+	}
+	removed := []ResourceInfo{
+		{OCID: "ocid1.subnet.oc1..test1", ResourceName: "subnet-1"},
+	}
+	modified := []ModifiedResource{
+		{
+			ResourceInfo: ResourceInfo{
+				OCID:         "ocid1.instance.oc1..test1",
+				ResourceName: "instance-1-renamed",
+			},
+			Changes: []FieldChange{
+				{
+					Field:    "ResourceName",
+					OldValue: "instance-1",
+					NewValue: "instance-1-renamed",
+				},
+			},
+		},
+	}
+	unchanged := []ResourceInfo{
+		{OCID: "ocid1.volume.oc1..test1", ResourceName: "volume-1"},
+	}
+
+	result := BuildDiffResult(added, removed, modified, unchanged, "old.json", "new.json", true)
+
+	// Summary検証
+	if result.Summary.Added != 1 {
+		t.Errorf("BuildDiffResult() Summary.Added = %d, want 1", result.Summary.Added)
+	}
+	if result.Summary.Removed != 1 {
+		t.Errorf("BuildDiffResult() Summary.Removed = %d, want 1", result.Summary.Removed)
+	}
+	if result.Summary.Modified != 1 {
+		t.Errorf("BuildDiffResult() Summary.Modified = %d, want 1", result.Summary.Modified)
+	}
+	if result.Summary.Unchanged != 1 {
+		t.Errorf("BuildDiffResult() Summary.Unchanged = %d, want 1", result.Summary.Unchanged)
+	}
+
+	// ファイル名検証
+	if result.OldFile != "old.json" {
+		t.Errorf("BuildDiffResult() OldFile = %s, want old.json", result.OldFile)
+	}
+	if result.NewFile != "new.json" {
+		t.Errorf("BuildDiffResult() NewFile = %s, want new.json", result.NewFile)
+	}
+
+	// タイムスタンプ検証（形式のみ）
+	if result.Timestamp == "" {
+		t.Error("BuildDiffResult() Timestamp should not be empty")
+	}
+
+	// データ検証
+	if len(result.Added) != 1 {
+		t.Errorf("BuildDiffResult() Added length = %d, want 1", len(result.Added))
+	}
+	if len(result.Removed) != 1 {
+		t.Errorf("BuildDiffResult() Removed length = %d, want 1", len(result.Removed))
+	}
+	if len(result.Modified) != 1 {
+		t.Errorf("BuildDiffResult() Modified length = %d, want 1", len(result.Modified))
+	}
+	if len(result.Unchanged) != 1 {
+		t.Errorf("BuildDiffResult() Unchanged length = %d, want 1", len(result.Unchanged))
+	}
+}
+
+func TestCompareDumps_SameFiles(t *testing.T) {
+	// 同一リソースデータ作成
+	resources := []ResourceInfo{
+		{
+			ResourceType:  "ComputeInstance",
+			ResourceName:  "test-instance",
+			OCID:          "ocid1.instance.oc1..test1",
+			CompartmentID: "ocid1.compartment.oc1..test",
+		},
+	}
+
+	// 一時ファイル作成
+	tempDir, err := os.MkdirTemp("", "diff_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	oldFile := filepath.Join(tempDir, "old.json")
+	newFile := filepath.Join(tempDir, "new.json")
+
+	data, err := json.Marshal(resources)
+	if err != nil {
+		t.Fatalf("Failed to marshal test data: %v", err)
+	}
+
+	err = os.WriteFile(oldFile, data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write old file: %v", err)
+	}
+
+	err = os.WriteFile(newFile, data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write new file: %v", err)
+	}
+
+	// テスト実行
+	config := DiffConfig{Detailed: true}
+	result, err := CompareDumps(oldFile, newFile, config)
+	if err != nil {
+		t.Errorf("CompareDumps() error = %v, want nil", err)
+	}
+
+	// 変更なしを検証
+	if result.Summary.Added != 0 {
+		t.Errorf("CompareDumps() Added = %d, want 0", result.Summary.Added)
+	}
+	if result.Summary.Removed != 0 {
+		t.Errorf("CompareDumps() Removed = %d, want 0", result.Summary.Removed)
+	}
+	if result.Summary.Modified != 0 {
+		t.Errorf("CompareDumps() Modified = %d, want 0", result.Summary.Modified)
+	}
+	if result.Summary.Unchanged != 1 {
+		t.Errorf("CompareDumps() Unchanged = %d, want 1", result.Summary.Unchanged)
+	}
+}
+
+func TestCompareDumps_DifferentFiles(t *testing.T) {
+	// 異なるリソースデータ作成
+	oldResources := []ResourceInfo{
+		{
+			ResourceType:   "ComputeInstance",
+			ResourceName:   "old-instance",
+			OCID:           "ocid1.instance.oc1..test1",
+			CompartmentID:  "ocid1.compartment.oc1..test",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard2.1"},
+		},
+		{
+			ResourceType:  "VCN",
+			ResourceName:  "shared-vcn",
+			OCID:          "ocid1.vcn.oc1..test1",
+			CompartmentID: "ocid1.compartment.oc1..test",
+		},
+	}
+
+	newResources := []ResourceInfo{
+		{
+			ResourceType:   "ComputeInstance",
+			ResourceName:   "new-instance",
+			OCID:           "ocid1.instance.oc1..test1",
+			CompartmentID:  "ocid1.compartment.oc1..test",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard2.2"},
+		},
+		{
+			ResourceType:  "VCN",
+			ResourceName:  "shared-vcn",
+			OCID:          "ocid1.vcn.oc1..test1",
+			CompartmentID: "ocid1.compartment.oc1..test",
+		},
+		{
+			ResourceType:  "Subnet",
+			ResourceName:  "new-subnet",
+			OCID:          "ocid1.subnet.oc1..test1",
+			CompartmentID: "ocid1.compartment.oc1..test",
+		},
+	}
+
+	// 一時ファイル作成
+	tempDir, err := os.MkdirTemp("", "diff_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	oldFile := filepath.Join(tempDir, "old.json")
+	newFile := filepath.Join(tempDir, "new.json")
+
+	oldData, err := json.Marshal(oldResources)
+	if err != nil {
+		t.Fatalf("Failed to marshal old data: %v", err)
+	}
+
+	newData, err := json.Marshal(newResources)
+	if err != nil {
+		t.Fatalf("Failed to marshal new data: %v", err)
+	}
+
+	err = os.WriteFile(oldFile, oldData, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write old file: %v", err)
+	}
+
+	err = os.WriteFile(newFile, newData, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write new file: %v", err)
+	}
+
+	// テスト実行
+	config := DiffConfig{Detailed: false}
+	result, err := CompareDumps(oldFile, newFile, config)
+	if err != nil {
+		t.Errorf("CompareDumps() error = %v, want nil", err)
+	}
+
+	// 期待値検証
+	if result.Summary.Added != 1 {
+		t.Errorf("CompareDumps() Added = %d, want 1", result.Summary.Added)
+	}
+	if result.Summary.Removed != 0 {
+		t.Errorf("CompareDumps() Removed = %d, want 0", result.Summary.Removed)
+	}
+	if result.Summary.Modified != 1 {
+		t.Errorf("CompareDumps() Modified = %d, want 1", result.Summary.Modified)
+	}
+	if result.Summary.Unchanged != 1 {
+		t.Errorf("CompareDumps() Unchanged = %d, want 1", result.Summary.Unchanged)
+	}
+
+	// 追加されたリソース検証
+	if len(result.Added) != 1 || result.Added[0].OCID != "ocid1.subnet.oc1..test1" {
+		t.Error("CompareDumps() added resource should be subnet")
+	}
+
+	// 変更されたリソース検証
+	if len(result.Modified) != 1 || result.Modified[0].ResourceInfo.OCID != "ocid1.instance.oc1..test1" {
+		t.Error("CompareDumps() modified resource should be instance")
+	}
+
+	// Detailedがfalseなので、unchangedは空であることを確認
+	if len(result.Unchanged) != 0 {
+		t.Errorf("CompareDumps() Unchanged length = %d, want 0 when Detailed is false", len(result.Unchanged))
+	}
+}
+
+// formatTimestamp関数が非公開なので、RFC3339フォーマットのテストのみ実施
+func TestTimestampFormat(t *testing.T) {
+	now := time.Now()
+	formatted := now.Format(time.RFC3339)
+
+	// RFC3339形式であることを確認
+	_, err := time.Parse(time.RFC3339, formatted)
+	if err != nil {
+		t.Errorf("RFC3339 format test failed: %s", formatted)
+	}
+}
+
+func TestBuildDiffResult_ByOriginator(t *testing.T) {
+	added := []ResourceInfo{
+		{OCID: "ocid1.vcn.oc1..test1", AdditionalInfo: map[string]interface{}{"originator_class": OriginatorTerraform}},
+		{OCID: "ocid1.vcn.oc1..test2", AdditionalInfo: map[string]interface{}{"originator_class": OriginatorHuman}},
+	}
+	removed := []ResourceInfo{
+		{OCID: "ocid1.subnet.oc1..test1", AdditionalInfo: map[string]interface{}{}},
+	}
+
+	result := BuildDiffResult(added, removed, nil, nil, "old.json", "new.json", false)
+
+	if got := result.Summary.ByOriginator[OriginatorTerraform].Added; got != 1 {
+		t.Errorf("ByOriginator[terraform].Added = %d, want 1", got)
+	}
+	if got := result.Summary.ByOriginator[OriginatorHuman].Added; got != 1 {
+		t.Errorf("ByOriginator[human].Added = %d, want 1", got)
+	}
+	if got := result.Summary.ByOriginator[OriginatorUnknown].Removed; got != 1 {
+		t.Errorf("ByOriginator[unknown].Removed = %d, want 1", got)
+	}
+
+	// Unclassified dumps should not produce an originator breakdown
+	result = BuildDiffResult(removed, nil, nil, nil, "old.json", "new.json", false)
+	if result.Summary.ByOriginator != nil {
+		t.Errorf("ByOriginator = %v, want nil for unclassified resources", result.Summary.ByOriginator)
+	}
+}
+
+func TestOutputDiffMarkdown(t *testing.T) {
+	result := &DiffResult{
+		Summary: DiffSummary{
+			TotalOld: 1, TotalNew: 2, Added: 1, Modified: 1,
+			ByResourceType: map[string]DiffStats{"VCN": {Added: 1}, "Subnet": {Modified: 1}},
+		},
+		Added: []ResourceInfo{{ResourceType: "VCN", ResourceName: "a|b", OCID: "ocid1.vcn.oc1..new"}},
+		Modified: []ModifiedResource{{
+			ResourceInfo: ResourceInfo{ResourceType: "Subnet", ResourceName: "app", OCID: "ocid1.subnet.oc1..app"},
+			Changes:      []FieldChange{{Field: "AdditionalInfo.cidr_block", OldValue: "10.0.1.0/24", NewValue: "10.0.2.0/24"}},
+		}},
+		OldFile: "old.json",
+		NewFile: "new.json",
+	}
+
+	var buf bytes.Buffer
+	if err := writeDiffResult(result, DiffConfig{Format: "markdown"}, &buf); err != nil {
+		t.Fatalf("writeDiffResult(markdown) error = %v", err)
+	}
+	markdown := buf.String()
+
+	for _, want := range []string{
+		"## OCI Resource Dump Comparison",
+		"| 1 | 0 | 1 | 0 | 0 |",
+		"| Subnet | 0 | 0 | 1 | 0 | 0 |\n| VCN | 1 | 0 | 0 | 0 | 0 |",
+		"### Added Resources (1)",
+		"| VCN | a\\|b | `ocid1.vcn.oc1..new` |",
+		"| Subnet | app | cidr_block | 10.0.1.0/24 | 10.0.2.0/24 |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown diff does not contain %q:\n%s", want, markdown)
+		}
+	}
+	if strings.Contains(markdown, "Removed Resources") {
+		t.Error("empty sections should be omitted")
+	}
+}
+
+func TestOutputDiffCSVAndTSV(t *testing.T) {
+	result := &DiffResult{
+		Added:   []ResourceInfo{{ResourceType: "VCN", ResourceName: "vcn, prod", OCID: "ocid1.vcn.oc1..new", CompartmentID: "ocid1.compartment.oc1..a"}},
+		Removed: []ResourceInfo{{ResourceType: "Instance", ResourceName: "old", OCID: "ocid1.instance.oc1..old", CompartmentID: "ocid1.compartment.oc1..a"}},
+		Modified: []ModifiedResource{{
+			ResourceInfo: ResourceInfo{ResourceType: "Subnet", ResourceName: "app", OCID: "ocid1.subnet.oc1..app", CompartmentID: "ocid1.compartment.oc1..a"},
+			Changes: []FieldChange{
+				{Field: "ResourceName", OldValue: "app-old", NewValue: "app"},
+				{Field: "AdditionalInfo.cidr_block", OldValue: "10.0.1.0/24", NewValue: "10.0.2.0/24"},
+			},
+		}},
+	}
+
+	var csvBuf bytes.Buffer
+	if err := writeDiffResult(result, DiffConfig{Format: "csv"}, &csvBuf); err != nil {
+		t.Fatalf("writeDiffResult(csv) error = %v", err)
+	}
+	expectedCSV := "change_type,resource_type,resource_name,ocid,compartment_id,field,old,new\n" +
+		"added,VCN,\"vcn, prod\",ocid1.vcn.oc1..new,ocid1.compartment.oc1..a,,,\n" +
+		"removed,Instance,old,ocid1.instance.oc1..old,ocid1.compartment.oc1..a,,,\n" +
+		"modified,Subnet,app,ocid1.subnet.oc1..app,ocid1.compartment.oc1..a,ResourceName,app-old,app\n" +
+		"modified,Subnet,app,ocid1.subnet.oc1..app,ocid1.compartment.oc1..a,cidr_block,10.0.1.0/24,10.0.2.0/24\n"
+	if csvBuf.String() != expectedCSV {
+		t.Errorf("CSV diff =\n%s\nwant\n%s", csvBuf.String(), expectedCSV)
+	}
+
+	var tsvBuf bytes.Buffer
+	if err := writeDiffResult(result, DiffConfig{Format: "tsv"}, &tsvBuf); err != nil {
+		t.Fatalf("writeDiffResult(tsv) error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(tsvBuf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("TSV diff has %d lines, want 5", len(lines))
+	}
+	if got := strings.Split(lines[4], "\t"); len(got) != len(diffRecordHeader) || got[5] != "cidr_block" || got[7] != "10.0.2.0/24" {
+		t.Errorf("TSV modified row = %q", lines[4])
+	}
+}
+
+func TestWriteDiffResult_SummaryOnly(t *testing.T) {
+	result := &DiffResult{
+		Summary: DiffSummary{
+			TotalOld: 2, TotalNew: 2, Added: 1, Removed: 1,
+			ByResourceType: map[string]DiffStats{"VCN": {Added: 1}, "Instance": {Removed: 1}},
+		},
+		Added:   []ResourceInfo{{ResourceType: "VCN", ResourceName: "vcn-new", OCID: "ocid1.vcn.oc1..new"}},
+		Removed: []ResourceInfo{{ResourceType: "Instance", ResourceName: "old", OCID: "ocid1.instance.oc1..old"}},
+	}
+
+	for _, format := range []string{"json", "text", "html", "markdown", "csv", "tsv"} {
+		var buf bytes.Buffer
+		if err := writeDiffResult(result, DiffConfig{Format: format, SummaryOnly: true}, &buf); err != nil {
+			t.Fatalf("writeDiffResult(%s) error = %v", format, err)
+		}
+		if strings.Contains(buf.String(), "ocid1.vcn.oc1..new") {
+			t.Errorf("%s summary lists resources:\n%s", format, buf.String())
+		}
+		if !strings.Contains(buf.String(), "Instance") {
+			t.Errorf("%s summary misses the resource type counts:\n%s", format, buf.String())
+		}
+	}
+
+	var csvBuf bytes.Buffer
+	if err := writeDiffResult(result, DiffConfig{Format: "csv", SummaryOnly: true}, &csvBuf); err != nil {
+		t.Fatalf("writeDiffResult(csv) error = %v", err)
+	}
+	expectedCSV := "resource_type,added,removed,modified,moved,unchanged\n" +
+		"Instance,0,1,0,0,0\n" +
+		"VCN,1,0,0,0,0\n" +
+		"total,1,1,0,0,0\n"
+	if csvBuf.String() != expectedCSV {
+		t.Errorf("CSV summary =\n%s\nwant\n%s", csvBuf.String(), expectedCSV)
+	}
+}
+
+func TestDiffSummary_HasChanges(t *testing.T) {
+	if (DiffSummary{TotalOld: 3, TotalNew: 3, Unchanged: 3}).HasChanges() {
+		t.Error("HasChanges() = true for identical dumps")
+	}
+	for _, summary := range []DiffSummary{{Added: 1}, {Removed: 1}, {Modified: 1}} {
+		if !summary.HasChanges() {
+			t.Errorf("HasChanges() = false for %+v", summary)
+		}
+	}
+}
+
+func TestDiffResources_IgnoreFields(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	oldResources := []ResourceInfo{
+		{ResourceType: "Instance", ResourceName: "web-1", OCID: "ocid1.instance.oc1..web1",
+			AdditionalInfo: map[string]interface{}{"primary_ip": "10.0.0.5", "shape": "VM.Standard2.1"}},
+		{ResourceType: "Instance", ResourceName: "web-2", OCID: "ocid1.instance.oc1..web2",
+			AdditionalInfo: map[string]interface{}{"primary_ip": "10.0.0.6"},
+			FreeformTags:   map[string]string{"build": "41"}},
+	}
+	newResources := []ResourceInfo{
+		{ResourceType: "Instance", ResourceName: "web-1", OCID: "ocid1.instance.oc1..web1",
+			AdditionalInfo: map[string]interface{}{"primary_ip": "10.0.0.9", "shape": "VM.Standard.E4.Flex"}},
+		{ResourceType: "Instance", ResourceName: "web-2", OCID: "ocid1.instance.oc1..web2",
+			AdditionalInfo: map[string]interface{}{"primary_ip": "10.0.0.7"},
+			FreeformTags:   map[string]string{"build": "42"}},
+	}
+
+	config := DiffConfig{Detailed: true, IgnoreFields: ParseFieldList("primary_ip, FreeformTags.*")}
+	result := DiffResources(oldResources, newResources, "old", "new", config)
+
+	if result.Summary.Modified != 1 || result.Summary.Unchanged != 1 {
+		t.Fatalf("Modified = %d, Unchanged = %d, want 1 and 1", result.Summary.Modified, result.Summary.Unchanged)
+	}
+	changes := result.Modified[0].Changes
+	if len(changes) != 1 || changes[0].Field != "AdditionalInfo.shape" {
+		t.Errorf("Changes = %v, want only AdditionalInfo.shape", changes)
+	}
+	if result.Unchanged[0].OCID != "ocid1.instance.oc1..web2" {
+		t.Errorf("Unchanged = %v, want web-2 whose changes are all ignored", result.Unchanged)
+	}
+
+	if err := (DiffConfig{IgnoreFields: []string{"[primary"}}).Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for a malformed pattern")
+	}
+}
+
+func TestDiffResources_MovedResources(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+
+	oldResources := []ResourceInfo{
+		{ResourceType: "Instance", ResourceName: "web", OCID: "ocid1.instance.oc1..web", CompartmentID: "ocid1.compartment.oc1..dev", CompartmentName: "dev"},
+		{ResourceType: "Instance", ResourceName: "db", OCID: "ocid1.instance.oc1..db", CompartmentID: "ocid1.compartment.oc1..dev", CompartmentName: "dev",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard2.1"}},
+		{ResourceType: "VCN", ResourceName: "vcn", OCID: "ocid1.vcn.oc1..vcn", CompartmentID: "ocid1.compartment.oc1..dev", CompartmentName: "dev"},
+	}
+	newResources := []ResourceInfo{
+		{ResourceType: "Instance", ResourceName: "web", OCID: "ocid1.instance.oc1..web", CompartmentID: "ocid1.compartment.oc1..prod", CompartmentName: "prod"},
+		{ResourceType: "Instance", ResourceName: "db", OCID: "ocid1.instance.oc1..db", CompartmentID: "ocid1.compartment.oc1..dev", CompartmentName: "dev",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard3.Flex"}},
+		{ResourceType: "VCN", ResourceName: "vcn", OCID: "ocid1.vcn.oc1..vcn", CompartmentID: "ocid1.compartment.oc1..dev", CompartmentName: "dev"},
+	}
+
+	result := DiffResources(oldResources, newResources, "old", "new", DiffConfig{})
+	if len(result.Moved) != 1 || len(result.Modified) != 1 {
+		t.Fatalf("got %d moved and %d modified resources, want 1 and 1", len(result.Moved), len(result.Modified))
+	}
+	moved := result.Moved[0]
+	if moved.ResourceInfo.OCID != "ocid1.instance.oc1..web" || len(moved.Changes) != 0 {
+		t.Errorf("unexpected moved resource %+v", moved)
+	}
+	if moved.From() != "dev (ocid1.compartment.oc1..dev)" || moved.To() != "prod (ocid1.compartment.oc1..prod)" {
+		t.Errorf("From() = %q, To() = %q", moved.From(), moved.To())
+	}
+	if result.Summary.Moved != 1 || result.Summary.Modified != 1 || result.Summary.TotalOld != 3 || result.Summary.TotalNew != 3 {
+		t.Errorf("unexpected summary %+v", result.Summary)
+	}
+	if stats := result.Summary.ByResourceType["Instance"]; stats.Moved != 1 || stats.Modified != 1 {
+		t.Errorf("Instance stats = %+v", stats)
+	}
+	if !result.Summary.HasChanges() {
+		t.Error("HasChanges() = false with a moved resource")
+	}
+
+	var buf bytes.Buffer
+	if err := writeDiffResult(result, DiffConfig{Format: "text"}, &buf); err != nil {
+		t.Fatalf("writeDiffResult(text) error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "MOVED RESOURCES (1)") || !strings.Contains(buf.String(), "  To:   prod (ocid1.compartment.oc1..prod)") {
+		t.Errorf("text diff does not report the move:\n%s", buf.String())
+	}
+
+	// Ignoring the compartment leaves nothing to report for the moved resource
+	result = DiffResources(oldResources, newResources, "old", "new", DiffConfig{IgnoreFields: []string{"CompartmentID"}})
+	if len(result.Moved) != 0 || result.Summary.Unchanged != 2 {
+		t.Errorf("got %d moved and %d unchanged resources with CompartmentID ignored", len(result.Moved), result.Summary.Unchanged)
+	}
+}
+
+func TestDiffAgainstBaseline(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	dir := t.TempDir()
+	baselineFile := filepath.Join(dir, "baseline.json")
+	current := []ResourceInfo{
+		{ResourceType: "BlockVolume", ResourceName: "data", OCID: "ocid1.volume.oc1..data", CompartmentID: "ocid1.compartment.oc1..a",
+			AdditionalInfo: map[string]interface{}{"size_in_gbs": int64(100), "attached_to": []string{"ocid1.instance.oc1..a"}}},
+	}
+	data, err := json.Marshal(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(baselineFile, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := LoadResourcesFromFile(baselineFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Values read back from the baseline have JSON types, which must not count as changes
+	result, err := DiffAgainstBaseline(baseline, current, baselineFile, "discovery", DiffConfig{})
+	if err != nil {
+		t.Fatalf("DiffAgainstBaseline() error = %v", err)
+	}
+	if result.Summary.HasChanges() {
+		t.Errorf("DiffAgainstBaseline() = %+v, want no changes", result.Summary)
+	}
+
+	current[0].AdditionalInfo["size_in_gbs"] = int64(200)
+	result, err = DiffAgainstBaseline(baseline, current, baselineFile, "discovery", DiffConfig{})
+	if err != nil {
+		t.Fatalf("DiffAgainstBaseline() error = %v", err)
+	}
+	if result.Summary.Modified != 1 || result.OldFile != baselineFile || result.NewFile != "discovery" {
+		t.Errorf("DiffAgainstBaseline() = %+v, want 1 modified resource", result)
+	}
+}
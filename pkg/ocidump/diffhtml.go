@@ -0,0 +1,214 @@
+package ocidump
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+)
+
+// diffHTMLTemplate renders a DiffResult as a self-contained HTML page
+// Each resource is a collapsible <details> element, so large diffs stay readable when the report
+// is attached to a change ticket; modified resources list their field changes as a table.
+var diffHTMLTemplate = template.Must(template.New("diff").Funcs(template.FuncMap{
+	"field":           func(field string) string { return strings.TrimPrefix(field, "AdditionalInfo.") },
+	"value":           formatValue,
+	"resourceSection": newDiffHTMLResource,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>OCI Resource Dump Comparison Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.2em; margin-top: 1.5em; }
+table { border-collapse: collapse; margin: 0.5em 0; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { font-family: SFMono-Regular, Consolas, monospace; font-size: 0.9em; word-break: break-all; }
+details { border-left: 4px solid #d0d7de; margin: 4px 0; padding: 4px 8px; }
+details > summary { cursor: pointer; }
+.added { border-color: #2da44e; background: #e6ffec; }
+.removed { border-color: #cf222e; background: #ffebe9; }
+.modified { border-color: #bf8700; background: #fff8c5; }
+.moved { border-color: #0969da; background: #ddf4ff; }
+.unchanged { border-color: #8c959f; }
+td.old { background: #ffebe9; }
+td.new { background: #e6ffec; }
+</style>
+</head>
+<body>
+<h1>OCI Resource Dump Comparison Report</h1>
+<table>
+<tr><th>Old</th><td><code>{{.Result.OldFile}}</code> ({{.Result.Summary.TotalOld}} resources)</td></tr>
+<tr><th>New</th><td><code>{{.Result.NewFile}}</code> ({{.Result.Summary.TotalNew}} resources)</td></tr>
+<tr><th>Generated</th><td>{{.Result.Timestamp}}</td></tr>
+</table>
+
+<h2>Summary</h2>
+<table>
+<tr><th>Added</th><th>Removed</th><th>Modified</th><th>Moved</th><th>Unchanged</th></tr>
+<tr><td>{{.Result.Summary.Added}}</td><td>{{.Result.Summary.Removed}}</td><td>{{.Result.Summary.Modified}}</td><td>{{.Result.Summary.Moved}}</td><td>{{.Result.Summary.Unchanged}}</td></tr>
+</table>
+{{- if .ResourceTypes}}
+
+<h2>Changes by Resource Type</h2>
+<table>
+<tr><th>Resource Type</th><th>Added</th><th>Removed</th><th>Modified</th><th>Moved</th><th>Unchanged</th></tr>
+{{- range .ResourceTypes}}
+<tr><td>{{.Name}}</td><td>{{.Added}}</td><td>{{.Removed}}</td><td>{{.Modified}}</td><td>{{.Moved}}</td><td>{{.Unchanged}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Originators}}
+
+<h2>Changes by Originator</h2>
+<table>
+<tr><th>Originator</th><th>Added</th><th>Removed</th><th>Modified</th><th>Moved</th></tr>
+{{- range .Originators}}
+<tr><td>{{.Name}}</td><td>{{.Added}}</td><td>{{.Removed}}</td><td>{{.Modified}}</td><td>{{.Moved}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Result.Added}}
+
+<h2>Added Resources ({{len .Result.Added}})</h2>
+{{- range .Result.Added}}
+{{template "resource" (resourceSection "added" .)}}
+{{- end}}
+{{- end}}
+{{- if .Result.Removed}}
+
+<h2>Removed Resources ({{len .Result.Removed}})</h2>
+{{- range .Result.Removed}}
+{{template "resource" (resourceSection "removed" .)}}
+{{- end}}
+{{- end}}
+{{- if .Result.Modified}}
+
+<h2>Modified Resources ({{len .Result.Modified}})</h2>
+{{- range .Result.Modified}}
+<details class="modified" open>
+<summary>~ {{.ResourceInfo.ResourceType}}: <strong>{{.ResourceInfo.ResourceName}}</strong> ({{len .Changes}} changes)</summary>
+<p><code>{{.ResourceInfo.OCID}}</code><br>Compartment: <code>{{.ResourceInfo.CompartmentID}}</code></p>
+<table>
+<tr><th>Field</th><th>Old</th><th>New</th></tr>
+{{- range .Changes}}
+<tr><td>{{field .Field}}</td><td class="old">{{value .OldValue}}</td><td class="new">{{value .NewValue}}</td></tr>
+{{- end}}
+</table>
+</details>
+{{- end}}
+{{- end}}
+{{- if .Result.Moved}}
+
+<h2>Moved Resources ({{len .Result.Moved}})</h2>
+{{- range .Result.Moved}}
+<details class="moved" open>
+<summary>&gt; {{.ResourceInfo.ResourceType}}: <strong>{{.ResourceInfo.ResourceName}}</strong></summary>
+<p><code>{{.ResourceInfo.OCID}}</code><br>From: <code>{{.From}}</code><br>To: <code>{{.To}}</code></p>
+{{- if .Changes}}
+<table>
+<tr><th>Field</th><th>Old</th><th>New</th></tr>
+{{- range .Changes}}
+<tr><td>{{field .Field}}</td><td class="old">{{value .OldValue}}</td><td class="new">{{value .NewValue}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</details>
+{{- end}}
+{{- end}}
+{{- if .Result.Unchanged}}
+
+<h2>Unchanged Resources ({{len .Result.Unchanged}})</h2>
+<details class="unchanged">
+<summary>Show unchanged resources</summary>
+<ul>
+{{- range .Result.Unchanged}}
+<li>{{.ResourceType}}: {{.ResourceName}} (<code>{{.OCID}}</code>)</li>
+{{- end}}
+</ul>
+</details>
+{{- end}}
+</body>
+</html>
+{{define "resource"}}<details class="{{.Class}}">
+<summary>{{.Marker}} {{.Resource.ResourceType}}: <strong>{{.Resource.ResourceName}}</strong></summary>
+<p><code>{{.Resource.OCID}}</code><br>Compartment: <code>{{.Resource.CompartmentID}}</code></p>
+{{- if .Info}}
+<table>
+{{- range .Info}}
+<tr><th>{{.Key}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</details>{{end}}
+`))
+
+// diffHTMLData is the data the HTML diff template is executed with
+type diffHTMLData struct {
+	Result        *DiffResult
+	ResourceTypes []diffHTMLStats
+	Originators   []diffHTMLStats
+}
+
+// diffHTMLStats is a row of the change tables, sorted by name
+type diffHTMLStats struct {
+	Name string
+	DiffStats
+}
+
+// diffHTMLResource is an added or removed resource with its additional info in key order
+type diffHTMLResource struct {
+	Class    string
+	Marker   string
+	Resource ResourceInfo
+	Info     []diffHTMLInfo
+}
+
+type diffHTMLInfo struct {
+	Key   string
+	Value string
+}
+
+// newDiffHTMLResource prepares an added ("added") or removed ("removed") resource for the template
+func newDiffHTMLResource(class string, resource ResourceInfo) diffHTMLResource {
+	section := diffHTMLResource{Class: class, Marker: "+", Resource: resource}
+	if class == "removed" {
+		section.Marker = "-"
+	}
+	keys := make([]string, 0, len(resource.AdditionalInfo))
+	for key := range resource.AdditionalInfo {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		section.Info = append(section.Info, diffHTMLInfo{Key: key, Value: formatValue(resource.AdditionalInfo[key])})
+	}
+	return section
+}
+
+// sortedDiffStats returns the statistics as rows sorted by name
+func sortedDiffStats(stats map[string]DiffStats) []diffHTMLStats {
+	rows := make([]diffHTMLStats, 0, len(stats))
+	for name, stat := range stats {
+		rows = append(rows, diffHTMLStats{Name: name, DiffStats: stat})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+	return rows
+}
+
+// OutputDiffHTML outputs the diff result as a color-coded HTML report with collapsible resources
+func OutputDiffHTML(result *DiffResult, writer io.Writer) error {
+	data := diffHTMLData{
+		Result:        result,
+		ResourceTypes: sortedDiffStats(result.Summary.ByResourceType),
+		Originators:   sortedDiffStats(result.Summary.ByOriginator),
+	}
+	if err := diffHTMLTemplate.Execute(writer, data); err != nil {
+		return fmt.Errorf("failed to render HTML diff report: %w", err)
+	}
+	return nil
+}
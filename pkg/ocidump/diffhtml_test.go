@@ -0,0 +1,59 @@
+package ocidump
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOutputDiffHTML(t *testing.T) {
+	result := &DiffResult{
+		Summary: DiffSummary{
+			TotalOld: 2, TotalNew: 2, Added: 1, Removed: 1, Modified: 1,
+			ByResourceType: map[string]DiffStats{
+				"VCN":             {Added: 1},
+				"ComputeInstance": {Removed: 1, Modified: 1},
+			},
+		},
+		Added: []ResourceInfo{{
+			ResourceType: "VCN", ResourceName: "vcn-<prod>", OCID: "ocid1.vcn.oc1..new",
+			AdditionalInfo: map[string]interface{}{"cidr_block": "10.0.0.0/16"},
+		}},
+		Removed: []ResourceInfo{{ResourceType: "ComputeInstance", ResourceName: "old-vm", OCID: "ocid1.instance.oc1..old"}},
+		Modified: []ModifiedResource{{
+			ResourceInfo: ResourceInfo{ResourceType: "ComputeInstance", ResourceName: "web-1", OCID: "ocid1.instance.oc1..web"},
+			Changes:      []FieldChange{{Field: "AdditionalInfo.shape", OldValue: "VM.Standard2.1", NewValue: "VM.Standard.E4.Flex"}},
+		}},
+		OldFile: "old.json",
+		NewFile: "new.json",
+	}
+
+	var buf bytes.Buffer
+	if err := writeDiffResult(result, DiffConfig{Format: "html"}, &buf); err != nil {
+		t.Fatalf("writeDiffResult(html) error = %v", err)
+	}
+	html := buf.String()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`<details class="added">`,
+		`<details class="removed">`,
+		`<details class="modified" open>`,
+		"vcn-&lt;prod&gt;",
+		"<tr><th>cidr_block</th><td>10.0.0.0/16</td></tr>",
+		`<tr><td>shape</td><td class="old">VM.Standard2.1</td><td class="new">VM.Standard.E4.Flex</td></tr>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML diff report does not contain %q", want)
+		}
+	}
+	if strings.Contains(html, "vcn-<prod>") {
+		t.Error("resource names must be HTML-escaped")
+	}
+	if strings.Index(html, "<td>ComputeInstance</td>") > strings.Index(html, "<td>VCN</td>") {
+		t.Error("resource types should be sorted by name")
+	}
+	if strings.Contains(html, "Unchanged Resources") {
+		t.Error("unchanged resources should only be listed in detailed mode")
+	}
+}
@@ -0,0 +1,48 @@
+package ocidump
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// diffTemplateFuncs are the functions available to user-supplied diff templates
+var diffTemplateFuncs = template.FuncMap{
+	"field": func(field string) string { return strings.TrimPrefix(field, "AdditionalInfo.") },
+	"value": formatValue,
+	"stats": sortedDiffStats,
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ParseDiffTemplate reads and parses a Go text/template file for diff output
+func ParseDiffTemplate(filename string) (*template.Template, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read diff template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(filename)).Funcs(diffTemplateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff template %s: %w", filename, err)
+	}
+	return tmpl, nil
+}
+
+// OutputDiffTemplate renders the diff result with a Go text/template file
+// The template is executed with the DiffResult; besides the built-in functions it can use
+// field (field name without the AdditionalInfo prefix), value (formatted value), stats
+// (summary map as rows sorted by Name), join, lower and upper.
+func OutputDiffTemplate(result *DiffResult, filename string, writer io.Writer) error {
+	tmpl, err := ParseDiffTemplate(filename)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(writer, result); err != nil {
+		return fmt.Errorf("failed to render diff template %s: %w", filename, err)
+	}
+	return nil
+}
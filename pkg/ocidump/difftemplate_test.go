@@ -0,0 +1,64 @@
+package ocidump
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOutputDiffTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templateFile := filepath.Join(dir, "cab.tmpl")
+	template := `Change request: {{.Summary.Added}} added, {{.Summary.Modified}} modified
+{{range stats .Summary.ByResourceType}}{{.Name}}={{.Added}}/{{.Modified}}
+{{end}}{{range .Modified}}{{upper .ResourceInfo.ResourceType}} {{.ResourceInfo.ResourceName}}:{{range .Changes}} {{field .Field}} {{value .OldValue}}->{{value .NewValue}}{{end}}
+{{end}}`
+	if err := os.WriteFile(templateFile, []byte(template), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := &DiffResult{
+		Summary: DiffSummary{
+			Added: 1, Modified: 1,
+			ByResourceType: map[string]DiffStats{"VCN": {Added: 1}, "Subnet": {Modified: 1}},
+		},
+		Added: []ResourceInfo{{ResourceType: "VCN", ResourceName: "vcn", OCID: "ocid1.vcn.oc1..new"}},
+		Modified: []ModifiedResource{{
+			ResourceInfo: ResourceInfo{ResourceType: "Subnet", ResourceName: "app"},
+			Changes:      []FieldChange{{Field: "AdditionalInfo.cidr_block", OldValue: "10.0.1.0/24", NewValue: "10.0.2.0/24"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeDiffResult(result, DiffConfig{Format: "json", Template: templateFile}, &buf); err != nil {
+		t.Fatalf("writeDiffResult() error = %v", err)
+	}
+	expected := "Change request: 1 added, 1 modified\nSubnet=0/1\nVCN=1/0\nSUBNET app: cidr_block 10.0.1.0/24->10.0.2.0/24\n"
+	if buf.String() != expected {
+		t.Errorf("template output =\n%s\nwant\n%s", buf.String(), expected)
+	}
+
+	// Summary-only output hands the template a result without resource listings
+	buf.Reset()
+	if err := writeDiffResult(result, DiffConfig{Template: templateFile, SummaryOnly: true}, &buf); err != nil {
+		t.Fatalf("writeDiffResult(summary) error = %v", err)
+	}
+	if expected := "Change request: 1 added, 1 modified\nSubnet=0/1\nVCN=1/0\n"; buf.String() != expected {
+		t.Errorf("summary template output =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
+
+func TestDiffConfig_ValidateTemplate(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.tmpl")
+	if err := os.WriteFile(broken, []byte("{{.Summary.Added"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, templateFile := range []string{broken, filepath.Join(dir, "missing.tmpl")} {
+		if err := (DiffConfig{Template: templateFile}).Validate(); err == nil {
+			t.Errorf("Validate() accepted template %s", templateFile)
+		}
+	}
+}
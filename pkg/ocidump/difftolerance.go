@@ -0,0 +1,63 @@
+package ocidump
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path"
+)
+
+// DiffTolerance marks a field of a resource type as volatile
+// Numeric changes of the field within the tolerance are ignored, e.g. metered sizes that grow a
+// little between every run. A change is ignored when it is within the absolute tolerance or within
+// the percentage of the old value; non-numeric changes are always reported.
+type DiffTolerance struct {
+	ResourceType string  `yaml:"resource_type"` // resource type, alias or group, e.g. "BlockVolume" or "storage"; empty for all types
+	Field        string  `yaml:"field"`         // field name as for ignore_fields, e.g. "size_in_gbs"; glob patterns allowed
+	Percent      float64 `yaml:"percent"`       // tolerance in percent of the old value
+	Absolute     float64 `yaml:"absolute"`      // tolerance in the unit of the field
+}
+
+// Validate checks the tolerance rule
+func (t DiffTolerance) Validate() error {
+	var problems []error
+	if t.Field == "" {
+		problems = append(problems, fmt.Errorf("field is required"))
+	} else if _, err := path.Match(t.Field, ""); err != nil {
+		problems = append(problems, fmt.Errorf("invalid field pattern '%s': %v", t.Field, err))
+	}
+	if t.Percent < 0 || t.Absolute < 0 {
+		problems = append(problems, fmt.Errorf("percent and absolute must not be negative"))
+	} else if t.Percent == 0 && t.Absolute == 0 {
+		problems = append(problems, fmt.Errorf("percent or absolute is required for field '%s' (use ignore_fields to ignore it completely)", t.Field))
+	}
+	return errors.Join(problems...)
+}
+
+// appliesTo reports whether the rule covers the changed field of the resource
+func (t DiffTolerance) appliesTo(resource ResourceInfo, field string) bool {
+	if t.ResourceType != "" && !matchesAnyResourceType(resource.ResourceType, expandResourceTypes([]string{t.ResourceType})) {
+		return false
+	}
+	return isIgnoredDiffField(field, []string{t.Field})
+}
+
+// withinDiffTolerance reports whether a change is small enough to be ignored by one of the rules
+func withinDiffTolerance(resource ResourceInfo, change FieldChange, tolerances []DiffTolerance) bool {
+	oldValue, oldOK := normalizeDiffValue(change.OldValue).(float64)
+	newValue, newOK := normalizeDiffValue(change.NewValue).(float64)
+	if !oldOK || !newOK {
+		return false
+	}
+
+	delta := math.Abs(newValue - oldValue)
+	for _, tolerance := range tolerances {
+		if !tolerance.appliesTo(resource, change.Field) {
+			continue
+		}
+		if delta <= tolerance.Absolute || delta <= tolerance.Percent/100*math.Abs(oldValue) {
+			return true
+		}
+	}
+	return false
+}
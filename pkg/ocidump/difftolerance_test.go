@@ -0,0 +1,65 @@
+package ocidump
+
+import "testing"
+
+func TestDiffTolerance_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance DiffTolerance
+		wantErr   bool
+	}{
+		{"percent", DiffTolerance{ResourceType: "BlockVolume", Field: "size_in_gbs", Percent: 5}, false},
+		{"absolute", DiffTolerance{Field: "size_in_*", Absolute: 10}, false},
+		{"missing field", DiffTolerance{Percent: 5}, true},
+		{"invalid pattern", DiffTolerance{Field: "[", Percent: 5}, true},
+		{"negative", DiffTolerance{Field: "size_in_gbs", Percent: -1}, true},
+		{"no tolerance", DiffTolerance{Field: "size_in_gbs"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tolerance.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiffResources_Tolerances(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+
+	volume := func(ocid string, size interface{}) ResourceInfo {
+		return ResourceInfo{ResourceType: "BlockVolume", ResourceName: ocid, OCID: ocid, AdditionalInfo: map[string]interface{}{"size_in_gbs": size}}
+	}
+	oldResources := []ResourceInfo{
+		volume("ocid1.volume.oc1..small", float64(100)),
+		volume("ocid1.volume.oc1..large", float64(100)),
+		volume("ocid1.volume.oc1..text", "100"),
+		{ResourceType: "BootVolume", ResourceName: "boot", OCID: "ocid1.bootvolume.oc1..boot", AdditionalInfo: map[string]interface{}{"size_in_gbs": float64(100)}},
+	}
+	newResources := []ResourceInfo{
+		volume("ocid1.volume.oc1..small", int64(104)),
+		volume("ocid1.volume.oc1..large", float64(110)),
+		volume("ocid1.volume.oc1..text", "101"),
+		{ResourceType: "BootVolume", ResourceName: "boot", OCID: "ocid1.bootvolume.oc1..boot", AdditionalInfo: map[string]interface{}{"size_in_gbs": float64(101)}},
+	}
+
+	config := DiffConfig{Tolerances: []DiffTolerance{{ResourceType: "block_volumes", Field: "size_in_gbs", Percent: 5}}}
+	result := DiffResources(oldResources, newResources, "old", "new", config)
+
+	modified := make(map[string]bool)
+	for _, resource := range result.Modified {
+		modified[resource.ResourceInfo.OCID] = true
+	}
+	if modified["ocid1.volume.oc1..small"] {
+		t.Error("a 4% size change should be within the 5% tolerance")
+	}
+	for _, ocid := range []string{"ocid1.volume.oc1..large", "ocid1.volume.oc1..text", "ocid1.bootvolume.oc1..boot"} {
+		if !modified[ocid] {
+			t.Errorf("%s should be reported as modified", ocid)
+		}
+	}
+	if result.Summary.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", result.Summary.Unchanged)
+	}
+}
@@ -0,0 +1,5625 @@
+package ocidump
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gosuri/uiprogress"
+	"github.com/oracle/oci-go-sdk/v65/apigateway"
+	"github.com/oracle/oci-go-sdk/v65/blockchain"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/oracle/oci-go-sdk/v65/databasemanagement"
+	"github.com/oracle/oci-go-sdk/v65/filestorage"
+	"github.com/oracle/oci-go-sdk/v65/functions"
+	"github.com/oracle/oci-go-sdk/v65/healthchecks"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/opensearch"
+	"github.com/oracle/oci-go-sdk/v65/opsi"
+	"github.com/oracle/oci-go-sdk/v65/osmanagementhub"
+	"github.com/oracle/oci-go-sdk/v65/streaming"
+)
+
+// createResourceInfo creates a ResourceInfo with optimized compartment name resolution
+func createResourceInfo(ctx context.Context, resourceType, resourceName, ocid, compartmentID string, additionalInfo map[string]interface{}, cache *ResourceNameCache) ResourceInfo {
+	// Optimized compartment name lookup with context timeout
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	compartmentName := cache.GetCompartmentName(ctxWithTimeout, compartmentID)
+
+	return ResourceInfo{
+		ResourceType:    resourceType,
+		CompartmentName: compartmentName,
+		ResourceName:    resourceName,
+		OCID:            ocid,
+		CompartmentID:   compartmentID,
+		AdditionalInfo:  additionalInfo,
+	}
+}
+
+// withTags returns the resource with its freeform and defined tags set
+// Empty tag maps are normalized to nil so they are omitted from JSON output.
+// The Oracle-Tags.CreatedBy default tag is also recorded as created_by for originator classification.
+func (r ResourceInfo) withTags(freeformTags map[string]string, definedTags map[string]map[string]interface{}) ResourceInfo {
+	if len(freeformTags) > 0 {
+		r.FreeformTags = freeformTags
+	}
+	if len(definedTags) > 0 {
+		r.DefinedTags = definedTags
+	}
+	if createdBy, ok := definedTags["Oracle-Tags"]["CreatedBy"].(string); ok && createdBy != "" {
+		if r.AdditionalInfo == nil {
+			r.AdditionalInfo = make(map[string]interface{})
+		}
+		r.AdditionalInfo["created_by"] = createdBy
+	}
+	return r
+}
+
+// withLifecycle returns the resource with its creation time (RFC3339) and lifecycle state set
+func (r ResourceInfo) withLifecycle(timeCreated *common.SDKTime, lifecycleState string) ResourceInfo {
+	if timeCreated != nil {
+		r.TimeCreated = timeCreated.Format(time.RFC3339)
+	}
+	r.LifecycleState = lifecycleState
+	return r
+}
+
+// isRetriableError checks if the error means the resource type is not available in the compartment
+// OCI service errors with HTTP status 401, 403 or 404 (non-existent resource, permission issue, etc.)
+// should not cause the entire program to fail; the message text is not inspected.
+func isRetriableError(err error) bool {
+	var serviceErr common.ServiceError
+	if !errors.As(err, &serviceErr) {
+		return false
+	}
+	switch serviceErr.GetHTTPStatusCode() {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return true
+	}
+	return false
+}
+
+// isNotFoundError checks if the error is an OCI service error with HTTP status 404
+func isNotFoundError(err error) bool {
+	serviceErr, ok := common.IsServiceError(err)
+	return ok && serviceErr.GetHTTPStatusCode() == 404
+}
+
+// forEachConcurrently calls fn for each index below n with at most workers calls at once
+func forEachConcurrently(n, workers int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// discoverComputeInstances discovers all compute instances in a compartment
+func discoverComputeInstances(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allInstances []core.Instance
+
+	logger.Debug("Starting compute instances discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all instances
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching compute instances page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListInstancesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ComputeClient.ListInstances(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allInstances = append(allInstances, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// Resolve the VNICs of all instances in one batch
+	var instanceVnics map[string][]string
+	var vnics map[string]core.Vnic
+	if len(allInstances) > 0 {
+		resolver := newVNICResolver(clients)
+		var err error
+		instanceVnics, err = resolver.instanceVnics(ctx, compartmentID)
+		if err != nil {
+			logger.Verbose("Error listing VNIC attachments in compartment %s: %v", compartmentID, err)
+		}
+		var vnicIDs []string
+		for _, instance := range allInstances {
+			if instance.Id != nil && instance.LifecycleState != core.InstanceLifecycleStateTerminated {
+				vnicIDs = append(vnicIDs, instanceVnics[*instance.Id]...)
+			}
+		}
+		vnics = resolver.vnics(ctx, vnicIDs)
+	}
+
+	for _, instance := range allInstances {
+		if instance.LifecycleState != core.InstanceLifecycleStateTerminated {
+			name := ""
+			if instance.DisplayName != nil {
+				name = *instance.DisplayName
+			}
+			ocid := ""
+			if instance.Id != nil {
+				ocid = *instance.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Get primary IP address
+			for _, vnicID := range instanceVnics[ocid] {
+				vnic, ok := vnics[vnicID]
+				if ok && vnic.IsPrimary != nil && *vnic.IsPrimary {
+					if vnic.PrivateIp != nil {
+						additionalInfo["primary_ip"] = *vnic.PrivateIp
+					}
+					if vnic.SubnetId != nil {
+						additionalInfo["subnet_id"] = *vnic.SubnetId
+					}
+					break
+				}
+			}
+
+			// Add shape information
+			if instance.Shape != nil {
+				additionalInfo["shape"] = *instance.Shape
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "ComputeInstance", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(instance.FreeformTags, instance.DefinedTags).
+				withLifecycle(instance.TimeCreated, string(instance.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d compute instances in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverVCNs discovers all Virtual Cloud Networks in a compartment
+func discoverVCNs(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allVcns []core.Vcn
+
+	logger.Debug("Starting VCN discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all VCNs
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching VCNs page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListVcnsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.VirtualNetworkClient.ListVcns(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allVcns = append(allVcns, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, vcn := range allVcns {
+		if vcn.LifecycleState != core.VcnLifecycleStateTerminated {
+			name := ""
+			if vcn.DisplayName != nil {
+				name = *vcn.DisplayName
+			}
+			ocid := ""
+			if vcn.Id != nil {
+				ocid = *vcn.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add CIDR blocks
+			if len(vcn.CidrBlocks) > 0 {
+				additionalInfo["cidr_blocks"] = vcn.CidrBlocks
+			}
+
+			// Add DNS label
+			if vcn.DnsLabel != nil {
+				additionalInfo["dns_label"] = *vcn.DnsLabel
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "VCN", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(vcn.FreeformTags, vcn.DefinedTags).
+				withLifecycle(vcn.TimeCreated, string(vcn.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d VCNs in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverSubnets discovers all subnets in a compartment
+func discoverSubnets(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allSubnets []core.Subnet
+
+	logger.Debug("Starting subnet discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all subnets
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching subnets page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListSubnetsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.VirtualNetworkClient.ListSubnets(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allSubnets = append(allSubnets, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, subnet := range allSubnets {
+		if subnet.LifecycleState != core.SubnetLifecycleStateTerminated {
+			name := ""
+			if subnet.DisplayName != nil {
+				name = *subnet.DisplayName
+			}
+			ocid := ""
+			if subnet.Id != nil {
+				ocid = *subnet.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add CIDR block
+			if subnet.CidrBlock != nil {
+				additionalInfo["cidr_block"] = *subnet.CidrBlock
+			}
+
+			// Add availability domain
+			if subnet.AvailabilityDomain != nil {
+				additionalInfo["availability_domain"] = *subnet.AvailabilityDomain
+			}
+
+			// Add parent VCN
+			if subnet.VcnId != nil {
+				additionalInfo["vcn_id"] = *subnet.VcnId
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "Subnet", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(subnet.FreeformTags, subnet.DefinedTags).
+				withLifecycle(subnet.TimeCreated, string(subnet.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d subnets in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverBlockVolumes discovers all block volumes in a compartment
+func discoverBlockVolumes(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allVolumes []core.Volume
+
+	logger.Debug("Starting block volume discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all volumes
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching block volumes page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListVolumesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.BlockStorageClient.ListVolumes(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allVolumes = append(allVolumes, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, volume := range allVolumes {
+		if volume.LifecycleState != core.VolumeLifecycleStateTerminated {
+			name := ""
+			if volume.DisplayName != nil {
+				name = *volume.DisplayName
+			}
+			ocid := ""
+			if volume.Id != nil {
+				ocid = *volume.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add size in GBs
+			if volume.SizeInGBs != nil {
+				additionalInfo["size_in_gbs"] = *volume.SizeInGBs
+			}
+
+			// Add volume performance (VPUs per GB)
+			if volume.VpusPerGB != nil {
+				additionalInfo["vpus_per_gb"] = *volume.VpusPerGB
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "BlockVolume", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(volume.FreeformTags, volume.DefinedTags).
+				withLifecycle(volume.TimeCreated, string(volume.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d block volumes in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverObjectStorageBuckets discovers all object storage buckets in a compartment
+func discoverObjectStorageBuckets(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting object storage bucket discovery for compartment: %s", compartmentID)
+
+	// Get the namespace first
+	req := objectstorage.GetNamespaceRequest{}
+	resp, err := clients.ObjectStorageClient.GetNamespace(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := *resp.Value
+
+	// List buckets with pagination
+	var allBuckets []objectstorage.BucketSummary
+	var page *string
+	for {
+		listReq := objectstorage.ListBucketsRequest{
+			NamespaceName: common.String(namespace),
+			CompartmentId: common.String(compartmentID),
+			Fields:        []objectstorage.ListBucketsFieldsEnum{objectstorage.ListBucketsFieldsTags},
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		listResp, err := clients.ObjectStorageClient.ListBuckets(ctx, listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		allBuckets = append(allBuckets, listResp.Items...)
+
+		if listResp.OpcNextPage == nil {
+			break
+		}
+		page = listResp.OpcNextPage
+	}
+
+	for _, bucket := range allBuckets {
+		name := ""
+		if bucket.Name != nil {
+			name = *bucket.Name
+		}
+
+		additionalInfo := make(map[string]interface{})
+		additionalInfo["namespace"] = namespace
+
+		// Note: Storage tier is not available in BucketSummary
+
+		// Add data-retention configuration (one extra API call per policy kind)
+		if clients.Options.Detail {
+			addBucketRetentionPolicies(ctx, clients, namespace, name, additionalInfo)
+		}
+
+		// Note: Object Storage buckets don't have traditional OCIDs like other resources
+		// The bucket name serves as the identifier
+		resources = append(resources, createResourceInfo(ctx, "ObjectStorageBucket", name, fmt.Sprintf("bucket:%s:%s", namespace, name), compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(bucket.FreeformTags, bucket.DefinedTags).
+			withLifecycle(bucket.TimeCreated, ""))
+	}
+
+	logger.Verbose("Found %d object storage buckets in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// addBucketRetentionPolicies adds lifecycle and replication policy presence of a bucket to additionalInfo
+func addBucketRetentionPolicies(ctx context.Context, clients *OCIClients, namespace, bucketName string, additionalInfo map[string]interface{}) {
+	lifecycleReq := objectstorage.GetObjectLifecyclePolicyRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucketName),
+	}
+	lifecycleResp, err := clients.ObjectStorageClient.GetObjectLifecyclePolicy(ctx, lifecycleReq)
+	if err == nil {
+		enabledRules := 0
+		for _, rule := range lifecycleResp.Items {
+			if rule.IsEnabled != nil && *rule.IsEnabled {
+				enabledRules++
+			}
+		}
+		additionalInfo["has_lifecycle_policy"] = len(lifecycleResp.Items) > 0
+		additionalInfo["lifecycle_rule_count"] = len(lifecycleResp.Items)
+		additionalInfo["lifecycle_enabled_rule_count"] = enabledRules
+	} else if isNotFoundError(err) {
+		// Buckets without a lifecycle policy return 404
+		additionalInfo["has_lifecycle_policy"] = false
+	} else {
+		logger.Verbose("Error getting lifecycle policy for bucket %s: %v", bucketName, err)
+	}
+
+	policies, err := listBucketReplicationPolicies(ctx, clients, namespace, bucketName)
+	if err == nil {
+		additionalInfo["has_replication_policy"] = len(policies) > 0
+		if len(policies) > 0 {
+			var destinations []string
+			for _, policy := range policies {
+				if policy.DestinationRegionName != nil && policy.DestinationBucketName != nil {
+					destinations = append(destinations, *policy.DestinationRegionName+"/"+*policy.DestinationBucketName)
+				}
+			}
+			additionalInfo["replication_destinations"] = destinations
+		}
+	} else {
+		logger.Verbose("Error listing replication policies for bucket %s: %v", bucketName, err)
+	}
+}
+
+// listBucketReplicationPolicies lists all replication policies of a bucket
+func listBucketReplicationPolicies(ctx context.Context, clients *OCIClients, namespace, bucketName string) ([]objectstorage.ReplicationPolicySummary, error) {
+	var policies []objectstorage.ReplicationPolicySummary
+	var page *string
+	for {
+		req := objectstorage.ListReplicationPoliciesRequest{
+			NamespaceName: common.String(namespace),
+			BucketName:    common.String(bucketName),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+		resp, err := clients.ObjectStorageClient.ListReplicationPolicies(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, resp.Items...)
+		if resp.OpcNextPage == nil {
+			return policies, nil
+		}
+		page = resp.OpcNextPage
+	}
+}
+
+// discoverOKEClusters discovers all OKE clusters in a compartment
+func discoverOKEClusters(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allClusters []containerengine.ClusterSummary
+
+	logger.Debug("Starting OKE cluster discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all clusters
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching OKE clusters page %d for compartment: %s", pageCount, compartmentID)
+		req := containerengine.ListClustersRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ContainerEngineClient.ListClusters(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allClusters = append(allClusters, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, cluster := range allClusters {
+		if cluster.LifecycleState != containerengine.ClusterLifecycleStateDeleted {
+			name := ""
+			if cluster.Name != nil {
+				name = *cluster.Name
+			}
+			ocid := ""
+			if cluster.Id != nil {
+				ocid = *cluster.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add Kubernetes version
+			if cluster.KubernetesVersion != nil {
+				additionalInfo["kubernetes_version"] = *cluster.KubernetesVersion
+			}
+
+			// Creation time is part of the cluster metadata
+			var timeCreated *common.SDKTime
+			if cluster.Metadata != nil {
+				timeCreated = cluster.Metadata.TimeCreated
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "OKECluster", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(cluster.FreeformTags, cluster.DefinedTags).
+				withLifecycle(timeCreated, string(cluster.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d OKE clusters in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverLoadBalancers discovers all load balancers in a compartment
+func discoverLoadBalancers(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allLoadBalancers []loadbalancer.LoadBalancer
+
+	logger.Debug("Starting load balancer discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all load balancers
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching load balancers page %d for compartment: %s", pageCount, compartmentID)
+		req := loadbalancer.ListLoadBalancersRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit64(maxPageSize),
+		}
+
+		resp, err := clients.LoadBalancerClient.ListLoadBalancers(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allLoadBalancers = append(allLoadBalancers, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, lb := range allLoadBalancers {
+		if lb.LifecycleState != loadbalancer.LoadBalancerLifecycleStateDeleted {
+			name := ""
+			if lb.DisplayName != nil {
+				name = *lb.DisplayName
+			}
+			ocid := ""
+			if lb.Id != nil {
+				ocid = *lb.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add shape
+			if lb.ShapeName != nil {
+				additionalInfo["shape"] = *lb.ShapeName
+			}
+
+			// Add IP addresses
+			if len(lb.IpAddresses) > 0 {
+				var ipAddresses []string
+				for _, ip := range lb.IpAddresses {
+					if ip.IpAddress != nil {
+						ipAddresses = append(ipAddresses, *ip.IpAddress)
+					}
+				}
+				additionalInfo["ip_addresses"] = ipAddresses
+			}
+
+			// Add subnets
+			if len(lb.SubnetIds) > 0 {
+				additionalInfo["subnet_ids"] = lb.SubnetIds
+			}
+
+			// Add listener, backend set and certificate configuration
+			if clients.Options.Detail {
+				addLoadBalancerDetails(ctx, clients, lb, additionalInfo)
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "LoadBalancer", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(lb.FreeformTags, lb.DefinedTags).
+				withLifecycle(lb.TimeCreated, string(lb.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d load balancers in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// addLoadBalancerDetails adds listener count, backend counts per backend set and certificate expiry to additionalInfo
+func addLoadBalancerDetails(ctx context.Context, clients *OCIClients, lb loadbalancer.LoadBalancer, additionalInfo map[string]interface{}) {
+	additionalInfo["listener_count"] = len(lb.Listeners)
+
+	backendSets := make(map[string]int)
+	for name, backendSet := range lb.BackendSets {
+		backendSets[name] = len(backendSet.Backends)
+	}
+	additionalInfo["backend_sets"] = backendSets
+
+	if lb.Id == nil {
+		return
+	}
+
+	certReq := loadbalancer.ListCertificatesRequest{
+		LoadBalancerId: lb.Id,
+	}
+	certResp, err := clients.LoadBalancerClient.ListCertificates(ctx, certReq)
+	if err != nil {
+		logger.Verbose("Error listing certificates for load balancer %s: %v", *lb.Id, err)
+		return
+	}
+
+	var certificates []map[string]interface{}
+	for _, certificate := range certResp.Items {
+		certInfo := make(map[string]interface{})
+		if certificate.CertificateName != nil {
+			certInfo["name"] = *certificate.CertificateName
+		}
+		if certificate.PublicCertificate != nil {
+			if expiry, err := parseCertificateExpiry(*certificate.PublicCertificate); err == nil {
+				certInfo["expires"] = expiry.Format(time.RFC3339)
+			} else {
+				logger.Debug("Could not parse certificate %v: %v", certInfo["name"], err)
+			}
+		}
+		certificates = append(certificates, certInfo)
+	}
+	if len(certificates) > 0 {
+		additionalInfo["certificates"] = certificates
+	}
+}
+
+// parseCertificateExpiry returns the NotAfter time of the first certificate in a PEM bundle
+func parseCertificateExpiry(pemData string) (time.Time, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return time.Time{}, fmt.Errorf("no PEM data found")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert.NotAfter, nil
+}
+
+// discoverDatabases discovers all database systems in a compartment
+func discoverDatabases(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allDbSystems []database.DbSystemSummary
+
+	logger.Debug("Starting database system discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all database systems
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching database systems page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListDbSystemsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListDbSystems(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allDbSystems = append(allDbSystems, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, dbSystem := range allDbSystems {
+		if dbSystem.LifecycleState != database.DbSystemSummaryLifecycleStateTerminated {
+			name := ""
+			if dbSystem.DisplayName != nil {
+				name = *dbSystem.DisplayName
+			}
+			ocid := ""
+			if dbSystem.Id != nil {
+				ocid = *dbSystem.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add shape
+			if dbSystem.Shape != nil {
+				additionalInfo["shape"] = *dbSystem.Shape
+			}
+
+			// Add database edition
+			additionalInfo["database_edition"] = string(dbSystem.DatabaseEdition)
+
+			resources = append(resources, createResourceInfo(ctx,
+				"DatabaseSystem", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(dbSystem.FreeformTags, dbSystem.DefinedTags).
+				withLifecycle(dbSystem.TimeCreated, string(dbSystem.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d database systems in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverDRGs discovers all Dynamic Routing Gateways in a compartment
+func discoverDRGs(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allDrgs []core.Drg
+
+	logger.Debug("Starting DRG discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all DRGs
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching DRGs page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListDrgsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.VirtualNetworkClient.ListDrgs(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allDrgs = append(allDrgs, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, drg := range allDrgs {
+		if drg.LifecycleState != core.DrgLifecycleStateTerminated {
+			name := ""
+			if drg.DisplayName != nil {
+				name = *drg.DisplayName
+			}
+			ocid := ""
+			if drg.Id != nil {
+				ocid = *drg.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			resources = append(resources, createResourceInfo(ctx, "DRG", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(drg.FreeformTags, drg.DefinedTags).
+				withLifecycle(drg.TimeCreated, string(drg.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d DRGs in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverAutonomousDatabases discovers all autonomous databases in a compartment
+func discoverAutonomousDatabases(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allAutonomousDBs []database.AutonomousDatabaseSummary
+
+	logger.Debug("Starting autonomous database discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all autonomous databases
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching autonomous databases page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListAutonomousDatabasesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListAutonomousDatabases(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allAutonomousDBs = append(allAutonomousDBs, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, autonomousDB := range allAutonomousDBs {
+		if autonomousDB.LifecycleState != database.AutonomousDatabaseSummaryLifecycleStateTerminated {
+			name := ""
+			if autonomousDB.DisplayName != nil {
+				name = *autonomousDB.DisplayName
+			}
+			ocid := ""
+			if autonomousDB.Id != nil {
+				ocid = *autonomousDB.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add workload type
+			additionalInfo["workload_type"] = string(autonomousDB.DbWorkload)
+
+			// Add CPU core count
+			if autonomousDB.CpuCoreCount != nil {
+				additionalInfo["cpu_core_count"] = *autonomousDB.CpuCoreCount
+			}
+
+			// Add data storage size
+			if autonomousDB.DataStorageSizeInTBs != nil {
+				additionalInfo["data_storage_size_in_tbs"] = *autonomousDB.DataStorageSizeInTBs
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "AutonomousDatabase", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(autonomousDB.FreeformTags, autonomousDB.DefinedTags).
+				withLifecycle(autonomousDB.TimeCreated, string(autonomousDB.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d autonomous databases in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverFunctions discovers all functions in a compartment
+func discoverFunctions(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting functions discovery for compartment: %s", compartmentID)
+
+	// First, get all applications
+	var allApplications []functions.ApplicationSummary
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching function applications page %d for compartment: %s", pageCount, compartmentID)
+		req := functions.ListApplicationsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(50),
+		}
+
+		resp, err := clients.FunctionsClient.ListApplications(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allApplications = append(allApplications, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// Then, get all functions for each application
+	for _, app := range allApplications {
+		if app.LifecycleState != functions.ApplicationLifecycleStateDeleted {
+			var allFunctions []functions.FunctionSummary
+			var funcPage *string
+			funcPageCount := 0
+			for {
+				funcPageCount++
+				logger.Debug("Fetching functions for application %s, page %d", *app.DisplayName, funcPageCount)
+				funcReq := functions.ListFunctionsRequest{
+					ApplicationId: app.Id,
+					Page:          funcPage,
+					Limit:         clients.pageLimit(50),
+				}
+
+				funcResp, err := clients.FunctionsClient.ListFunctions(ctx, funcReq)
+
+				if err != nil {
+					logger.Verbose("Error listing functions for application %s: %v", *app.DisplayName, err)
+					break
+				}
+
+				allFunctions = append(allFunctions, funcResp.Items...)
+
+				if funcResp.OpcNextPage == nil {
+					break
+				}
+				funcPage = funcResp.OpcNextPage
+			}
+
+			for _, function := range allFunctions {
+				if function.LifecycleState != functions.FunctionLifecycleStateDeleted {
+					name := ""
+					if function.DisplayName != nil {
+						name = *function.DisplayName
+					}
+					ocid := ""
+					if function.Id != nil {
+						ocid = *function.Id
+					}
+
+					additionalInfo := make(map[string]interface{})
+
+					// Add application name
+					if app.DisplayName != nil {
+						additionalInfo["application_name"] = *app.DisplayName
+					}
+
+					// Add runtime
+					if function.Image != nil {
+						additionalInfo["image"] = *function.Image
+					}
+
+					// Add memory in MBs
+					if function.MemoryInMBs != nil {
+						additionalInfo["memory_in_mbs"] = *function.MemoryInMBs
+					}
+
+					resources = append(resources, createResourceInfo(ctx, "Function", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+						withTags(function.FreeformTags, function.DefinedTags).
+						withLifecycle(function.TimeCreated, string(function.LifecycleState)))
+				}
+			}
+		}
+	}
+
+	logger.Verbose("Found %d functions in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverAPIGateways discovers all API gateways in a compartment
+func discoverAPIGateways(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allGateways []apigateway.GatewaySummary
+
+	logger.Debug("Starting API gateway discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all API gateways
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching API gateways page %d for compartment: %s", pageCount, compartmentID)
+		req := apigateway.ListGatewaysRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.APIGatewayClient.ListGateways(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allGateways = append(allGateways, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, gateway := range allGateways {
+		if gateway.LifecycleState != apigateway.GatewayLifecycleStateDeleted {
+			name := ""
+			if gateway.DisplayName != nil {
+				name = *gateway.DisplayName
+			}
+			ocid := ""
+			if gateway.Id != nil {
+				ocid = *gateway.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Note: Endpoint is not available in GatewaySummary, would need to fetch gateway details
+
+			// Note: Would need to use different API client to get deployment information
+
+			resources = append(resources, createResourceInfo(ctx, "APIGateway", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(gateway.FreeformTags, gateway.DefinedTags).
+				withLifecycle(gateway.TimeCreated, string(gateway.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d API gateways in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// getAvailabilityDomains retrieves all availability domains for a compartment
+func getAvailabilityDomains(ctx context.Context, clients *OCIClients, compartmentID string) ([]identity.AvailabilityDomain, error) {
+	logger.Debug("Getting availability domains for compartment: %s", compartmentID)
+
+	req := identity.ListAvailabilityDomainsRequest{
+		CompartmentId: common.String(compartmentID),
+	}
+
+	resp, err := clients.IdentityClient.ListAvailabilityDomains(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability domains: %w", err)
+	}
+
+	logger.Debug("Found %d availability domains", len(resp.Items))
+	return resp.Items, nil
+}
+
+// discoverFileStorageSystems discovers all file storage systems in a compartment
+func discoverFileStorageSystems(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting file storage system discovery for compartment: %s", compartmentID)
+
+	// Get all availability domains for this compartment
+	availabilityDomains, err := getAvailabilityDomains(ctx, clients, compartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability domains: %w", err)
+	}
+
+	// Search file systems in each availability domain
+	for _, ad := range availabilityDomains {
+		if ad.Name == nil {
+			continue
+		}
+
+		adName := *ad.Name
+		logger.Debug("Searching file systems in availability domain: %s", adName)
+
+		var allFileSystems []filestorage.FileSystemSummary
+
+		// Implement pagination to get all file systems in this AD
+		var page *string
+		pageCount := 0
+		for {
+			pageCount++
+			logger.Debug("Fetching file systems page %d for compartment: %s, AD: %s", pageCount, compartmentID, adName)
+			req := filestorage.ListFileSystemsRequest{
+				CompartmentId:      common.String(compartmentID),
+				AvailabilityDomain: common.String(adName),
+				Page:               page,
+				Limit:              clients.pageLimit(maxPageSize),
+			}
+
+			resp, err := clients.FileStorageClient.ListFileSystems(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing file systems in AD %s: %v", adName, err)
+				break // Continue with next AD instead of failing completely
+			}
+
+			allFileSystems = append(allFileSystems, resp.Items...)
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+
+		// Process file systems found in this AD
+		for _, fileSystem := range allFileSystems {
+			if fileSystem.LifecycleState != filestorage.FileSystemSummaryLifecycleStateDeleted {
+				name := ""
+				if fileSystem.DisplayName != nil {
+					name = *fileSystem.DisplayName
+				}
+				ocid := ""
+				if fileSystem.Id != nil {
+					ocid = *fileSystem.Id
+				}
+
+				additionalInfo := make(map[string]interface{})
+
+				// Add metered bytes (current storage usage)
+				if fileSystem.MeteredBytes != nil {
+					sizeInGB := float64(*fileSystem.MeteredBytes) / (1024 * 1024 * 1024)
+					additionalInfo["size_in_gb"] = fmt.Sprintf("%.2f", sizeInGB)
+				}
+
+				// Add availability domain
+				additionalInfo["availability_domain"] = adName
+
+				resources = append(resources, createResourceInfo(ctx, "FileStorageSystem", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+					withTags(fileSystem.FreeformTags, fileSystem.DefinedTags).
+					withLifecycle(fileSystem.TimeCreated, string(fileSystem.LifecycleState)))
+			}
+		}
+	}
+
+	logger.Verbose("Found %d file storage systems in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverNetworkLoadBalancers discovers all network load balancers in a compartment
+func discoverNetworkLoadBalancers(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allNLBs []networkloadbalancer.NetworkLoadBalancerSummary
+
+	logger.Debug("Starting network load balancer discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all network load balancers
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching network load balancers page %d for compartment: %s", pageCount, compartmentID)
+		req := networkloadbalancer.ListNetworkLoadBalancersRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.NetworkLoadBalancerClient.ListNetworkLoadBalancers(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allNLBs = append(allNLBs, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, nlb := range allNLBs {
+		if nlb.LifecycleState != networkloadbalancer.LifecycleStateDeleted {
+			name := ""
+			if nlb.DisplayName != nil {
+				name = *nlb.DisplayName
+			}
+			ocid := ""
+			if nlb.Id != nil {
+				ocid = *nlb.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Note: Health status not available in NetworkLoadBalancerSummary
+
+			// Add IP addresses
+			if len(nlb.IpAddresses) > 0 {
+				var ipAddresses []string
+				for _, ip := range nlb.IpAddresses {
+					if ip.IpAddress != nil {
+						ipAddresses = append(ipAddresses, *ip.IpAddress)
+					}
+				}
+				additionalInfo["ip_addresses"] = ipAddresses
+			}
+
+			// Add subnet
+			if nlb.SubnetId != nil {
+				additionalInfo["subnet_id"] = *nlb.SubnetId
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "NetworkLoadBalancer", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(nlb.FreeformTags, nlb.DefinedTags).
+				withLifecycle(nlb.TimeCreated, string(nlb.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d network load balancers in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverStreams discovers all streams in a compartment
+func discoverStreams(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allStreams []streaming.StreamSummary
+
+	logger.Debug("Starting stream discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all streams
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching streams page %d for compartment: %s", pageCount, compartmentID)
+		req := streaming.ListStreamsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(50),
+		}
+
+		resp, err := clients.StreamingClient.ListStreams(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allStreams = append(allStreams, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// Retention is not part of the summary and needs one GetStream call per stream
+	var retention map[string]int
+	if clients.Options.Detail {
+		retention = fetchStreamRetention(ctx, clients, allStreams)
+	}
+
+	for _, stream := range allStreams {
+		if stream.LifecycleState != streaming.StreamSummaryLifecycleStateDeleted {
+			name := ""
+			if stream.Name != nil {
+				name = *stream.Name
+			}
+			ocid := ""
+			if stream.Id != nil {
+				ocid = *stream.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add partitions
+			if stream.Partitions != nil {
+				additionalInfo["partitions"] = *stream.Partitions
+			}
+
+			// Link stream to its stream pool
+			if stream.StreamPoolId != nil {
+				additionalInfo["stream_pool_id"] = *stream.StreamPoolId
+			}
+
+			// Add retention in hours
+			if hours, ok := retention[ocid]; ok {
+				additionalInfo["retention_in_hours"] = hours
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "Stream", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(stream.FreeformTags, stream.DefinedTags).
+				withLifecycle(stream.TimeCreated, string(stream.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d streams in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// streamDetailWorkers is the number of concurrent GetStream calls per compartment
+const streamDetailWorkers = 5
+
+// fetchStreamRetention gets the retention in hours of the streams concurrently, by stream ID
+// Streams whose details cannot be fetched are logged and left out.
+func fetchStreamRetention(ctx context.Context, clients *OCIClients, streams []streaming.StreamSummary) map[string]int {
+	var ids []string
+	for _, stream := range streams {
+		if stream.Id != nil && stream.LifecycleState != streaming.StreamSummaryLifecycleStateDeleted {
+			ids = append(ids, *stream.Id)
+		}
+	}
+
+	var mu sync.Mutex
+	retention := make(map[string]int, len(ids))
+	forEachConcurrently(len(ids), streamDetailWorkers, func(i int) {
+		resp, err := clients.StreamingClient.GetStream(ctx, streaming.GetStreamRequest{StreamId: common.String(ids[i])})
+		if err != nil {
+			logger.Verbose("Error getting stream %s: %v", ids[i], err)
+			return
+		}
+		if resp.Stream.RetentionInHours != nil {
+			mu.Lock()
+			retention[ids[i]] = *resp.Stream.RetentionInHours
+			mu.Unlock()
+		}
+	})
+	return retention
+}
+
+// ResourceSink receives discovered resources as soon as each resource type of a compartment completes
+type ResourceSink func(resources []ResourceInfo) error
+
+// builtinDiscoverers lists the resource types discovered out of the box
+var builtinDiscoverers = map[string]DiscovererFunc{
+	"ComputeInstances":              discoverComputeInstances,
+	"VCNs":                          discoverVCNs,
+	"Subnets":                       discoverSubnets,
+	"BlockVolumes":                  discoverBlockVolumes,
+	"BootVolumes":                   discoverBootVolumes,
+	"BlockVolumeBackups":            discoverBlockVolumeBackups,
+	"BootVolumeBackups":             discoverBootVolumeBackups,
+	"ObjectStorageBuckets":          discoverObjectStorageBuckets,
+	"OKEClusters":                   discoverOKEClusters,
+	"LoadBalancers":                 discoverLoadBalancers,
+	"DatabaseSystems":               discoverDatabases,
+	"DRGs":                          discoverDRGs,
+	"LocalPeeringGateways":          discoverLocalPeeringGateways,
+	"AutonomousDatabases":           discoverAutonomousDatabases,
+	"ExadataInfrastructures":        discoverExadataInfrastructures,
+	"CloudExadataInfrastructures":   discoverCloudExadataInfrastructures,
+	"VmClusters":                    discoverVmClusters,
+	"Databases":                     discoverDatabasesInVmClusters,
+	"DbHomes":                       discoverDbHomes,
+	"DbNodes":                       discoverDbNodes,
+	"Functions":                     discoverFunctions,
+	"APIGateways":                   discoverAPIGateways,
+	"FileStorageSystems":            discoverFileStorageSystems,
+	"NetworkLoadBalancers":          discoverNetworkLoadBalancers,
+	"Streams":                       discoverStreams,
+	"Users":                         discoverUsers,
+	"Groups":                        discoverGroups,
+	"DynamicGroups":                 discoverDynamicGroups,
+	"Policies":                      discoverPolicies,
+	"OpenSearchClusters":            discoverOpenSearchClusters,
+	"OpenSearchClusterBackups":      discoverOpenSearchClusterBackups,
+	"Quotas":                        discoverQuotas,
+	"TagNamespaces":                 discoverTagNamespaces,
+	"TagDefinitions":                discoverTagDefinitions,
+	"IdentityDomains":               discoverIdentityDomains,
+	"InstancePools":                 discoverInstancePools,
+	"InstanceConfigurations":        discoverInstanceConfigurations,
+	"ClusterNetworks":               discoverClusterNetworks,
+	"VolumeGroups":                  discoverVolumeGroups,
+	"VolumeGroupBackups":            discoverVolumeGroupBackups,
+	"MountTargets":                  discoverMountTargets,
+	"FileStorageExports":            discoverFileStorageExports,
+	"FileStorageSnapshots":          discoverFileStorageSnapshots,
+	"StreamPools":                   discoverStreamPools,
+	"HealthChecks":                  discoverHealthChecks,
+	"ManagedInstances":              discoverManagedInstances,
+	"ManagedInstanceGroups":         discoverManagedInstanceGroups,
+	"DatabaseInsights":              discoverDatabaseInsights,
+	"HostInsights":                  discoverHostInsights,
+	"ManagedDatabases":              discoverManagedDatabases,
+	"ExternalContainerDatabases":    discoverExternalContainerDatabases,
+	"ExternalPluggableDatabases":    discoverExternalPluggableDatabases,
+	"ExternalNonContainerDatabases": discoverExternalNonContainerDatabases,
+	"ExadataDbServers":              discoverExadataDbServers,
+	"ExadataStorageServers":         discoverExadataStorageServers,
+	"BlockchainPlatforms":           discoverBlockchainPlatforms,
+	"PrivateIps":                    discoverPrivateIps,
+	"Vnics":                         discoverVnics,
+}
+
+func init() {
+	for name, discover := range builtinDiscoverers {
+		Register(name, discover)
+	}
+}
+
+// discoverAllResourcesWithProgress coordinates the discovery of all resource types with progress tracking.
+// When sink is non-nil, resources are streamed to it instead of being collected and returned, and
+// passes that need the complete inventory (volume attachment mapping) are skipped.
+func discoverAllResourcesWithProgress(ctx context.Context, clients *OCIClients, enableProgress bool, filters FilterConfig, sink ResourceSink, checkpoint *Checkpoint, cache *ResultCache, stats *DiscoveryStats) ([]ResourceInfo, error) {
+	var allResources []ResourceInfo
+	var totalResources int
+	var sinkErr error
+
+	// Resources recorded by an interrupted run are merged into this one
+	if checkpoint != nil {
+		restored := checkpoint.Restored()
+		totalResources += len(restored)
+		if sink == nil {
+			allResources = append(allResources, restored...)
+		} else if len(restored) > 0 {
+			sinkErr = sink(restored)
+		}
+	}
+
+	// Get list of compartments, including nested ones when subtrees are selected
+	compartments, err := listCompartments(ctx, clients, len(filters.CompartmentSubtrees) > 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compartments: %w", err)
+	}
+
+	// Apply compartment filters, with compartment names and patterns resolved to OCIDs
+	filters, err = ResolveCompartmentFilter(compartments, filters)
+	if err != nil {
+		return nil, err
+	}
+	filteredCompartments := ApplyCompartmentFilter(compartments, filters)
+	logger.Info("Found %d compartments to process (filtered from %d)", len(filteredCompartments), len(compartments))
+
+	// Compile filter regex patterns for efficient matching
+	compiledFilters, err := CompileFilters(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile filter patterns: %w", err)
+	}
+
+	// Discoverers registered for this run (built-in and third-party)
+	discoveryFuncs := registeredDiscoverers()
+	if clients.Options.Mode == DiscoveryModeSearch {
+		discoveryFuncs = searchDiscoverers(ctx, clients, discoveryFuncs, filters)
+	}
+
+	// Initialize uiprogress if enabled
+	var progress *uiprogress.Progress
+	var compartmentBars map[string]*uiprogress.Bar
+	var totalBar *uiprogress.Bar
+	var eta *etaEstimator
+	var resourceCounts sync.Map // compartmentID -> resource count
+	var barsMu sync.Mutex
+	completedTypes := make(map[string]int) // compartmentID -> resource types completed
+	workers := clients.RateLimiter.workers(5)
+
+	// addCompartmentBar adds the progress bar of a compartment; callers hold barsMu
+	addCompartmentBar := func(compID, compName string) *uiprogress.Bar {
+		bar := progress.AddBar(len(discoveryFuncs)) // one step per resource type
+
+		// Compartment name display (left side)
+		bar.PrependFunc(func(b *uiprogress.Bar) string {
+			return fmt.Sprintf("%-15s", compName)
+		})
+
+		// Resource count display (right side)
+		bar.AppendFunc(func(b *uiprogress.Bar) string {
+			if count, ok := resourceCounts.Load(compID); ok {
+				return fmt.Sprintf("| %d resources found", count.(int))
+			}
+			return "| 0 resources found"
+		})
+
+		compartmentBars[compID] = bar
+		return bar
+	}
+
+	if enableProgress {
+		// Render progress bars to stderr so they never corrupt piped output on stdout
+		progress = uiprogress.New()
+		progress.SetOut(os.Stderr)
+		progress.Start()
+		defer progress.Stop()
+
+		compartmentBars = make(map[string]*uiprogress.Bar)
+
+		// Overall bar with the remaining time, estimated from the durations of each resource type
+		activeCompartments := 0
+		for _, compartment := range filteredCompartments {
+			if compartment.LifecycleState == "ACTIVE" {
+				activeCompartments++
+			}
+		}
+		eta = newETAEstimator(workers)
+		for resourceType := range discoveryFuncs {
+			eta.plan(resourceType, activeCompartments)
+		}
+		totalBar = progress.AddBar(activeCompartments * len(discoveryFuncs))
+		totalBar.PrependFunc(func(b *uiprogress.Bar) string {
+			return fmt.Sprintf("%-15s", "total")
+		})
+		totalBar.AppendFunc(func(b *uiprogress.Bar) string {
+			return "| " + eta.String()
+		})
+
+		// With SkipEmptyCompartments, a compartment gets its bar once its first resource is found
+		if !filters.SkipEmptyCompartments {
+			for _, compartment := range filteredCompartments {
+				if compartment.LifecycleState == "ACTIVE" {
+					addCompartmentBar(*compartment.Id, *compartment.Name)
+				}
+			}
+		}
+	}
+
+	// Use a semaphore to limit concurrent compartments (max 5, more with adaptive concurrency)
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var discoveryErrors []string
+	var skippedDiscoveries int
+	var processedCompartmentIDs []string
+
+	// advanceProgress completes a resource type of a compartment in the progress bars
+	advanceProgress := func(comp string) {
+		if enableProgress && compartmentBars != nil {
+			barsMu.Lock()
+			completedTypes[comp]++
+			if bar, exists := compartmentBars[comp]; exists {
+				bar.Incr()
+			}
+			barsMu.Unlock()
+			totalBar.Incr()
+		}
+	}
+
+	// countProgress adds resources found in a compartment to its progress bar
+	countProgress := func(comp, compName string, found int) {
+		if !enableProgress || compartmentBars == nil || found == 0 {
+			return
+		}
+		barsMu.Lock()
+		defer barsMu.Unlock()
+		if _, exists := compartmentBars[comp]; !exists {
+			bar := addCompartmentBar(comp, compName)
+			bar.Set(completedTypes[comp])
+		}
+		count, _ := resourceCounts.Load(comp)
+		total, _ := count.(int)
+		resourceCounts.Store(comp, total+found)
+	}
+
+	breaker := newCircuitBreaker(clients.Options.CircuitBreakerThreshold)
+
+	for _, compartment := range filteredCompartments {
+		if compartment.LifecycleState != "ACTIVE" {
+			continue
+		}
+		processedCompartmentIDs = append(processedCompartmentIDs, *compartment.Id)
+
+		wg.Add(1)
+		go func(comp string, compName string) {
+			defer wg.Done()
+
+			// Acquire semaphore
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			logger.Verbose("Processing compartment: %s (%s)", compName, comp)
+
+			// Process each resource type for this compartment
+			for resourceType, discoveryFunc := range discoveryFuncs {
+				// Apply resource type filter
+				if !ApplyResourceTypeFilter(resourceType, filters) {
+					logger.Debug("Skipping resource type %s due to filters", resourceType)
+					// Still update progress for skipped resource types
+					eta.skip(resourceType)
+					advanceProgress(comp)
+					continue
+				}
+
+				// Skip resource types completed by the run being resumed
+				if checkpoint != nil && checkpoint.Completed(comp, resourceType) {
+					logger.Debug("Skipping %s in %s, restored from checkpoint", resourceType, compName)
+					eta.skip(resourceType)
+					advanceProgress(comp)
+					continue
+				}
+
+				// Skip resource types of services that kept failing in the previous compartments
+				if !breaker.allow(resourceType) {
+					logger.Debug("Skipping %s in %s, circuit breaker open", resourceType, compName)
+					mu.Lock()
+					skippedDiscoveries++
+					mu.Unlock()
+					eta.skip(resourceType)
+					advanceProgress(comp)
+					continue
+				}
+
+				var resources []ResourceInfo
+				var err error
+
+				// Execute discovery with retry, unless a recent result is cached
+				operation := func() error {
+					resources, err = discoveryFunc.Discover(ctx, clients, comp)
+					return err
+				}
+
+				var retryErr error
+				cached := false
+				if cache != nil {
+					resources, cached = cache.Get(comp, resourceType)
+				}
+				if cached {
+					logger.Debug("Using cached %s in %s", resourceType, compName)
+					eta.skip(resourceType)
+				} else {
+					started := time.Now()
+					retryErr = withRetryAndProgress(ctx, operation, 3, fmt.Sprintf("%s in %s", resourceType, compName), nil)
+					eta.done(resourceType, time.Since(started))
+					if breaker.record(resourceType, retryErr) {
+						logger.ErrorWith(LogFields{Compartment: compName, ResourceType: resourceType, ErrorCode: LogErrorCode(retryErr)}, "Warning: the %s service failed %d times in a row, skipping its resource types in the remaining compartments: %v", resourceTypeService(resourceType), breaker.threshold, retryErr)
+					}
+					if retryErr == nil && cache != nil {
+						if err := cache.Put(comp, resourceType, resources); err != nil {
+							logger.Verbose("Warning: Could not update result cache: %v", err)
+						}
+					}
+				}
+
+				if retryErr != nil {
+					fields := LogFields{Compartment: compName, ResourceType: resourceType, ErrorCode: LogErrorCode(retryErr)}
+					if isRetriableError(retryErr) {
+						logger.VerboseWith(fields, "Skipping %s in compartment %s due to retriable error: %v", resourceType, compName, retryErr)
+						mu.Lock()
+						skippedDiscoveries++
+						mu.Unlock()
+					} else {
+						errorMsg := fmt.Sprintf("Error discovering %s in compartment %s: %v", resourceType, compName, retryErr)
+						logger.VerboseWith(fields, "%s", errorMsg)
+						mu.Lock()
+						discoveryErrors = append(discoveryErrors, errorMsg)
+						mu.Unlock()
+					}
+					// Update progress even for failed resource types
+					advanceProgress(comp)
+					continue
+				}
+
+				// Apply OCID, name, lifecycle, shape, size, where and expression filters to discovered resources
+				filteredResources := make([]ResourceInfo, 0, len(resources))
+				for _, resource := range resources {
+					if !ApplyOCIDFilter(resource, compiledFilters) {
+						logger.Debug("Filtering out resource %s not in the OCID file", resource.ResourceName)
+					} else if !ApplyNameFilter(resource.ResourceName, compiledFilters) {
+						logger.Debug("Filtering out resource %s due to name filters", resource.ResourceName)
+					} else if !ApplyLifecycleFilter(resource, compiledFilters) {
+						logger.Debug("Filtering out resource %s due to lifecycle filters", resource.ResourceName)
+					} else if !ApplyShapeFilter(resource, compiledFilters) {
+						logger.Debug("Filtering out resource %s due to shape filters", resource.ResourceName)
+					} else if !ApplySizeFilter(resource, compiledFilters) {
+						logger.Debug("Filtering out resource %s due to size filters", resource.ResourceName)
+					} else if !ApplyWhereFilter(resource, compiledFilters) {
+						logger.Debug("Filtering out resource %s due to where conditions", resource.ResourceName)
+					} else if !ApplyExpressionFilter(resource, compiledFilters) {
+						logger.Debug("Filtering out resource %s due to the filter expression", resource.ResourceName)
+					} else {
+						filteredResources = append(filteredResources, resource)
+					}
+				}
+
+				// Add filtered resources to the global list, or stream them to the sink
+				if len(filteredResources) > 0 {
+					mu.Lock()
+					totalResources += len(filteredResources)
+					if sink == nil {
+						allResources = append(allResources, filteredResources...)
+					} else if sinkErr == nil {
+						sinkErr = sink(filteredResources)
+					}
+					mu.Unlock()
+					
+					// Update resource count for this compartment
+					countProgress(comp, compName, len(filteredResources))
+				}
+
+				if len(resources) > len(filteredResources) {
+					logger.Verbose("Filtered %d resources by name in %s %s", len(resources)-len(filteredResources), resourceType, compName)
+				}
+
+				// Record completion; a failed write only means the work is repeated on resume
+				if checkpoint != nil {
+					if err := checkpoint.Record(comp, resourceType, filteredResources); err != nil {
+						logger.Verbose("Warning: Could not update checkpoint: %v", err)
+					}
+				}
+				
+				// Update progress bar for this resource type completion
+				advanceProgress(comp)
+			}
+
+			// Compartment processing complete - no additional action needed
+			// Progress is automatically complete when all resource types are processed
+
+			logger.Verbose("Completed processing compartment: %s", compName)
+		}(*compartment.Id, *compartment.Name)
+	}
+
+	// Wait for all goroutines to complete
+	wg.Wait()
+
+	if sinkErr != nil {
+		return nil, fmt.Errorf("failed to write streamed resources: %w", sinkErr)
+	}
+
+	// Link volumes and instances through their attachments
+	if sink == nil && shouldMapVolumeAttachments(filters) {
+		var compartmentIDs []string
+		for _, compartment := range filteredCompartments {
+			if compartment.LifecycleState == "ACTIVE" {
+				compartmentIDs = append(compartmentIDs, *compartment.Id)
+			}
+		}
+		mapVolumeAttachments(ctx, clients, compartmentIDs, allResources)
+	}
+
+	// Add names next to the OCIDs of related resources
+	if sink == nil {
+		resolveRelationshipNames(allResources, clients.CompartmentCache)
+	}
+
+	// Report discovery summary
+	if len(discoveryErrors) > 0 {
+		logger.Verbose("Discovery completed with %d errors:", len(discoveryErrors))
+		for i, err := range discoveryErrors {
+			if i < 5 { // Limit to first 5 errors
+				logger.Verbose("  %s", err)
+			}
+		}
+		if len(discoveryErrors) > 5 {
+			logger.Verbose("  ... and %d more errors", len(discoveryErrors)-5)
+		}
+	}
+
+	logger.Info("Resource discovery completed. Found %d resources across %d compartments", totalResources, len(compartments))
+
+	if stats != nil {
+		stats.Compartments = len(processedCompartmentIDs)
+		stats.CompartmentIDs = processedCompartmentIDs
+		stats.Resources = totalResources
+		stats.Errors = len(discoveryErrors) + skippedDiscoveries
+	}
+
+	return allResources, nil
+}
+
+// discoverBootVolumes discovers all boot volumes in a compartment
+func discoverBootVolumes(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allBootVolumes []core.BootVolume
+
+	logger.Debug("Starting boot volume discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all boot volumes
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching boot volumes page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListBootVolumesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.BlockStorageClient.ListBootVolumes(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allBootVolumes = append(allBootVolumes, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, bootVolume := range allBootVolumes {
+		if bootVolume.LifecycleState != core.BootVolumeLifecycleStateTerminated {
+			name := ""
+			if bootVolume.DisplayName != nil {
+				name = *bootVolume.DisplayName
+			}
+			ocid := ""
+			if bootVolume.Id != nil {
+				ocid = *bootVolume.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add size in GBs
+			if bootVolume.SizeInGBs != nil {
+				additionalInfo["size_in_gbs"] = *bootVolume.SizeInGBs
+			}
+
+			// Add volume performance (VPUs per GB)
+			if bootVolume.VpusPerGB != nil {
+				additionalInfo["vpus_per_gb"] = *bootVolume.VpusPerGB
+			}
+
+			// Add availability domain
+			if bootVolume.AvailabilityDomain != nil {
+				additionalInfo["availability_domain"] = *bootVolume.AvailabilityDomain
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "BootVolume", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(bootVolume.FreeformTags, bootVolume.DefinedTags).
+				withLifecycle(bootVolume.TimeCreated, string(bootVolume.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d boot volumes in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverBootVolumeBackups discovers all boot volume backups in a compartment
+func discoverBootVolumeBackups(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allBootVolumeBackups []core.BootVolumeBackup
+
+	logger.Debug("Starting boot volume backup discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all boot volume backups
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching boot volume backups page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListBootVolumeBackupsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.BlockStorageClient.ListBootVolumeBackups(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allBootVolumeBackups = append(allBootVolumeBackups, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, backup := range allBootVolumeBackups {
+		if backup.LifecycleState != core.BootVolumeBackupLifecycleStateTerminated {
+			name := ""
+			if backup.DisplayName != nil {
+				name = *backup.DisplayName
+			}
+			ocid := ""
+			if backup.Id != nil {
+				ocid = *backup.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add size in GBs
+			if backup.SizeInGBs != nil {
+				additionalInfo["size_in_gbs"] = *backup.SizeInGBs
+			}
+
+			// Add source boot volume ID
+			if backup.BootVolumeId != nil {
+				additionalInfo["source_boot_volume_id"] = *backup.BootVolumeId
+			}
+
+			// Add backup type
+			additionalInfo["type"] = string(backup.Type)
+
+			resources = append(resources, createResourceInfo(ctx, "BootVolumeBackup", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(backup.FreeformTags, backup.DefinedTags).
+				withLifecycle(backup.TimeCreated, string(backup.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d boot volume backups in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverBlockVolumeBackups discovers all block volume backups in a compartment
+func discoverBlockVolumeBackups(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allVolumeBackups []core.VolumeBackup
+
+	logger.Debug("Starting block volume backup discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all volume backups
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching block volume backups page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListVolumeBackupsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.BlockStorageClient.ListVolumeBackups(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allVolumeBackups = append(allVolumeBackups, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, backup := range allVolumeBackups {
+		if backup.LifecycleState != core.VolumeBackupLifecycleStateTerminated {
+			name := ""
+			if backup.DisplayName != nil {
+				name = *backup.DisplayName
+			}
+			ocid := ""
+			if backup.Id != nil {
+				ocid = *backup.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add size in GBs
+			if backup.SizeInGBs != nil {
+				additionalInfo["size_in_gbs"] = *backup.SizeInGBs
+			}
+
+			// Add source volume ID
+			if backup.VolumeId != nil {
+				additionalInfo["source_volume_id"] = *backup.VolumeId
+			}
+
+			// Add backup type
+			additionalInfo["type"] = string(backup.Type)
+
+			resources = append(resources, createResourceInfo(ctx, "BlockVolumeBackup", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(backup.FreeformTags, backup.DefinedTags).
+				withLifecycle(backup.TimeCreated, string(backup.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d block volume backups in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverLocalPeeringGateways discovers all Local Peering Gateways in a compartment
+func discoverLocalPeeringGateways(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allLPGs []core.LocalPeeringGateway
+
+	logger.Debug("Starting Local Peering Gateway discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all Local Peering Gateways
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching Local Peering Gateways page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListLocalPeeringGatewaysRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.VirtualNetworkClient.ListLocalPeeringGateways(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allLPGs = append(allLPGs, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, lpg := range allLPGs {
+		if lpg.LifecycleState != core.LocalPeeringGatewayLifecycleStateTerminated {
+			name := ""
+			if lpg.DisplayName != nil {
+				name = *lpg.DisplayName
+			}
+			ocid := ""
+			if lpg.Id != nil {
+				ocid = *lpg.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add VCN ID
+			if lpg.VcnId != nil {
+				additionalInfo["vcn_id"] = *lpg.VcnId
+			}
+
+			// Add peering status
+			additionalInfo["peering_status"] = string(lpg.PeeringStatus)
+
+			// Add peer advertised CIDR
+			if lpg.PeerAdvertisedCidr != nil {
+				additionalInfo["peer_advertised_cidr"] = *lpg.PeerAdvertisedCidr
+			}
+
+			// Add route table ID
+			if lpg.RouteTableId != nil {
+				additionalInfo["route_table_id"] = *lpg.RouteTableId
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "LocalPeeringGateway", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(lpg.FreeformTags, lpg.DefinedTags).
+				withLifecycle(lpg.TimeCreated, string(lpg.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d Local Peering Gateways in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverExadataInfrastructures discovers all Exadata Infrastructures in a compartment
+func discoverExadataInfrastructures(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allExadataInfrastructures []database.ExadataInfrastructureSummary
+
+	logger.Debug("Starting Exadata Infrastructure discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all Exadata Infrastructures
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching Exadata Infrastructures page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListExadataInfrastructuresRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListExadataInfrastructures(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allExadataInfrastructures = append(allExadataInfrastructures, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, exaInfra := range allExadataInfrastructures {
+		if string(exaInfra.LifecycleState) != "TERMINATED" {
+			name := ""
+			if exaInfra.DisplayName != nil {
+				name = *exaInfra.DisplayName
+			}
+			ocid := ""
+			if exaInfra.Id != nil {
+				ocid = *exaInfra.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add shape
+			if exaInfra.Shape != nil {
+				additionalInfo["shape"] = *exaInfra.Shape
+			}
+
+			// Add compute count
+			if exaInfra.ComputeCount != nil {
+				additionalInfo["compute_count"] = *exaInfra.ComputeCount
+			}
+
+			// Add storage count
+			if exaInfra.StorageCount != nil {
+				additionalInfo["storage_count"] = *exaInfra.StorageCount
+			}
+
+			// Add cloud control plane server version
+			if exaInfra.CloudControlPlaneServer1 != nil {
+				additionalInfo["cloud_control_plane_server1"] = *exaInfra.CloudControlPlaneServer1
+			}
+
+			// Add storage server details
+			if exaInfra.ActivatedStorageCount != nil {
+				additionalInfo["activated_storage_count"] = *exaInfra.ActivatedStorageCount
+			}
+			if exaInfra.StorageServerVersion != nil {
+				additionalInfo["storage_server_version"] = *exaInfra.StorageServerVersion
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "ExadataInfrastructure", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(exaInfra.FreeformTags, exaInfra.DefinedTags).
+				withLifecycle(exaInfra.TimeCreated, string(exaInfra.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d Exadata Infrastructures in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverCloudExadataInfrastructures discovers all Cloud Exadata Infrastructures in a compartment
+func discoverCloudExadataInfrastructures(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allCloudExadataInfrastructures []database.CloudExadataInfrastructureSummary
+
+	logger.Debug("Starting Cloud Exadata Infrastructure discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all Cloud Exadata Infrastructures
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching Cloud Exadata Infrastructures page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListCloudExadataInfrastructuresRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListCloudExadataInfrastructures(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allCloudExadataInfrastructures = append(allCloudExadataInfrastructures, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, cloudExaInfra := range allCloudExadataInfrastructures {
+		if string(cloudExaInfra.LifecycleState) != "TERMINATED" {
+			name := ""
+			if cloudExaInfra.DisplayName != nil {
+				name = *cloudExaInfra.DisplayName
+			}
+			ocid := ""
+			if cloudExaInfra.Id != nil {
+				ocid = *cloudExaInfra.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add shape
+			if cloudExaInfra.Shape != nil {
+				additionalInfo["shape"] = *cloudExaInfra.Shape
+			}
+
+			// Add compute count
+			if cloudExaInfra.ComputeCount != nil {
+				additionalInfo["compute_count"] = *cloudExaInfra.ComputeCount
+			}
+
+			// Add storage count
+			if cloudExaInfra.StorageCount != nil {
+				additionalInfo["storage_count"] = *cloudExaInfra.StorageCount
+			}
+
+			// Add availability domain
+			if cloudExaInfra.AvailabilityDomain != nil {
+				additionalInfo["availability_domain"] = *cloudExaInfra.AvailabilityDomain
+			}
+
+			// Add storage server details
+			if cloudExaInfra.TotalStorageSizeInGBs != nil {
+				additionalInfo["total_storage_size_in_gbs"] = *cloudExaInfra.TotalStorageSizeInGBs
+			}
+			if cloudExaInfra.AvailableStorageSizeInGBs != nil {
+				additionalInfo["available_storage_size_in_gbs"] = *cloudExaInfra.AvailableStorageSizeInGBs
+			}
+			if cloudExaInfra.StorageServerVersion != nil {
+				additionalInfo["storage_server_version"] = *cloudExaInfra.StorageServerVersion
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "CloudExadataInfrastructure", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(cloudExaInfra.FreeformTags, cloudExaInfra.DefinedTags).
+				withLifecycle(cloudExaInfra.TimeCreated, string(cloudExaInfra.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d Cloud Exadata Infrastructures in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverVmClusters discovers all VM Clusters in a compartment
+func discoverVmClusters(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allVmClusters []database.VmClusterSummary
+
+	logger.Debug("Starting VM Cluster discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all VM Clusters
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching VM Clusters page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListVmClustersRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListVmClusters(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allVmClusters = append(allVmClusters, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, vmCluster := range allVmClusters {
+		if vmCluster.LifecycleState != database.VmClusterSummaryLifecycleStateTerminated {
+			name := ""
+			if vmCluster.DisplayName != nil {
+				name = *vmCluster.DisplayName
+			}
+			ocid := ""
+			if vmCluster.Id != nil {
+				ocid = *vmCluster.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add shape
+			if vmCluster.Shape != nil {
+				additionalInfo["shape"] = *vmCluster.Shape
+			}
+
+			// Add CPU core count
+			if vmCluster.CpusEnabled != nil {
+				additionalInfo["cpus_enabled"] = *vmCluster.CpusEnabled
+			}
+
+			// Add Exadata Infrastructure ID
+			if vmCluster.ExadataInfrastructureId != nil {
+				additionalInfo["exadata_infrastructure_id"] = *vmCluster.ExadataInfrastructureId
+			}
+
+			// Add VM Cluster Network ID
+			if vmCluster.VmClusterNetworkId != nil {
+				additionalInfo["vm_cluster_network_id"] = *vmCluster.VmClusterNetworkId
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "VmCluster", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(vmCluster.FreeformTags, vmCluster.DefinedTags).
+				withLifecycle(vmCluster.TimeCreated, string(vmCluster.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d VM Clusters in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverDatabasesInVmClusters discovers all databases within VM Clusters in a compartment
+func discoverDatabasesInVmClusters(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting Database discovery for compartment: %s", compartmentID)
+
+	// First, get all VM Clusters in the compartment
+	vmClusters, err := discoverVmClusters(ctx, clients, compartmentID)
+	if err != nil {
+		logger.Verbose("Error discovering VM Clusters for database search: %v", err)
+		return resources, nil // Return empty but don't fail
+	}
+
+	// For each VM Cluster, discover databases
+	for _, vmClusterResource := range vmClusters {
+		vmClusterID := vmClusterResource.OCID
+		logger.Debug("Discovering databases in VM Cluster: %s", vmClusterID)
+
+		var allDatabases []database.DatabaseSummary
+		var page *string
+		pageCount := 0
+		for {
+			pageCount++
+			logger.Debug("Fetching databases page %d for VM Cluster: %s", pageCount, vmClusterID)
+			req := database.ListDatabasesRequest{
+				CompartmentId: common.String(compartmentID),
+				DbHomeId:      nil, // We'll search by compartment
+				Page:          page,
+				Limit:         clients.pageLimit(maxPageSize),
+			}
+
+			resp, err := clients.DatabaseClient.ListDatabases(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing databases in VM Cluster %s: %v", vmClusterID, err)
+				break // Continue with next VM Cluster
+			}
+
+			allDatabases = append(allDatabases, resp.Items...)
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+
+		for _, database := range allDatabases {
+			if string(database.LifecycleState) != "TERMINATED" {
+				name := ""
+				if database.DbName != nil {
+					name = *database.DbName
+				}
+				ocid := ""
+				if database.Id != nil {
+					ocid = *database.Id
+				}
+
+				additionalInfo := make(map[string]interface{})
+
+				// Add DB Home ID
+				if database.DbHomeId != nil {
+					additionalInfo["db_home_id"] = *database.DbHomeId
+				}
+
+				// Add DB unique name
+				if database.DbUniqueName != nil {
+					additionalInfo["db_unique_name"] = *database.DbUniqueName
+				}
+
+				// Add character set
+				if database.CharacterSet != nil {
+					additionalInfo["character_set"] = *database.CharacterSet
+				}
+
+				// Add associated VM Cluster
+				additionalInfo["vm_cluster_id"] = vmClusterID
+				additionalInfo["vm_cluster_name"] = vmClusterResource.ResourceName
+
+				resources = append(resources, createResourceInfo(ctx, "Database", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+					withTags(database.FreeformTags, database.DefinedTags).
+					withLifecycle(database.TimeCreated, string(database.LifecycleState)))
+			}
+		}
+	}
+
+	logger.Verbose("Found %d databases in VM Clusters in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverDbHomes discovers all Database Homes in a compartment
+func discoverDbHomes(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allDbHomes []database.DbHomeSummary
+
+	logger.Debug("Starting Database Home discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all Database Homes
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching Database Homes page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListDbHomesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListDbHomes(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allDbHomes = append(allDbHomes, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, dbHome := range allDbHomes {
+		if dbHome.LifecycleState != database.DbHomeSummaryLifecycleStateTerminated {
+			name := ""
+			if dbHome.DisplayName != nil {
+				name = *dbHome.DisplayName
+			}
+			ocid := ""
+			if dbHome.Id != nil {
+				ocid = *dbHome.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add DB system ID
+			if dbHome.DbSystemId != nil {
+				additionalInfo["db_system_id"] = *dbHome.DbSystemId
+			}
+
+			// Add VM Cluster ID
+			if dbHome.VmClusterId != nil {
+				additionalInfo["vm_cluster_id"] = *dbHome.VmClusterId
+			}
+
+			// Add database software image ID
+			if dbHome.DatabaseSoftwareImageId != nil {
+				additionalInfo["database_software_image_id"] = *dbHome.DatabaseSoftwareImageId
+			}
+
+			// Add DB version
+			if dbHome.DbVersion != nil {
+				additionalInfo["db_version"] = *dbHome.DbVersion
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "DbHome", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(dbHome.FreeformTags, dbHome.DefinedTags).
+				withLifecycle(dbHome.TimeCreated, string(dbHome.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d Database Homes in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverDbNodes discovers all Database Nodes in a compartment
+func discoverDbNodes(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting Database Node discovery for compartment: %s", compartmentID)
+
+	// First, get all database systems in the compartment to find nodes
+	var allDbSystems []database.DbSystemSummary
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching database systems page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListDbSystemsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListDbSystems(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allDbSystems = append(allDbSystems, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// For each database system, get its nodes
+	type systemNode struct {
+		dbSystem database.DbSystemSummary
+		dbNode   database.DbNodeSummary
+	}
+	var allNodes []systemNode
+	for _, dbSystem := range allDbSystems {
+		if dbSystem.LifecycleState != database.DbSystemSummaryLifecycleStateTerminated && dbSystem.Id != nil {
+			var allDbNodes []database.DbNodeSummary
+			var nodePage *string
+			nodePageCount := 0
+			for {
+				nodePageCount++
+				logger.Debug("Fetching Database Nodes page %d for DB System: %s", nodePageCount, *dbSystem.Id)
+				nodeReq := database.ListDbNodesRequest{
+					CompartmentId: common.String(compartmentID),
+					DbSystemId:    dbSystem.Id,
+					Page:          nodePage,
+					Limit:         clients.pageLimit(maxPageSize),
+				}
+
+				nodeResp, err := clients.DatabaseClient.ListDbNodes(ctx, nodeReq)
+
+				if err != nil {
+					logger.Verbose("Error listing database nodes for DB System %s: %v", *dbSystem.Id, err)
+					break // Continue with next DB System
+				}
+
+				allDbNodes = append(allDbNodes, nodeResp.Items...)
+
+				if nodeResp.OpcNextPage == nil {
+					break
+				}
+				nodePage = nodeResp.OpcNextPage
+			}
+
+			for _, dbNode := range allDbNodes {
+				allNodes = append(allNodes, systemNode{dbSystem: dbSystem, dbNode: dbNode})
+			}
+		}
+	}
+
+	// Resolve the VNICs of all nodes in one batch
+	var vnicIDs []string
+	for _, node := range allNodes {
+		if node.dbNode.LifecycleState == database.DbNodeSummaryLifecycleStateTerminated {
+			continue
+		}
+		if node.dbNode.VnicId != nil {
+			vnicIDs = append(vnicIDs, *node.dbNode.VnicId)
+		}
+		if node.dbNode.BackupVnicId != nil {
+			vnicIDs = append(vnicIDs, *node.dbNode.BackupVnicId)
+		}
+	}
+	var vnics map[string]core.Vnic
+	if len(vnicIDs) > 0 {
+		vnics = newVNICResolver(clients).vnics(ctx, vnicIDs)
+	}
+
+	for _, node := range allNodes {
+		dbSystem, dbNode := node.dbSystem, node.dbNode
+		if dbNode.LifecycleState != database.DbNodeSummaryLifecycleStateTerminated {
+			name := ""
+			if dbNode.Hostname != nil {
+				name = *dbNode.Hostname
+			}
+			ocid := ""
+			if dbNode.Id != nil {
+				ocid = *dbNode.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add DB system ID
+			if dbSystem.Id != nil {
+				additionalInfo["db_system_id"] = *dbSystem.Id
+			}
+
+			// Add DB system name
+			if dbSystem.DisplayName != nil {
+				additionalInfo["db_system_name"] = *dbSystem.DisplayName
+			}
+
+			// Add VNIC ID
+			if dbNode.VnicId != nil {
+				additionalInfo["vnic_id"] = *dbNode.VnicId
+			}
+
+			// Add backup VNIC ID
+			if dbNode.BackupVnicId != nil {
+				additionalInfo["backup_vnic_id"] = *dbNode.BackupVnicId
+			}
+
+			// Add the private IP addresses of the VNICs
+			if dbNode.VnicId != nil {
+				if vnic, ok := vnics[*dbNode.VnicId]; ok && vnic.PrivateIp != nil {
+					additionalInfo["private_ip"] = *vnic.PrivateIp
+				}
+			}
+			if dbNode.BackupVnicId != nil {
+				if vnic, ok := vnics[*dbNode.BackupVnicId]; ok && vnic.PrivateIp != nil {
+					additionalInfo["backup_private_ip"] = *vnic.PrivateIp
+				}
+			}
+
+			// Add software storage size in GB
+			if dbNode.SoftwareStorageSizeInGB != nil {
+				additionalInfo["software_storage_size_in_gb"] = *dbNode.SoftwareStorageSizeInGB
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "DbNode", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(dbNode.FreeformTags, dbNode.DefinedTags).
+				withLifecycle(dbNode.TimeCreated, string(dbNode.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d Database Nodes in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// isTenancyOCID checks whether the given compartment OCID refers to the tenancy (root compartment)
+func isTenancyOCID(compartmentID string) bool {
+	return strings.HasPrefix(compartmentID, "ocid1.tenancy.")
+}
+
+// discoverUsers discovers the IAM users of the identity domains in a compartment and, in the
+// tenancy, the legacy IAM users not already returned by an identity domain
+func discoverUsers(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var allUsers []identity.User
+
+	resources, seen := discoverDomainMembers(ctx, clients, "User", compartmentID, listDomainUsers)
+
+	// Legacy IAM users only exist at the tenancy level
+	if !isTenancyOCID(compartmentID) {
+		logger.Verbose("Found %d identity domain users in compartment %s", len(resources), compartmentID)
+		return resources, nil
+	}
+
+	logger.Debug("Starting IAM user discovery for tenancy: %s", compartmentID)
+
+	// Implement pagination to get all users
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching IAM users page %d for tenancy: %s", pageCount, compartmentID)
+		req := identity.ListUsersRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.IdentityClient.ListUsers(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allUsers = append(allUsers, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, user := range allUsers {
+		if user.LifecycleState != identity.UserLifecycleStateDeleted && (user.Id == nil || !seen[*user.Id]) {
+			name := ""
+			if user.Name != nil {
+				name = *user.Name
+			}
+			ocid := ""
+			if user.Id != nil {
+				ocid = *user.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add email
+			if user.Email != nil {
+				additionalInfo["email"] = *user.Email
+			}
+
+			// Add MFA activation status
+			if user.IsMfaActivated != nil {
+				additionalInfo["is_mfa_activated"] = *user.IsMfaActivated
+			}
+
+			// Add identity provider (federated / identity domain users)
+			if user.IdentityProviderId != nil {
+				additionalInfo["identity_provider_id"] = *user.IdentityProviderId
+			}
+
+			// Add last successful login time
+			if user.LastSuccessfulLoginTime != nil {
+				additionalInfo["last_successful_login_time"] = user.LastSuccessfulLoginTime.Format(time.RFC3339)
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "User", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(user.FreeformTags, user.DefinedTags).
+				withLifecycle(user.TimeCreated, string(user.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d IAM users in tenancy %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverGroups discovers the IAM groups of the identity domains in a compartment and, in the
+// tenancy, the legacy IAM groups not already returned by an identity domain
+func discoverGroups(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var allGroups []identity.Group
+
+	resources, seen := discoverDomainMembers(ctx, clients, "Group", compartmentID, listDomainGroups)
+
+	// Legacy IAM groups only exist at the tenancy level
+	if !isTenancyOCID(compartmentID) {
+		logger.Verbose("Found %d identity domain groups in compartment %s", len(resources), compartmentID)
+		return resources, nil
+	}
+
+	logger.Debug("Starting IAM group discovery for tenancy: %s", compartmentID)
+
+	// Implement pagination to get all groups
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching IAM groups page %d for tenancy: %s", pageCount, compartmentID)
+		req := identity.ListGroupsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.IdentityClient.ListGroups(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allGroups = append(allGroups, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, group := range allGroups {
+		if group.LifecycleState != identity.GroupLifecycleStateDeleted && (group.Id == nil || !seen[*group.Id]) {
+			name := ""
+			if group.Name != nil {
+				name = *group.Name
+			}
+			ocid := ""
+			if group.Id != nil {
+				ocid = *group.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add description
+			if group.Description != nil {
+				additionalInfo["description"] = *group.Description
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "Group", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(group.FreeformTags, group.DefinedTags).
+				withLifecycle(group.TimeCreated, string(group.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d IAM groups in tenancy %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverDynamicGroups discovers all dynamic groups in the tenancy (root compartment only)
+func discoverDynamicGroups(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allDynamicGroups []identity.DynamicGroup
+
+	// Dynamic groups only exist at the tenancy level
+	if !isTenancyOCID(compartmentID) {
+		return resources, nil
+	}
+
+	logger.Debug("Starting dynamic group discovery for tenancy: %s", compartmentID)
+
+	// Implement pagination to get all dynamic groups
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching dynamic groups page %d for tenancy: %s", pageCount, compartmentID)
+		req := identity.ListDynamicGroupsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.IdentityClient.ListDynamicGroups(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allDynamicGroups = append(allDynamicGroups, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, dynamicGroup := range allDynamicGroups {
+		if dynamicGroup.LifecycleState != identity.DynamicGroupLifecycleStateDeleted {
+			name := ""
+			if dynamicGroup.Name != nil {
+				name = *dynamicGroup.Name
+			}
+			ocid := ""
+			if dynamicGroup.Id != nil {
+				ocid = *dynamicGroup.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add matching rule
+			if dynamicGroup.MatchingRule != nil {
+				additionalInfo["matching_rule"] = *dynamicGroup.MatchingRule
+			}
+
+			// Add description
+			if dynamicGroup.Description != nil {
+				additionalInfo["description"] = *dynamicGroup.Description
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "DynamicGroup", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(dynamicGroup.FreeformTags, dynamicGroup.DefinedTags).
+				withLifecycle(dynamicGroup.TimeCreated, string(dynamicGroup.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d dynamic groups in tenancy %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverPolicies discovers all IAM policies attached to a compartment
+func discoverPolicies(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allPolicies []identity.Policy
+
+	logger.Debug("Starting IAM policy discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all policies
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching IAM policies page %d for compartment: %s", pageCount, compartmentID)
+		req := identity.ListPoliciesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.IdentityClient.ListPolicies(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allPolicies = append(allPolicies, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, policy := range allPolicies {
+		if policy.LifecycleState != identity.PolicyLifecycleStateDeleted {
+			name := ""
+			if policy.Name != nil {
+				name = *policy.Name
+			}
+			ocid := ""
+			if policy.Id != nil {
+				ocid = *policy.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add statement count
+			additionalInfo["statement_count"] = len(policy.Statements)
+
+			// Add attached compartment (policies apply to the compartment they are defined in)
+			if policy.CompartmentId != nil {
+				additionalInfo["attached_compartment_id"] = *policy.CompartmentId
+			}
+
+			// Add version date
+			if policy.VersionDate != nil {
+				additionalInfo["version_date"] = policy.VersionDate.Date.Format(time.RFC3339)
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "Policy", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(policy.FreeformTags, policy.DefinedTags).
+				withLifecycle(policy.TimeCreated, string(policy.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d IAM policies in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// listOpenSearchClusterBackups retrieves all OpenSearch cluster backups in a compartment
+func listOpenSearchClusterBackups(ctx context.Context, clients *OCIClients, compartmentID string) ([]opensearch.OpensearchClusterBackupSummary, error) {
+	var allBackups []opensearch.OpensearchClusterBackupSummary
+
+	// Implement pagination to get all backups
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching OpenSearch cluster backups page %d for compartment: %s", pageCount, compartmentID)
+		req := opensearch.ListOpensearchClusterBackupsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.OpenSearchBackupClient.ListOpensearchClusterBackups(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allBackups = append(allBackups, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	return allBackups, nil
+}
+
+// discoverOpenSearchClusters discovers all OpenSearch clusters in a compartment, including backup policy coverage
+func discoverOpenSearchClusters(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allClusters []opensearch.OpensearchClusterSummary
+
+	logger.Debug("Starting OpenSearch cluster discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all clusters
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching OpenSearch clusters page %d for compartment: %s", pageCount, compartmentID)
+		req := opensearch.ListOpensearchClustersRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.OpenSearchClient.ListOpensearchClusters(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allClusters = append(allClusters, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	if len(allClusters) == 0 {
+		logger.Verbose("Found 0 OpenSearch clusters in compartment %s", compartmentID)
+		return resources, nil
+	}
+
+	// Determine the most recent snapshot per cluster from the backups in this compartment
+	// When the backups cannot be listed, each cluster records why instead of looking never backed up.
+	lastBackupTimes := make(map[string]time.Time)
+	backups, backupErr := listOpenSearchClusterBackups(ctx, clients, compartmentID)
+	if backupErr != nil {
+		logger.Error("Warning: Could not list OpenSearch cluster backups in compartment %s, last_backup_time is unknown: %v", compartmentID, backupErr)
+	}
+	for _, backup := range backups {
+		if backup.SourceClusterId == nil || backup.TimeCreated == nil || backup.LifecycleState != opensearch.OpensearchClusterBackupLifecycleStateActive {
+			continue
+		}
+		if backup.TimeCreated.Time.After(lastBackupTimes[*backup.SourceClusterId]) {
+			lastBackupTimes[*backup.SourceClusterId] = backup.TimeCreated.Time
+		}
+	}
+
+	for _, cluster := range allClusters {
+		if cluster.LifecycleState != opensearch.OpensearchClusterLifecycleStateDeleted {
+			name := ""
+			if cluster.DisplayName != nil {
+				name = *cluster.DisplayName
+			}
+			ocid := ""
+			if cluster.Id != nil {
+				ocid = *cluster.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add software version
+			if cluster.SoftwareVersion != nil {
+				additionalInfo["software_version"] = *cluster.SoftwareVersion
+			}
+
+			// Add total storage
+			if cluster.TotalStorageGB != nil {
+				additionalInfo["total_storage_gb"] = *cluster.TotalStorageGB
+			}
+
+			// Add automated snapshot policy
+			if cluster.BackupPolicy != nil {
+				if cluster.BackupPolicy.IsEnabled != nil {
+					additionalInfo["backup_policy_enabled"] = *cluster.BackupPolicy.IsEnabled
+				}
+				if cluster.BackupPolicy.FrequencyInHours != nil {
+					additionalInfo["backup_frequency_in_hours"] = *cluster.BackupPolicy.FrequencyInHours
+				}
+				if cluster.BackupPolicy.RetentionInDays != nil {
+					additionalInfo["backup_retention_in_days"] = *cluster.BackupPolicy.RetentionInDays
+				}
+			} else {
+				additionalInfo["backup_policy_enabled"] = false
+			}
+
+			// Add last snapshot time
+			if backupErr != nil {
+				additionalInfo["last_backup_time_error"] = backupErr.Error()
+			} else if lastBackup, exists := lastBackupTimes[ocid]; exists {
+				additionalInfo["last_backup_time"] = lastBackup.Format(time.RFC3339)
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "OpenSearchCluster", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(cluster.FreeformTags, cluster.DefinedTags).
+				withLifecycle(cluster.TimeCreated, string(cluster.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d OpenSearch clusters in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverOpenSearchClusterBackups discovers all OpenSearch cluster backups (snapshots) in a compartment
+func discoverOpenSearchClusterBackups(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting OpenSearch cluster backup discovery for compartment: %s", compartmentID)
+
+	allBackups, err := listOpenSearchClusterBackups(ctx, clients, compartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backup := range allBackups {
+		if backup.LifecycleState != opensearch.OpensearchClusterBackupLifecycleStateDeleted {
+			name := ""
+			if backup.DisplayName != nil {
+				name = *backup.DisplayName
+			}
+			ocid := ""
+			if backup.Id != nil {
+				ocid = *backup.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add backup type
+			additionalInfo["backup_type"] = string(backup.BackupType)
+
+			// Add source cluster
+			if backup.SourceClusterId != nil {
+				additionalInfo["source_cluster_id"] = *backup.SourceClusterId
+			}
+			if backup.SourceClusterDisplayName != nil {
+				additionalInfo["source_cluster_name"] = *backup.SourceClusterDisplayName
+			}
+
+			// Add backup size
+			if backup.BackupSize != nil {
+				additionalInfo["backup_size"] = *backup.BackupSize
+			}
+
+			// Add expiry time
+			if backup.TimeExpired != nil {
+				additionalInfo["time_expired"] = backup.TimeExpired.Format(time.RFC3339)
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "OpenSearchClusterBackup", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(backup.FreeformTags, backup.DefinedTags).
+				withLifecycle(backup.TimeCreated, string(backup.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d OpenSearch cluster backups in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverQuotas discovers all compartment quota policies as pseudo-resources, including their statements
+func discoverQuotas(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allQuotas []limits.QuotaSummary
+
+	logger.Debug("Starting quota discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all quotas
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching quotas page %d for compartment: %s", pageCount, compartmentID)
+		req := limits.ListQuotasRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.QuotasClient.ListQuotas(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allQuotas = append(allQuotas, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, quota := range allQuotas {
+		name := ""
+		if quota.Name != nil {
+			name = *quota.Name
+		}
+		ocid := ""
+		if quota.Id != nil {
+			ocid = *quota.Id
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		// Add description
+		if quota.Description != nil {
+			additionalInfo["description"] = *quota.Description
+		}
+
+		// Get quota details for the policy statements (not available in QuotaSummary)
+		if quota.Id != nil {
+			getReq := limits.GetQuotaRequest{
+				QuotaId: quota.Id,
+			}
+			getResp, err := clients.QuotasClient.GetQuota(ctx, getReq)
+			if err == nil {
+				additionalInfo["statement_count"] = len(getResp.Quota.Statements)
+				additionalInfo["statements"] = getResp.Quota.Statements
+			} else {
+				logger.Verbose("Error getting quota statements for %s: %v", name, err)
+			}
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "Quota", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(quota.FreeformTags, quota.DefinedTags).
+			withLifecycle(quota.TimeCreated, string(quota.LifecycleState)))
+	}
+
+	logger.Verbose("Found %d quotas in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// listTagNamespaces retrieves all tag namespaces defined in a compartment
+func listTagNamespaces(ctx context.Context, clients *OCIClients, compartmentID string) ([]identity.TagNamespaceSummary, error) {
+	var allTagNamespaces []identity.TagNamespaceSummary
+
+	// Implement pagination to get all tag namespaces
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching tag namespaces page %d for compartment: %s", pageCount, compartmentID)
+		req := identity.ListTagNamespacesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.IdentityClient.ListTagNamespaces(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTagNamespaces = append(allTagNamespaces, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	return allTagNamespaces, nil
+}
+
+// discoverTagNamespaces discovers all tag namespaces in a compartment
+func discoverTagNamespaces(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting tag namespace discovery for compartment: %s", compartmentID)
+
+	allTagNamespaces, err := listTagNamespaces(ctx, clients, compartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tagNamespace := range allTagNamespaces {
+		if tagNamespace.LifecycleState != identity.TagNamespaceLifecycleStateDeleted {
+			name := ""
+			if tagNamespace.Name != nil {
+				name = *tagNamespace.Name
+			}
+			ocid := ""
+			if tagNamespace.Id != nil {
+				ocid = *tagNamespace.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add description
+			if tagNamespace.Description != nil {
+				additionalInfo["description"] = *tagNamespace.Description
+			}
+
+			// Add retired flag
+			if tagNamespace.IsRetired != nil {
+				additionalInfo["is_retired"] = *tagNamespace.IsRetired
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "TagNamespace", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(tagNamespace.FreeformTags, tagNamespace.DefinedTags).
+				withLifecycle(tagNamespace.TimeCreated, string(tagNamespace.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d tag namespaces in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverTagDefinitions discovers all tag keys defined in the tag namespaces of a compartment
+func discoverTagDefinitions(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting tag definition discovery for compartment: %s", compartmentID)
+
+	// First, get all tag namespaces
+	allTagNamespaces, err := listTagNamespaces(ctx, clients, compartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Then, get all tag definitions for each namespace
+	for _, tagNamespace := range allTagNamespaces {
+		if tagNamespace.LifecycleState == identity.TagNamespaceLifecycleStateDeleted || tagNamespace.Id == nil {
+			continue
+		}
+
+		namespaceName := ""
+		if tagNamespace.Name != nil {
+			namespaceName = *tagNamespace.Name
+		}
+
+		var allTags []identity.TagSummary
+		var tagPage *string
+		tagPageCount := 0
+		for {
+			tagPageCount++
+			logger.Debug("Fetching tag definitions for namespace %s, page %d", namespaceName, tagPageCount)
+			tagReq := identity.ListTagsRequest{
+				TagNamespaceId: tagNamespace.Id,
+				Page:           tagPage,
+				Limit:          clients.pageLimit(maxPageSize),
+			}
+
+			tagResp, err := clients.IdentityClient.ListTags(ctx, tagReq)
+
+			if err != nil {
+				logger.Verbose("Error listing tag definitions for namespace %s: %v", namespaceName, err)
+				break
+			}
+
+			allTags = append(allTags, tagResp.Items...)
+
+			if tagResp.OpcNextPage == nil {
+				break
+			}
+			tagPage = tagResp.OpcNextPage
+		}
+
+		for _, tag := range allTags {
+			if tag.LifecycleState != identity.TagLifecycleStateDeleted {
+				name := ""
+				if tag.Name != nil {
+					name = fmt.Sprintf("%s.%s", namespaceName, *tag.Name)
+				}
+				ocid := ""
+				if tag.Id != nil {
+					ocid = *tag.Id
+				}
+
+				additionalInfo := make(map[string]interface{})
+
+				// Add namespace
+				additionalInfo["tag_namespace"] = namespaceName
+				additionalInfo["tag_namespace_id"] = *tagNamespace.Id
+
+				// Add cost-tracking flag
+				if tag.IsCostTracking != nil {
+					additionalInfo["is_cost_tracking"] = *tag.IsCostTracking
+				}
+
+				// Add retired flag
+				if tag.IsRetired != nil {
+					additionalInfo["is_retired"] = *tag.IsRetired
+				}
+
+				// Add description
+				if tag.Description != nil {
+					additionalInfo["description"] = *tag.Description
+				}
+
+				resources = append(resources, createResourceInfo(ctx, "TagDefinition", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+					withTags(tag.FreeformTags, tag.DefinedTags).
+					withLifecycle(tag.TimeCreated, string(tag.LifecycleState)))
+			}
+		}
+	}
+
+	logger.Verbose("Found %d tag definitions in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverIdentityDomains discovers all IAM identity domains in a compartment
+func discoverIdentityDomains(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting identity domain discovery for compartment: %s", compartmentID)
+
+	allDomains, err := listIdentityDomains(ctx, clients, compartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, domain := range allDomains {
+		if domain.LifecycleState != identity.DomainLifecycleStateDeleting {
+			name := ""
+			if domain.DisplayName != nil {
+				name = *domain.DisplayName
+			}
+			ocid := ""
+			if domain.Id != nil {
+				ocid = *domain.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add license type
+			if domain.LicenseType != nil {
+				additionalInfo["license_type"] = *domain.LicenseType
+			}
+
+			// Add domain URL
+			if domain.Url != nil {
+				additionalInfo["url"] = *domain.Url
+			}
+
+			// Add domain type (DEFAULT / SECONDARY)
+			additionalInfo["type"] = string(domain.Type)
+
+			// Add home region
+			if domain.HomeRegion != nil {
+				additionalInfo["home_region"] = *domain.HomeRegion
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "IdentityDomain", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(domain.FreeformTags, domain.DefinedTags).
+				withLifecycle(domain.TimeCreated, string(domain.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d identity domains in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverInstancePools discovers all instance pools in a compartment
+func discoverInstancePools(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allInstancePools []core.InstancePoolSummary
+
+	logger.Debug("Starting instance pool discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all instance pools
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching instance pools page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListInstancePoolsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ComputeManagementClient.ListInstancePools(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allInstancePools = append(allInstancePools, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, pool := range allInstancePools {
+		if pool.LifecycleState == core.InstancePoolSummaryLifecycleStateTerminated {
+			continue
+		}
+
+		name := ""
+		if pool.DisplayName != nil {
+			name = *pool.DisplayName
+		}
+		ocid := ""
+		if pool.Id != nil {
+			ocid = *pool.Id
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		// Add pool size and state
+		if pool.Size != nil {
+			additionalInfo["size"] = *pool.Size
+		}
+
+		// Add instance configuration used as the pool template
+		if pool.InstanceConfigurationId != nil {
+			additionalInfo["instance_configuration_id"] = *pool.InstanceConfigurationId
+		}
+
+		if len(pool.AvailabilityDomains) > 0 {
+			additionalInfo["availability_domains"] = pool.AvailabilityDomains
+		}
+
+		// Get pool details for load balancer attachments (not available in InstancePoolSummary)
+		if pool.Id != nil {
+			getReq := core.GetInstancePoolRequest{
+				InstancePoolId: pool.Id,
+			}
+			getResp, err := clients.ComputeManagementClient.GetInstancePool(ctx, getReq)
+			if err == nil {
+				var loadBalancers []map[string]interface{}
+				for _, attachment := range getResp.InstancePool.LoadBalancers {
+					if attachment.LifecycleState == core.InstancePoolLoadBalancerAttachmentLifecycleStateDetached {
+						continue
+					}
+					lbInfo := make(map[string]interface{})
+					if attachment.LoadBalancerId != nil {
+						lbInfo["load_balancer_id"] = *attachment.LoadBalancerId
+					}
+					if attachment.BackendSetName != nil {
+						lbInfo["backend_set_name"] = *attachment.BackendSetName
+					}
+					if attachment.Port != nil {
+						lbInfo["port"] = *attachment.Port
+					}
+					if attachment.VnicSelection != nil {
+						lbInfo["vnic_selection"] = *attachment.VnicSelection
+					}
+					loadBalancers = append(loadBalancers, lbInfo)
+				}
+				if len(loadBalancers) > 0 {
+					additionalInfo["load_balancers"] = loadBalancers
+				}
+			} else {
+				logger.Verbose("Error getting instance pool details for %s: %v", name, err)
+			}
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "InstancePool", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(pool.FreeformTags, pool.DefinedTags).
+			withLifecycle(pool.TimeCreated, string(pool.LifecycleState)))
+	}
+
+	logger.Verbose("Found %d instance pools in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverInstanceConfigurations discovers all instance configurations in a compartment
+func discoverInstanceConfigurations(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allInstanceConfigurations []core.InstanceConfigurationSummary
+
+	logger.Debug("Starting instance configuration discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all instance configurations
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching instance configurations page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListInstanceConfigurationsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ComputeManagementClient.ListInstanceConfigurations(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allInstanceConfigurations = append(allInstanceConfigurations, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, instanceConfiguration := range allInstanceConfigurations {
+		name := ""
+		if instanceConfiguration.DisplayName != nil {
+			name = *instanceConfiguration.DisplayName
+		}
+		ocid := ""
+		if instanceConfiguration.Id != nil {
+			ocid = *instanceConfiguration.Id
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		// Get configuration details for the launch template (not available in InstanceConfigurationSummary)
+		if instanceConfiguration.Id != nil {
+			getReq := core.GetInstanceConfigurationRequest{
+				InstanceConfigurationId: instanceConfiguration.Id,
+			}
+			getResp, err := clients.ComputeManagementClient.GetInstanceConfiguration(ctx, getReq)
+			if err == nil {
+				addInstanceConfigurationDetails(additionalInfo, getResp.InstanceConfiguration.InstanceDetails)
+			} else {
+				logger.Verbose("Error getting instance configuration details for %s: %v", name, err)
+			}
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "InstanceConfiguration", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(instanceConfiguration.FreeformTags, instanceConfiguration.DefinedTags).
+			withLifecycle(instanceConfiguration.TimeCreated, ""))
+	}
+
+	logger.Verbose("Found %d instance configurations in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// addInstanceConfigurationDetails adds the launch template of an instance configuration to additionalInfo
+func addInstanceConfigurationDetails(additionalInfo map[string]interface{}, instanceDetails core.InstanceConfigurationInstanceDetails) {
+	switch details := instanceDetails.(type) {
+	case core.ComputeInstanceDetails:
+		additionalInfo["instance_type"] = "compute"
+		additionalInfo["block_volume_count"] = len(details.BlockVolumes)
+		additionalInfo["secondary_vnic_count"] = len(details.SecondaryVnics)
+
+		launchDetails := details.LaunchDetails
+		if launchDetails == nil {
+			return
+		}
+		if launchDetails.Shape != nil {
+			additionalInfo["shape"] = *launchDetails.Shape
+		}
+		if launchDetails.ShapeConfig != nil {
+			if launchDetails.ShapeConfig.Ocpus != nil {
+				additionalInfo["ocpus"] = *launchDetails.ShapeConfig.Ocpus
+			}
+			if launchDetails.ShapeConfig.MemoryInGBs != nil {
+				additionalInfo["memory_in_gbs"] = *launchDetails.ShapeConfig.MemoryInGBs
+			}
+		}
+		if launchDetails.AvailabilityDomain != nil {
+			additionalInfo["availability_domain"] = *launchDetails.AvailabilityDomain
+		}
+		if source, ok := launchDetails.SourceDetails.(core.InstanceConfigurationInstanceSourceViaImageDetails); ok && source.ImageId != nil {
+			additionalInfo["image_id"] = *source.ImageId
+		}
+	case core.ComputeInstanceOptions:
+		additionalInfo["instance_type"] = "instance_options"
+		additionalInfo["option_count"] = len(details.Options)
+	}
+}
+
+// discoverClusterNetworks discovers all cluster networks (RDMA / HPC) in a compartment
+func discoverClusterNetworks(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allClusterNetworks []core.ClusterNetworkSummary
+
+	logger.Debug("Starting cluster network discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all cluster networks
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching cluster networks page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListClusterNetworksRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ComputeManagementClient.ListClusterNetworks(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allClusterNetworks = append(allClusterNetworks, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, clusterNetwork := range allClusterNetworks {
+		if clusterNetwork.LifecycleState == core.ClusterNetworkSummaryLifecycleStateTerminated {
+			continue
+		}
+
+		name := ""
+		if clusterNetwork.DisplayName != nil {
+			name = *clusterNetwork.DisplayName
+		}
+		ocid := ""
+		if clusterNetwork.Id != nil {
+			ocid = *clusterNetwork.Id
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		// Add instance pool linkage; the cluster network size is the sum of its pool sizes
+		totalSize := 0
+		var instancePoolIDs []string
+		for _, pool := range clusterNetwork.InstancePools {
+			if pool.Id != nil {
+				instancePoolIDs = append(instancePoolIDs, *pool.Id)
+			}
+			if pool.Size != nil {
+				totalSize += *pool.Size
+			}
+		}
+		additionalInfo["size"] = totalSize
+		additionalInfo["instance_pool_count"] = len(clusterNetwork.InstancePools)
+		if len(instancePoolIDs) > 0 {
+			additionalInfo["instance_pool_ids"] = instancePoolIDs
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "ClusterNetwork", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(clusterNetwork.FreeformTags, clusterNetwork.DefinedTags).
+			withLifecycle(clusterNetwork.TimeCreated, string(clusterNetwork.LifecycleState)))
+	}
+
+	logger.Verbose("Found %d cluster networks in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverVolumeGroups discovers all volume groups in a compartment
+func discoverVolumeGroups(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allVolumeGroups []core.VolumeGroup
+
+	logger.Debug("Starting volume group discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all volume groups
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching volume groups page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListVolumeGroupsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.BlockStorageClient.ListVolumeGroups(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allVolumeGroups = append(allVolumeGroups, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, volumeGroup := range allVolumeGroups {
+		if volumeGroup.LifecycleState != core.VolumeGroupLifecycleStateTerminated {
+			name := ""
+			if volumeGroup.DisplayName != nil {
+				name = *volumeGroup.DisplayName
+			}
+			ocid := ""
+			if volumeGroup.Id != nil {
+				ocid = *volumeGroup.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add member volume count and total size
+			additionalInfo["volume_count"] = len(volumeGroup.VolumeIds)
+			if volumeGroup.SizeInGBs != nil {
+				additionalInfo["size_in_gbs"] = *volumeGroup.SizeInGBs
+			}
+
+			if volumeGroup.AvailabilityDomain != nil {
+				additionalInfo["availability_domain"] = *volumeGroup.AvailabilityDomain
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "VolumeGroup", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(volumeGroup.FreeformTags, volumeGroup.DefinedTags).
+				withLifecycle(volumeGroup.TimeCreated, string(volumeGroup.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d volume groups in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverVolumeGroupBackups discovers all volume group backups in a compartment
+func discoverVolumeGroupBackups(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allVolumeGroupBackups []core.VolumeGroupBackup
+
+	logger.Debug("Starting volume group backup discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all volume group backups
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching volume group backups page %d for compartment: %s", pageCount, compartmentID)
+		req := core.ListVolumeGroupBackupsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.BlockStorageClient.ListVolumeGroupBackups(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allVolumeGroupBackups = append(allVolumeGroupBackups, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, backup := range allVolumeGroupBackups {
+		if backup.LifecycleState != core.VolumeGroupBackupLifecycleStateTerminated {
+			name := ""
+			if backup.DisplayName != nil {
+				name = *backup.DisplayName
+			}
+			ocid := ""
+			if backup.Id != nil {
+				ocid = *backup.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add backup size and type
+			if backup.SizeInGBs != nil {
+				additionalInfo["size_in_gbs"] = *backup.SizeInGBs
+			}
+			additionalInfo["type"] = string(backup.Type)
+			if backup.SourceType != "" {
+				additionalInfo["source_type"] = string(backup.SourceType)
+			}
+			additionalInfo["volume_backup_count"] = len(backup.VolumeBackupIds)
+
+			// Add source volume group linkage
+			if backup.VolumeGroupId != nil {
+				additionalInfo["volume_group_id"] = *backup.VolumeGroupId
+			}
+
+			if backup.ExpirationTime != nil {
+				additionalInfo["expiration_time"] = backup.ExpirationTime.Format(time.RFC3339)
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "VolumeGroupBackup", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(backup.FreeformTags, backup.DefinedTags).
+				withLifecycle(backup.TimeCreated, string(backup.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d volume group backups in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverMountTargets discovers all file storage mount targets in a compartment
+func discoverMountTargets(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting mount target discovery for compartment: %s", compartmentID)
+
+	// Get all availability domains for this compartment
+	availabilityDomains, err := getAvailabilityDomains(ctx, clients, compartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability domains: %w", err)
+	}
+
+	// Private IPs of the mount targets in all availability domains are resolved per subnet
+	resolver := newVNICResolver(clients)
+
+	// Search mount targets in each availability domain
+	for _, ad := range availabilityDomains {
+		if ad.Name == nil {
+			continue
+		}
+
+		adName := *ad.Name
+		logger.Debug("Searching mount targets in availability domain: %s", adName)
+
+		var allMountTargets []filestorage.MountTargetSummary
+
+		// Implement pagination to get all mount targets in this AD
+		var page *string
+		pageCount := 0
+		for {
+			pageCount++
+			logger.Debug("Fetching mount targets page %d for compartment: %s, AD: %s", pageCount, compartmentID, adName)
+			req := filestorage.ListMountTargetsRequest{
+				CompartmentId:      common.String(compartmentID),
+				AvailabilityDomain: common.String(adName),
+				Page:               page,
+				Limit:              clients.pageLimit(maxPageSize),
+			}
+
+			resp, err := clients.FileStorageClient.ListMountTargets(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing mount targets in AD %s: %v", adName, err)
+				break // Continue with next AD instead of failing completely
+			}
+
+			allMountTargets = append(allMountTargets, resp.Items...)
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+
+		// Process mount targets found in this AD
+		for _, mountTarget := range allMountTargets {
+			if mountTarget.LifecycleState != filestorage.MountTargetSummaryLifecycleStateDeleted {
+				name := ""
+				if mountTarget.DisplayName != nil {
+					name = *mountTarget.DisplayName
+				}
+				ocid := ""
+				if mountTarget.Id != nil {
+					ocid = *mountTarget.Id
+				}
+
+				additionalInfo := make(map[string]interface{})
+
+				// Resolve private IP addresses from their OCIDs, listing each subnet once
+				subnetID := ""
+				if mountTarget.SubnetId != nil {
+					subnetID = *mountTarget.SubnetId
+				}
+				var privateIPs []string
+				for _, privateIPID := range mountTarget.PrivateIpIds {
+					address, err := resolver.privateIPAddress(ctx, subnetID, privateIPID)
+					if err != nil {
+						logger.Verbose("Error getting private IP %s for mount target %s: %v", privateIPID, name, err)
+						continue
+					}
+					if address != "" {
+						privateIPs = append(privateIPs, address)
+					}
+				}
+				if len(privateIPs) > 0 {
+					additionalInfo["private_ips"] = privateIPs
+				}
+
+				if mountTarget.SubnetId != nil {
+					additionalInfo["subnet_id"] = *mountTarget.SubnetId
+				}
+				if mountTarget.ExportSetId != nil {
+					additionalInfo["export_set_id"] = *mountTarget.ExportSetId
+				}
+
+				// Add availability domain
+				additionalInfo["availability_domain"] = adName
+
+				resources = append(resources, createResourceInfo(ctx, "MountTarget", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+					withTags(mountTarget.FreeformTags, mountTarget.DefinedTags).
+					withLifecycle(mountTarget.TimeCreated, string(mountTarget.LifecycleState)))
+			}
+		}
+	}
+
+	logger.Verbose("Found %d mount targets in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverFileStorageExports discovers all file storage exports in a compartment
+func discoverFileStorageExports(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allExports []filestorage.ExportSummary
+
+	logger.Debug("Starting file storage export discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all exports
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching file storage exports page %d for compartment: %s", pageCount, compartmentID)
+		req := filestorage.ListExportsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.FileStorageClient.ListExports(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allExports = append(allExports, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, export := range allExports {
+		if export.LifecycleState != filestorage.ExportSummaryLifecycleStateDeleted {
+			// Exports have no display name; the export path identifies them
+			name := ""
+			if export.Path != nil {
+				name = *export.Path
+			}
+			ocid := ""
+			if export.Id != nil {
+				ocid = *export.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			if export.Path != nil {
+				additionalInfo["path"] = *export.Path
+			}
+			if export.FileSystemId != nil {
+				additionalInfo["file_system_id"] = *export.FileSystemId
+			}
+			if export.ExportSetId != nil {
+				additionalInfo["export_set_id"] = *export.ExportSetId
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "FileStorageExport", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withLifecycle(export.TimeCreated, string(export.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d file storage exports in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverFileStorageSnapshots discovers all snapshots of the file systems in a compartment
+func discoverFileStorageSnapshots(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	logger.Debug("Starting file storage snapshot discovery for compartment: %s", compartmentID)
+
+	// Get all availability domains for this compartment
+	availabilityDomains, err := getAvailabilityDomains(ctx, clients, compartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability domains: %w", err)
+	}
+
+	// Collect file systems in each availability domain
+	var allFileSystems []filestorage.FileSystemSummary
+	for _, ad := range availabilityDomains {
+		if ad.Name == nil {
+			continue
+		}
+
+		adName := *ad.Name
+
+		// Implement pagination to get all file systems in this AD
+		var page *string
+		for {
+			req := filestorage.ListFileSystemsRequest{
+				CompartmentId:      common.String(compartmentID),
+				AvailabilityDomain: common.String(adName),
+				Page:               page,
+				Limit:              clients.pageLimit(maxPageSize),
+			}
+
+			resp, err := clients.FileStorageClient.ListFileSystems(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing file systems in AD %s: %v", adName, err)
+				break // Continue with next AD instead of failing completely
+			}
+
+			allFileSystems = append(allFileSystems, resp.Items...)
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+	}
+
+	// List snapshots for each file system
+	for _, fileSystem := range allFileSystems {
+		if fileSystem.Id == nil || fileSystem.LifecycleState == filestorage.FileSystemSummaryLifecycleStateDeleted {
+			continue
+		}
+
+		fileSystemName := ""
+		if fileSystem.DisplayName != nil {
+			fileSystemName = *fileSystem.DisplayName
+		}
+
+		var page *string
+		pageCount := 0
+		for {
+			pageCount++
+			logger.Debug("Fetching snapshots page %d for file system: %s", pageCount, fileSystemName)
+			req := filestorage.ListSnapshotsRequest{
+				FileSystemId: fileSystem.Id,
+				Page:         page,
+				Limit:        clients.pageLimit(100),
+			}
+
+			resp, err := clients.FileStorageClient.ListSnapshots(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing snapshots for file system %s: %v", fileSystemName, err)
+				break // Continue with next file system instead of failing completely
+			}
+
+			for _, snapshot := range resp.Items {
+				if snapshot.LifecycleState == filestorage.SnapshotSummaryLifecycleStateDeleted {
+					continue
+				}
+
+				name := ""
+				if snapshot.Name != nil {
+					name = *snapshot.Name
+				}
+				ocid := ""
+				if snapshot.Id != nil {
+					ocid = *snapshot.Id
+				}
+
+				additionalInfo := make(map[string]interface{})
+
+				// Add source file system linkage
+				additionalInfo["file_system_id"] = *fileSystem.Id
+				additionalInfo["file_system_name"] = fileSystemName
+
+				if snapshot.SnapshotType != "" {
+					additionalInfo["snapshot_type"] = string(snapshot.SnapshotType)
+				}
+				if snapshot.ExpirationTime != nil {
+					additionalInfo["expiration_time"] = snapshot.ExpirationTime.Format(time.RFC3339)
+				}
+
+				resources = append(resources, createResourceInfo(ctx, "FileStorageSnapshot", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+					withTags(snapshot.FreeformTags, snapshot.DefinedTags).
+					withLifecycle(snapshot.TimeCreated, string(snapshot.LifecycleState)))
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+	}
+
+	logger.Verbose("Found %d file storage snapshots in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverStreamPools discovers all stream pools in a compartment
+func discoverStreamPools(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allStreamPools []streaming.StreamPoolSummary
+
+	logger.Debug("Starting stream pool discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all stream pools
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching stream pools page %d for compartment: %s", pageCount, compartmentID)
+		req := streaming.ListStreamPoolsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(50),
+		}
+
+		resp, err := clients.StreamingClient.ListStreamPools(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allStreamPools = append(allStreamPools, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, streamPool := range allStreamPools {
+		if streamPool.LifecycleState != streaming.StreamPoolSummaryLifecycleStateDeleted {
+			name := ""
+			if streamPool.Name != nil {
+				name = *streamPool.Name
+			}
+			ocid := ""
+			if streamPool.Id != nil {
+				ocid = *streamPool.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			if streamPool.IsPrivate != nil {
+				additionalInfo["is_private"] = *streamPool.IsPrivate
+			}
+
+			// Get stream pool details for Kafka and endpoint settings
+			if streamPool.Id != nil {
+				getReq := streaming.GetStreamPoolRequest{
+					StreamPoolId: streamPool.Id,
+				}
+				getResp, err := clients.StreamingClient.GetStreamPool(ctx, getReq)
+				if err == nil {
+					pool := getResp.StreamPool
+					if pool.EndpointFqdn != nil {
+						additionalInfo["endpoint_fqdn"] = *pool.EndpointFqdn
+					}
+					if pool.KafkaSettings != nil {
+						if pool.KafkaSettings.BootstrapServers != nil {
+							additionalInfo["kafka_bootstrap_servers"] = *pool.KafkaSettings.BootstrapServers
+						}
+						if pool.KafkaSettings.AutoCreateTopicsEnable != nil {
+							additionalInfo["kafka_auto_create_topics"] = *pool.KafkaSettings.AutoCreateTopicsEnable
+						}
+						if pool.KafkaSettings.LogRetentionHours != nil {
+							additionalInfo["kafka_log_retention_hours"] = *pool.KafkaSettings.LogRetentionHours
+						}
+						if pool.KafkaSettings.NumPartitions != nil {
+							additionalInfo["kafka_num_partitions"] = *pool.KafkaSettings.NumPartitions
+						}
+					}
+					if pool.PrivateEndpointSettings != nil {
+						if pool.PrivateEndpointSettings.SubnetId != nil {
+							additionalInfo["private_endpoint_subnet_id"] = *pool.PrivateEndpointSettings.SubnetId
+						}
+						if pool.PrivateEndpointSettings.PrivateEndpointIp != nil {
+							additionalInfo["private_endpoint_ip"] = *pool.PrivateEndpointSettings.PrivateEndpointIp
+						}
+					}
+				} else {
+					logger.Verbose("Error getting stream pool details for %s: %v", name, err)
+				}
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "StreamPool", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(streamPool.FreeformTags, streamPool.DefinedTags).
+				withLifecycle(streamPool.TimeCreated, string(streamPool.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d stream pools in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverHealthChecks discovers all HTTP and ping health check monitors in a compartment
+func discoverHealthChecks(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allHttpMonitors []healthchecks.HttpMonitorSummary
+	var allPingMonitors []healthchecks.PingMonitorSummary
+
+	logger.Debug("Starting health check discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all HTTP monitors
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching HTTP monitors page %d for compartment: %s", pageCount, compartmentID)
+		req := healthchecks.ListHttpMonitorsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.HealthChecksClient.ListHttpMonitors(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allHttpMonitors = append(allHttpMonitors, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// Implement pagination to get all ping monitors
+	page = nil
+	pageCount = 0
+	for {
+		pageCount++
+		logger.Debug("Fetching ping monitors page %d for compartment: %s", pageCount, compartmentID)
+		req := healthchecks.ListPingMonitorsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.HealthChecksClient.ListPingMonitors(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allPingMonitors = append(allPingMonitors, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, monitor := range allHttpMonitors {
+		name := ""
+		if monitor.DisplayName != nil {
+			name = *monitor.DisplayName
+		}
+		ocid := ""
+		if monitor.Id != nil {
+			ocid = *monitor.Id
+		}
+
+		additionalInfo := make(map[string]interface{})
+		additionalInfo["monitor_type"] = "http"
+		additionalInfo["protocol"] = string(monitor.Protocol)
+		if monitor.IntervalInSeconds != nil {
+			additionalInfo["interval_in_seconds"] = *monitor.IntervalInSeconds
+		}
+		if monitor.IsEnabled != nil {
+			additionalInfo["is_enabled"] = *monitor.IsEnabled
+		}
+
+		// Get monitor details for targets (not available in HttpMonitorSummary)
+		if monitor.Id != nil {
+			getReq := healthchecks.GetHttpMonitorRequest{
+				MonitorId: monitor.Id,
+			}
+			getResp, err := clients.HealthChecksClient.GetHttpMonitor(ctx, getReq)
+			if err == nil {
+				additionalInfo["targets"] = getResp.HttpMonitor.Targets
+				if getResp.HttpMonitor.Port != nil {
+					additionalInfo["port"] = *getResp.HttpMonitor.Port
+				}
+				if getResp.HttpMonitor.Path != nil {
+					additionalInfo["path"] = *getResp.HttpMonitor.Path
+				}
+			} else {
+				logger.Verbose("Error getting HTTP monitor details for %s: %v", name, err)
+			}
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "HealthCheck", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(monitor.FreeformTags, monitor.DefinedTags).
+			withLifecycle(monitor.TimeCreated, ""))
+	}
+
+	for _, monitor := range allPingMonitors {
+		name := ""
+		if monitor.DisplayName != nil {
+			name = *monitor.DisplayName
+		}
+		ocid := ""
+		if monitor.Id != nil {
+			ocid = *monitor.Id
+		}
+
+		additionalInfo := make(map[string]interface{})
+		additionalInfo["monitor_type"] = "ping"
+		additionalInfo["protocol"] = string(monitor.Protocol)
+		if monitor.IntervalInSeconds != nil {
+			additionalInfo["interval_in_seconds"] = *monitor.IntervalInSeconds
+		}
+		if monitor.IsEnabled != nil {
+			additionalInfo["is_enabled"] = *monitor.IsEnabled
+		}
+
+		// Get monitor details for targets (not available in PingMonitorSummary)
+		if monitor.Id != nil {
+			getReq := healthchecks.GetPingMonitorRequest{
+				MonitorId: monitor.Id,
+			}
+			getResp, err := clients.HealthChecksClient.GetPingMonitor(ctx, getReq)
+			if err == nil {
+				additionalInfo["targets"] = getResp.PingMonitor.Targets
+				if getResp.PingMonitor.Port != nil {
+					additionalInfo["port"] = *getResp.PingMonitor.Port
+				}
+			} else {
+				logger.Verbose("Error getting ping monitor details for %s: %v", name, err)
+			}
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "HealthCheck", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(monitor.FreeformTags, monitor.DefinedTags).
+			withLifecycle(monitor.TimeCreated, ""))
+	}
+
+	logger.Verbose("Found %d health checks in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverManagedInstances discovers all OS Management Hub managed instances in a compartment
+func discoverManagedInstances(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allManagedInstances []osmanagementhub.ManagedInstanceSummary
+
+	logger.Debug("Starting managed instance discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all managed instances
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching managed instances page %d for compartment: %s", pageCount, compartmentID)
+		req := osmanagementhub.ListManagedInstancesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ManagedInstanceClient.ListManagedInstances(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allManagedInstances = append(allManagedInstances, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// OS Management Hub does not return tags; an OCI instance shares its OCID with its compute instance
+	var instances map[string]core.Instance
+	for _, managedInstance := range allManagedInstances {
+		if managedInstance.Location == osmanagementhub.ManagedInstanceLocationOciCompute {
+			instances = listManagedComputeInstances(ctx, clients, compartmentID)
+			break
+		}
+	}
+
+	for _, managedInstance := range allManagedInstances {
+		name := ""
+		if managedInstance.DisplayName != nil {
+			name = *managedInstance.DisplayName
+		}
+		ocid := ""
+		if managedInstance.Id != nil {
+			ocid = *managedInstance.Id
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		// Add patch-management status
+		additionalInfo["status"] = string(managedInstance.Status)
+		if managedInstance.OsFamily != "" {
+			additionalInfo["os_family"] = string(managedInstance.OsFamily)
+		}
+		if managedInstance.Location != "" {
+			additionalInfo["location"] = string(managedInstance.Location)
+		}
+		if managedInstance.UpdatesAvailable != nil {
+			additionalInfo["updates_available"] = *managedInstance.UpdatesAvailable
+		}
+		if managedInstance.IsRebootRequired != nil {
+			additionalInfo["is_reboot_required"] = *managedInstance.IsRebootRequired
+		}
+		if managedInstance.AgentVersion != nil {
+			additionalInfo["agent_version"] = *managedInstance.AgentVersion
+		}
+
+		// Add group membership
+		if managedInstance.ManagedInstanceGroup != nil {
+			if managedInstance.ManagedInstanceGroup.Id != nil {
+				additionalInfo["managed_instance_group_id"] = *managedInstance.ManagedInstanceGroup.Id
+			}
+			if managedInstance.ManagedInstanceGroup.DisplayName != nil {
+				additionalInfo["managed_instance_group_name"] = *managedInstance.ManagedInstanceGroup.DisplayName
+			}
+		}
+
+		instance := instances[ocid]
+		resources = append(resources, createResourceInfo(ctx, "ManagedInstance", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(instance.FreeformTags, instance.DefinedTags))
+	}
+
+	logger.Verbose("Found %d managed instances in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// listManagedComputeInstances returns the compute instances of a compartment by OCID for the tags of managed instances
+// Tags are supplementary, so a failure is logged and the managed instances are kept without tags.
+func listManagedComputeInstances(ctx context.Context, clients *OCIClients, compartmentID string) map[string]core.Instance {
+	instances := make(map[string]core.Instance)
+
+	var page *string
+	for {
+		resp, err := clients.ComputeClient.ListInstances(ctx, core.ListInstancesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		})
+		if err != nil {
+			logger.Verbose("Could not list compute instances for managed instance tags in compartment %s: %v", compartmentID, err)
+			return instances
+		}
+
+		for _, instance := range resp.Items {
+			if instance.Id != nil {
+				instances[*instance.Id] = instance
+			}
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	return instances
+}
+
+// discoverManagedInstanceGroups discovers all OS Management Hub managed instance groups in a compartment
+func discoverManagedInstanceGroups(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allGroups []osmanagementhub.ManagedInstanceGroupSummary
+
+	logger.Debug("Starting managed instance group discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all managed instance groups
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching managed instance groups page %d for compartment: %s", pageCount, compartmentID)
+		req := osmanagementhub.ListManagedInstanceGroupsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.ManagedInstanceGroupClient.ListManagedInstanceGroups(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allGroups = append(allGroups, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, group := range allGroups {
+		if group.LifecycleState != osmanagementhub.ManagedInstanceGroupLifecycleStateDeleted {
+			name := ""
+			if group.DisplayName != nil {
+				name = *group.DisplayName
+			}
+			ocid := ""
+			if group.Id != nil {
+				ocid = *group.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			if group.ManagedInstanceCount != nil {
+				additionalInfo["managed_instance_count"] = *group.ManagedInstanceCount
+			}
+			if group.OsFamily != "" {
+				additionalInfo["os_family"] = string(group.OsFamily)
+			}
+			if group.ArchType != "" {
+				additionalInfo["arch_type"] = string(group.ArchType)
+			}
+			if group.Location != "" {
+				additionalInfo["location"] = string(group.Location)
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "ManagedInstanceGroup", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(group.FreeformTags, group.DefinedTags).
+				withLifecycle(group.TimeCreated, string(group.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d managed instance groups in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// opsiEnrollmentStatuses lists the Operations Insights statuses reported as enrollment status
+var opsiEnrollmentStatuses = []opsi.ResourceStatusEnum{opsi.ResourceStatusEnabled, opsi.ResourceStatusDisabled}
+
+// discoverDatabaseInsights discovers all Operations Insights database insights in a compartment
+func discoverDatabaseInsights(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allInsights []opsi.DatabaseInsightSummary
+
+	logger.Debug("Starting database insight discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all database insights
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching database insights page %d for compartment: %s", pageCount, compartmentID)
+		req := opsi.ListDatabaseInsightsRequest{
+			CompartmentId: common.String(compartmentID),
+			Status:        opsiEnrollmentStatuses,
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.OperationsInsightsClient.ListDatabaseInsights(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allInsights = append(allInsights, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, insight := range allInsights {
+		// Skip entity sources unknown to the SDK
+		if insight == nil || insight.GetLifecycleState() == opsi.LifecycleStateDeleted {
+			continue
+		}
+
+		name := ""
+		if insight.GetDatabaseDisplayName() != nil {
+			name = *insight.GetDatabaseDisplayName()
+		} else if insight.GetDatabaseName() != nil {
+			name = *insight.GetDatabaseName()
+		}
+		ocid := ""
+		if insight.GetId() != nil {
+			ocid = *insight.GetId()
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		// Add enrollment status
+		additionalInfo["status"] = string(insight.GetStatus())
+		if insight.GetDatabaseId() != nil {
+			additionalInfo["database_id"] = *insight.GetDatabaseId()
+		}
+		if insight.GetDatabaseType() != nil {
+			additionalInfo["database_type"] = *insight.GetDatabaseType()
+		}
+		if insight.GetDatabaseVersion() != nil {
+			additionalInfo["database_version"] = *insight.GetDatabaseVersion()
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "DatabaseInsight", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(insight.GetFreeformTags(), insight.GetDefinedTags()).
+			withLifecycle(insight.GetTimeCreated(), string(insight.GetLifecycleState())))
+	}
+
+	logger.Verbose("Found %d database insights in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverHostInsights discovers all Operations Insights host insights in a compartment
+func discoverHostInsights(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allInsights []opsi.HostInsightSummary
+
+	logger.Debug("Starting host insight discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all host insights
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching host insights page %d for compartment: %s", pageCount, compartmentID)
+		req := opsi.ListHostInsightsRequest{
+			CompartmentId: common.String(compartmentID),
+			Status:        opsiEnrollmentStatuses,
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.OperationsInsightsClient.ListHostInsights(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allInsights = append(allInsights, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, insight := range allInsights {
+		// Skip entity sources unknown to the SDK
+		if insight == nil || insight.GetLifecycleState() == opsi.LifecycleStateDeleted {
+			continue
+		}
+
+		name := ""
+		if insight.GetHostDisplayName() != nil {
+			name = *insight.GetHostDisplayName()
+		} else if insight.GetHostName() != nil {
+			name = *insight.GetHostName()
+		}
+		ocid := ""
+		if insight.GetId() != nil {
+			ocid = *insight.GetId()
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		// Add enrollment status
+		additionalInfo["status"] = string(insight.GetStatus())
+		if insight.GetHostName() != nil {
+			additionalInfo["host_name"] = *insight.GetHostName()
+		}
+		if insight.GetHostType() != nil {
+			additionalInfo["host_type"] = *insight.GetHostType()
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "HostInsight", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(insight.GetFreeformTags(), insight.GetDefinedTags()).
+			withLifecycle(insight.GetTimeCreated(), string(insight.GetLifecycleState())))
+	}
+
+	logger.Verbose("Found %d host insights in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverManagedDatabases discovers all Database Management managed databases in a compartment
+func discoverManagedDatabases(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allManagedDatabases []databasemanagement.ManagedDatabaseSummary
+
+	logger.Debug("Starting managed database discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all managed databases
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching managed databases page %d for compartment: %s", pageCount, compartmentID)
+		req := databasemanagement.ListManagedDatabasesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DbManagementClient.ListManagedDatabases(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allManagedDatabases = append(allManagedDatabases, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, managedDatabase := range allManagedDatabases {
+		name := ""
+		if managedDatabase.Name != nil {
+			name = *managedDatabase.Name
+		}
+		ocid := ""
+		if managedDatabase.Id != nil {
+			ocid = *managedDatabase.Id
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		// Add management type (BASIC / ADVANCED)
+		if managedDatabase.ManagementOption != "" {
+			additionalInfo["management_option"] = string(managedDatabase.ManagementOption)
+		}
+
+		additionalInfo["database_type"] = string(managedDatabase.DatabaseType)
+		additionalInfo["database_sub_type"] = string(managedDatabase.DatabaseSubType)
+		if managedDatabase.DeploymentType != "" {
+			additionalInfo["deployment_type"] = string(managedDatabase.DeploymentType)
+		}
+		if managedDatabase.DatabaseVersion != nil {
+			additionalInfo["database_version"] = *managedDatabase.DatabaseVersion
+		}
+		if managedDatabase.IsCluster != nil {
+			additionalInfo["is_cluster"] = *managedDatabase.IsCluster
+		}
+		if managedDatabase.ParentContainerId != nil {
+			additionalInfo["parent_container_id"] = *managedDatabase.ParentContainerId
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "ManagedDatabase", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(managedDatabase.FreeformTags, managedDatabase.DefinedTags).
+			withLifecycle(managedDatabase.TimeCreated, ""))
+	}
+
+	logger.Verbose("Found %d managed databases in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverExternalContainerDatabases discovers all registered external container databases in a compartment
+func discoverExternalContainerDatabases(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allDatabases []database.ExternalContainerDatabaseSummary
+
+	logger.Debug("Starting external container database discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all external container databases
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching external container databases page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListExternalContainerDatabasesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListExternalContainerDatabases(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allDatabases = append(allDatabases, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, db := range allDatabases {
+		if db.LifecycleState != database.ExternalContainerDatabaseSummaryLifecycleStateTerminated {
+			name := ""
+			if db.DisplayName != nil {
+				name = *db.DisplayName
+			}
+			ocid := ""
+			if db.Id != nil {
+				ocid = *db.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+			addExternalDatabaseInfo(additionalInfo, db.DbUniqueName, db.DatabaseVersion, string(db.DatabaseEdition), db.DatabaseManagementConfig)
+
+			resources = append(resources, createResourceInfo(ctx, "ExternalContainerDatabase", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(db.FreeformTags, db.DefinedTags).
+				withLifecycle(db.TimeCreated, string(db.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d external container databases in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverExternalPluggableDatabases discovers all registered external pluggable databases in a compartment
+func discoverExternalPluggableDatabases(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allDatabases []database.ExternalPluggableDatabaseSummary
+
+	logger.Debug("Starting external pluggable database discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all external pluggable databases
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching external pluggable databases page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListExternalPluggableDatabasesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListExternalPluggableDatabases(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allDatabases = append(allDatabases, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, db := range allDatabases {
+		if db.LifecycleState != database.ExternalPluggableDatabaseSummaryLifecycleStateTerminated {
+			name := ""
+			if db.DisplayName != nil {
+				name = *db.DisplayName
+			}
+			ocid := ""
+			if db.Id != nil {
+				ocid = *db.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+			addExternalDatabaseInfo(additionalInfo, db.DbUniqueName, db.DatabaseVersion, string(db.DatabaseEdition), db.DatabaseManagementConfig)
+
+			// Link pluggable database to its container database
+			if db.ExternalContainerDatabaseId != nil {
+				additionalInfo["external_container_database_id"] = *db.ExternalContainerDatabaseId
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "ExternalPluggableDatabase", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(db.FreeformTags, db.DefinedTags).
+				withLifecycle(db.TimeCreated, string(db.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d external pluggable databases in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverExternalNonContainerDatabases discovers all registered external non-container databases in a compartment
+func discoverExternalNonContainerDatabases(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allDatabases []database.ExternalNonContainerDatabaseSummary
+
+	logger.Debug("Starting external non-container database discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all external non-container databases
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching external non-container databases page %d for compartment: %s", pageCount, compartmentID)
+		req := database.ListExternalNonContainerDatabasesRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListExternalNonContainerDatabases(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allDatabases = append(allDatabases, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, db := range allDatabases {
+		if db.LifecycleState != database.ExternalNonContainerDatabaseSummaryLifecycleStateTerminated {
+			name := ""
+			if db.DisplayName != nil {
+				name = *db.DisplayName
+			}
+			ocid := ""
+			if db.Id != nil {
+				ocid = *db.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+			addExternalDatabaseInfo(additionalInfo, db.DbUniqueName, db.DatabaseVersion, string(db.DatabaseEdition), db.DatabaseManagementConfig)
+
+			resources = append(resources, createResourceInfo(ctx, "ExternalNonContainerDatabase", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(db.FreeformTags, db.DefinedTags).
+				withLifecycle(db.TimeCreated, string(db.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d external non-container databases in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// addExternalDatabaseInfo adds the fields shared by all external database kinds to additionalInfo
+func addExternalDatabaseInfo(additionalInfo map[string]interface{}, dbUniqueName, databaseVersion *string, databaseEdition string, managementConfig *database.DatabaseManagementConfig) {
+	if dbUniqueName != nil {
+		additionalInfo["db_unique_name"] = *dbUniqueName
+	}
+	if databaseVersion != nil {
+		additionalInfo["database_version"] = *databaseVersion
+	}
+	if databaseEdition != "" {
+		additionalInfo["database_edition"] = databaseEdition
+	}
+	if managementConfig != nil {
+		additionalInfo["database_management_status"] = string(managementConfig.DatabaseManagementStatus)
+	}
+}
+
+// discoverExadataDbServers discovers the DB servers of all Exadata and Cloud Exadata Infrastructures in a compartment
+func discoverExadataDbServers(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var infrastructureIDs []string
+
+	logger.Debug("Starting Exadata DB server discovery for compartment: %s", compartmentID)
+
+	// Collect Exadata Cloud@Customer infrastructures
+	var page *string
+	for {
+		req := database.ListExadataInfrastructuresRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListExadataInfrastructures(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, exaInfra := range resp.Items {
+			if exaInfra.Id != nil && string(exaInfra.LifecycleState) != "TERMINATED" {
+				infrastructureIDs = append(infrastructureIDs, *exaInfra.Id)
+			}
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// Collect Cloud Exadata infrastructures
+	page = nil
+	for {
+		req := database.ListCloudExadataInfrastructuresRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DatabaseClient.ListCloudExadataInfrastructures(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cloudExaInfra := range resp.Items {
+			if cloudExaInfra.Id != nil && string(cloudExaInfra.LifecycleState) != "TERMINATED" {
+				infrastructureIDs = append(infrastructureIDs, *cloudExaInfra.Id)
+			}
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// List DB servers beneath each infrastructure
+	for _, infrastructureID := range infrastructureIDs {
+		var page *string
+		pageCount := 0
+		for {
+			pageCount++
+			logger.Debug("Fetching DB servers page %d for Exadata infrastructure: %s", pageCount, infrastructureID)
+			req := database.ListDbServersRequest{
+				CompartmentId:           common.String(compartmentID),
+				ExadataInfrastructureId: common.String(infrastructureID),
+				Page:                    page,
+				Limit:                   clients.pageLimit(maxPageSize),
+			}
+
+			resp, err := clients.DatabaseClient.ListDbServers(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing DB servers for Exadata infrastructure %s: %v", infrastructureID, err)
+				break // Continue with next infrastructure instead of failing completely
+			}
+
+			for _, dbServer := range resp.Items {
+				if dbServer.LifecycleState == database.DbServerSummaryLifecycleStateDeleted {
+					continue
+				}
+
+				name := ""
+				if dbServer.DisplayName != nil {
+					name = *dbServer.DisplayName
+				}
+				ocid := ""
+				if dbServer.Id != nil {
+					ocid = *dbServer.Id
+				}
+
+				additionalInfo := make(map[string]interface{})
+				additionalInfo["exadata_infrastructure_id"] = infrastructureID
+
+				if dbServer.Shape != nil {
+					additionalInfo["shape"] = *dbServer.Shape
+				}
+				if dbServer.CpuCoreCount != nil {
+					additionalInfo["cpu_core_count"] = *dbServer.CpuCoreCount
+				}
+				if dbServer.MaxCpuCount != nil {
+					additionalInfo["max_cpu_count"] = *dbServer.MaxCpuCount
+				}
+				if dbServer.MemorySizeInGBs != nil {
+					additionalInfo["memory_size_in_gbs"] = *dbServer.MemorySizeInGBs
+				}
+				if dbServer.DbNodeStorageSizeInGBs != nil {
+					additionalInfo["db_node_storage_size_in_gbs"] = *dbServer.DbNodeStorageSizeInGBs
+				}
+				additionalInfo["db_node_count"] = len(dbServer.DbNodeIds)
+				additionalInfo["vm_cluster_count"] = len(dbServer.VmClusterIds)
+
+				resources = append(resources, createResourceInfo(ctx, "ExadataDbServer", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+					withTags(dbServer.FreeformTags, dbServer.DefinedTags).
+					withLifecycle(dbServer.TimeCreated, string(dbServer.LifecycleState)))
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+	}
+
+	logger.Verbose("Found %d Exadata DB servers in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverExadataStorageServers discovers the storage servers of the Exadata infrastructures monitored by Database Management
+// The Database service reports storage servers only as counts on the infrastructure, so only Exadata systems
+// enabled for Database Management have their storage servers listed.
+func discoverExadataStorageServers(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var infrastructureIDs []string
+
+	logger.Debug("Starting Exadata storage server discovery for compartment: %s", compartmentID)
+
+	// Collect the Exadata infrastructures known to Database Management
+	var page *string
+	for {
+		req := databasemanagement.ListExternalExadataInfrastructuresRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.DbManagementClient.ListExternalExadataInfrastructures(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, exaInfra := range resp.Items {
+			if exaInfra.Id != nil && exaInfra.LifecycleState != databasemanagement.DbmResourceLifecycleStateDeleted {
+				infrastructureIDs = append(infrastructureIDs, *exaInfra.Id)
+			}
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// List storage servers beneath each infrastructure
+	for _, infrastructureID := range infrastructureIDs {
+		var page *string
+		pageCount := 0
+		for {
+			pageCount++
+			logger.Debug("Fetching storage servers page %d for Exadata infrastructure: %s", pageCount, infrastructureID)
+			req := databasemanagement.ListExternalExadataStorageServersRequest{
+				CompartmentId:                   common.String(compartmentID),
+				ExternalExadataInfrastructureId: common.String(infrastructureID),
+				Page:                            page,
+				Limit:                           clients.pageLimit(maxPageSize),
+			}
+
+			resp, err := clients.DbManagementClient.ListExternalExadataStorageServers(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing storage servers for Exadata infrastructure %s: %v", infrastructureID, err)
+				break // Continue with next infrastructure instead of failing completely
+			}
+
+			for _, storageServer := range resp.Items {
+				if storageServer.LifecycleState == databasemanagement.DbmResourceLifecycleStateDeleted {
+					continue
+				}
+
+				name := ""
+				if storageServer.DisplayName != nil {
+					name = *storageServer.DisplayName
+				}
+				ocid := ""
+				if storageServer.Id != nil {
+					ocid = *storageServer.Id
+				}
+
+				additionalInfo := make(map[string]interface{})
+				additionalInfo["exadata_infrastructure_id"] = infrastructureID
+
+				if storageServer.Version != nil {
+					additionalInfo["version"] = *storageServer.Version
+				}
+				if storageServer.Status != nil {
+					additionalInfo["status"] = *storageServer.Status
+				}
+				if storageServer.MakeModel != nil {
+					additionalInfo["make_model"] = *storageServer.MakeModel
+				}
+				if storageServer.IpAddress != nil {
+					additionalInfo["ip_address"] = *storageServer.IpAddress
+				}
+				if storageServer.CpuCount != nil {
+					additionalInfo["cpu_count"] = *storageServer.CpuCount
+				}
+				if storageServer.MemoryGB != nil {
+					additionalInfo["memory_gb"] = *storageServer.MemoryGB
+				}
+
+				resources = append(resources, createResourceInfo(ctx, "ExadataStorageServer", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+					withTags(storageServer.FreeformTags, storageServer.DefinedTags).
+					withLifecycle(storageServer.TimeCreated, string(storageServer.LifecycleState)))
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+	}
+
+	logger.Verbose("Found %d Exadata storage servers in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverBlockchainPlatforms discovers all blockchain platforms in a compartment
+func discoverBlockchainPlatforms(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allPlatforms []blockchain.BlockchainPlatformSummary
+
+	logger.Debug("Starting blockchain platform discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all blockchain platforms
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching blockchain platforms page %d for compartment: %s", pageCount, compartmentID)
+		req := blockchain.ListBlockchainPlatformsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.BlockchainPlatformClient.ListBlockchainPlatforms(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allPlatforms = append(allPlatforms, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	for _, platform := range allPlatforms {
+		if platform.LifecycleState != blockchain.BlockchainPlatformLifecycleStateDeleted {
+			name := ""
+			if platform.DisplayName != nil {
+				name = *platform.DisplayName
+			}
+			ocid := ""
+			if platform.Id != nil {
+				ocid = *platform.Id
+			}
+
+			additionalInfo := make(map[string]interface{})
+
+			// Add compute shape and platform role
+			additionalInfo["compute_shape"] = string(platform.ComputeShape)
+			if platform.PlatformRole != "" {
+				additionalInfo["platform_role"] = string(platform.PlatformRole)
+			}
+			if platform.ServiceEndpoint != nil {
+				additionalInfo["service_endpoint"] = *platform.ServiceEndpoint
+			}
+
+			// Get platform details for peer and orderer counts (not available in BlockchainPlatformSummary)
+			if platform.Id != nil {
+				getReq := blockchain.GetBlockchainPlatformRequest{
+					BlockchainPlatformId: platform.Id,
+				}
+				getResp, err := clients.BlockchainPlatformClient.GetBlockchainPlatform(ctx, getReq)
+				if err == nil {
+					if getResp.BlockchainPlatform.ComponentDetails != nil {
+						additionalInfo["peer_count"] = len(getResp.BlockchainPlatform.ComponentDetails.Peers)
+						additionalInfo["orderer_count"] = len(getResp.BlockchainPlatform.ComponentDetails.Osns)
+					}
+				} else {
+					logger.Verbose("Error getting blockchain platform details for %s: %v", name, err)
+				}
+			}
+
+			resources = append(resources, createResourceInfo(ctx, "BlockchainPlatform", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+				withTags(platform.FreeformTags, platform.DefinedTags).
+				withLifecycle(platform.TimeCreated, string(platform.LifecycleState)))
+		}
+	}
+
+	logger.Verbose("Found %d blockchain platforms in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverPrivateIps discovers all private IPs (primary and secondary) of the subnets in a compartment
+func discoverPrivateIps(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allSubnets []core.Subnet
+
+	logger.Debug("Starting private IP discovery for compartment: %s", compartmentID)
+
+	// Implement pagination to get all subnets
+	var page *string
+	for {
+		req := core.ListSubnetsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.VirtualNetworkClient.ListSubnets(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allSubnets = append(allSubnets, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// List private IPs in each subnet
+	for _, subnet := range allSubnets {
+		if subnet.Id == nil || subnet.LifecycleState == core.SubnetLifecycleStateTerminated {
+			continue
+		}
+
+		subnetName := ""
+		if subnet.DisplayName != nil {
+			subnetName = *subnet.DisplayName
+		}
+
+		var page *string
+		pageCount := 0
+		for {
+			pageCount++
+			logger.Debug("Fetching private IPs page %d for subnet: %s", pageCount, subnetName)
+			req := core.ListPrivateIpsRequest{
+				SubnetId: subnet.Id,
+				Page:     page,
+				Limit:    clients.pageLimit(maxPageSize),
+			}
+
+			resp, err := clients.VirtualNetworkClient.ListPrivateIps(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing private IPs for subnet %s: %v", subnetName, err)
+				break // Continue with next subnet instead of failing completely
+			}
+
+			for _, privateIP := range resp.Items {
+				// Private IPs have no display name of their own; the address identifies them
+				name := ""
+				if privateIP.IpAddress != nil {
+					name = *privateIP.IpAddress
+				}
+				ocid := ""
+				if privateIP.Id != nil {
+					ocid = *privateIP.Id
+				}
+
+				additionalInfo := make(map[string]interface{})
+
+				// Add subnet and VNIC linkage
+				additionalInfo["subnet_id"] = *subnet.Id
+				additionalInfo["subnet_name"] = subnetName
+				if subnet.CidrBlock != nil {
+					additionalInfo["subnet_cidr_block"] = *subnet.CidrBlock
+				}
+				if privateIP.VnicId != nil {
+					additionalInfo["vnic_id"] = *privateIP.VnicId
+				}
+				if privateIP.IsPrimary != nil {
+					additionalInfo["is_primary"] = *privateIP.IsPrimary
+				}
+				if privateIP.HostnameLabel != nil {
+					additionalInfo["hostname_label"] = *privateIP.HostnameLabel
+				}
+
+				resources = append(resources, createResourceInfo(ctx, "PrivateIp", name, ocid, compartmentID, additionalInfo, clients.CompartmentCache).
+					withTags(privateIP.FreeformTags, privateIP.DefinedTags).
+					withLifecycle(privateIP.TimeCreated, ""))
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+	}
+
+	logger.Verbose("Found %d private IPs in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
+
+// discoverVnics discovers the VNICs of a compartment with their instance and private IP addresses
+// VNICs are found through the VNIC attachments of the compartment's instances and through the
+// private IPs of its subnets, which also covers VNICs of services such as load balancers and
+// database nodes. Each VNIC lists all of its private IP addresses, primary and secondary.
+func discoverVnics(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+	var allSubnets []core.Subnet
+
+	logger.Debug("Starting VNIC discovery for compartment: %s", compartmentID)
+
+	resolver := newVNICResolver(clients)
+	instanceVnics, err := resolver.instanceVnics(ctx, compartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var vnicIDs []string
+	seenVnics := make(map[string]struct{})
+	vnicInstances := make(map[string]string)
+	for instanceID, ids := range instanceVnics {
+		for _, id := range ids {
+			vnicInstances[id] = instanceID
+			seenVnics[id] = struct{}{}
+			vnicIDs = append(vnicIDs, id)
+		}
+	}
+
+	// Implement pagination to get all subnets
+	var page *string
+	for {
+		req := core.ListSubnetsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.VirtualNetworkClient.ListSubnets(ctx, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allSubnets = append(allSubnets, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	// Collect the private IPs of each VNIC from the subnets
+	vnicPrivateIPs := make(map[string][]core.PrivateIp)
+	for _, subnet := range allSubnets {
+		if subnet.Id == nil || subnet.LifecycleState == core.SubnetLifecycleStateTerminated {
+			continue
+		}
+
+		var page *string
+		for {
+			req := core.ListPrivateIpsRequest{
+				SubnetId: subnet.Id,
+				Page:     page,
+				Limit:    clients.pageLimit(maxPageSize),
+			}
+
+			resp, err := clients.VirtualNetworkClient.ListPrivateIps(ctx, req)
+
+			if err != nil {
+				logger.Verbose("Error listing private IPs for subnet %s: %v", *subnet.Id, err)
+				break // Continue with next subnet instead of failing completely
+			}
+
+			for _, privateIP := range resp.Items {
+				if privateIP.VnicId == nil {
+					continue
+				}
+				if _, seen := seenVnics[*privateIP.VnicId]; !seen {
+					seenVnics[*privateIP.VnicId] = struct{}{}
+					vnicIDs = append(vnicIDs, *privateIP.VnicId)
+				}
+				vnicPrivateIPs[*privateIP.VnicId] = append(vnicPrivateIPs[*privateIP.VnicId], privateIP)
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			page = resp.OpcNextPage
+		}
+	}
+
+	vnics := resolver.vnics(ctx, vnicIDs)
+	for _, id := range vnicIDs {
+		vnic, ok := vnics[id]
+		// VNICs in subnets of this compartment can belong to other compartments; those are
+		// reported with their own compartment
+		if !ok || vnic.CompartmentId == nil || *vnic.CompartmentId != compartmentID ||
+			vnic.LifecycleState == core.VnicLifecycleStateTerminated {
+			continue
+		}
+
+		name := ""
+		if vnic.DisplayName != nil {
+			name = *vnic.DisplayName
+		}
+
+		additionalInfo := make(map[string]interface{})
+
+		if instanceID, ok := vnicInstances[id]; ok {
+			additionalInfo["instance_id"] = instanceID
+		}
+		if vnic.SubnetId != nil {
+			additionalInfo["subnet_id"] = *vnic.SubnetId
+		}
+		if vnic.VlanId != nil {
+			additionalInfo["vlan_id"] = *vnic.VlanId
+		}
+		if vnic.AvailabilityDomain != nil {
+			additionalInfo["availability_domain"] = *vnic.AvailabilityDomain
+		}
+		if vnic.PrivateIp != nil {
+			additionalInfo["private_ip"] = *vnic.PrivateIp
+		}
+		if vnic.PublicIp != nil {
+			additionalInfo["public_ip"] = *vnic.PublicIp
+		}
+		if vnic.MacAddress != nil {
+			additionalInfo["mac_address"] = *vnic.MacAddress
+		}
+		if vnic.IsPrimary != nil {
+			additionalInfo["is_primary"] = *vnic.IsPrimary
+		}
+		if vnic.HostnameLabel != nil {
+			additionalInfo["hostname_label"] = *vnic.HostnameLabel
+		}
+		if vnic.SkipSourceDestCheck != nil {
+			additionalInfo["skip_source_dest_check"] = *vnic.SkipSourceDestCheck
+		}
+		if len(vnic.NsgIds) > 0 {
+			additionalInfo["nsg_ids"] = vnic.NsgIds
+		}
+
+		// All addresses of the VNIC, the primary private IP first
+		var addresses []string
+		for _, privateIP := range vnicPrivateIPs[id] {
+			if privateIP.IpAddress == nil {
+				continue
+			}
+			if privateIP.IsPrimary != nil && *privateIP.IsPrimary {
+				addresses = append([]string{*privateIP.IpAddress}, addresses...)
+			} else {
+				addresses = append(addresses, *privateIP.IpAddress)
+			}
+		}
+		if len(addresses) > 0 {
+			additionalInfo["private_ips"] = addresses
+			additionalInfo["private_ip_count"] = len(addresses)
+		}
+
+		resources = append(resources, createResourceInfo(ctx, "Vnic", name, id, compartmentID, additionalInfo, clients.CompartmentCache).
+			withTags(vnic.FreeformTags, vnic.DefinedTags).
+			withLifecycle(vnic.TimeCreated, string(vnic.LifecycleState)))
+	}
+
+	logger.Verbose("Found %d VNICs in compartment %s", len(resources), compartmentID)
+	return resources, nil
+}
@@ -0,0 +1,506 @@
+package ocidump
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/databasemanagement"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/identitydomains"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/opensearch"
+	"github.com/oracle/oci-go-sdk/v65/osmanagementhub"
+	"github.com/oracle/oci-go-sdk/v65/streaming"
+)
+
+func TestParseCertificateExpiry(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "lb.example.com"},
+		NotBefore:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	expiry, err := parseCertificateExpiry(pemData)
+	if err != nil {
+		t.Fatalf("parseCertificateExpiry() error = %v, want nil", err)
+	}
+	if !expiry.Equal(notAfter) {
+		t.Errorf("parseCertificateExpiry() = %v, want %v", expiry, notAfter)
+	}
+
+	if _, err := parseCertificateExpiry("not a certificate"); err == nil {
+		t.Error("parseCertificateExpiry() with invalid PEM should return error")
+	}
+}
+
+func TestDiscoverVCNs_Pagination(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	network := &fakeVirtualNetwork{vcns: []core.Vcn{
+		fakeVcn("ocid1.vcn.oc1..a", "vcn-a", prod, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..b", "vcn-b", prod, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..c", "vcn-c", prod, core.VcnLifecycleStateTerminated),
+		fakeVcn("ocid1.vcn.oc1..d", "vcn-d", prod, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..e", "vcn-e", prod, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..other", "vcn-other", "ocid1.compartment.oc1..dev", core.VcnLifecycleStateAvailable),
+	}}
+	identityFake := &fakeIdentity{compartments: []identity.Compartment{fakeCompartment(prod, "prod")}}
+	clients := newFakeOCIClients(identityFake, network, &fakeCompute{})
+
+	resources, err := discoverVCNs(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverVCNs() error = %v", err)
+	}
+	if calls := network.count("ListVcns"); calls != 3 {
+		t.Errorf("ListVcns called %d times, want 3 pages", calls)
+	}
+
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.ResourceName)
+		if resource.ResourceType != "VCN" || resource.CompartmentName != "prod" {
+			t.Errorf("unexpected resource %+v", resource)
+		}
+	}
+	if got := fmt.Sprint(names); got != "[vcn-a vcn-b vcn-d vcn-e]" {
+		t.Errorf("discovered VCNs = %s, want all pages without the terminated VCN", got)
+	}
+
+	network.listErr = errors.New("service unavailable")
+	if _, err := discoverVCNs(context.Background(), clients, prod); err == nil {
+		t.Error("discoverVCNs() should return the list error")
+	}
+}
+
+func TestDiscoverObjectStorageBuckets_Pagination(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	storage := &fakeObjectStorage{replicationPolicies: map[string][]objectstorage.ReplicationPolicySummary{}}
+	for _, name := range []string{"logs", "backups", "images", "archive", "tmp"} {
+		storage.buckets = append(storage.buckets, objectstorage.BucketSummary{Name: common.String(name), CompartmentId: common.String(prod), Namespace: common.String("fakenamespace")})
+	}
+	for _, region := range []string{"us-phoenix-1", "eu-frankfurt-1", "ap-tokyo-1"} {
+		storage.replicationPolicies["backups"] = append(storage.replicationPolicies["backups"], objectstorage.ReplicationPolicySummary{
+			DestinationRegionName: common.String(region),
+			DestinationBucketName: common.String("backups-replica"),
+		})
+	}
+	identityFake := &fakeIdentity{compartments: []identity.Compartment{fakeCompartment(prod, "prod")}}
+	clients := newFakeOCIClients(identityFake, &fakeVirtualNetwork{}, &fakeCompute{})
+	clients.ObjectStorageClient = storage
+	clients.Options.Detail = true
+
+	resources, err := discoverObjectStorageBuckets(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverObjectStorageBuckets() error = %v", err)
+	}
+	if calls := storage.count("ListBuckets"); calls != 3 {
+		t.Errorf("ListBuckets called %d times, want 3 pages", calls)
+	}
+	if len(resources) != 5 {
+		t.Fatalf("discovered %d buckets, want all 5 pages of buckets", len(resources))
+	}
+	for _, resource := range resources {
+		if resource.ResourceName != "backups" {
+			continue
+		}
+		destinations, _ := resource.AdditionalInfo["replication_destinations"].([]string)
+		if len(destinations) != 3 {
+			t.Errorf("replication_destinations = %v, want the policies of both pages", destinations)
+		}
+	}
+}
+
+func TestDiscoverOpenSearchClusters_LastBackupTime(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	backupTime := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+	search := &fakeOpenSearch{
+		clusters: []opensearch.OpensearchClusterSummary{
+			{Id: common.String("ocid1.opensearchcluster.oc1..logs"), DisplayName: common.String("logs"), LifecycleState: opensearch.OpensearchClusterLifecycleStateActive},
+			{Id: common.String("ocid1.opensearchcluster.oc1..new"), DisplayName: common.String("new"), LifecycleState: opensearch.OpensearchClusterLifecycleStateActive},
+		},
+		backups: []opensearch.OpensearchClusterBackupSummary{
+			{SourceClusterId: common.String("ocid1.opensearchcluster.oc1..logs"), TimeCreated: &common.SDKTime{Time: backupTime}, LifecycleState: opensearch.OpensearchClusterBackupLifecycleStateActive},
+		},
+	}
+	identityFake := &fakeIdentity{compartments: []identity.Compartment{fakeCompartment(prod, "prod")}}
+	clients := newFakeOCIClients(identityFake, &fakeVirtualNetwork{}, &fakeCompute{})
+	clients.OpenSearchClient = search
+	clients.OpenSearchBackupClient = search
+
+	resources, err := discoverOpenSearchClusters(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverOpenSearchClusters() error = %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("discovered %d clusters, want 2", len(resources))
+	}
+	if got := resources[0].AdditionalInfo["last_backup_time"]; got != "2025-03-01T02:00:00Z" {
+		t.Errorf("last_backup_time = %v, want the time of the active backup", got)
+	}
+	if _, exists := resources[1].AdditionalInfo["last_backup_time"]; exists {
+		t.Errorf("a cluster without backups has last_backup_time %v", resources[1].AdditionalInfo["last_backup_time"])
+	}
+
+	// A failed backup listing is recorded on every cluster instead of looking like no backups
+	search.backupErr = errors.New("not authorized")
+	resources, err = discoverOpenSearchClusters(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverOpenSearchClusters() error = %v", err)
+	}
+	for _, resource := range resources {
+		if resource.AdditionalInfo["last_backup_time_error"] != "not authorized" {
+			t.Errorf("%s last_backup_time_error = %v, want the listing error", resource.ResourceName, resource.AdditionalInfo["last_backup_time_error"])
+		}
+		if _, exists := resource.AdditionalInfo["last_backup_time"]; exists {
+			t.Errorf("%s has last_backup_time although the backups could not be listed", resource.ResourceName)
+		}
+	}
+}
+
+func TestDiscoverComputeInstances_PrimaryVnic(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	compute := &fakeCompute{
+		instances: []core.Instance{
+			{Id: common.String("ocid1.instance.oc1..web"), DisplayName: common.String("web"), CompartmentId: common.String(prod), Shape: common.String("VM.Standard.E4.Flex"), LifecycleState: core.InstanceLifecycleStateRunning},
+			{Id: common.String("ocid1.instance.oc1..old"), DisplayName: common.String("old"), CompartmentId: common.String(prod), LifecycleState: core.InstanceLifecycleStateTerminated},
+		},
+		vnicAttachments: []core.VnicAttachment{
+			{InstanceId: common.String("ocid1.instance.oc1..web"), VnicId: common.String("ocid1.vnic.oc1..secondary"), LifecycleState: core.VnicAttachmentLifecycleStateAttached},
+			{InstanceId: common.String("ocid1.instance.oc1..web"), VnicId: common.String("ocid1.vnic.oc1..primary"), LifecycleState: core.VnicAttachmentLifecycleStateAttached},
+		},
+	}
+	network := &fakeVirtualNetwork{vnics: map[string]core.Vnic{
+		"ocid1.vnic.oc1..secondary": {IsPrimary: common.Bool(false), PrivateIp: common.String("10.0.1.20")},
+		"ocid1.vnic.oc1..primary":   {IsPrimary: common.Bool(true), PrivateIp: common.String("10.0.0.10"), SubnetId: common.String("ocid1.subnet.oc1..app")},
+	}}
+	clients := newFakeOCIClients(&fakeIdentity{}, network, compute)
+
+	resources, err := discoverComputeInstances(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverComputeInstances() error = %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("discovered %d instances, want 1 (terminated skipped)", len(resources))
+	}
+	info := resources[0].AdditionalInfo
+	if info["primary_ip"] != "10.0.0.10" || info["subnet_id"] != "ocid1.subnet.oc1..app" || info["shape"] != "VM.Standard.E4.Flex" {
+		t.Errorf("additional info = %v", info)
+	}
+	if resources[0].LifecycleState != "RUNNING" {
+		t.Errorf("lifecycle state = %q, want RUNNING", resources[0].LifecycleState)
+	}
+	if calls := compute.count("ListVnicAttachments"); calls != 1 {
+		t.Errorf("ListVnicAttachments called %d times, want one call per compartment", calls)
+	}
+}
+
+func TestDiscoverStreams_DetailRetention(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	streamingFake := &fakeStreaming{
+		streams: []streaming.StreamSummary{
+			{Id: common.String("ocid1.stream.oc1..a"), Name: common.String("events"), Partitions: common.Int(3), LifecycleState: streaming.StreamSummaryLifecycleStateActive},
+			{Id: common.String("ocid1.stream.oc1..b"), Name: common.String("audit"), Partitions: common.Int(1), LifecycleState: streaming.StreamSummaryLifecycleStateActive},
+			{Id: common.String("ocid1.stream.oc1..c"), Name: common.String("old"), LifecycleState: streaming.StreamSummaryLifecycleStateDeleted},
+		},
+		retention: map[string]int{"ocid1.stream.oc1..a": 24, "ocid1.stream.oc1..b": 168, "ocid1.stream.oc1..c": 24},
+	}
+	clients := newFakeOCIClients(&fakeIdentity{}, &fakeVirtualNetwork{}, &fakeCompute{})
+	clients.StreamingClient = streamingFake
+
+	// Without detail mode the summaries are enough
+	resources, err := discoverStreams(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverStreams() error = %v", err)
+	}
+	if len(resources) != 2 || resources[0].AdditionalInfo["partitions"] != 3 {
+		t.Fatalf("discovered streams = %+v", resources)
+	}
+	if _, ok := resources[0].AdditionalInfo["retention_in_hours"]; ok || streamingFake.count("GetStream") != 0 {
+		t.Errorf("retention fetched without detail mode, GetStream calls: %d", streamingFake.count("GetStream"))
+	}
+
+	clients.Options.Detail = true
+	resources, err = discoverStreams(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverStreams() error = %v", err)
+	}
+	if resources[0].AdditionalInfo["retention_in_hours"] != 24 || resources[1].AdditionalInfo["retention_in_hours"] != 168 {
+		t.Errorf("retention = %v, %v", resources[0].AdditionalInfo["retention_in_hours"], resources[1].AdditionalInfo["retention_in_hours"])
+	}
+	if calls := streamingFake.count("GetStream"); calls != 2 {
+		t.Errorf("GetStream called %d times, want once per active stream", calls)
+	}
+}
+
+func TestDiscoverManagedInstances_Tags(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	computeFake := &fakeCompute{instances: []core.Instance{
+		{Id: common.String("ocid1.instance.oc1..web"), DisplayName: common.String("web"),
+			FreeformTags: map[string]string{"env": "prod"},
+			DefinedTags:  map[string]map[string]interface{}{"Oracle-Tags": {"CreatedBy": "alice"}}},
+	}}
+	clients := newFakeOCIClients(&fakeIdentity{}, &fakeVirtualNetwork{}, computeFake)
+	clients.ManagedInstanceClient = &fakeManagedInstance{managedInstances: []osmanagementhub.ManagedInstanceSummary{
+		{Id: common.String("ocid1.instance.oc1..web"), DisplayName: common.String("web"), Location: osmanagementhub.ManagedInstanceLocationOciCompute},
+		{Id: common.String("ocid1.managementhubmanagedinstance.oc1..onprem"), DisplayName: common.String("onprem"), Location: osmanagementhub.ManagedInstanceLocationOnPremise},
+	}}
+
+	resources, err := discoverManagedInstances(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverManagedInstances() error = %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("discovered managed instances = %+v", resources)
+	}
+
+	// The OCI instance gets the tags of its compute instance, the on-premises one has none
+	if resources[0].FreeformTags["env"] != "prod" || resources[0].AdditionalInfo["created_by"] != "alice" {
+		t.Errorf("OCI managed instance = %+v, want the compute instance tags", resources[0])
+	}
+	if resources[1].FreeformTags != nil || resources[1].DefinedTags != nil {
+		t.Errorf("on-premises managed instance tags = %v, %v, want none", resources[1].FreeformTags, resources[1].DefinedTags)
+	}
+	if calls := computeFake.count("ListInstances"); calls != 1 {
+		t.Errorf("ListInstances called %d times, want once per compartment", calls)
+	}
+}
+
+func TestDiscoverExadataStorageServers(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	const infra = "ocid1.externalexadatainfrastructure.oc1..x8m"
+	storageServer := func(id, name string, state databasemanagement.DbmResourceLifecycleStateEnum) databasemanagement.ExternalExadataStorageServerSummary {
+		return databasemanagement.ExternalExadataStorageServerSummary{Id: common.String(id), DisplayName: common.String(name),
+			Version: common.String("23.1.0"), LifecycleState: state}
+	}
+	clients := newFakeOCIClients(&fakeIdentity{}, &fakeVirtualNetwork{}, &fakeCompute{})
+	clients.DbManagementClient = &fakeDbManagement{
+		infrastructures: []databasemanagement.ExternalExadataInfrastructureSummary{
+			{Id: common.String(infra), DisplayName: common.String("x8m"), LifecycleState: databasemanagement.DbmResourceLifecycleStateActive},
+			{Id: common.String("ocid1.externalexadatainfrastructure.oc1..old"), DisplayName: common.String("old"), LifecycleState: databasemanagement.DbmResourceLifecycleStateDeleted},
+		},
+		storageServers: map[string][]databasemanagement.ExternalExadataStorageServerSummary{
+			infra: {
+				storageServer("ocid1.externalexadatastorageserver.oc1..a", "cel01", databasemanagement.DbmResourceLifecycleStateActive),
+				storageServer("ocid1.externalexadatastorageserver.oc1..b", "cel02", databasemanagement.DbmResourceLifecycleStateActive),
+				storageServer("ocid1.externalexadatastorageserver.oc1..c", "cel03", databasemanagement.DbmResourceLifecycleStateDeleted),
+			},
+		},
+	}
+
+	resources, err := discoverExadataStorageServers(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverExadataStorageServers() error = %v", err)
+	}
+	if len(resources) != 2 || resources[0].ResourceName != "cel01" || resources[1].ResourceName != "cel02" {
+		t.Fatalf("discovered storage servers = %+v, want cel01 and cel02", resources)
+	}
+	if resources[0].ResourceType != "ExadataStorageServer" || resources[0].AdditionalInfo["exadata_infrastructure_id"] != infra ||
+		resources[0].AdditionalInfo["version"] != "23.1.0" {
+		t.Errorf("storage server = %+v", resources[0])
+	}
+}
+
+func TestGetCompartments_Pagination(t *testing.T) {
+	identityFake := &fakeIdentity{compartments: []identity.Compartment{
+		fakeCompartment("ocid1.compartment.oc1..a", "a"),
+		fakeCompartment("ocid1.compartment.oc1..b", "b"),
+		fakeCompartment("ocid1.compartment.oc1..c", "c"),
+	}}
+	clients := newFakeOCIClients(identityFake, &fakeVirtualNetwork{}, &fakeCompute{})
+
+	compartments, err := getCompartments(context.Background(), clients)
+	if err != nil {
+		t.Fatalf("getCompartments() error = %v", err)
+	}
+	var names []string
+	for _, compartment := range compartments {
+		names = append(names, *compartment.Name)
+	}
+	if got := fmt.Sprint(names); got != "[root a b c]" {
+		t.Errorf("compartments = %s, want the root compartment and all pages", got)
+	}
+	if *compartments[0].Id != fakeTenancyID {
+		t.Errorf("root compartment ID = %s, want the tenancy", *compartments[0].Id)
+	}
+}
+
+func TestDiscoverAllResourcesWithProgress_Fake(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	identityFake := &fakeIdentity{compartments: []identity.Compartment{fakeCompartment(prod, "prod")}}
+	network := &fakeVirtualNetwork{vcns: []core.Vcn{
+		fakeVcn("ocid1.vcn.oc1..root", "root-vcn", fakeTenancyID, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..a", "prod-a", prod, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..b", "prod-b", prod, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..c", "test-c", prod, core.VcnLifecycleStateAvailable),
+	}}
+	clients := newFakeOCIClients(identityFake, network, &fakeCompute{})
+
+	filters := FilterConfig{IncludeResourceTypes: []string{"vcns"}, NamePattern: "^(prod|root)-"}
+	var stats DiscoveryStats
+	resources, err := discoverAllResourcesWithProgress(context.Background(), clients, false, filters, nil, nil, nil, &stats)
+	if err != nil {
+		t.Fatalf("discoverAllResourcesWithProgress() error = %v", err)
+	}
+
+	SortResources(resources, []string{"name"})
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.ResourceName)
+	}
+	if got := fmt.Sprint(names); got != "[prod-a prod-b root-vcn]" {
+		t.Errorf("resources = %s", got)
+	}
+	if stats.Compartments != 2 || stats.Resources != 3 || stats.Errors != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if calls := network.count("ListVcns"); calls != 3 {
+		t.Errorf("ListVcns called %d times, want 2 pages in prod and 1 in root", calls)
+	}
+}
+
+func TestDiscoverAllResourcesWithProgress_CompartmentSubtree(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const platform, network, dev = "ocid1.compartment.oc1..platform", "ocid1.compartment.oc1..network", "ocid1.compartment.oc1..dev"
+	nested := fakeCompartment(network, "network")
+	nested.CompartmentId = common.String(platform)
+	identityFake := &fakeIdentity{compartments: []identity.Compartment{fakeCompartment(platform, "platform"), nested, fakeCompartment(dev, "dev")}}
+	virtualNetwork := &fakeVirtualNetwork{vcns: []core.Vcn{
+		fakeVcn("ocid1.vcn.oc1..shared", "shared", platform, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..hub", "hub", network, core.VcnLifecycleStateAvailable),
+		fakeVcn("ocid1.vcn.oc1..sandbox", "sandbox", dev, core.VcnLifecycleStateAvailable),
+	}}
+	clients := newFakeOCIClients(identityFake, virtualNetwork, &fakeCompute{})
+
+	filters := FilterConfig{IncludeResourceTypes: []string{"vcns"}, CompartmentSubtrees: []string{"platform"}}
+	var stats DiscoveryStats
+	resources, err := discoverAllResourcesWithProgress(context.Background(), clients, false, filters, nil, nil, nil, &stats)
+	if err != nil {
+		t.Fatalf("discoverAllResourcesWithProgress() error = %v", err)
+	}
+
+	SortResources(resources, []string{"name"})
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.ResourceName)
+	}
+	if got := fmt.Sprint(names); got != "[hub shared]" {
+		t.Errorf("resources = %s, want the VCNs of platform and its nested network compartment", got)
+	}
+	if stats.Compartments != 2 {
+		t.Errorf("discovered %d compartments, want 2", stats.Compartments)
+	}
+
+	// A baseline of the whole tenancy, scoped to the processed compartments, reports no
+	// removals for the compartments outside the subtree
+	baseline := []ResourceInfo{
+		{ResourceType: "VCN", ResourceName: "shared", OCID: "ocid1.vcn.oc1..shared", CompartmentID: platform},
+		{ResourceType: "VCN", ResourceName: "hub", OCID: "ocid1.vcn.oc1..hub", CompartmentID: network},
+		{ResourceType: "VCN", ResourceName: "retired", OCID: "ocid1.vcn.oc1..retired", CompartmentID: network},
+		{ResourceType: "VCN", ResourceName: "sandbox", OCID: "ocid1.vcn.oc1..sandbox", CompartmentID: dev},
+	}
+	scoped := FilterResourcesByCompartment(baseline, stats.CompartmentIDs)
+	result, err := DiffAgainstBaseline(scoped, resources, "baseline.json", "discovery", DiffConfig{})
+	if err != nil {
+		t.Fatalf("DiffAgainstBaseline() error = %v", err)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].OCID != "ocid1.vcn.oc1..retired" || len(result.Added) != 0 {
+		t.Errorf("removed = %+v, added = %+v, want only the retired VCN of the subtree removed", result.Removed, result.Added)
+	}
+}
+
+func TestDiscoverUsers_IdentityDomains(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const security = "ocid1.compartment.oc1..security"
+	domainUser := func(ocid, name string) identitydomains.User {
+		return identitydomains.User{
+			Ocid:     common.String(ocid),
+			UserName: common.String(name),
+			Active:   common.Bool(true),
+			Emails:   []identitydomains.UserEmails{{Value: common.String(name + "@example.com"), Primary: common.Bool(true)}},
+			Meta:     &identitydomains.Meta{Created: common.String("2025-01-02T03:04:05Z")},
+		}
+	}
+	identityFake := &fakeIdentity{
+		domains: []identity.DomainSummary{
+			{Id: common.String("ocid1.domain.oc1..default"), DisplayName: common.String("Default"), CompartmentId: common.String(fakeTenancyID),
+				Url: common.String("https://default.example.com"), LifecycleState: identity.DomainLifecycleStateActive},
+			{Id: common.String("ocid1.domain.oc1..partners"), DisplayName: common.String("Partners"), CompartmentId: common.String(security),
+				Url: common.String("https://partners.example.com"), LifecycleState: identity.DomainLifecycleStateActive},
+			{Id: common.String("ocid1.domain.oc1..broken"), DisplayName: common.String("Broken"), CompartmentId: common.String(fakeTenancyID),
+				Url: common.String("https://broken.example.com"), LifecycleState: identity.DomainLifecycleStateActive},
+		},
+		users: []identity.User{
+			// Legacy IAM returns the users of the Default domain again, and users of tenancies without domains
+			{Id: common.String("ocid1.user.oc1..alice"), Name: common.String("alice"), LifecycleState: identity.UserLifecycleStateActive},
+			{Id: common.String("ocid1.user.oc1..legacy"), Name: common.String("legacy"), LifecycleState: identity.UserLifecycleStateActive},
+		},
+	}
+	domainFakes := map[string]*fakeIdentityDomain{
+		"https://default.example.com":  {users: []identitydomains.User{domainUser("ocid1.user.oc1..alice", "alice"), domainUser("ocid1.user.oc1..bob", "bob"), domainUser("ocid1.user.oc1..carol", "carol")}},
+		"https://partners.example.com": {users: []identitydomains.User{domainUser("ocid1.user.oc1..dave", "dave")}},
+		"https://broken.example.com":   {listErr: fmt.Errorf("NotAuthorizedOrNotFound")},
+	}
+	clients := newFakeOCIClients(identityFake, &fakeVirtualNetwork{}, &fakeCompute{})
+	clients.IdentityDomainsClient = func(domainURL string) (IdentityDomainsAPI, error) {
+		return domainFakes[domainURL], nil
+	}
+
+	resources, err := discoverUsers(context.Background(), clients, fakeTenancyID)
+	if err != nil {
+		t.Fatalf("discoverUsers() error = %v", err)
+	}
+	byName := make(map[string]ResourceInfo)
+	for _, resource := range resources {
+		byName[resource.ResourceName] = resource
+	}
+	if len(resources) != 4 || len(byName) != 4 {
+		t.Fatalf("discovered users = %+v, want alice, bob, carol and legacy once each", resources)
+	}
+	alice := byName["alice"]
+	if alice.AdditionalInfo["identity_domain"] != "Default" || alice.AdditionalInfo["email"] != "alice@example.com" || alice.TimeCreated != "2025-01-02T03:04:05Z" {
+		t.Errorf("alice = %+v", alice)
+	}
+	if _, ok := byName["legacy"].AdditionalInfo["identity_domain"]; ok {
+		t.Errorf("legacy IAM user has an identity domain: %+v", byName["legacy"])
+	}
+
+	// Users of a domain in another compartment are discovered in that compartment
+	resources, err = discoverUsers(context.Background(), clients, security)
+	if err != nil {
+		t.Fatalf("discoverUsers() error = %v", err)
+	}
+	if len(resources) != 1 || resources[0].ResourceName != "dave" || resources[0].AdditionalInfo["identity_domain"] != "Partners" {
+		t.Errorf("discovered users in %s = %+v", security, resources)
+	}
+}
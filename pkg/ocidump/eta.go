@@ -0,0 +1,106 @@
+package ocidump
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// etaSmoothing is the weight of the latest duration in the moving average of a resource type
+const etaSmoothing = 0.3
+
+// etaEstimator estimates the remaining discovery time from the durations of each resource type
+// Resource types differ by orders of magnitude, e.g. listing VCNs against instances with their
+// VNICs, so the remaining operations are weighted by the moving average of their own type
+// instead of assuming all compartment and resource type operations take equally long.
+type etaEstimator struct {
+	mu        sync.Mutex
+	workers   int
+	averages  map[string]time.Duration // exponential moving average per resource type
+	remaining map[string]int           // operations left per resource type
+}
+
+// newETAEstimator returns an estimator for operations running on workers compartments at once
+func newETAEstimator(workers int) *etaEstimator {
+	return &etaEstimator{workers: max(workers, 1), averages: make(map[string]time.Duration), remaining: make(map[string]int)}
+}
+
+// plan adds n operations of a resource type to the remaining work
+func (e *etaEstimator) plan(resourceType string, n int) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.remaining[resourceType] += n
+}
+
+// done records an operation of a resource type that took elapsed
+func (e *etaEstimator) done(resourceType string, elapsed time.Duration) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.complete(resourceType)
+	if average, ok := e.averages[resourceType]; ok {
+		e.averages[resourceType] = time.Duration(etaSmoothing*float64(elapsed) + (1-etaSmoothing)*float64(average))
+	} else {
+		e.averages[resourceType] = elapsed
+	}
+}
+
+// skip removes an operation that did not call the OCI APIs, e.g. one filtered out or cached,
+// from the remaining work without affecting the averages
+func (e *etaEstimator) skip(resourceType string) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.complete(resourceType)
+}
+
+func (e *etaEstimator) complete(resourceType string) {
+	if e.remaining[resourceType] > 0 {
+		e.remaining[resourceType]--
+	}
+}
+
+// estimate returns the remaining time, or false until an operation has completed
+// Resource types without a duration yet are weighted with the mean of the known averages.
+func (e *etaEstimator) estimate() (time.Duration, bool) {
+	if e == nil {
+		return 0, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.averages) == 0 {
+		return 0, false
+	}
+
+	var sum time.Duration
+	for _, average := range e.averages {
+		sum += average
+	}
+	fallback := sum / time.Duration(len(e.averages))
+
+	var total time.Duration
+	for resourceType, n := range e.remaining {
+		average, ok := e.averages[resourceType]
+		if !ok {
+			average = fallback
+		}
+		total += time.Duration(n) * average
+	}
+	return total / time.Duration(e.workers), true
+}
+
+// String formats the estimate for the progress display
+func (e *etaEstimator) String() string {
+	remaining, ok := e.estimate()
+	if !ok {
+		return "ETA --"
+	}
+	return fmt.Sprintf("ETA %s", remaining.Round(time.Second))
+}
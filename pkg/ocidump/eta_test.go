@@ -0,0 +1,58 @@
+package ocidump
+
+import (
+	"testing"
+	"time"
+)
+
+func TestETAEstimator_WeightsResourceTypes(t *testing.T) {
+	eta := newETAEstimator(2)
+	eta.plan("ComputeInstances", 10)
+	eta.plan("VCNs", 10)
+	eta.plan("Buckets", 10)
+
+	if _, ok := eta.estimate(); ok {
+		t.Error("estimate before any operation completed should not be available")
+	}
+	if got := eta.String(); got != "ETA --" {
+		t.Errorf("String() = %q, want ETA --", got)
+	}
+
+	// Slow instances and fast VCNs; buckets have no duration yet and use the mean of both
+	eta.done("ComputeInstances", 10*time.Second)
+	eta.done("VCNs", 1*time.Second)
+	eta.skip("Buckets")
+
+	remaining, ok := eta.estimate()
+	if !ok {
+		t.Fatal("estimate not available")
+	}
+	// (9*10s + 9*1s + 9*5.5s) / 2 workers
+	if want := 74250 * time.Millisecond; remaining != want {
+		t.Errorf("estimate = %v, want %v", remaining, want)
+	}
+
+	// The average moves towards recent durations
+	eta.done("ComputeInstances", 20*time.Second)
+	if average := eta.averages["ComputeInstances"]; average != 13*time.Second {
+		t.Errorf("moving average = %v, want 13s", average)
+	}
+
+	// Completing more operations than planned does not go below zero
+	for i := 0; i < 20; i++ {
+		eta.skip("VCNs")
+	}
+	if eta.remaining["VCNs"] != 0 {
+		t.Errorf("remaining VCNs = %d, want 0", eta.remaining["VCNs"])
+	}
+}
+
+func TestETAEstimator_Nil(t *testing.T) {
+	var eta *etaEstimator
+	eta.plan("VCNs", 1)
+	eta.done("VCNs", time.Second)
+	eta.skip("VCNs")
+	if _, ok := eta.estimate(); ok {
+		t.Error("nil estimator should not estimate")
+	}
+}
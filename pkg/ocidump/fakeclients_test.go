@@ -0,0 +1,481 @@
+package ocidump
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/oracle/oci-go-sdk/v65/databasemanagement"
+	"github.com/oracle/oci-go-sdk/v65/filestorage"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/identitydomains"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/opensearch"
+	"github.com/oracle/oci-go-sdk/v65/osmanagementhub"
+	"github.com/oracle/oci-go-sdk/v65/streaming"
+)
+
+// In-memory fakes of the OCI service clients for discovery tests
+//
+// Each fake embeds the service interface, so calling an operation the fake does not
+// implement panics and points at the missing method. List operations return their items
+// in pages of fakePageSize to exercise the pagination loops of the discovery functions.
+
+const fakeTenancyID = "ocid1.tenancy.oc1..fake"
+
+// fakePageSize is the number of items returned per page by the fakes
+const fakePageSize = 2
+
+// fakePage returns the page of items starting at the page token, and the token of the next page
+func fakePage[T any](items []T, page *string) ([]T, *string) {
+	start := 0
+	if page != nil {
+		start, _ = strconv.Atoi(*page)
+	}
+	if start >= len(items) {
+		return nil, nil
+	}
+	end := start + fakePageSize
+	if end >= len(items) {
+		return items[start:], nil
+	}
+	return items[start:end], common.String(strconv.Itoa(end))
+}
+
+// fakeCalls counts the calls of each operation; fakes are used from concurrent discoveries
+type fakeCalls struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *fakeCalls) record(operation string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[operation]++
+}
+
+func (c *fakeCalls) count(operation string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[operation]
+}
+
+// fakeIdentity serves the compartments, availability domains, identity domains and legacy users of the tenancy
+// ListCompartments returns the children of the requested compartment, or all compartments in its subtree.
+type fakeIdentity struct {
+	IdentityAPI
+	fakeCalls
+	compartments        []identity.Compartment
+	availabilityDomains []string
+	domains             []identity.DomainSummary
+	users               []identity.User
+}
+
+func (f *fakeIdentity) ListDomains(ctx context.Context, request identity.ListDomainsRequest) (identity.ListDomainsResponse, error) {
+	f.record("ListDomains")
+	var domains []identity.DomainSummary
+	for _, domain := range f.domains {
+		if *domain.CompartmentId == *request.CompartmentId {
+			domains = append(domains, domain)
+		}
+	}
+	items, next := fakePage(domains, request.Page)
+	return identity.ListDomainsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeIdentity) ListUsers(ctx context.Context, request identity.ListUsersRequest) (identity.ListUsersResponse, error) {
+	f.record("ListUsers")
+	items, next := fakePage(f.users, request.Page)
+	return identity.ListUsersResponse{Items: items, OpcNextPage: next}, nil
+}
+
+// fakeIdentityDomain serves the users of an identity domain in SCIM pages of fakePageSize
+type fakeIdentityDomain struct {
+	IdentityDomainsAPI
+	users   []identitydomains.User
+	listErr error
+}
+
+func (f *fakeIdentityDomain) ListUsers(ctx context.Context, request identitydomains.ListUsersRequest) (identitydomains.ListUsersResponse, error) {
+	if f.listErr != nil {
+		return identitydomains.ListUsersResponse{}, f.listErr
+	}
+	start := *request.StartIndex - 1
+	end := min(start+fakePageSize, len(f.users))
+	return identitydomains.ListUsersResponse{Users: identitydomains.Users{
+		TotalResults: common.Int(len(f.users)),
+		Resources:    f.users[start:end],
+		StartIndex:   request.StartIndex,
+	}}, nil
+}
+
+func (f *fakeIdentity) ListAvailabilityDomains(ctx context.Context, request identity.ListAvailabilityDomainsRequest) (identity.ListAvailabilityDomainsResponse, error) {
+	f.record("ListAvailabilityDomains")
+	var items []identity.AvailabilityDomain
+	for _, name := range f.availabilityDomains {
+		items = append(items, identity.AvailabilityDomain{Name: common.String(name)})
+	}
+	return identity.ListAvailabilityDomainsResponse{Items: items}, nil
+}
+
+func (f *fakeIdentity) ListCompartments(ctx context.Context, request identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error) {
+	f.record("ListCompartments")
+	compartments := f.compartments
+	if request.CompartmentIdInSubtree == nil || !*request.CompartmentIdInSubtree {
+		compartments = nil
+		for _, compartment := range f.compartments {
+			if *compartment.CompartmentId == *request.CompartmentId {
+				compartments = append(compartments, compartment)
+			}
+		}
+	}
+	items, next := fakePage(compartments, request.Page)
+	return identity.ListCompartmentsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeIdentity) GetCompartment(ctx context.Context, request identity.GetCompartmentRequest) (identity.GetCompartmentResponse, error) {
+	f.record("GetCompartment")
+	for _, compartment := range f.compartments {
+		if *compartment.Id == *request.CompartmentId {
+			return identity.GetCompartmentResponse{Compartment: compartment}, nil
+		}
+	}
+	return identity.GetCompartmentResponse{}, fmt.Errorf("compartment %s not found", *request.CompartmentId)
+}
+
+// fakeVirtualNetwork serves VCNs, subnets, VNICs and private IPs; listErr makes every List call fail
+type fakeVirtualNetwork struct {
+	VirtualNetworkAPI
+	fakeCalls
+	vcns       []core.Vcn
+	subnets    []core.Subnet
+	vnics      map[string]core.Vnic
+	privateIPs []core.PrivateIp
+	listErr    error
+}
+
+func (f *fakeVirtualNetwork) ListVcns(ctx context.Context, request core.ListVcnsRequest) (core.ListVcnsResponse, error) {
+	f.record("ListVcns")
+	if f.listErr != nil {
+		return core.ListVcnsResponse{}, f.listErr
+	}
+	var vcns []core.Vcn
+	for _, vcn := range f.vcns {
+		if *vcn.CompartmentId == *request.CompartmentId {
+			vcns = append(vcns, vcn)
+		}
+	}
+	items, next := fakePage(vcns, request.Page)
+	return core.ListVcnsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeVirtualNetwork) ListSubnets(ctx context.Context, request core.ListSubnetsRequest) (core.ListSubnetsResponse, error) {
+	f.record("ListSubnets")
+	if f.listErr != nil {
+		return core.ListSubnetsResponse{}, f.listErr
+	}
+	var subnets []core.Subnet
+	for _, subnet := range f.subnets {
+		if *subnet.CompartmentId == *request.CompartmentId {
+			subnets = append(subnets, subnet)
+		}
+	}
+	items, next := fakePage(subnets, request.Page)
+	return core.ListSubnetsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeVirtualNetwork) GetVnic(ctx context.Context, request core.GetVnicRequest) (core.GetVnicResponse, error) {
+	f.record("GetVnic")
+	vnic, ok := f.vnics[*request.VnicId]
+	if !ok {
+		return core.GetVnicResponse{}, fmt.Errorf("vnic %s not found", *request.VnicId)
+	}
+	return core.GetVnicResponse{Vnic: vnic}, nil
+}
+
+func (f *fakeVirtualNetwork) ListPrivateIps(ctx context.Context, request core.ListPrivateIpsRequest) (core.ListPrivateIpsResponse, error) {
+	f.record("ListPrivateIps")
+	if f.listErr != nil {
+		return core.ListPrivateIpsResponse{}, f.listErr
+	}
+	var privateIPs []core.PrivateIp
+	for _, privateIP := range f.privateIPs {
+		if *privateIP.SubnetId == *request.SubnetId {
+			privateIPs = append(privateIPs, privateIP)
+		}
+	}
+	items, next := fakePage(privateIPs, request.Page)
+	return core.ListPrivateIpsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeVirtualNetwork) GetPrivateIp(ctx context.Context, request core.GetPrivateIpRequest) (core.GetPrivateIpResponse, error) {
+	f.record("GetPrivateIp")
+	for _, privateIP := range f.privateIPs {
+		if *privateIP.Id == *request.PrivateIpId {
+			return core.GetPrivateIpResponse{PrivateIp: privateIP}, nil
+		}
+	}
+	return core.GetPrivateIpResponse{}, fmt.Errorf("private IP %s not found", *request.PrivateIpId)
+}
+
+// fakeCompute serves instances and their VNIC, volume and boot volume attachments
+// volumeAttachmentErrs fails the volume attachment listing of a compartment, and
+// bootVolumeAttachmentErrs the boot volume attachment listing of an availability domain.
+type fakeCompute struct {
+	ComputeAPI
+	fakeCalls
+	instances             []core.Instance
+	vnicAttachments       []core.VnicAttachment
+	volumeAttachments     []core.VolumeAttachment
+	bootVolumeAttachments []core.BootVolumeAttachment
+
+	volumeAttachmentErrs     map[string]error
+	bootVolumeAttachmentErrs map[string]error
+}
+
+func (f *fakeCompute) ListVolumeAttachments(ctx context.Context, request core.ListVolumeAttachmentsRequest) (core.ListVolumeAttachmentsResponse, error) {
+	f.record("ListVolumeAttachments")
+	if err := f.volumeAttachmentErrs[*request.CompartmentId]; err != nil {
+		return core.ListVolumeAttachmentsResponse{}, err
+	}
+	var attachments []core.VolumeAttachment
+	for _, attachment := range f.volumeAttachments {
+		if *attachment.GetCompartmentId() == *request.CompartmentId {
+			attachments = append(attachments, attachment)
+		}
+	}
+	items, next := fakePage(attachments, request.Page)
+	return core.ListVolumeAttachmentsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeCompute) ListBootVolumeAttachments(ctx context.Context, request core.ListBootVolumeAttachmentsRequest) (core.ListBootVolumeAttachmentsResponse, error) {
+	f.record("ListBootVolumeAttachments")
+	if err := f.bootVolumeAttachmentErrs[*request.AvailabilityDomain]; err != nil {
+		return core.ListBootVolumeAttachmentsResponse{}, err
+	}
+	var attachments []core.BootVolumeAttachment
+	for _, attachment := range f.bootVolumeAttachments {
+		if *attachment.CompartmentId == *request.CompartmentId && *attachment.AvailabilityDomain == *request.AvailabilityDomain {
+			attachments = append(attachments, attachment)
+		}
+	}
+	items, next := fakePage(attachments, request.Page)
+	return core.ListBootVolumeAttachmentsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeCompute) ListInstances(ctx context.Context, request core.ListInstancesRequest) (core.ListInstancesResponse, error) {
+	f.record("ListInstances")
+	items, next := fakePage(f.instances, request.Page)
+	return core.ListInstancesResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeCompute) ListVnicAttachments(ctx context.Context, request core.ListVnicAttachmentsRequest) (core.ListVnicAttachmentsResponse, error) {
+	f.record("ListVnicAttachments")
+	var attachments []core.VnicAttachment
+	for _, attachment := range f.vnicAttachments {
+		if request.InstanceId == nil || *attachment.InstanceId == *request.InstanceId {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return core.ListVnicAttachmentsResponse{Items: attachments}, nil
+}
+
+// fakeObjectStorage serves the buckets of one namespace and the replication policies of each bucket
+type fakeObjectStorage struct {
+	ObjectStorageAPI
+	fakeCalls
+	buckets             []objectstorage.BucketSummary
+	replicationPolicies map[string][]objectstorage.ReplicationPolicySummary
+}
+
+func (f *fakeObjectStorage) GetNamespace(ctx context.Context, request objectstorage.GetNamespaceRequest) (objectstorage.GetNamespaceResponse, error) {
+	f.record("GetNamespace")
+	return objectstorage.GetNamespaceResponse{Value: common.String("fakenamespace")}, nil
+}
+
+func (f *fakeObjectStorage) ListBuckets(ctx context.Context, request objectstorage.ListBucketsRequest) (objectstorage.ListBucketsResponse, error) {
+	f.record("ListBuckets")
+	var buckets []objectstorage.BucketSummary
+	for _, bucket := range f.buckets {
+		if *bucket.CompartmentId == *request.CompartmentId {
+			buckets = append(buckets, bucket)
+		}
+	}
+	items, next := fakePage(buckets, request.Page)
+	return objectstorage.ListBucketsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeObjectStorage) GetObjectLifecyclePolicy(ctx context.Context, request objectstorage.GetObjectLifecyclePolicyRequest) (objectstorage.GetObjectLifecyclePolicyResponse, error) {
+	f.record("GetObjectLifecyclePolicy")
+	return objectstorage.GetObjectLifecyclePolicyResponse{}, nil
+}
+
+func (f *fakeObjectStorage) ListReplicationPolicies(ctx context.Context, request objectstorage.ListReplicationPoliciesRequest) (objectstorage.ListReplicationPoliciesResponse, error) {
+	f.record("ListReplicationPolicies")
+	items, next := fakePage(f.replicationPolicies[*request.BucketName], request.Page)
+	return objectstorage.ListReplicationPoliciesResponse{Items: items, OpcNextPage: next}, nil
+}
+
+// fakeOpenSearch serves OpenSearch clusters and their backups; backupErr makes the backup listing fail
+type fakeOpenSearch struct {
+	clusters  []opensearch.OpensearchClusterSummary
+	backups   []opensearch.OpensearchClusterBackupSummary
+	backupErr error
+}
+
+func (f *fakeOpenSearch) ListOpensearchClusters(ctx context.Context, request opensearch.ListOpensearchClustersRequest) (opensearch.ListOpensearchClustersResponse, error) {
+	items, next := fakePage(f.clusters, request.Page)
+	return opensearch.ListOpensearchClustersResponse{OpensearchClusterCollection: opensearch.OpensearchClusterCollection{Items: items}, OpcNextPage: next}, nil
+}
+
+func (f *fakeOpenSearch) ListOpensearchClusterBackups(ctx context.Context, request opensearch.ListOpensearchClusterBackupsRequest) (opensearch.ListOpensearchClusterBackupsResponse, error) {
+	if f.backupErr != nil {
+		return opensearch.ListOpensearchClusterBackupsResponse{}, f.backupErr
+	}
+	items, next := fakePage(f.backups, request.Page)
+	return opensearch.ListOpensearchClusterBackupsResponse{OpensearchClusterBackupCollection: opensearch.OpensearchClusterBackupCollection{Items: items}, OpcNextPage: next}, nil
+}
+
+// newFakeOCIClients returns clients backed by the given fakes
+// The tenancy has a root compartment and the compartments listed by the identity fake.
+func newFakeOCIClients(identityFake *fakeIdentity, networkFake *fakeVirtualNetwork, computeFake *fakeCompute) *OCIClients {
+	clients := &OCIClients{
+		IdentityClient:       identityFake,
+		VirtualNetworkClient: networkFake,
+		ComputeClient:        computeFake,
+		ConfigProvider:       common.NewRawConfigurationProvider(fakeTenancyID, "ocid1.user.oc1..fake", "us-ashburn-1", "", "", nil),
+	}
+	clients.CompartmentCache = NewResourceNameCache(identityFake)
+	return clients
+}
+
+// fakeCompartment returns an active compartment
+func fakeCompartment(id, name string) identity.Compartment {
+	return identity.Compartment{
+		Id:             common.String(id),
+		Name:           common.String(name),
+		CompartmentId:  common.String(fakeTenancyID),
+		LifecycleState: identity.CompartmentLifecycleStateActive,
+	}
+}
+
+// fakeVcn returns a VCN in the given state
+func fakeVcn(id, name, compartmentID string, state core.VcnLifecycleStateEnum) core.Vcn {
+	return core.Vcn{
+		Id:             common.String(id),
+		DisplayName:    common.String(name),
+		CompartmentId:  common.String(compartmentID),
+		CidrBlocks:     []string{"10.0.0.0/16"},
+		LifecycleState: state,
+	}
+}
+
+// fakeDatabase serves DB systems and their nodes
+type fakeDatabase struct {
+	DatabaseAPI
+	fakeCalls
+	dbSystems []database.DbSystemSummary
+	dbNodes   []database.DbNodeSummary
+}
+
+func (f *fakeDatabase) ListDbSystems(ctx context.Context, request database.ListDbSystemsRequest) (database.ListDbSystemsResponse, error) {
+	f.record("ListDbSystems")
+	items, next := fakePage(f.dbSystems, request.Page)
+	return database.ListDbSystemsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeDatabase) ListDbNodes(ctx context.Context, request database.ListDbNodesRequest) (database.ListDbNodesResponse, error) {
+	f.record("ListDbNodes")
+	var nodes []database.DbNodeSummary
+	for _, node := range f.dbNodes {
+		if *node.DbSystemId == *request.DbSystemId {
+			nodes = append(nodes, node)
+		}
+	}
+	items, next := fakePage(nodes, request.Page)
+	return database.ListDbNodesResponse{Items: items, OpcNextPage: next}, nil
+}
+
+// fakeFileStorage serves mount targets per availability domain
+type fakeFileStorage struct {
+	FileStorageAPI
+	fakeCalls
+	mountTargets []filestorage.MountTargetSummary
+}
+
+func (f *fakeFileStorage) ListMountTargets(ctx context.Context, request filestorage.ListMountTargetsRequest) (filestorage.ListMountTargetsResponse, error) {
+	f.record("ListMountTargets")
+	var mountTargets []filestorage.MountTargetSummary
+	for _, mountTarget := range f.mountTargets {
+		if *mountTarget.AvailabilityDomain == *request.AvailabilityDomain {
+			mountTargets = append(mountTargets, mountTarget)
+		}
+	}
+	items, next := fakePage(mountTargets, request.Page)
+	return filestorage.ListMountTargetsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+// fakeStreaming serves streams and their retention
+type fakeStreaming struct {
+	StreamingAPI
+	fakeCalls
+	streams   []streaming.StreamSummary
+	retention map[string]int
+}
+
+func (f *fakeStreaming) ListStreams(ctx context.Context, request streaming.ListStreamsRequest) (streaming.ListStreamsResponse, error) {
+	f.record("ListStreams")
+	items, next := fakePage(f.streams, request.Page)
+	return streaming.ListStreamsResponse{Items: items, OpcNextPage: next}, nil
+}
+
+func (f *fakeStreaming) GetStream(ctx context.Context, request streaming.GetStreamRequest) (streaming.GetStreamResponse, error) {
+	f.record("GetStream")
+	hours, ok := f.retention[*request.StreamId]
+	if !ok {
+		return streaming.GetStreamResponse{}, fmt.Errorf("stream %s not found", *request.StreamId)
+	}
+	return streaming.GetStreamResponse{Stream: streaming.Stream{Id: request.StreamId, RetentionInHours: common.Int(hours)}}, nil
+}
+
+// fakeManagedInstance serves the managed instances of OS Management Hub
+type fakeManagedInstance struct {
+	ManagedInstanceAPI
+	fakeCalls
+	managedInstances []osmanagementhub.ManagedInstanceSummary
+}
+
+func (f *fakeManagedInstance) ListManagedInstances(ctx context.Context, request osmanagementhub.ListManagedInstancesRequest) (osmanagementhub.ListManagedInstancesResponse, error) {
+	f.record("ListManagedInstances")
+	items, next := fakePage(f.managedInstances, request.Page)
+	return osmanagementhub.ListManagedInstancesResponse{ManagedInstanceCollection: osmanagementhub.ManagedInstanceCollection{Items: items}, OpcNextPage: next}, nil
+}
+
+// fakeDbManagement serves the Exadata infrastructures of Database Management and their storage servers
+type fakeDbManagement struct {
+	DbManagementAPI
+	fakeCalls
+	infrastructures []databasemanagement.ExternalExadataInfrastructureSummary
+	storageServers  map[string][]databasemanagement.ExternalExadataStorageServerSummary // Keyed by infrastructure OCID
+}
+
+func (f *fakeDbManagement) ListExternalExadataInfrastructures(ctx context.Context, request databasemanagement.ListExternalExadataInfrastructuresRequest) (databasemanagement.ListExternalExadataInfrastructuresResponse, error) {
+	f.record("ListExternalExadataInfrastructures")
+	items, next := fakePage(f.infrastructures, request.Page)
+	return databasemanagement.ListExternalExadataInfrastructuresResponse{
+		ExternalExadataInfrastructureCollection: databasemanagement.ExternalExadataInfrastructureCollection{Items: items}, OpcNextPage: next}, nil
+}
+
+func (f *fakeDbManagement) ListExternalExadataStorageServers(ctx context.Context, request databasemanagement.ListExternalExadataStorageServersRequest) (databasemanagement.ListExternalExadataStorageServersResponse, error) {
+	f.record("ListExternalExadataStorageServers")
+	items, next := fakePage(f.storageServers[*request.ExternalExadataInfrastructureId], request.Page)
+	return databasemanagement.ListExternalExadataStorageServersResponse{
+		ExternalExadataStorageServerCollection: databasemanagement.ExternalExadataStorageServerCollection{Items: items}, OpcNextPage: next}, nil
+}
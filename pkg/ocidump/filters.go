@@ -0,0 +1,1080 @@
+package ocidump
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+// FilterConfig represents the filtering configuration
+type FilterConfig struct {
+	IncludeCompartments  []string          `yaml:"include_compartments"`
+	ExcludeCompartments  []string          `yaml:"exclude_compartments"`
+	CompartmentSubtrees  []string          `yaml:"compartment_subtrees"` // Compartments included with all their descendants
+	IncludeResourceTypes []string          `yaml:"include_resource_types"`
+	ExcludeResourceTypes []string          `yaml:"exclude_resource_types"`
+	NamePattern          string            `yaml:"name_pattern"`
+	ExcludeNamePattern   string            `yaml:"exclude_name_pattern"`
+	NameGlob             string            `yaml:"name_glob"`         // Glob pattern for names to include, e.g. prod-*
+	ExcludeNameGlob      string            `yaml:"exclude_name_glob"` // Glob pattern for names to exclude
+	IgnoreCase           bool              `yaml:"ignore_case"`       // Match name patterns and globs case-insensitively
+	LifecycleStates      []string          `yaml:"lifecycle_states"`
+	CreatedAfter         string            `yaml:"created_after"`  // RFC3339 or YYYY-MM-DD
+	CreatedBefore        string            `yaml:"created_before"` // RFC3339 or YYYY-MM-DD
+	Shapes               []string          `yaml:"shapes"`         // Shape glob patterns, e.g. VM.Standard.E4.*
+	Expression           string            `yaml:"expression"`     // JMESPath expression a resource must satisfy
+	MinSizeGB            float64           `yaml:"min_size_gb"`    // Smallest storage size in GB to include (0 = no bound)
+	MaxSizeGB            float64           `yaml:"max_size_gb"`    // Largest storage size in GB to include (0 = no bound)
+	Where                map[string]string `yaml:"where"`          // Additional info values a resource must have, e.g. kubernetes_version: v1.27.2
+	OCIDFile             string            `yaml:"ocid_file"`      // File of OCIDs (one per line) to restrict the output to
+	OCIDs                []string          `yaml:"ocids"`          // OCIDs to restrict the output to, combined with the OCID file
+
+	SkipEmptyCompartments bool `yaml:"skip_empty_compartments"` // Show progress bars only for compartments with resources
+}
+
+// Compiled regex patterns for efficient matching
+type CompiledFilters struct {
+	NameRegex        *regexp.Regexp
+	ExcludeNameRegex *regexp.Regexp
+	NameGlob         *regexp.Regexp // Name glob converted to a regex
+	ExcludeNameGlob  *regexp.Regexp
+	LifecycleStates  map[string]bool
+	CreatedAfter     time.Time
+	CreatedBefore    time.Time
+	Shapes           []string // Lower-case shape patterns
+	Expression       *Query
+	MinSizeGB        float64
+	MaxSizeGB        float64
+	Where            map[string]string
+	OCIDs            map[string]bool // OCIDs of the OCID file and the ocids list
+}
+
+// supportedResourceTypes maps CLI-friendly names to internal resource type names
+var resourceTypeAliases = map[string]string{
+	"compute_instances":                "ComputeInstances",
+	"vcns":                             "VCNs",
+	"subnets":                          "Subnets",
+	"block_volumes":                    "BlockVolumes",
+	"object_storage_buckets":           "ObjectStorageBuckets",
+	"object_storage":                   "ObjectStorageBuckets", // Short alias for compatibility
+	"oke_clusters":                     "OKEClusters",
+	"load_balancers":                   "LoadBalancers",
+	"database_systems":                 "DatabaseSystems",
+	"databases":                        "DatabaseSystems", // Short alias for compatibility
+	"drgs":                             "DRGs",
+	"autonomous_databases":             "AutonomousDatabases",
+	"functions":                        "Functions",
+	"api_gateways":                     "APIGateways",
+	"file_storage_systems":             "FileStorageSystems",
+	"file_storage":                     "FileStorageSystems", // Short alias for compatibility
+	"network_load_balancers":           "NetworkLoadBalancers",
+	"streams":                          "Streams",
+	"streaming":                        "Streams", // Short alias for compatibility
+	"users":                            "Users",
+	"groups":                           "Groups",
+	"dynamic_groups":                   "DynamicGroups",
+	"policies":                         "Policies",
+	"opensearch_clusters":              "OpenSearchClusters",
+	"opensearch_backups":               "OpenSearchClusterBackups",
+	"quotas":                           "Quotas",
+	"tag_namespaces":                   "TagNamespaces",
+	"tag_definitions":                  "TagDefinitions",
+	"identity_domains":                 "IdentityDomains",
+	"instance_pools":                   "InstancePools",
+	"instance_configurations":          "InstanceConfigurations",
+	"cluster_networks":                 "ClusterNetworks",
+	"volume_groups":                    "VolumeGroups",
+	"volume_group_backups":             "VolumeGroupBackups",
+	"mount_targets":                    "MountTargets",
+	"file_storage_exports":             "FileStorageExports",
+	"file_storage_snapshots":           "FileStorageSnapshots",
+	"stream_pools":                     "StreamPools",
+	"health_checks":                    "HealthChecks",
+	"managed_instances":                "ManagedInstances",
+	"managed_instance_groups":          "ManagedInstanceGroups",
+	"database_insights":                "DatabaseInsights",
+	"host_insights":                    "HostInsights",
+	"managed_databases":                "ManagedDatabases",
+	"external_container_databases":     "ExternalContainerDatabases",
+	"external_pluggable_databases":     "ExternalPluggableDatabases",
+	"external_non_container_databases": "ExternalNonContainerDatabases",
+	"exadata_db_servers":               "ExadataDbServers",
+	"exadata_storage_servers":          "ExadataStorageServers",
+	"blockchain_platforms":             "BlockchainPlatforms",
+	"private_ips":                      "PrivateIps",
+	"vnics":                            "Vnics",
+}
+
+// reverseResourceTypeAliases maps internal names to CLI-friendly names
+var reverseResourceTypeAliases = map[string]string{
+	"ComputeInstances":              "compute_instances",
+	"VCNs":                          "vcns",
+	"Subnets":                       "subnets",
+	"BlockVolumes":                  "block_volumes",
+	"ObjectStorageBuckets":          "object_storage_buckets",
+	"OKEClusters":                   "oke_clusters",
+	"LoadBalancers":                 "load_balancers",
+	"DatabaseSystems":               "database_systems",
+	"DRGs":                          "drgs",
+	"AutonomousDatabases":           "autonomous_databases",
+	"Functions":                     "functions",
+	"APIGateways":                   "api_gateways",
+	"FileStorageSystems":            "file_storage_systems",
+	"NetworkLoadBalancers":          "network_load_balancers",
+	"Streams":                       "streams",
+	"Users":                         "users",
+	"Groups":                        "groups",
+	"DynamicGroups":                 "dynamic_groups",
+	"Policies":                      "policies",
+	"OpenSearchClusters":            "opensearch_clusters",
+	"OpenSearchClusterBackups":      "opensearch_backups",
+	"Quotas":                        "quotas",
+	"TagNamespaces":                 "tag_namespaces",
+	"TagDefinitions":                "tag_definitions",
+	"IdentityDomains":               "identity_domains",
+	"InstancePools":                 "instance_pools",
+	"InstanceConfigurations":        "instance_configurations",
+	"ClusterNetworks":               "cluster_networks",
+	"VolumeGroups":                  "volume_groups",
+	"VolumeGroupBackups":            "volume_group_backups",
+	"MountTargets":                  "mount_targets",
+	"FileStorageExports":            "file_storage_exports",
+	"FileStorageSnapshots":          "file_storage_snapshots",
+	"StreamPools":                   "stream_pools",
+	"HealthChecks":                  "health_checks",
+	"ManagedInstances":              "managed_instances",
+	"ManagedInstanceGroups":         "managed_instance_groups",
+	"DatabaseInsights":              "database_insights",
+	"HostInsights":                  "host_insights",
+	"ManagedDatabases":              "managed_databases",
+	"ExternalContainerDatabases":    "external_container_databases",
+	"ExternalPluggableDatabases":    "external_pluggable_databases",
+	"ExternalNonContainerDatabases": "external_non_container_databases",
+	"ExadataDbServers":              "exadata_db_servers",
+	"ExadataStorageServers":         "exadata_storage_servers",
+	"BlockchainPlatforms":           "blockchain_platforms",
+	"PrivateIps":                    "private_ips",
+	"Vnics":                         "vnics",
+}
+
+// supportedResourceTypes contains all supported resource type names (internal format)
+var supportedResourceTypes = []string{
+	"ComputeInstances",
+	"VCNs",
+	"Subnets",
+	"BlockVolumes",
+	"ObjectStorageBuckets",
+	"OKEClusters",
+	"LoadBalancers",
+	"DatabaseSystems",
+	"DRGs",
+	"AutonomousDatabases",
+	"Functions",
+	"APIGateways",
+	"FileStorageSystems",
+	"NetworkLoadBalancers",
+	"Streams",
+	"Users",
+	"Groups",
+	"DynamicGroups",
+	"Policies",
+	"OpenSearchClusters",
+	"OpenSearchClusterBackups",
+	"Quotas",
+	"TagNamespaces",
+	"TagDefinitions",
+	"IdentityDomains",
+	"InstancePools",
+	"InstanceConfigurations",
+	"ClusterNetworks",
+	"VolumeGroups",
+	"VolumeGroupBackups",
+	"MountTargets",
+	"FileStorageExports",
+	"FileStorageSnapshots",
+	"StreamPools",
+	"HealthChecks",
+	"ManagedInstances",
+	"ManagedInstanceGroups",
+	"DatabaseInsights",
+	"HostInsights",
+	"ManagedDatabases",
+	"ExternalContainerDatabases",
+	"ExternalPluggableDatabases",
+	"ExternalNonContainerDatabases",
+	"ExadataDbServers",
+	"ExadataStorageServers",
+	"BlockchainPlatforms",
+	"PrivateIps",
+	"Vnics",
+}
+
+// optInResourceTypes contains resource types that are only discovered when explicitly
+// listed in the include filter (tenancy-level data or expensive discoveries)
+var optInResourceTypes = map[string]bool{
+	"Users":                true,
+	"Groups":               true,
+	"DynamicGroups":        true,
+	"Policies":             true,
+	"FileStorageSnapshots": true,
+	"PrivateIps":           true,
+	"Vnics":                true,
+}
+
+// resourceTypeGroups maps category names usable in resource type filters to their resource types
+// Opt-in types with very many resources (FileStorageSnapshots, PrivateIps, Vnics) are not part of any group
+// and still have to be named on their own.
+var resourceTypeGroups = map[string][]string{
+	"compute": {"ComputeInstances", "InstancePools", "InstanceConfigurations", "ClusterNetworks",
+		"OKEClusters", "ManagedInstances", "ManagedInstanceGroups"},
+	"network": {"VCNs", "Subnets", "DRGs", "LocalPeeringGateways", "LoadBalancers", "NetworkLoadBalancers"},
+	"storage": {"BlockVolumes", "BootVolumes", "BlockVolumeBackups", "BootVolumeBackups", "VolumeGroups",
+		"VolumeGroupBackups", "ObjectStorageBuckets", "FileStorageSystems", "MountTargets", "FileStorageExports"},
+	"database": {"DatabaseSystems", "Databases", "DbHomes", "DbNodes", "AutonomousDatabases",
+		"ExadataInfrastructures", "CloudExadataInfrastructures", "VmClusters", "ExadataDbServers", "ExadataStorageServers",
+		"ManagedDatabases", "ExternalContainerDatabases", "ExternalPluggableDatabases", "ExternalNonContainerDatabases"},
+	"serverless":    {"Functions", "APIGateways"},
+	"messaging":     {"Streams", "StreamPools"},
+	"analytics":     {"OpenSearchClusters", "OpenSearchClusterBackups"},
+	"observability": {"HealthChecks", "DatabaseInsights", "HostInsights"},
+	"identity": {"Users", "Groups", "DynamicGroups", "Policies", "IdentityDomains", "Quotas",
+		"TagNamespaces", "TagDefinitions"},
+}
+
+// ValidateFilterConfig validates the filter configuration
+// Every problem is reported; the returned error joins them with errors.Join.
+func ValidateFilterConfig(filter FilterConfig) error {
+	var problems []error
+
+	// Validate compartment OCIDs, names and patterns
+	selectors := append(append([]string{}, filter.IncludeCompartments...), filter.ExcludeCompartments...)
+	for _, compartment := range append(selectors, filter.CompartmentSubtrees...) {
+		if err := validateCompartmentSelector(compartment); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	// Validate resource types and groups
+	for _, rt := range append(append([]string{}, filter.IncludeResourceTypes...), filter.ExcludeResourceTypes...) {
+		if _, isGroup := resourceTypeGroups[strings.ToLower(rt)]; isGroup {
+			continue
+		}
+		if !isValidResourceType(rt) {
+			problems = append(problems, fmt.Errorf("unknown resource type '%s', supported types: %v, groups: %v", rt, getSupportedResourceTypeNames(), getResourceTypeGroupNames()))
+		}
+	}
+
+	// Validate regex and glob patterns
+	for _, pattern := range []string{filter.NamePattern, filter.ExcludeNamePattern} {
+		if pattern != "" {
+			if _, err := compileNamePattern(pattern, filter.IgnoreCase); err != nil {
+				problems = append(problems, fmt.Errorf("invalid regex pattern '%s': %v", pattern, err))
+			}
+		}
+	}
+	for _, glob := range []string{filter.NameGlob, filter.ExcludeNameGlob} {
+		if glob != "" {
+			if _, err := compileNameGlob(glob, filter.IgnoreCase); err != nil {
+				problems = append(problems, fmt.Errorf("invalid glob pattern '%s': %v", glob, err))
+			}
+		}
+	}
+
+	// Validate creation time bounds
+	if _, err := parseFilterTime(filter.CreatedAfter); err != nil {
+		problems = append(problems, fmt.Errorf("invalid created_after '%s': %v", filter.CreatedAfter, err))
+	}
+	if _, err := parseFilterTime(filter.CreatedBefore); err != nil {
+		problems = append(problems, fmt.Errorf("invalid created_before '%s': %v", filter.CreatedBefore, err))
+	}
+
+	// Validate shape patterns
+	for _, shape := range filter.Shapes {
+		if _, err := path.Match(shape, ""); err != nil {
+			problems = append(problems, fmt.Errorf("invalid shape pattern '%s': %v", shape, err))
+		}
+	}
+
+	// Validate storage size bounds
+	if filter.MinSizeGB < 0 || filter.MaxSizeGB < 0 {
+		problems = append(problems, fmt.Errorf("size bounds must not be negative, got: min %v, max %v", filter.MinSizeGB, filter.MaxSizeGB))
+	} else if filter.MaxSizeGB > 0 && filter.MinSizeGB > filter.MaxSizeGB {
+		problems = append(problems, fmt.Errorf("min_size_gb %v must not be greater than max_size_gb %v", filter.MinSizeGB, filter.MaxSizeGB))
+	}
+
+	// Validate additional info conditions
+	for key := range filter.Where {
+		if strings.TrimSpace(key) == "" {
+			problems = append(problems, fmt.Errorf("where conditions need an additional info key"))
+			break
+		}
+	}
+
+	// Validate the OCID file and list
+	if filter.OCIDFile != "" {
+		if _, err := LoadOCIDFile(filter.OCIDFile); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	for _, ocid := range filter.OCIDs {
+		if !isOCID(strings.TrimSpace(ocid)) {
+			problems = append(problems, fmt.Errorf("invalid OCID in ocids: %s", ocid))
+		}
+	}
+
+	// Validate the filter expression
+	if filter.Expression != "" {
+		if _, err := CompileQuery(filter.Expression); err != nil {
+			problems = append(problems, fmt.Errorf("invalid filter expression '%s': %v", filter.Expression, err))
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// CompileFilters compiles regex patterns for efficient matching
+func CompileFilters(filter FilterConfig) (*CompiledFilters, error) {
+	compiled := &CompiledFilters{}
+
+	if filter.NamePattern != "" {
+		regex, err := compileNamePattern(filter.NamePattern, filter.IgnoreCase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile name pattern '%s': %v", filter.NamePattern, err)
+		}
+		compiled.NameRegex = regex
+	}
+
+	if filter.ExcludeNamePattern != "" {
+		regex, err := compileNamePattern(filter.ExcludeNamePattern, filter.IgnoreCase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile exclude name pattern '%s': %v", filter.ExcludeNamePattern, err)
+		}
+		compiled.ExcludeNameRegex = regex
+	}
+
+	if filter.NameGlob != "" {
+		regex, err := compileNameGlob(filter.NameGlob, filter.IgnoreCase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile name glob '%s': %v", filter.NameGlob, err)
+		}
+		compiled.NameGlob = regex
+	}
+
+	if filter.ExcludeNameGlob != "" {
+		regex, err := compileNameGlob(filter.ExcludeNameGlob, filter.IgnoreCase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile exclude name glob '%s': %v", filter.ExcludeNameGlob, err)
+		}
+		compiled.ExcludeNameGlob = regex
+	}
+
+	if len(filter.LifecycleStates) > 0 {
+		compiled.LifecycleStates = make(map[string]bool, len(filter.LifecycleStates))
+		for _, state := range filter.LifecycleStates {
+			compiled.LifecycleStates[strings.ToUpper(state)] = true
+		}
+	}
+
+	var err error
+	if compiled.CreatedAfter, err = parseFilterTime(filter.CreatedAfter); err != nil {
+		return nil, fmt.Errorf("failed to parse created_after '%s': %v", filter.CreatedAfter, err)
+	}
+	if compiled.CreatedBefore, err = parseFilterTime(filter.CreatedBefore); err != nil {
+		return nil, fmt.Errorf("failed to parse created_before '%s': %v", filter.CreatedBefore, err)
+	}
+
+	for _, shape := range filter.Shapes {
+		if _, err := path.Match(shape, ""); err != nil {
+			return nil, fmt.Errorf("failed to compile shape pattern '%s': %v", shape, err)
+		}
+		compiled.Shapes = append(compiled.Shapes, strings.ToLower(shape))
+	}
+
+	compiled.MinSizeGB = filter.MinSizeGB
+	compiled.MaxSizeGB = filter.MaxSizeGB
+	compiled.Where = filter.Where
+
+	if filter.OCIDFile != "" {
+		if compiled.OCIDs, err = LoadOCIDFile(filter.OCIDFile); err != nil {
+			return nil, err
+		}
+	}
+	if len(filter.OCIDs) > 0 && compiled.OCIDs == nil {
+		compiled.OCIDs = make(map[string]bool, len(filter.OCIDs))
+	}
+	for _, ocid := range filter.OCIDs {
+		compiled.OCIDs[strings.TrimSpace(ocid)] = true
+	}
+
+	if filter.Expression != "" {
+		if compiled.Expression, err = CompileQuery(filter.Expression); err != nil {
+			return nil, fmt.Errorf("failed to compile filter expression '%s': %v", filter.Expression, err)
+		}
+	}
+
+	return compiled, nil
+}
+
+// ResolveCompartmentFilter replaces the compartment names and glob patterns (e.g. prod-*) of the
+// include/exclude lists with the OCIDs of the matching compartments, and adds the compartments of
+// the selected subtrees to the include list
+// A name must match exactly one compartment, since names are only unique among siblings; an ambiguous
+// name is an error. Names and patterns that match no compartment are logged and dropped, but an include
+// list that matches nothing at all is an error rather than selecting every compartment.
+func ResolveCompartmentFilter(compartments []identity.Compartment, filter FilterConfig) (FilterConfig, error) {
+	include, err := resolveCompartmentSelectors(compartments, filter.IncludeCompartments)
+	if err != nil {
+		return filter, err
+	}
+	subtrees, err := resolveCompartmentSelectors(compartments, filter.CompartmentSubtrees)
+	if err != nil {
+		return filter, err
+	}
+	include = appendCompartmentSubtrees(include, compartments, subtrees)
+
+	// An empty include list selects every compartment, so one that matched nothing must not become empty
+	if len(filter.IncludeCompartments)+len(filter.CompartmentSubtrees) > 0 && len(include) == 0 {
+		return filter, fmt.Errorf("no compartment matches the included compartments %v",
+			append(append([]string{}, filter.IncludeCompartments...), filter.CompartmentSubtrees...))
+	}
+	filter.IncludeCompartments = include
+	filter.CompartmentSubtrees = nil
+
+	filter.ExcludeCompartments, err = resolveCompartmentSelectors(compartments, filter.ExcludeCompartments)
+	if err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// appendCompartmentSubtrees appends the OCIDs of the root compartments and all their descendants
+// that are not in ocids yet, following the parents of the compartments
+func appendCompartmentSubtrees(ocids []string, compartments []identity.Compartment, roots []string) []string {
+	if len(roots) == 0 {
+		return ocids
+	}
+
+	children := make(map[string][]string)
+	for _, compartment := range compartments {
+		if compartment.Id == nil || compartment.CompartmentId == nil || *compartment.Id == *compartment.CompartmentId {
+			continue // The root compartment is listed as its own parent
+		}
+		children[*compartment.CompartmentId] = append(children[*compartment.CompartmentId], *compartment.Id)
+	}
+
+	included := make(map[string]bool, len(ocids))
+	for _, ocid := range ocids {
+		included[ocid] = true
+	}
+	visited := make(map[string]bool)
+	pending := append([]string{}, roots...)
+	for len(pending) > 0 {
+		ocid := pending[0]
+		pending = pending[1:]
+		if visited[ocid] {
+			continue
+		}
+		visited[ocid] = true
+		if !included[ocid] {
+			included[ocid] = true
+			ocids = append(ocids, ocid)
+		}
+		pending = append(pending, children[ocid]...)
+	}
+	return ocids
+}
+
+// resolveCompartmentSelectors resolves compartment OCIDs, names and patterns to OCIDs
+func resolveCompartmentSelectors(compartments []identity.Compartment, selectors []string) ([]string, error) {
+	if len(selectors) == 0 {
+		return selectors, nil
+	}
+
+	var ocids []string
+	for _, selector := range selectors {
+		if isOCID(selector) {
+			ocids = append(ocids, selector)
+			continue
+		}
+
+		var matches []string
+		for _, compartment := range compartments {
+			if compartment.Id == nil || compartment.Name == nil {
+				continue
+			}
+			if matchCompartmentName(selector, *compartment.Name) {
+				matches = append(matches, *compartment.Id)
+			}
+		}
+
+		switch {
+		case len(matches) == 0:
+			logger.Error("Warning: No compartment matches '%s'", selector)
+		case len(matches) > 1 && !isCompartmentPattern(selector):
+			return nil, fmt.Errorf("compartment name '%s' is ambiguous, it matches %d compartments (%s); use an OCID instead",
+				selector, len(matches), strings.Join(matches, ", "))
+		default:
+			logger.Verbose("Compartment '%s' resolved to %s", selector, strings.Join(matches, ", "))
+		}
+		ocids = append(ocids, matches...)
+	}
+	return ocids, nil
+}
+
+// matchCompartmentName reports whether a compartment name matches a name or glob pattern
+func matchCompartmentName(selector, name string) bool {
+	if !isCompartmentPattern(selector) {
+		return selector == name
+	}
+	matched, _ := path.Match(selector, name)
+	return matched
+}
+
+// isCompartmentPattern reports whether a compartment selector is a glob pattern instead of a name
+func isCompartmentPattern(selector string) bool {
+	return strings.ContainsAny(selector, "*?[")
+}
+
+// validateCompartmentSelector checks a compartment OCID, name or glob pattern
+func validateCompartmentSelector(selector string) error {
+	if isOCID(selector) {
+		if !isValidCompartmentOCID(selector) {
+			return fmt.Errorf("invalid compartment OCID format: %s", selector)
+		}
+		return nil
+	}
+	if isCompartmentPattern(selector) {
+		if _, err := path.Match(selector, ""); err != nil {
+			return fmt.Errorf("invalid compartment pattern '%s': %v", selector, err)
+		}
+	}
+	return nil
+}
+
+// isOCID reports whether a value is an OCID rather than a name
+func isOCID(value string) bool {
+	return strings.HasPrefix(value, "ocid1.")
+}
+
+// ApplyCompartmentFilter filters compartments based on include/exclude lists
+func ApplyCompartmentFilter(compartments []identity.Compartment, filter FilterConfig) []identity.Compartment {
+	if len(filter.IncludeCompartments) == 0 && len(filter.ExcludeCompartments) == 0 {
+		return compartments // No filtering
+	}
+
+	var filtered []identity.Compartment
+
+	for _, compartment := range compartments {
+		compartmentID := *compartment.Id
+
+		// Apply include filter (if specified, only include compartments in the list)
+		if len(filter.IncludeCompartments) > 0 {
+			if !stringInSlice(compartmentID, filter.IncludeCompartments) {
+				continue // Skip this compartment
+			}
+		}
+
+		// Apply exclude filter (skip compartments in the exclude list)
+		if len(filter.ExcludeCompartments) > 0 {
+			if stringInSlice(compartmentID, filter.ExcludeCompartments) {
+				continue // Skip this compartment
+			}
+		}
+
+		filtered = append(filtered, compartment)
+	}
+
+	return filtered
+}
+
+// FilterResources applies a filter configuration to resources read from a dump, e.g. to scope a diff
+// Resource types and groups are matched against the recorded types (e.g. "VCN"), and compartments
+// by OCID, name or glob pattern against the recorded compartment. Compartment subtrees are not
+// supported, as dumps do not record the compartment hierarchy.
+func FilterResources(resources []ResourceInfo, filter FilterConfig) ([]ResourceInfo, error) {
+	if len(filter.CompartmentSubtrees) > 0 {
+		return nil, fmt.Errorf("compartment subtrees cannot be applied to dump files, which do not record the compartment hierarchy")
+	}
+	compiled, err := CompileFilters(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile filter patterns: %w", err)
+	}
+	includeTypes := expandResourceTypes(filter.IncludeResourceTypes)
+	excludeTypes := expandResourceTypes(filter.ExcludeResourceTypes)
+
+	filtered := make([]ResourceInfo, 0, len(resources))
+	for _, resource := range resources {
+		if len(includeTypes) > 0 && !matchesAnyResourceType(resource.ResourceType, includeTypes) {
+			continue
+		}
+		if len(excludeTypes) > 0 && matchesAnyResourceType(resource.ResourceType, excludeTypes) {
+			continue
+		}
+		if len(filter.IncludeCompartments) > 0 && !matchesAnyCompartment(resource, filter.IncludeCompartments) {
+			continue
+		}
+		if matchesAnyCompartment(resource, filter.ExcludeCompartments) {
+			continue
+		}
+		if ApplyOCIDFilter(resource, compiled) && ApplyNameFilter(resource.ResourceName, compiled) &&
+			ApplyLifecycleFilter(resource, compiled) && ApplyShapeFilter(resource, compiled) &&
+			ApplySizeFilter(resource, compiled) && ApplyWhereFilter(resource, compiled) &&
+			ApplyExpressionFilter(resource, compiled) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered, nil
+}
+
+// matchesAnyCompartment reports whether the compartment of a resource matches one of the selectors
+func matchesAnyCompartment(resource ResourceInfo, selectors []string) bool {
+	for _, selector := range selectors {
+		if isOCID(selector) {
+			if resource.CompartmentID == selector {
+				return true
+			}
+		} else if matchCompartmentName(selector, resource.CompartmentName) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyResourceTypeFilter checks if a resource type should be processed
+func ApplyResourceTypeFilter(resourceType string, filter FilterConfig) bool {
+	// Apply include filter (if specified, only process resource types in the list)
+	if len(filter.IncludeResourceTypes) > 0 {
+		if !stringInSlice(resourceType, expandResourceTypes(filter.IncludeResourceTypes)) {
+			return false
+		}
+	} else if optInResourceTypes[resourceType] {
+		// Opt-in resource types must be requested explicitly
+		return false
+	}
+
+	// Apply exclude filter (skip resource types in the exclude list)
+	if len(filter.ExcludeResourceTypes) > 0 {
+		if stringInSlice(resourceType, expandResourceTypes(filter.ExcludeResourceTypes)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ApplyNameFilter checks if a resource name matches the filter criteria
+func ApplyNameFilter(resourceName string, compiled *CompiledFilters) bool {
+	// Apply include name pattern (if specified, only include resources matching the pattern)
+	if compiled.NameRegex != nil {
+		if !compiled.NameRegex.MatchString(resourceName) {
+			return false
+		}
+	}
+
+	// Apply exclude name pattern (skip resources matching the exclude pattern)
+	if compiled.ExcludeNameRegex != nil {
+		if compiled.ExcludeNameRegex.MatchString(resourceName) {
+			return false
+		}
+	}
+
+	// Apply name globs, which must match the whole name
+	if compiled.NameGlob != nil && !compiled.NameGlob.MatchString(resourceName) {
+		return false
+	}
+	if compiled.ExcludeNameGlob != nil && compiled.ExcludeNameGlob.MatchString(resourceName) {
+		return false
+	}
+
+	return true
+}
+
+// ApplyLifecycleFilter checks if a resource matches the lifecycle state and creation time criteria
+// Resources without the corresponding field are excluded when that filter is set
+func ApplyLifecycleFilter(resource ResourceInfo, compiled *CompiledFilters) bool {
+	if compiled.LifecycleStates != nil && !compiled.LifecycleStates[strings.ToUpper(resource.LifecycleState)] {
+		return false
+	}
+
+	if compiled.CreatedAfter.IsZero() && compiled.CreatedBefore.IsZero() {
+		return true
+	}
+
+	timeCreated, err := time.Parse(time.RFC3339, resource.TimeCreated)
+	if err != nil {
+		return false
+	}
+	if !compiled.CreatedAfter.IsZero() && timeCreated.Before(compiled.CreatedAfter) {
+		return false
+	}
+	if !compiled.CreatedBefore.IsZero() && !timeCreated.Before(compiled.CreatedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// ApplyShapeFilter checks if the shape of a resource matches one of the shape patterns
+// Patterns are matched case-insensitively; resources without a shape are excluded when the filter is set
+func ApplyShapeFilter(resource ResourceInfo, compiled *CompiledFilters) bool {
+	if len(compiled.Shapes) == 0 {
+		return true
+	}
+	shape, ok := resource.AdditionalInfo["shape"].(string)
+	if !ok || shape == "" {
+		return false
+	}
+	shape = strings.ToLower(shape)
+	for _, pattern := range compiled.Shapes {
+		if matched, _ := path.Match(pattern, shape); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplySizeFilter checks if the storage size of a resource is within the size bounds
+// Resources without a storage size are excluded when a bound is set
+func ApplySizeFilter(resource ResourceInfo, compiled *CompiledFilters) bool {
+	if compiled.MinSizeGB == 0 && compiled.MaxSizeGB == 0 {
+		return true
+	}
+	size, ok := storageSizeGB(resource.AdditionalInfo)
+	if !ok {
+		return false
+	}
+	if size < compiled.MinSizeGB {
+		return false
+	}
+	if compiled.MaxSizeGB > 0 && size > compiled.MaxSizeGB {
+		return false
+	}
+	return true
+}
+
+// storageSizeGB returns the storage size of a resource in GB from its additional info:
+// size_in_gbs of volumes, volume groups and backups, size_in_gb of file systems and
+// data_storage_size_in_tbs of autonomous databases
+func storageSizeGB(additionalInfo map[string]interface{}) (float64, bool) {
+	if size, ok := numericInfoValue(additionalInfo["size_in_gbs"]); ok {
+		return size, true
+	}
+	if size, ok := numericInfoValue(additionalInfo["size_in_gb"]); ok {
+		return size, true
+	}
+	if size, ok := numericInfoValue(additionalInfo["data_storage_size_in_tbs"]); ok {
+		return size * 1024, true
+	}
+	return 0, false
+}
+
+// numericInfoValue converts a numeric additional info value, which may also be formatted as a
+// string or decoded from JSON, to a float64
+func numericInfoValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		size, err := strconv.ParseFloat(v, 64)
+		return size, err == nil
+	}
+	return 0, false
+}
+
+// ApplyWhereFilter checks if the additional info of a resource has all the values of the where conditions
+// Values are compared as formatted in text output; a list matches when one of its elements does.
+// Resources without a key are excluded when it has a condition.
+func ApplyWhereFilter(resource ResourceInfo, compiled *CompiledFilters) bool {
+	for key, want := range compiled.Where {
+		value, ok := resource.AdditionalInfo[key]
+		if !ok || !matchInfoValue(value, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchInfoValue reports whether an additional info value, or an element of a list value, formats as want
+func matchInfoValue(value interface{}, want string) bool {
+	if value == nil {
+		return false
+	}
+	if list := reflect.ValueOf(value); list.Kind() == reflect.Slice {
+		for i := 0; i < list.Len(); i++ {
+			if fmt.Sprint(list.Index(i).Interface()) == want {
+				return true
+			}
+		}
+		return false
+	}
+	return fmt.Sprint(value) == want
+}
+
+// ApplyOCIDFilter checks if the OCID of a resource is in the OCID file or the ocids list
+func ApplyOCIDFilter(resource ResourceInfo, compiled *CompiledFilters) bool {
+	return compiled.OCIDs == nil || compiled.OCIDs[resource.OCID]
+}
+
+// LoadOCIDFile reads a file of OCIDs, one per line
+// Surrounding whitespace, blank lines and lines starting with # are ignored.
+func LoadOCIDFile(filename string) (map[string]bool, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCID file: %w", err)
+	}
+
+	ocids := make(map[string]bool)
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !isOCID(line) {
+			return nil, fmt.Errorf("invalid OCID on line %d of %s: %s", i+1, filename, line)
+		}
+		ocids[line] = true
+	}
+	if len(ocids) == 0 {
+		return nil, fmt.Errorf("OCID file %s contains no OCIDs", filename)
+	}
+	return ocids, nil
+}
+
+// ApplyExpressionFilter checks if a resource satisfies the filter expression
+// The JMESPath expression is evaluated against the resource as it appears in the JSON output and
+// must yield a truthy value; resources the expression fails on are excluded.
+func ApplyExpressionFilter(resource ResourceInfo, compiled *CompiledFilters) bool {
+	if compiled.Expression == nil {
+		return true
+	}
+	matched, err := compiled.Expression.Matches(resource)
+	if err != nil {
+		logger.Debug("Filter expression failed on resource %s: %v", resource.ResourceName, err)
+		return false
+	}
+	return matched
+}
+
+// Helper functions
+
+// compileNamePattern compiles a name regex, case-insensitively with ignoreCase
+func compileNamePattern(pattern string, ignoreCase bool) (*regexp.Regexp, error) {
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
+// compileNameGlob converts a glob pattern to a regex matching whole names
+// * matches any run of characters, ? a single character and [...] (or [!...]) a character class;
+// a backslash escapes the next character.
+func compileNameGlob(glob string, ignoreCase bool) (*regexp.Regexp, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			pattern.WriteString(".*")
+		case '?':
+			pattern.WriteString(".")
+		case '\\':
+			if i+1 == len(glob) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			i++
+			pattern.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class")
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			pattern.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	pattern.WriteString("$")
+	return compileNamePattern(pattern.String(), ignoreCase)
+}
+
+// parseFilterTime parses a creation time bound in RFC3339 or YYYY-MM-DD format
+// An empty value returns the zero time (no bound)
+func parseFilterTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// isValidCompartmentOCID validates the OCID format for compartments
+func isValidCompartmentOCID(ocid string) bool {
+	// Basic OCID format validation
+	// Format: ocid1.compartment.oc1..<unique_id>
+	return strings.HasPrefix(ocid, "ocid1.compartment.oc1..")
+}
+
+// isValidResourceType checks if the resource type is supported
+func isValidResourceType(resourceType string) bool {
+	// Check both CLI-friendly names and internal names
+	if _, exists := resourceTypeAliases[strings.ToLower(resourceType)]; exists {
+		return true
+	}
+	return stringInSlice(resourceType, supportedResourceTypes)
+}
+
+// normalizeResourceType converts CLI-friendly resource type names to internal names
+func normalizeResourceType(resourceType string) string {
+	if internal, exists := resourceTypeAliases[strings.ToLower(resourceType)]; exists {
+		return internal
+	}
+	return resourceType // Return as-is if not found in aliases
+}
+
+// expandResourceTypes converts resource type names and groups to internal names
+func expandResourceTypes(resourceTypes []string) []string {
+	var expanded []string
+	for _, rt := range resourceTypes {
+		if group, isGroup := resourceTypeGroups[strings.ToLower(rt)]; isGroup {
+			expanded = append(expanded, group...)
+		} else {
+			expanded = append(expanded, normalizeResourceType(rt))
+		}
+	}
+	return expanded
+}
+
+// getResourceTypeGroupNames returns the sorted names of the resource type groups
+func getResourceTypeGroupNames() []string {
+	names := make([]string, 0, len(resourceTypeGroups))
+	for name := range resourceTypeGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getSupportedResourceTypeNames returns a list of all supported resource type names (CLI-friendly)
+func getSupportedResourceTypeNames() []string {
+	var names []string
+	for alias := range resourceTypeAliases {
+		names = append(names, alias)
+	}
+	return names
+}
+
+// stringInSlice checks if a string exists in a slice
+func stringInSlice(str string, slice []string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseResourceTypeList parses a comma-separated string of resource types
+func ParseResourceTypeList(input string) []string {
+	if input == "" {
+		return nil
+	}
+
+	var result []string
+	types := strings.Split(input, ",")
+	for _, t := range types {
+		trimmed := strings.TrimSpace(t)
+		if trimmed != "" {
+			// Normalize to lowercase for consistency
+			result = append(result, strings.ToLower(trimmed))
+		}
+	}
+	return result
+}
+
+// ParseCompartmentList parses a comma-separated string of compartment OCIDs
+func ParseCompartmentList(input string) []string {
+	if input == "" {
+		return nil
+	}
+
+	var result []string
+	ocids := strings.Split(input, ",")
+	for _, ocid := range ocids {
+		trimmed := strings.TrimSpace(ocid)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// ParseWhereList parses key=value conditions on additional info into a map
+func ParseWhereList(conditions []string) (map[string]string, error) {
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	where := make(map[string]string, len(conditions))
+	for _, condition := range conditions {
+		key, value, ok := strings.Cut(condition, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid where condition '%s', expected key=value", condition)
+		}
+		where[key] = strings.TrimSpace(value)
+	}
+	return where, nil
+}
+
+// ParseShapeList parses a comma-separated string of shape patterns
+func ParseShapeList(input string) []string {
+	if input == "" {
+		return nil
+	}
+
+	var result []string
+	for _, shape := range strings.Split(input, ",") {
+		trimmed := strings.TrimSpace(shape)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// ParseLifecycleStateList parses comma-separated lifecycle states into upper-case values
+func ParseLifecycleStateList(input string) []string {
+	if input == "" {
+		return nil
+	}
+
+	var result []string
+	states := strings.Split(input, ",")
+	for _, state := range states {
+		trimmed := strings.ToUpper(strings.TrimSpace(state))
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
@@ -0,0 +1,285 @@
+package ocidump
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// GraphFormats lists the output formats that render the resource dependency graph
+var GraphFormats = []string{"dot", "mermaid"}
+
+// IsGraphFormat reports whether format renders the dependency graph instead of the resource list
+func IsGraphFormat(format string) bool {
+	return contains(GraphFormats, format)
+}
+
+// ResourceGraph holds resources as nodes and the references between them as edges
+type ResourceGraph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// GraphNode is a resource in the dependency graph
+type GraphNode struct {
+	OCID            string
+	ResourceType    string
+	Name            string
+	CompartmentID   string
+	CompartmentName string
+}
+
+// GraphEdge is a reference from one resource to another, e.g. an instance to its subnet
+type GraphEdge struct {
+	From  string // OCID of the referencing resource
+	To    string // OCID of the referenced resource
+	Label string // AdditionalInfo key without the _id / _ids suffix, e.g. "subnet"
+}
+
+// BuildResourceGraph creates the dependency graph of the resources
+// Edges are taken from AdditionalInfo values holding OCIDs of other resources in the same dump
+// (subnet_id, vcn_id, db_system_id, subnet_ids, attached_volumes, ...); references to resources
+// outside the dump are dropped. A relationship recorded on both sides, such as attached_volumes and
+// attached_instance, produces a single edge.
+// With connectedOnly, resources without any edge are left out.
+func BuildResourceGraph(resources []ResourceInfo, connectedOnly bool) *ResourceGraph {
+	known := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		if resource.OCID != "" {
+			known[resource.OCID] = true
+		}
+	}
+
+	graph := &ResourceGraph{}
+	linked := make(map[[2]string]bool)
+	connected := make(map[string]bool)
+	for _, resource := range resources {
+		if resource.OCID == "" {
+			continue
+		}
+
+		keys := make([]string, 0, len(resource.AdditionalInfo))
+		for key := range resource.AdditionalInfo {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			for _, target := range referencedOCIDs(resource.AdditionalInfo[key]) {
+				if target == resource.OCID || !known[target] {
+					continue
+				}
+				pair := [2]string{resource.OCID, target}
+				if pair[0] > pair[1] {
+					pair[0], pair[1] = pair[1], pair[0]
+				}
+				if linked[pair] {
+					continue
+				}
+				linked[pair] = true
+				connected[resource.OCID] = true
+				connected[target] = true
+				graph.Edges = append(graph.Edges, GraphEdge{From: resource.OCID, To: target, Label: edgeLabel(key)})
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		if resource.OCID == "" || seen[resource.OCID] || (connectedOnly && !connected[resource.OCID]) {
+			continue
+		}
+		seen[resource.OCID] = true
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			OCID:            resource.OCID,
+			ResourceType:    resource.ResourceType,
+			Name:            resource.ResourceName,
+			CompartmentID:   resource.CompartmentID,
+			CompartmentName: resource.CompartmentName,
+		})
+	}
+
+	return graph
+}
+
+// referencedOCIDs returns the OCIDs held by an AdditionalInfo value
+// Values are a single OCID, a comma-separated list (attached_instance) or a list, which is
+// []string when discovered and []interface{} when loaded from a dump file
+func referencedOCIDs(value interface{}) []string {
+	var candidates []string
+	switch v := value.(type) {
+	case string:
+		candidates = strings.Split(v, ",")
+	case []string:
+		candidates = v
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				candidates = append(candidates, s)
+			}
+		}
+	}
+
+	var ocids []string
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if strings.HasPrefix(candidate, "ocid1.") {
+			ocids = append(ocids, candidate)
+		}
+	}
+	return ocids
+}
+
+// edgeLabel derives the edge label from an AdditionalInfo key
+func edgeLabel(key string) string {
+	if label, ok := strings.CutSuffix(key, "_ids"); ok {
+		return label
+	}
+	if label, ok := strings.CutSuffix(key, "_id"); ok {
+		return label
+	}
+	return key
+}
+
+// compartmentGroups returns the node indexes grouped by compartment, in order of first appearance
+func (g *ResourceGraph) compartmentGroups() (order []string, groups map[string][]int) {
+	groups = make(map[string][]int)
+	for i, node := range g.Nodes {
+		if _, ok := groups[node.CompartmentID]; !ok {
+			order = append(order, node.CompartmentID)
+		}
+		groups[node.CompartmentID] = append(groups[node.CompartmentID], i)
+	}
+	return order, groups
+}
+
+// nodeIDs assigns short identifiers (n0, n1, ...) to the nodes, keyed by OCID
+func (g *ResourceGraph) nodeIDs() map[string]string {
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		ids[node.OCID] = fmt.Sprintf("n%d", i)
+	}
+	return ids
+}
+
+// compartmentLabel returns the compartment name, falling back to its OCID
+func (n GraphNode) compartmentLabel() string {
+	if n.CompartmentName != "" {
+		return n.CompartmentName
+	}
+	return n.CompartmentID
+}
+
+// WriteGraph renders the graph in the given format (dot or mermaid)
+func WriteGraph(graph *ResourceGraph, format string, w io.Writer) error {
+	switch format {
+	case "dot":
+		return writeGraphDOT(graph, w)
+	case "mermaid":
+		return writeGraphMermaid(graph, w)
+	default:
+		return fmt.Errorf("unsupported graph format: %s", format)
+	}
+}
+
+// writeGraphDOT renders the graph in Graphviz DOT, with one cluster per compartment
+func writeGraphDOT(graph *ResourceGraph, w io.Writer) error {
+	var b strings.Builder
+	ids := graph.nodeIDs()
+
+	b.WriteString("digraph resources {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	order, groups := graph.compartmentGroups()
+	for i, compartmentID := range order {
+		nodes := groups[compartmentID]
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(graph.Nodes[nodes[0]].compartmentLabel()))
+		for _, index := range nodes {
+			node := graph.Nodes[index]
+			fmt.Fprintf(&b, "    %s [label=%s, tooltip=%s];\n", ids[node.OCID], dotQuote(node.ResourceType+"\n"+node.Name), dotQuote(node.OCID))
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, edge := range graph.Edges {
+		from, fromOK := ids[edge.From]
+		to, toOK := ids[edge.To]
+		if !fromOK || !toOK {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", from, to, dotQuote(edge.Label))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeGraphMermaid renders the graph as a Mermaid flowchart, with one subgraph per compartment
+func writeGraphMermaid(graph *ResourceGraph, w io.Writer) error {
+	var b strings.Builder
+	ids := graph.nodeIDs()
+
+	b.WriteString("flowchart LR\n")
+
+	order, groups := graph.compartmentGroups()
+	for i, compartmentID := range order {
+		nodes := groups[compartmentID]
+		fmt.Fprintf(&b, "  subgraph c%d[%s]\n", i, mermaidQuote(graph.Nodes[nodes[0]].compartmentLabel()))
+		for _, index := range nodes {
+			node := graph.Nodes[index]
+			fmt.Fprintf(&b, "    %s[%s]\n", ids[node.OCID], mermaidQuote(node.ResourceType+"\n"+node.Name))
+		}
+		b.WriteString("  end\n")
+	}
+
+	for _, edge := range graph.Edges {
+		from, fromOK := ids[edge.From]
+		to, toOK := ids[edge.To]
+		if !fromOK || !toOK {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", from, mermaidQuote(edge.Label), to)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote returns s as a DOT string literal
+func dotQuote(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// mermaidQuote returns s as a quoted Mermaid label
+// Quotes are written as entity codes and newlines as line breaks
+func mermaidQuote(s string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "\n", "<br/>")
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// OutputGraph writes the dependency graph of the resources to stdout or to a file
+func OutputGraph(resources []ResourceInfo, format, filename string, connectedOnly bool) error {
+	graph := BuildResourceGraph(resources, connectedOnly)
+
+	if IsStdoutPath(filename) {
+		return WriteGraph(graph, format, os.Stdout)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := WriteGraph(graph, format, file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return CloseOutputFile(file)
+}
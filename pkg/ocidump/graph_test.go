@@ -0,0 +1,97 @@
+package ocidump
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func graphTestResources() []ResourceInfo {
+	return []ResourceInfo{
+		{ResourceType: "VCN", ResourceName: "main", OCID: "ocid1.vcn.oc1..vcn", CompartmentID: "ocid1.compartment.oc1..net", CompartmentName: "network",
+			AdditionalInfo: map[string]interface{}{"cidr_block": "10.0.0.0/16"}},
+		{ResourceType: "Subnet", ResourceName: "app", OCID: "ocid1.subnet.oc1..app", CompartmentID: "ocid1.compartment.oc1..net", CompartmentName: "network",
+			AdditionalInfo: map[string]interface{}{"vcn_id": "ocid1.vcn.oc1..vcn"}},
+		{ResourceType: "ComputeInstance", ResourceName: "web \"1\"", OCID: "ocid1.instance.oc1..web", CompartmentID: "ocid1.compartment.oc1..app", CompartmentName: "app",
+			AdditionalInfo: map[string]interface{}{"subnet_id": "ocid1.subnet.oc1..app", "attached_volumes": []string{"ocid1.volume.oc1..data"}}},
+		{ResourceType: "BlockVolume", ResourceName: "data", OCID: "ocid1.volume.oc1..data", CompartmentID: "ocid1.compartment.oc1..app", CompartmentName: "app",
+			AdditionalInfo: map[string]interface{}{"attached_instance": "ocid1.instance.oc1..web"}},
+		// Lists loaded from a dump file are []interface{}; subnets outside the dump are dropped
+		{ResourceType: "LoadBalancer", ResourceName: "lb", OCID: "ocid1.loadbalancer.oc1..lb", CompartmentID: "ocid1.compartment.oc1..app", CompartmentName: "app",
+			AdditionalInfo: map[string]interface{}{"subnet_ids": []interface{}{"ocid1.subnet.oc1..app", "ocid1.subnet.oc1..other"}}},
+		{ResourceType: "User", ResourceName: "alice", OCID: "ocid1.user.oc1..alice", CompartmentID: "ocid1.tenancy.oc1..root",
+			AdditionalInfo: map[string]interface{}{}},
+	}
+}
+
+func TestBuildResourceGraph(t *testing.T) {
+	graph := BuildResourceGraph(graphTestResources(), false)
+
+	if len(graph.Nodes) != 6 {
+		t.Errorf("expected 6 nodes, got %d", len(graph.Nodes))
+	}
+
+	expected := []GraphEdge{
+		{From: "ocid1.subnet.oc1..app", To: "ocid1.vcn.oc1..vcn", Label: "vcn"},
+		{From: "ocid1.instance.oc1..web", To: "ocid1.volume.oc1..data", Label: "attached_volumes"},
+		{From: "ocid1.instance.oc1..web", To: "ocid1.subnet.oc1..app", Label: "subnet"},
+		{From: "ocid1.loadbalancer.oc1..lb", To: "ocid1.subnet.oc1..app", Label: "subnet"},
+	}
+	if len(graph.Edges) != len(expected) {
+		t.Fatalf("expected %d edges, got %+v", len(expected), graph.Edges)
+	}
+	for i, edge := range expected {
+		if graph.Edges[i] != edge {
+			t.Errorf("edge %d = %+v, expected %+v", i, graph.Edges[i], edge)
+		}
+	}
+
+	connected := BuildResourceGraph(graphTestResources(), true)
+	if len(connected.Nodes) != 5 {
+		t.Errorf("connectedOnly: expected 5 nodes, got %d", len(connected.Nodes))
+	}
+	for _, node := range connected.Nodes {
+		if node.ResourceType == "User" {
+			t.Errorf("connectedOnly: unconnected node %s was included", node.OCID)
+		}
+	}
+}
+
+func TestWriteGraph(t *testing.T) {
+	graph := BuildResourceGraph(graphTestResources(), true)
+
+	var dot bytes.Buffer
+	if err := WriteGraph(graph, "dot", &dot); err != nil {
+		t.Fatalf("WriteGraph(dot) error = %v", err)
+	}
+	for _, want := range []string{
+		"digraph resources {",
+		`subgraph cluster_0 {`,
+		`label="network";`,
+		`n2 [label="ComputeInstance\nweb \"1\"", tooltip="ocid1.instance.oc1..web"];`,
+		`n1 -> n0 [label="vcn"];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT output missing %q:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid bytes.Buffer
+	if err := WriteGraph(graph, "mermaid", &mermaid); err != nil {
+		t.Fatalf("WriteGraph(mermaid) error = %v", err)
+	}
+	for _, want := range []string{
+		"flowchart LR",
+		`subgraph c1["app"]`,
+		`n2["ComputeInstance<br/>web #quot;1#quot;"]`,
+		`n2 -->|"subnet"| n1`,
+	} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, mermaid.String())
+		}
+	}
+
+	if err := WriteGraph(graph, "png", &bytes.Buffer{}); err == nil {
+		t.Errorf("WriteGraph(png) expected error")
+	}
+}
@@ -0,0 +1,397 @@
+package ocidump
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ocidumpv1 "oci-resource-dump/proto/ocidump/v1"
+)
+
+// GRPCDiscoverFunc runs a discovery with the options of a gRPC request; used by the gRPC server
+type GRPCDiscoverFunc func(ctx context.Context, opts Options) ([]ResourceInfo, error)
+
+// GRPCServer implements the ResourceDumpService of proto/ocidump/v1
+//
+//	Discover         runs a discovery and returns all resources
+//	StreamResources  runs a discovery and sends each batch handed to Options.Sink as it is found
+//	Diff             compares two resource lists with DiffResources
+//
+// Discoveries use the base options with the filters and detail mode of the request; a request
+// without filters uses those of the base options. Requests may shorten the discovery timeout of the
+// server but not extend it, and must list OCIDs inline: the server never opens an ocid_file chosen
+// by a client. Every RPC requires "authorization: Bearer <token>" metadata, the same token as the
+// REST API.
+type GRPCServer struct {
+	ocidumpv1.UnimplementedResourceDumpServiceServer
+
+	discover GRPCDiscoverFunc
+	base     Options
+	timeout  time.Duration // default and maximum discovery timeout of a request
+	token    string
+
+	dumpMu sync.Mutex // serializes discovery runs
+}
+
+// NewGRPCServer creates a gRPC service that runs discover with base and accepts requests carrying token
+// timeout is the default and maximum discovery time of a request. An empty token rejects every request.
+func NewGRPCServer(discover GRPCDiscoverFunc, base Options, timeout time.Duration, token string) *GRPCServer {
+	return &GRPCServer{discover: discover, base: base, timeout: timeout, token: token}
+}
+
+// Server returns a gRPC server with the service registered behind the bearer token check
+func (s *GRPCServer) Server() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := s.authorize(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authorize(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	)
+	ocidumpv1.RegisterResourceDumpServiceServer(server, s)
+	return server
+}
+
+// authorize checks the bearer token in the metadata of a request
+func (s *GRPCServer) authorize(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && s.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+}
+
+// Discover implements ResourceDumpService.Discover
+func (s *GRPCServer) Discover(ctx context.Context, req *ocidumpv1.DiscoverRequest) (*ocidumpv1.DiscoverResponse, error) {
+	resources, err := s.run(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ocidumpv1.DiscoverResponse{DiscoveredAt: timestamppb.Now()}
+	for _, resource := range resources {
+		message, err := resourceToProto(resource)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		response.Resources = append(response.Resources, message)
+	}
+	return response, nil
+}
+
+// StreamResources implements ResourceDumpService.StreamResources
+func (s *GRPCServer) StreamResources(req *ocidumpv1.DiscoverRequest, stream grpc.ServerStreamingServer[ocidumpv1.Resource]) error {
+	// Discovery calls the sink one batch at a time, so sends never overlap
+	_, err := s.run(stream.Context(), req, func(resources []ResourceInfo) error {
+		for _, resource := range resources {
+			message, err := resourceToProto(resource)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// Diff implements ResourceDumpService.Diff
+func (s *GRPCServer) Diff(ctx context.Context, req *ocidumpv1.DiffRequest) (*ocidumpv1.DiffResponse, error) {
+	var resources [2][]ResourceInfo
+	for i, messages := range [][]*ocidumpv1.Resource{req.GetOldResources(), req.GetNewResources()} {
+		resources[i] = make([]ResourceInfo, 0, len(messages))
+		for _, message := range messages {
+			resources[i] = append(resources[i], resourceFromProto(message))
+		}
+	}
+
+	result := DiffResources(resources[0], resources[1], "old_resources", "new_resources", DiffConfig{Detailed: req.GetDetailed()})
+	response, err := diffResultToProto(result)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return response, nil
+}
+
+// run runs a discovery with the filters, detail mode and timeout of a request
+// With a sink, the resources are streamed to it instead of being returned.
+func (s *GRPCServer) run(ctx context.Context, req *ocidumpv1.DiscoverRequest, sink ResourceSink) ([]ResourceInfo, error) {
+	opts := s.base
+	if req.GetFilters() != nil {
+		if req.GetFilters().GetOcidFile() != "" {
+			return nil, status.Error(codes.InvalidArgument, "ocid_file is not accepted in requests, list the OCIDs in ocids")
+		}
+		opts.Filters = filtersFromProto(req.GetFilters())
+	}
+	if req.GetDetail() {
+		opts.Detail = true
+	}
+	opts.Sink = sink
+	if err := ValidateFilterConfig(opts.Filters); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter configuration: %v", err)
+	}
+
+	timeout := s.timeout
+	if seconds := req.GetTimeoutSeconds(); seconds != 0 {
+		requested := time.Duration(seconds) * time.Second
+		if seconds < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "timeout_seconds must not be negative, got: %d", seconds)
+		}
+		if s.timeout > 0 && requested > s.timeout {
+			return nil, status.Errorf(codes.InvalidArgument, "timeout_seconds must not exceed the server timeout of %d, got: %d", int(s.timeout.Seconds()), seconds)
+		}
+		timeout = requested
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	s.dumpMu.Lock()
+	defer s.dumpMu.Unlock()
+
+	logger.Info("Starting resource discovery for gRPC request")
+	resources, err := s.discover(ctx, opts)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return nil, status.FromContextError(err).Err()
+		}
+		return nil, status.Errorf(codes.Unavailable, "discovery failed: %v", err)
+	}
+	logger.Info("Discovery complete: %d resources", len(resources))
+	return resources, nil
+}
+
+// filtersFromProto converts the filters of a request to a FilterConfig
+func filtersFromProto(filters *ocidumpv1.Filters) FilterConfig {
+	return FilterConfig{
+		IncludeCompartments:   filters.GetIncludeCompartments(),
+		ExcludeCompartments:   filters.GetExcludeCompartments(),
+		CompartmentSubtrees:   filters.GetCompartmentSubtrees(),
+		IncludeResourceTypes:  filters.GetIncludeResourceTypes(),
+		ExcludeResourceTypes:  filters.GetExcludeResourceTypes(),
+		NamePattern:           filters.GetNamePattern(),
+		ExcludeNamePattern:    filters.GetExcludeNamePattern(),
+		NameGlob:              filters.GetNameGlob(),
+		ExcludeNameGlob:       filters.GetExcludeNameGlob(),
+		IgnoreCase:            filters.GetIgnoreCase(),
+		LifecycleStates:       filters.GetLifecycleStates(),
+		CreatedAfter:          filters.GetCreatedAfter(),
+		CreatedBefore:         filters.GetCreatedBefore(),
+		Shapes:                filters.GetShapes(),
+		Expression:            filters.GetExpression(),
+		MinSizeGB:             filters.GetMinSizeGb(),
+		MaxSizeGB:             filters.GetMaxSizeGb(),
+		Where:                 filters.GetWhere(),
+		OCIDs:                 filters.GetOcids(),
+		SkipEmptyCompartments: filters.GetSkipEmptyCompartments(),
+	}
+}
+
+// resourceToProto converts a resource to its message
+// Additional info and defined tags go through JSON, as in the dump format, so lists of any
+// element type become list values.
+func resourceToProto(resource ResourceInfo) (*ocidumpv1.Resource, error) {
+	additionalInfo, err := jsonStruct(resource.AdditionalInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert additional info of %s: %w", resource.OCID, err)
+	}
+
+	message := &ocidumpv1.Resource{
+		ResourceType:    resource.ResourceType,
+		CompartmentName: resource.CompartmentName,
+		ResourceName:    resource.ResourceName,
+		Ocid:            resource.OCID,
+		CompartmentId:   resource.CompartmentID,
+		AdditionalInfo:  additionalInfo,
+		TimeCreated:     resource.TimeCreated,
+		LifecycleState:  resource.LifecycleState,
+		FreeformTags:    resource.FreeformTags,
+	}
+	if len(resource.DefinedTags) > 0 {
+		message.DefinedTags = make(map[string]*structpb.Struct, len(resource.DefinedTags))
+		for namespace, tags := range resource.DefinedTags {
+			if message.DefinedTags[namespace], err = jsonStruct(tags); err != nil {
+				return nil, fmt.Errorf("failed to convert defined tags of %s: %w", resource.OCID, err)
+			}
+		}
+	}
+	return message, nil
+}
+
+// resourceFromProto converts a message to a resource, with values as decoded from a JSON dump
+func resourceFromProto(message *ocidumpv1.Resource) ResourceInfo {
+	resource := ResourceInfo{
+		ResourceType:    message.GetResourceType(),
+		CompartmentName: message.GetCompartmentName(),
+		ResourceName:    message.GetResourceName(),
+		OCID:            message.GetOcid(),
+		CompartmentID:   message.GetCompartmentId(),
+		AdditionalInfo:  message.GetAdditionalInfo().AsMap(),
+		TimeCreated:     message.GetTimeCreated(),
+		LifecycleState:  message.GetLifecycleState(),
+		FreeformTags:    message.GetFreeformTags(),
+	}
+	if len(message.GetDefinedTags()) > 0 {
+		resource.DefinedTags = make(map[string]map[string]interface{}, len(message.GetDefinedTags()))
+		for namespace, tags := range message.GetDefinedTags() {
+			resource.DefinedTags[namespace] = tags.AsMap()
+		}
+	}
+	return resource
+}
+
+// diffResultToProto converts a diff result to its message
+func diffResultToProto(result *DiffResult) (*ocidumpv1.DiffResponse, error) {
+	summary := result.Summary
+	response := &ocidumpv1.DiffResponse{
+		Summary: &ocidumpv1.DiffSummary{
+			TotalOld:       int32(summary.TotalOld),
+			TotalNew:       int32(summary.TotalNew),
+			Added:          int32(summary.Added),
+			Removed:        int32(summary.Removed),
+			Modified:       int32(summary.Modified),
+			Unchanged:      int32(summary.Unchanged),
+			Unverified:     int32(summary.Unverified),
+			Moved:          int32(summary.Moved),
+			ByResourceType: diffStatsToProto(summary.ByResourceType),
+			ByOriginator:   diffStatsToProto(summary.ByOriginator),
+		},
+	}
+
+	var err error
+	lists := []struct {
+		resources []ResourceInfo
+		messages  *[]*ocidumpv1.Resource
+	}{
+		{result.Added, &response.Added},
+		{result.Removed, &response.Removed},
+		{result.Unchanged, &response.Unchanged},
+		{result.Unverified, &response.Unverified},
+	}
+	for _, list := range lists {
+		for _, resource := range list.resources {
+			message, err := resourceToProto(resource)
+			if err != nil {
+				return nil, err
+			}
+			*list.messages = append(*list.messages, message)
+		}
+	}
+
+	for _, modified := range result.Modified {
+		message := &ocidumpv1.ModifiedResource{}
+		if message.Resource, err = resourceToProto(modified.ResourceInfo); err != nil {
+			return nil, err
+		}
+		if message.Changes, err = fieldChangesToProto(modified.Changes); err != nil {
+			return nil, err
+		}
+		response.Modified = append(response.Modified, message)
+	}
+	for _, moved := range result.Moved {
+		message := &ocidumpv1.MovedResource{
+			FromCompartmentId:   moved.FromCompartmentID,
+			FromCompartmentName: moved.FromCompartmentName,
+			ToCompartmentId:     moved.ToCompartmentID,
+			ToCompartmentName:   moved.ToCompartmentName,
+		}
+		if message.Resource, err = resourceToProto(moved.ResourceInfo); err != nil {
+			return nil, err
+		}
+		if message.Changes, err = fieldChangesToProto(moved.Changes); err != nil {
+			return nil, err
+		}
+		response.Moved = append(response.Moved, message)
+	}
+	return response, nil
+}
+
+// diffStatsToProto converts per-key diff counts to their messages
+func diffStatsToProto(stats map[string]DiffStats) map[string]*ocidumpv1.DiffStats {
+	if len(stats) == 0 {
+		return nil
+	}
+	messages := make(map[string]*ocidumpv1.DiffStats, len(stats))
+	for key, s := range stats {
+		messages[key] = &ocidumpv1.DiffStats{
+			Added:     int32(s.Added),
+			Removed:   int32(s.Removed),
+			Modified:  int32(s.Modified),
+			Unchanged: int32(s.Unchanged),
+			Moved:     int32(s.Moved),
+		}
+	}
+	return messages
+}
+
+// fieldChangesToProto converts field changes to their messages
+func fieldChangesToProto(changes []FieldChange) ([]*ocidumpv1.FieldChange, error) {
+	messages := make([]*ocidumpv1.FieldChange, 0, len(changes))
+	for _, change := range changes {
+		oldValue, err := jsonValue(change.OldValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert old value of %s: %w", change.Field, err)
+		}
+		newValue, err := jsonValue(change.NewValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert new value of %s: %w", change.Field, err)
+		}
+		messages = append(messages, &ocidumpv1.FieldChange{Field: change.Field, OldValue: oldValue, NewValue: newValue})
+	}
+	return messages, nil
+}
+
+// jsonStruct converts a map to a Struct through its JSON encoding
+func jsonStruct[V any](m map[string]V) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	message := &structpb.Struct{}
+	if err := message.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// jsonValue converts a value to a Value through its JSON encoding
+func jsonValue(value interface{}) (*structpb.Value, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	message := &structpb.Value{}
+	if err := message.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
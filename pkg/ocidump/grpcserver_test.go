@@ -0,0 +1,256 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	ocidumpv1 "oci-resource-dump/proto/ocidump/v1"
+)
+
+// newTestGRPCClient serves the gRPC service over an in-memory connection and returns a client
+func newTestGRPCClient(t *testing.T, discover GRPCDiscoverFunc, base Options) ocidumpv1.ResourceDumpServiceClient {
+	t.Helper()
+	logger = NewLogger(LogLevelSilent)
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewGRPCServer(discover, base, time.Minute, testServerToken).Server()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return ocidumpv1.NewResourceDumpServiceClient(conn)
+}
+
+// withTestToken returns a context carrying the bearer token of the test server
+func withTestToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func grpcTestResources() []ResourceInfo {
+	return []ResourceInfo{
+		{ResourceType: "VCN", ResourceName: "main", OCID: "ocid1.vcn.oc1..a", CompartmentID: "ocid1.compartment.oc1..net", CompartmentName: "network",
+			AdditionalInfo: map[string]interface{}{"cidr_blocks": []string{"10.0.0.0/16"}, "dns_label": "main"},
+			DefinedTags:    map[string]map[string]interface{}{"Operations": {"CostCenter": "42"}}},
+		{ResourceType: "Subnet", ResourceName: "app", OCID: "ocid1.subnet.oc1..b", CompartmentID: "ocid1.compartment.oc1..net", CompartmentName: "network",
+			AdditionalInfo: map[string]interface{}{"vcn_id": "ocid1.vcn.oc1..a"}, FreeformTags: map[string]string{"env": "prod"}},
+	}
+}
+
+func TestGRPCServer_Authorization(t *testing.T) {
+	calls := 0
+	client := newTestGRPCClient(t, func(ctx context.Context, opts Options) ([]ResourceInfo, error) {
+		calls++
+		return nil, nil
+	}, Options{})
+
+	for _, ctx := range []context.Context{context.Background(), withTestToken("wrong")} {
+		if _, err := client.Discover(ctx, &ocidumpv1.DiscoverRequest{}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Discover() error = %v, want Unauthenticated", err)
+		}
+		stream, err := client.StreamResources(ctx, &ocidumpv1.DiscoverRequest{})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("StreamResources() error = %v, want Unauthenticated", err)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("unauthorized requests ran %d discoveries", calls)
+	}
+}
+
+func TestGRPCServer_Discover(t *testing.T) {
+	var got Options
+	client := newTestGRPCClient(t, func(ctx context.Context, opts Options) ([]ResourceInfo, error) {
+		got = opts
+		if _, ok := ctx.Deadline(); !ok {
+			t.Errorf("discovery context has no deadline")
+		}
+		return grpcTestResources(), nil
+	}, Options{Filters: FilterConfig{IncludeResourceTypes: []string{"policies"}}, PageSize: 50})
+	ctx := withTestToken(testServerToken)
+
+	// Without filters, the filters of the configuration apply
+	response, err := client.Discover(ctx, &ocidumpv1.DiscoverRequest{})
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(got.Filters.IncludeResourceTypes) != 1 || got.PageSize != 50 || got.Detail {
+		t.Errorf("Discover() without filters ran with %+v, want the base options", got)
+	}
+	if len(response.GetResources()) != 2 || response.GetDiscoveredAt() == nil {
+		t.Fatalf("Discover() = %v, want 2 resources", response)
+	}
+	vcn := response.GetResources()[0]
+	if vcn.GetOcid() != "ocid1.vcn.oc1..a" || vcn.GetAdditionalInfo().AsMap()["dns_label"] != "main" ||
+		vcn.GetDefinedTags()["Operations"].AsMap()["CostCenter"] != "42" {
+		t.Errorf("Discover() resource = %v", vcn)
+	}
+	if cidrs, ok := vcn.GetAdditionalInfo().AsMap()["cidr_blocks"].([]interface{}); !ok || len(cidrs) != 1 || cidrs[0] != "10.0.0.0/16" {
+		t.Errorf("Discover() cidr_blocks = %v, want a list value", vcn.GetAdditionalInfo().AsMap()["cidr_blocks"])
+	}
+
+	// The filters and detail mode of a request replace those of the configuration
+	_, err = client.Discover(ctx, &ocidumpv1.DiscoverRequest{
+		Filters: &ocidumpv1.Filters{IncludeResourceTypes: []string{"vcns"}, NameGlob: "prod-*", Where: map[string]string{"dns_label": "main"}},
+		Detail:  true,
+	})
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if got.Filters.IncludeResourceTypes[0] != "vcns" || got.Filters.NameGlob != "prod-*" || got.Filters.Where["dns_label"] != "main" || !got.Detail {
+		t.Errorf("Discover() with filters ran with %+v", got)
+	}
+
+	_, err = client.Discover(ctx, &ocidumpv1.DiscoverRequest{Filters: &ocidumpv1.Filters{NamePattern: "("}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Discover() with an invalid pattern error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGRPCServer_RequestLimits(t *testing.T) {
+	var got Options
+	var deadline time.Time
+	client := newTestGRPCClient(t, func(ctx context.Context, opts Options) ([]ResourceInfo, error) {
+		got = opts
+		deadline, _ = ctx.Deadline()
+		return nil, nil
+	}, Options{})
+	ctx := withTestToken(testServerToken)
+
+	// The server never reads a file named by a client; OCIDs are listed inline
+	got = Options{}
+	_, err := client.Discover(ctx, &ocidumpv1.DiscoverRequest{Filters: &ocidumpv1.Filters{OcidFile: "/etc/passwd"}})
+	if status.Code(err) != codes.InvalidArgument || got.Filters.OCIDFile != "" {
+		t.Errorf("Discover() with an ocid_file error = %v, want InvalidArgument without a discovery", err)
+	}
+	_, err = client.Discover(ctx, &ocidumpv1.DiscoverRequest{Filters: &ocidumpv1.Filters{Ocids: []string{"ocid1.instance.oc1..web"}}})
+	if err != nil || len(got.Filters.OCIDs) != 1 || got.Filters.OCIDs[0] != "ocid1.instance.oc1..web" {
+		t.Errorf("Discover() with ocids error = %v, filters = %+v", err, got.Filters)
+	}
+	_, err = client.Discover(ctx, &ocidumpv1.DiscoverRequest{Filters: &ocidumpv1.Filters{Ocids: []string{"web-server"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Discover() with an invalid OCID error = %v, want InvalidArgument", err)
+	}
+
+	// Requests may shorten the server timeout of a minute but not extend it
+	if _, err := client.Discover(ctx, &ocidumpv1.DiscoverRequest{TimeoutSeconds: 10}); err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if remaining := time.Until(deadline); remaining > 10*time.Second {
+		t.Errorf("Discover() ran with %v left, want at most the requested 10s", remaining)
+	}
+	for _, seconds := range []int32{-1, 61, 86400} {
+		_, err := client.Discover(ctx, &ocidumpv1.DiscoverRequest{TimeoutSeconds: seconds})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Discover() with timeout_seconds %d error = %v, want InvalidArgument", seconds, err)
+		}
+	}
+}
+
+func TestGRPCServer_DiscoverError(t *testing.T) {
+	client := newTestGRPCClient(t, func(ctx context.Context, opts Options) ([]ResourceInfo, error) {
+		return nil, errors.New("authentication failed")
+	}, Options{})
+
+	_, err := client.Discover(withTestToken(testServerToken), &ocidumpv1.DiscoverRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Discover() error = %v, want Unavailable", err)
+	}
+}
+
+func TestGRPCServer_StreamResources(t *testing.T) {
+	client := newTestGRPCClient(t, func(ctx context.Context, opts Options) ([]ResourceInfo, error) {
+		if opts.Sink == nil {
+			return nil, errors.New("StreamResources() ran a discovery without a sink")
+		}
+		for _, resource := range grpcTestResources() {
+			if err := opts.Sink([]ResourceInfo{resource}); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}, Options{})
+
+	stream, err := client.StreamResources(withTestToken(testServerToken), &ocidumpv1.DiscoverRequest{})
+	if err != nil {
+		t.Fatalf("StreamResources() error = %v", err)
+	}
+	var ocids []string
+	for {
+		resource, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		ocids = append(ocids, resource.GetOcid())
+	}
+	if len(ocids) != 2 || ocids[0] != "ocid1.vcn.oc1..a" || ocids[1] != "ocid1.subnet.oc1..b" {
+		t.Errorf("StreamResources() sent %v, want the resources in discovery order", ocids)
+	}
+}
+
+func TestGRPCServer_Diff(t *testing.T) {
+	client := newTestGRPCClient(t, nil, Options{})
+
+	var oldResources, newResources []*ocidumpv1.Resource
+	for _, resource := range grpcTestResources() {
+		message, err := resourceToProto(resource)
+		if err != nil {
+			t.Fatalf("resourceToProto() error = %v", err)
+		}
+		oldResources = append(oldResources, message)
+	}
+	changed := grpcTestResources()
+	changed[0].AdditionalInfo["dns_label"] = "renamed"
+	for _, resource := range append(changed[:1], ResourceInfo{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..c", AdditionalInfo: map[string]interface{}{}}) {
+		message, err := resourceToProto(resource)
+		if err != nil {
+			t.Fatalf("resourceToProto() error = %v", err)
+		}
+		newResources = append(newResources, message)
+	}
+
+	response, err := client.Diff(withTestToken(testServerToken), &ocidumpv1.DiffRequest{OldResources: oldResources, NewResources: newResources})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	summary := response.GetSummary()
+	if summary.GetAdded() != 1 || summary.GetRemoved() != 1 || summary.GetModified() != 1 || summary.GetByResourceType()["VCN"].GetAdded() != 1 {
+		t.Errorf("Diff() summary = %v", summary)
+	}
+	if len(response.GetAdded()) != 1 || response.GetAdded()[0].GetOcid() != "ocid1.vcn.oc1..c" {
+		t.Errorf("Diff() added = %v", response.GetAdded())
+	}
+	if len(response.GetRemoved()) != 1 || response.GetRemoved()[0].GetOcid() != "ocid1.subnet.oc1..b" {
+		t.Errorf("Diff() removed = %v", response.GetRemoved())
+	}
+	if len(response.GetModified()) != 1 {
+		t.Fatalf("Diff() modified = %v, want the VCN", response.GetModified())
+	}
+	change := response.GetModified()[0].GetChanges()
+	if len(change) != 1 || change[0].GetField() != "AdditionalInfo.dns_label" ||
+		change[0].GetOldValue().GetStringValue() != "main" || change[0].GetNewValue().GetStringValue() != "renamed" {
+		t.Errorf("Diff() changes = %v", change)
+	}
+}
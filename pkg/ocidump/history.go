@@ -0,0 +1,261 @@
+package ocidump
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryConfig holds the settings of the local history store
+type HistoryConfig struct {
+	Enabled  bool   `yaml:"enabled"`   // Record every run in the history store
+	Path     string `yaml:"path"`      // Database file (default: ~/.oci-resource-dump/history.db)
+	KeepLast int    `yaml:"keep_last"` // Keep at most this many snapshots (0 = unlimited)
+}
+
+// HistoryEntry describes a snapshot recorded in the history store
+type HistoryEntry struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	ResourceCount int       `json:"resource_count"`
+}
+
+// Label returns a human-readable reference to the snapshot, used in diff results
+func (e HistoryEntry) Label() string {
+	if e.Name != "" {
+		return fmt.Sprintf("history:%d (%s)", e.ID, e.Name)
+	}
+	return fmt.Sprintf("history:%d", e.ID)
+}
+
+// HistoryStore keeps dumps of past runs in a SQLite database
+// Each snapshot is a row of the snapshots table holding the regular JSON dump of the run.
+// Snapshots are recorded in a write transaction, so concurrent runs get distinct IDs.
+type HistoryStore struct {
+	path     string
+	keepLast int
+	db       *sql.DB
+}
+
+// historyBusyTimeout is how long a run waits for another run recording a snapshot
+const historyBusyTimeout = 30 * time.Second
+
+// historySchema creates the snapshots table
+// AUTOINCREMENT keeps the IDs of pruned snapshots from being reused.
+const historySchema = `CREATE TABLE IF NOT EXISTS snapshots (
+	id             INTEGER PRIMARY KEY AUTOINCREMENT,
+	name           TEXT NOT NULL DEFAULT '',
+	created_at     TEXT NOT NULL,
+	resource_count INTEGER NOT NULL,
+	resources      BLOB NOT NULL
+)`
+
+// Validate checks the history settings
+func (c HistoryConfig) Validate() error {
+	if c.KeepLast < 0 {
+		return fmt.Errorf("history keep_last must not be negative, got: %d", c.KeepLast)
+	}
+	return nil
+}
+
+// DefaultHistoryPath returns ~/.oci-resource-dump/history.db
+func DefaultHistoryPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory for history store: %w", err)
+	}
+	return filepath.Join(homeDir, ".oci-resource-dump", "history.db"), nil
+}
+
+// OpenHistoryStore opens the history store configured by config, creating its database if needed
+func OpenHistoryStore(config HistoryConfig) (*HistoryStore, error) {
+	path := config.Path
+	if path == "" {
+		var err error
+		if path, err = DefaultHistoryPath(); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create history store: %w", err)
+	}
+
+	// Immediate transactions take the write lock up front; a locked database is retried until the busy timeout
+	dsn := (&url.URL{
+		Scheme:   "file",
+		OmitHost: true,
+		Path:     path,
+		RawQuery: fmt.Sprintf("_pragma=busy_timeout(%d)&_txlock=immediate", historyBusyTimeout.Milliseconds()),
+	}).String()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history store: %w", err)
+	}
+	if _, err := db.Exec(historySchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open history store %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open history store: %w", err)
+	}
+
+	return &HistoryStore{path: path, keepLast: config.KeepLast, db: db}, nil
+}
+
+// Path returns the database file of the store
+func (s *HistoryStore) Path() string {
+	return s.path
+}
+
+// Close closes the database of the store
+func (s *HistoryStore) Close() error {
+	return s.db.Close()
+}
+
+// Record stores the resources as a new snapshot and prunes snapshots beyond keep_last
+func (s *HistoryStore) Record(resources []ResourceInfo, name string, createdAt time.Time) (HistoryEntry, error) {
+	if resources == nil {
+		resources = []ResourceInfo{}
+	}
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return HistoryEntry{}, fmt.Errorf("failed to encode history snapshot: %w", err)
+	}
+
+	entry := HistoryEntry{
+		Name:          name,
+		CreatedAt:     createdAt.UTC(),
+		ResourceCount: len(resources),
+	}
+
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HistoryEntry{}, fmt.Errorf("failed to record history snapshot: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO snapshots (name, created_at, resource_count, resources) VALUES (?, ?, ?, ?)",
+		entry.Name, entry.CreatedAt.Format(time.RFC3339Nano), entry.ResourceCount, data)
+	if err != nil {
+		return HistoryEntry{}, fmt.Errorf("failed to record history snapshot: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return HistoryEntry{}, fmt.Errorf("failed to record history snapshot: %w", err)
+	}
+	entry.ID = int(id)
+
+	if s.keepLast > 0 {
+		pruned, err := tx.ExecContext(ctx, "DELETE FROM snapshots WHERE id NOT IN (SELECT id FROM snapshots ORDER BY id DESC LIMIT ?)", s.keepLast)
+		if err != nil {
+			return HistoryEntry{}, fmt.Errorf("failed to prune history snapshots: %w", err)
+		}
+		if count, err := pruned.RowsAffected(); err == nil && count > 0 {
+			logger.Verbose("Pruned %d history snapshots beyond keep_last", count)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return HistoryEntry{}, fmt.Errorf("failed to record history snapshot: %w", err)
+	}
+
+	logger.Verbose("Recorded history snapshot %d (%d resources)", entry.ID, entry.ResourceCount)
+	return entry, nil
+}
+
+// List returns the recorded snapshots, oldest first
+func (s *HistoryStore) List() ([]HistoryEntry, error) {
+	rows, err := s.db.Query("SELECT id, name, created_at, resource_count FROM snapshots ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history store: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []HistoryEntry
+	for rows.Next() {
+		var entry HistoryEntry
+		var createdAt string
+		if err := rows.Scan(&entry.ID, &entry.Name, &createdAt, &entry.ResourceCount); err != nil {
+			return nil, fmt.Errorf("failed to read history store: %w", err)
+		}
+		if entry.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return nil, fmt.Errorf("invalid creation time of history snapshot %d: %w", entry.ID, err)
+		}
+		snapshots = append(snapshots, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history store: %w", err)
+	}
+	return snapshots, nil
+}
+
+// Resolve finds a snapshot by reference: a numeric ID, "latest", "latest~N" (N runs before
+// the latest) or a snapshot name (the newest snapshot with that name)
+func (s *HistoryStore) Resolve(ref string) (HistoryEntry, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	if len(snapshots) == 0 {
+		return HistoryEntry{}, fmt.Errorf("history store %s is empty", s.path)
+	}
+
+	if ref == "latest" || strings.HasPrefix(ref, "latest~") {
+		offset := 0
+		if ref != "latest" {
+			offset, err = strconv.Atoi(strings.TrimPrefix(ref, "latest~"))
+			if err != nil || offset < 0 {
+				return HistoryEntry{}, fmt.Errorf("invalid snapshot reference '%s'", ref)
+			}
+		}
+		if offset >= len(snapshots) {
+			return HistoryEntry{}, fmt.Errorf("snapshot '%s' not found: only %d snapshots recorded", ref, len(snapshots))
+		}
+		return snapshots[len(snapshots)-1-offset], nil
+	}
+
+	if id, err := strconv.Atoi(ref); err == nil {
+		for _, entry := range snapshots {
+			if entry.ID == id {
+				return entry, nil
+			}
+		}
+		return HistoryEntry{}, fmt.Errorf("snapshot %d not found", id)
+	}
+
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].Name == ref {
+			return snapshots[i], nil
+		}
+	}
+	return HistoryEntry{}, fmt.Errorf("snapshot '%s' not found", ref)
+}
+
+// Load reads the resources of a snapshot
+func (s *HistoryStore) Load(entry HistoryEntry) ([]ResourceInfo, error) {
+	var data []byte
+	err := s.db.QueryRow("SELECT resources FROM snapshots WHERE id = ?", entry.ID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("snapshot %d not found", entry.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history snapshot: %w", err)
+	}
+
+	var resources []ResourceInfo
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return nil, fmt.Errorf("invalid history snapshot %d: %w", entry.ID, err)
+	}
+	return resources, nil
+}
@@ -0,0 +1,165 @@
+package ocidump
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHistoryStore_RecordAndResolve(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	store, err := OpenHistoryStore(HistoryConfig{Path: filepath.Join(t.TempDir(), "state", "history.db")})
+	if err != nil {
+		t.Fatalf("OpenHistoryStore() error = %v", err)
+	}
+	defer store.Close()
+
+	if _, err := store.Resolve("latest"); err == nil {
+		t.Errorf("Resolve(latest) on an empty store expected error")
+	}
+
+	start := time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)
+	vcn := ResourceInfo{ResourceType: "VCN", ResourceName: "main", OCID: "ocid1.vcn.oc1..a", CompartmentID: "ocid1.compartment.oc1..a", AdditionalInfo: map[string]interface{}{}}
+	for i, name := range []string{"baseline", "", "release"} {
+		resources := make([]ResourceInfo, i)
+		for j := range resources {
+			resources[j] = vcn
+		}
+		entry, err := store.Record(resources, name, start.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+		if entry.ID != i+1 || entry.ResourceCount != i {
+			t.Errorf("Record() = %+v, expected ID %d with %d resources", entry, i+1, i)
+		}
+	}
+
+	tests := []struct {
+		ref      string
+		expected int
+	}{
+		{"latest", 3},
+		{"latest~1", 2},
+		{"latest~2", 1},
+		{"2", 2},
+		{"baseline", 1},
+		{"release", 3},
+	}
+	for _, tt := range tests {
+		entry, err := store.Resolve(tt.ref)
+		if err != nil {
+			t.Errorf("Resolve(%q) error = %v", tt.ref, err)
+			continue
+		}
+		if entry.ID != tt.expected {
+			t.Errorf("Resolve(%q) = %d, expected %d", tt.ref, entry.ID, tt.expected)
+		}
+	}
+	for _, ref := range []string{"latest~3", "latest~x", "9", "unknown"} {
+		if _, err := store.Resolve(ref); err == nil {
+			t.Errorf("Resolve(%q) expected error", ref)
+		}
+	}
+
+	entry, _ := store.Resolve("release")
+	resources, err := store.Load(entry)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(resources) != 2 || resources[0].OCID != vcn.OCID {
+		t.Errorf("Load() = %v, expected the recorded resources", resources)
+	}
+
+	// An empty run is stored as a valid empty dump
+	first, _ := store.Resolve("baseline")
+	if resources, err := store.Load(first); err != nil || len(resources) != 0 {
+		t.Errorf("Load(empty snapshot) = %v, %v", resources, err)
+	}
+	if first.Label() != "history:1 (baseline)" {
+		t.Errorf("Label() = %q", first.Label())
+	}
+}
+
+func TestHistoryStore_KeepLast(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	path := filepath.Join(t.TempDir(), "history.db")
+	store, err := OpenHistoryStore(HistoryConfig{Path: path, KeepLast: 2})
+	if err != nil {
+		t.Fatalf("OpenHistoryStore() error = %v", err)
+	}
+	defer store.Close()
+
+	for i := 0; i < 4; i++ {
+		if _, err := store.Record(nil, "", time.Now()); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	snapshots, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].ID != 3 || snapshots[1].ID != 4 {
+		t.Errorf("List() = %+v, expected snapshots 3 and 4", snapshots)
+	}
+	if _, err := store.Load(HistoryEntry{ID: 1}); err == nil {
+		t.Errorf("Load(pruned snapshot) expected error")
+	}
+
+	// IDs of pruned snapshots are not reused, also after reopening the store
+	reopened, err := OpenHistoryStore(HistoryConfig{Path: path, KeepLast: 2})
+	if err != nil {
+		t.Fatalf("OpenHistoryStore() error = %v", err)
+	}
+	defer reopened.Close()
+	if entry, err := reopened.Record(nil, "", time.Now()); err != nil || entry.ID != 5 {
+		t.Errorf("Record() after reopening = %+v, %v, expected ID 5", entry, err)
+	}
+}
+
+func TestHistoryStore_ConcurrentRecord(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	// Each run opens its own store, as separate processes would
+	const runs = 8
+	ids := make(chan int, runs)
+	errs := make(chan error, runs)
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store, err := OpenHistoryStore(HistoryConfig{Path: path})
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer store.Close()
+			entry, err := store.Record(nil, "", time.Now())
+			if err != nil {
+				errs <- err
+				return
+			}
+			ids <- entry.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent Record() error = %v", err)
+	}
+	seen := make(map[int]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Errorf("snapshot ID %d was recorded twice", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != runs {
+		t.Errorf("recorded %d distinct snapshots, expected %d", len(seen), runs)
+	}
+}
@@ -0,0 +1,262 @@
+package ocidump
+
+import (
+	"context"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/identitydomains"
+)
+
+// identityDomainPageSize is the maximum number of users or groups an identity domain returns per page
+const identityDomainPageSize = 1000
+
+// listIdentityDomains retrieves all identity domains in a compartment
+func listIdentityDomains(ctx context.Context, clients *OCIClients, compartmentID string) ([]identity.DomainSummary, error) {
+	var allDomains []identity.DomainSummary
+
+	var page *string
+	pageCount := 0
+	for {
+		pageCount++
+		logger.Debug("Fetching identity domains page %d for compartment: %s", pageCount, compartmentID)
+		req := identity.ListDomainsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         clients.pageLimit(maxPageSize),
+		}
+
+		resp, err := clients.IdentityClient.ListDomains(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		allDomains = append(allDomains, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	return allDomains, nil
+}
+
+// domainListFunc lists the users or groups of one identity domain
+type domainListFunc func(ctx context.Context, client IdentityDomainsAPI) ([]identityDomainMember, error)
+
+// identityDomainMember is a user or group read from an identity domain, before the domain is attached
+type identityDomainMember struct {
+	name           string
+	ocid           string
+	timeCreated    *common.SDKTime
+	lifecycleState string
+	additionalInfo map[string]interface{}
+	freeformTags   map[string]string
+	definedTags    map[string]map[string]interface{}
+}
+
+// discoverDomainMembers discovers the users or groups of every active identity domain in a compartment
+// A domain that cannot be read is logged and skipped, so legacy IAM discovery still covers the tenancy.
+// The returned set holds the OCIDs found, to skip the same users or groups returned by legacy IAM.
+func discoverDomainMembers(ctx context.Context, clients *OCIClients, resourceType, compartmentID string, list domainListFunc) ([]ResourceInfo, map[string]bool) {
+	if clients.IdentityDomainsClient == nil {
+		return nil, nil
+	}
+
+	domains, err := listIdentityDomains(ctx, clients, compartmentID)
+	if err != nil {
+		logger.Verbose("Could not list identity domains in compartment %s, using legacy IAM: %v", compartmentID, err)
+		return nil, nil
+	}
+
+	var resources []ResourceInfo
+	seen := make(map[string]bool)
+	for _, domain := range domains {
+		if domain.LifecycleState != identity.DomainLifecycleStateActive || domain.Url == nil {
+			continue
+		}
+		domainName := ""
+		if domain.DisplayName != nil {
+			domainName = *domain.DisplayName
+		}
+
+		client, err := clients.IdentityDomainsClient(*domain.Url)
+		if err != nil {
+			logger.Verbose("Could not create client for identity domain %s: %v", domainName, err)
+			continue
+		}
+		members, err := list(ctx, client)
+		if err != nil {
+			logger.VerboseWith(LogFields{Compartment: compartmentID, ResourceType: resourceType, ErrorCode: LogErrorCode(err)},
+				"Error listing %s in identity domain %s: %v", resourceType, domainName, err)
+			continue
+		}
+
+		for _, member := range members {
+			member.additionalInfo["identity_domain"] = domainName
+			if domain.Id != nil {
+				member.additionalInfo["identity_domain_id"] = *domain.Id
+			}
+			seen[member.ocid] = true
+			resources = append(resources, createResourceInfo(ctx, resourceType, member.name, member.ocid, compartmentID, member.additionalInfo, clients.CompartmentCache).
+				withTags(member.freeformTags, member.definedTags).
+				withLifecycle(member.timeCreated, member.lifecycleState))
+		}
+	}
+
+	return resources, seen
+}
+
+// listDomainUsers lists the users of an identity domain
+func listDomainUsers(ctx context.Context, client IdentityDomainsAPI) ([]identityDomainMember, error) {
+	var members []identityDomainMember
+
+	startIndex := 1
+	for {
+		resp, err := client.ListUsers(ctx, identitydomains.ListUsersRequest{
+			StartIndex: common.Int(startIndex),
+			Count:      common.Int(identityDomainPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range resp.Resources {
+			if user.Ocid == nil {
+				continue
+			}
+			additionalInfo := make(map[string]interface{})
+
+			// Add primary email
+			for _, email := range user.Emails {
+				if email.Value != nil && (email.Primary == nil || *email.Primary) {
+					additionalInfo["email"] = *email.Value
+					break
+				}
+			}
+
+			// Add display name when it differs from the user name
+			if user.DisplayName != nil && (user.UserName == nil || *user.DisplayName != *user.UserName) {
+				additionalInfo["display_name"] = *user.DisplayName
+			}
+
+			name := ""
+			if user.UserName != nil {
+				name = *user.UserName
+			}
+			freeformTags, definedTags := identityDomainTags(user.UrnIetfParamsScimSchemasOracleIdcsExtensionOciTags)
+			members = append(members, identityDomainMember{
+				name:           name,
+				ocid:           *user.Ocid,
+				timeCreated:    identityDomainTime(user.Meta),
+				lifecycleState: identityDomainState(user.Active),
+				additionalInfo: additionalInfo,
+				freeformTags:   freeformTags,
+				definedTags:    definedTags,
+			})
+		}
+
+		startIndex += len(resp.Resources)
+		if len(resp.Resources) == 0 || resp.TotalResults == nil || startIndex > *resp.TotalResults {
+			break
+		}
+	}
+
+	return members, nil
+}
+
+// listDomainGroups lists the groups of an identity domain
+func listDomainGroups(ctx context.Context, client IdentityDomainsAPI) ([]identityDomainMember, error) {
+	var members []identityDomainMember
+
+	startIndex := 1
+	for {
+		resp, err := client.ListGroups(ctx, identitydomains.ListGroupsRequest{
+			StartIndex: common.Int(startIndex),
+			Count:      common.Int(identityDomainPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range resp.Resources {
+			if group.Ocid == nil {
+				continue
+			}
+			additionalInfo := make(map[string]interface{})
+
+			// Add description
+			if ext := group.UrnIetfParamsScimSchemasOracleIdcsExtensionGroupGroup; ext != nil && ext.Description != nil {
+				additionalInfo["description"] = *ext.Description
+			}
+
+			name := ""
+			if group.DisplayName != nil {
+				name = *group.DisplayName
+			}
+			freeformTags, definedTags := identityDomainTags(group.UrnIetfParamsScimSchemasOracleIdcsExtensionOciTags)
+			members = append(members, identityDomainMember{
+				name:           name,
+				ocid:           *group.Ocid,
+				timeCreated:    identityDomainTime(group.Meta),
+				lifecycleState: string(identity.GroupLifecycleStateActive),
+				additionalInfo: additionalInfo,
+				freeformTags:   freeformTags,
+				definedTags:    definedTags,
+			})
+		}
+
+		startIndex += len(resp.Resources)
+		if len(resp.Resources) == 0 || resp.TotalResults == nil || startIndex > *resp.TotalResults {
+			break
+		}
+	}
+
+	return members, nil
+}
+
+// identityDomainState maps the active flag of an identity domain user to a lifecycle state
+func identityDomainState(active *bool) string {
+	if active != nil && !*active {
+		return string(identity.UserLifecycleStateInactive)
+	}
+	return string(identity.UserLifecycleStateActive)
+}
+
+// identityDomainTime parses the creation time of an identity domain resource, nil if it is unknown
+func identityDomainTime(meta *identitydomains.Meta) *common.SDKTime {
+	if meta == nil || meta.Created == nil {
+		return nil
+	}
+	created, err := time.Parse(time.RFC3339, *meta.Created)
+	if err != nil {
+		return nil
+	}
+	return &common.SDKTime{Time: created}
+}
+
+// identityDomainTags converts the tag lists of an identity domain resource to OCI tag maps
+func identityDomainTags(tags *identitydomains.ExtensionOciTags) (map[string]string, map[string]map[string]interface{}) {
+	if tags == nil {
+		return nil, nil
+	}
+	freeformTags := make(map[string]string)
+	for _, tag := range tags.FreeformTags {
+		if tag.Key != nil && tag.Value != nil {
+			freeformTags[*tag.Key] = *tag.Value
+		}
+	}
+	definedTags := make(map[string]map[string]interface{})
+	for _, tag := range tags.DefinedTags {
+		if tag.Namespace == nil || tag.Key == nil || tag.Value == nil {
+			continue
+		}
+		if definedTags[*tag.Namespace] == nil {
+			definedTags[*tag.Namespace] = make(map[string]interface{})
+		}
+		definedTags[*tag.Namespace][*tag.Key] = *tag.Value
+	}
+	return freeformTags, definedTags
+}
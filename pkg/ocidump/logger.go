@@ -0,0 +1,272 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// LogLevel represents the logging verbosity level
+type LogLevel int
+
+const (
+	LogLevelSilent  LogLevel = iota // Only errors
+	LogLevelNormal                  // Basic progress info (default)
+	LogLevelVerbose                 // Detailed operational info
+	LogLevelDebug                   // Full diagnostic info
+)
+
+// String returns the string representation of the log level
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelSilent:
+		return "silent"
+	case LogLevelNormal:
+		return "normal"
+	case LogLevelVerbose:
+		return "verbose"
+	case LogLevelDebug:
+		return "debug"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLogLevel parses a string into a LogLevel
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(s) {
+	case "silent":
+		return LogLevelSilent, nil
+	case "normal":
+		return LogLevelNormal, nil
+	case "verbose":
+		return LogLevelVerbose, nil
+	case "debug":
+		return LogLevelDebug, nil
+	default:
+		return LogLevelNormal, fmt.Errorf("invalid log level: %s (valid: silent, normal, verbose, debug)", s)
+	}
+}
+
+// LogFormat selects how log records are written
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text" // Human-readable lines (default)
+	LogFormatJSON LogFormat = "json" // One JSON record per line for log ingestion
+)
+
+// ParseLogFormat parses a string into a LogFormat
+func ParseLogFormat(s string) (LogFormat, error) {
+	switch strings.ToLower(s) {
+	case "", "text":
+		return LogFormatText, nil
+	case "json":
+		return LogFormatJSON, nil
+	default:
+		return LogFormatText, fmt.Errorf("invalid log format: %s (valid: text, json)", s)
+	}
+}
+
+// LogFields carries the context attached to a JSON log record
+type LogFields struct {
+	Compartment  string
+	ResourceType string
+	ErrorCode    string
+}
+
+// logRecord is a single line written in JSON log format
+type logRecord struct {
+	Timestamp    string `json:"timestamp"`
+	Level        string `json:"level"`
+	Message      string `json:"message"`
+	Compartment  string `json:"compartment,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty"`
+}
+
+// LogErrorCode returns the OCI service error code of err, or "" if it is not a service error
+func LogErrorCode(err error) string {
+	if serviceErr, ok := common.IsServiceError(err); ok {
+		return serviceErr.GetCode()
+	}
+	return ""
+}
+
+// Logger provides structured logging with multiple levels
+type Logger struct {
+	level    LogLevel
+	format   LogFormat
+	errorLog *log.Logger
+	infoLog  *log.Logger
+	debugLog *log.Logger
+	jsonLog  *log.Logger
+	mu       sync.RWMutex
+}
+
+// logger is the package-wide logger; it is silent until SetLogger is called
+var logger = NewLogger(LogLevelSilent)
+
+// SetLogger replaces the logger used by discovery, output and diff operations
+func SetLogger(l *Logger) {
+	logger = l
+}
+
+// NewLogger creates a new logger with the specified level
+func NewLogger(level LogLevel) *Logger {
+	logger := &Logger{
+		level:   level,
+		format:  LogFormatText,
+		jsonLog: log.New(os.Stderr, "", 0),
+	}
+
+	// Always create error logger (goes to stderr)
+	logger.errorLog = log.New(os.Stderr, "ERROR: ", log.LstdFlags)
+
+	// Create info logger based on level (goes to stderr for progress info)
+	if level >= LogLevelNormal {
+		logger.infoLog = log.New(os.Stderr, "", log.LstdFlags)
+	} else {
+		logger.infoLog = log.New(io.Discard, "", 0)
+	}
+
+	// Create debug logger based on level
+	if level >= LogLevelDebug {
+		logger.debugLog = log.New(os.Stderr, "DEBUG: ", log.LstdFlags|log.Lshortfile)
+	} else {
+		logger.debugLog = log.New(io.Discard, "", 0)
+	}
+
+	return logger
+}
+
+// Error logs error messages (always visible except in silent mode)
+func (l *Logger) Error(format string, args ...interface{}) {
+	l.ErrorWith(LogFields{}, format, args...)
+}
+
+// ErrorWith logs an error message with context fields for JSON log format
+func (l *Logger) ErrorWith(fields LogFields, format string, args ...interface{}) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.format == LogFormatJSON {
+		l.writeJSON("error", fields, fmt.Sprintf(format, args...))
+		return
+	}
+	l.errorLog.Printf(format, args...)
+}
+
+// Info logs informational messages (visible in normal, verbose, debug)
+func (l *Logger) Info(format string, args ...interface{}) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.level >= LogLevelNormal {
+		if l.format == LogFormatJSON {
+			l.writeJSON("info", LogFields{}, fmt.Sprintf(format, args...))
+			return
+		}
+		l.infoLog.Printf(format, args...)
+	}
+}
+
+// Verbose logs detailed operational messages (visible in verbose, debug)
+func (l *Logger) Verbose(format string, args ...interface{}) {
+	l.VerboseWith(LogFields{}, format, args...)
+}
+
+// VerboseWith logs a verbose message with context fields for JSON log format
+func (l *Logger) VerboseWith(fields LogFields, format string, args ...interface{}) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.level >= LogLevelVerbose {
+		if l.format == LogFormatJSON {
+			l.writeJSON("verbose", fields, fmt.Sprintf(format, args...))
+			return
+		}
+		l.infoLog.Printf("VERBOSE: "+format, args...)
+	}
+}
+
+// Debug logs debug messages (visible only in debug mode)
+func (l *Logger) Debug(format string, args ...interface{}) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.level >= LogLevelDebug {
+		if l.format == LogFormatJSON {
+			l.writeJSON("debug", LogFields{}, fmt.Sprintf(format, args...))
+			return
+		}
+		l.debugLog.Printf(format, args...)
+	}
+}
+
+// writeJSON writes one JSON log record; a "Warning: " prefix becomes the warning level
+func (l *Logger) writeJSON(level string, fields LogFields, message string) {
+	if rest, ok := strings.CutPrefix(message, "Warning: "); ok {
+		level, message = "warning", rest
+	}
+	record := logRecord{
+		Timestamp:    time.Now().UTC().Format(time.RFC3339Nano),
+		Level:        level,
+		Message:      message,
+		Compartment:  fields.Compartment,
+		ResourceType: fields.ResourceType,
+		ErrorCode:    fields.ErrorCode,
+	}
+	var line strings.Builder
+	encoder := json.NewEncoder(&line)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(record); err != nil {
+		l.errorLog.Printf("Could not encode log record: %v", err)
+		return
+	}
+	l.jsonLog.Print(line.String())
+}
+
+// SetFormat switches between text and JSON log records
+func (l *Logger) SetFormat(format LogFormat) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.format = format
+}
+
+// SetOutput redirects JSON log records, mainly for tests
+func (l *Logger) SetOutput(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.jsonLog = log.New(w, "", 0)
+}
+
+// SetLevel updates the logging level dynamically
+func (l *Logger) SetLevel(level LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+
+	// Recreate loggers based on new level
+	if level >= LogLevelNormal {
+		l.infoLog = log.New(os.Stderr, "", log.LstdFlags)
+	} else {
+		l.infoLog = log.New(io.Discard, "", 0)
+	}
+
+	if level >= LogLevelDebug {
+		l.debugLog = log.New(os.Stderr, "DEBUG: ", log.LstdFlags|log.Lshortfile)
+	} else {
+		l.debugLog = log.New(io.Discard, "", 0)
+	}
+}
+
+// GetLevel returns the current log level
+func (l *Logger) GetLevel() LogLevel {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.level
+}
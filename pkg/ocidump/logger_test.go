@@ -1,6 +1,8 @@
-package main
+package ocidump
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"sync"
 	"testing"
@@ -308,3 +310,60 @@ func captureLogOutput(f func()) string {
 
 	return output.String()
 }
+
+func TestParseLogFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    LogFormat
+		wantErr bool
+	}{
+		{"", LogFormatText, false},
+		{"text", LogFormatText, false},
+		{"JSON", LogFormatJSON, false},
+		{"logfmt", LogFormatText, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLogFormat(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLogFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseLogFormat(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestLogger_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(LogLevelNormal)
+	logger.SetFormat(LogFormatJSON)
+	logger.SetOutput(&buf)
+
+	logger.Info("Found %d compartments", 3)
+	logger.ErrorWith(LogFields{Compartment: "prod", ResourceType: "Buckets", ErrorCode: "NotAuthorizedOrNotFound"},
+		"Warning: %s failed", "Buckets")
+	logger.Verbose("hidden at normal level")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d records, want 2: %q", len(lines), buf.String())
+	}
+
+	var info, warning logRecord
+	if err := json.Unmarshal([]byte(lines[0]), &info); err != nil {
+		t.Fatalf("invalid JSON record %q: %v", lines[0], err)
+	}
+	if info.Level != "info" || info.Message != "Found 3 compartments" || info.Timestamp == "" {
+		t.Errorf("info record = %+v", info)
+	}
+
+	if err := json.Unmarshal([]byte(lines[1]), &warning); err != nil {
+		t.Fatalf("invalid JSON record %q: %v", lines[1], err)
+	}
+	want := logRecord{Timestamp: warning.Timestamp, Level: "warning", Message: "Buckets failed",
+		Compartment: "prod", ResourceType: "Buckets", ErrorCode: "NotAuthorizedOrNotFound"}
+	if warning != want {
+		t.Errorf("warning record = %+v, want %+v", warning, want)
+	}
+}
@@ -0,0 +1,173 @@
+package ocidump
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/resourcemanager"
+)
+
+// Values of the managed_by field
+const (
+	ManagedByTerraform = "terraform"
+	ManagedByManual    = "manual"
+)
+
+// TerraformConfig holds the settings of the Terraform-managed enrichment
+type TerraformConfig struct {
+	Enabled             bool     `yaml:"enabled"`               // Mark each resource with managed_by=terraform|manual
+	SkipResourceManager bool     `yaml:"skip_resource_manager"` // Do not read the states of Resource Manager stacks
+	StateFiles          []string `yaml:"state_files"`           // Local Terraform state files (version 4) also taken into account
+}
+
+// terraformSource records where a managed resource was found
+type terraformSource struct {
+	source  string // Stack name or state file path
+	address string // Terraform resource address
+}
+
+// terraformIndex maps the OCIDs found in Terraform states to their source
+type terraformIndex map[string]terraformSource
+
+// loadTerraformIndex reads the local state files and, unless skipped, the states of all Resource Manager
+// stacks in the tenancy. Local state files must be readable; stacks whose state cannot be read are
+// logged and skipped. A nil index is returned when no state could be read, so no resource is marked.
+func loadTerraformIndex(ctx context.Context, clients *OCIClients, config TerraformConfig) (terraformIndex, error) {
+	index := make(terraformIndex)
+	sources := 0
+
+	for _, filename := range config.StateFiles {
+		resources, err := LoadTerraformStateResources(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Terraform state %s: %w", filename, err)
+		}
+		index.add(filename, resources)
+		sources++
+	}
+
+	if !config.SkipResourceManager {
+		sources += loadStackStates(ctx, clients, index)
+	}
+
+	if sources == 0 {
+		logger.Error("Warning: No Terraform state could be read, resources are written without managed_by")
+		return nil, nil
+	}
+	logger.Verbose("Found %d Terraform-managed OCIDs in %d states", len(index), sources)
+	return index, nil
+}
+
+// loadStackStates adds the states of the Resource Manager stacks in all compartments to the index
+// and returns the number of states read
+func loadStackStates(ctx context.Context, clients *OCIClients, index terraformIndex) int {
+	client, err := resourcemanager.NewResourceManagerClientWithConfigurationProvider(clients.ConfigProvider)
+	if err != nil {
+		logger.Error("Warning: Could not create resource manager client: %v", err)
+		return 0
+	}
+	clients.Transport.Apply(&client.BaseClient)
+	clients.RateLimiter.Limit(&client.BaseClient, "resourcemanager")
+	compartments, err := getCompartments(ctx, clients)
+	if err != nil {
+		logger.Error("Warning: Could not list compartments for Resource Manager stacks: %v", err)
+		return 0
+	}
+
+	read := 0
+	for _, compartment := range compartments {
+		stacks, err := listStacks(ctx, client, *compartment.Id, clients.pageLimit(maxPageSize))
+		if err != nil {
+			logger.Verbose("Warning: Could not list stacks in compartment %s: %v", *compartment.Id, err)
+			continue
+		}
+		for _, stack := range stacks {
+			name := stringValue(stack.DisplayName)
+			resources, err := getStackStateResources(ctx, client, stringValue(stack.Id))
+			if err != nil {
+				logger.Verbose("Warning: Could not read the state of stack %s: %v", name, err)
+				continue
+			}
+			index.add("stack:"+name, resources)
+			read++
+		}
+	}
+	return read
+}
+
+// listStacks returns the active Resource Manager stacks of a compartment
+func listStacks(ctx context.Context, client resourcemanager.ResourceManagerClient, compartmentID string, limit *int) ([]resourcemanager.StackSummary, error) {
+	var stacks []resourcemanager.StackSummary
+	var page *string
+	for {
+		resp, err := client.ListStacks(ctx, resourcemanager.ListStacksRequest{
+			CompartmentId:  common.String(compartmentID),
+			LifecycleState: resourcemanager.StackLifecycleStateActive,
+			Page:           page,
+			Limit:          limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		stacks = append(stacks, resp.Items...)
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+	return stacks, nil
+}
+
+// getStackStateResources downloads the state of a stack and extracts its managed resources
+// Stacks that have never been applied have no state and return no resources
+func getStackStateResources(ctx context.Context, client resourcemanager.ResourceManagerClient, stackID string) ([]ResourceInfo, error) {
+	resp, err := client.GetStackTfState(ctx, resourcemanager.GetStackTfStateRequest{StackId: common.String(stackID)})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Content.Close()
+
+	data, err := io.ReadAll(resp.Content)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return parseTerraformStateResources(data)
+}
+
+// add records the resources of a state; the first source of an OCID wins
+func (t terraformIndex) add(source string, resources []ResourceInfo) {
+	for _, resource := range resources {
+		if _, exists := t[resource.OCID]; exists {
+			continue
+		}
+		address, _ := resource.AdditionalInfo["terraform_address"].(string)
+		t[resource.OCID] = terraformSource{source: source, address: address}
+	}
+}
+
+// apply sets managed_by on every resource, and terraform_source and terraform_address on
+// resources found in a state
+func (t terraformIndex) apply(resources []ResourceInfo) {
+	if t == nil {
+		return
+	}
+	for i := range resources {
+		if resources[i].AdditionalInfo == nil {
+			resources[i].AdditionalInfo = make(map[string]interface{})
+		}
+		source, managed := t[resources[i].OCID]
+		if !managed {
+			resources[i].AdditionalInfo["managed_by"] = ManagedByManual
+			continue
+		}
+		resources[i].AdditionalInfo["managed_by"] = ManagedByTerraform
+		resources[i].AdditionalInfo["terraform_source"] = source.source
+		if source.address != "" {
+			resources[i].AdditionalInfo["terraform_address"] = source.address
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package ocidump
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTerraformIndex_StateFiles(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(stateFile, []byte(testTerraformState), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config := TerraformConfig{Enabled: true, SkipResourceManager: true, StateFiles: []string{stateFile}}
+
+	index, err := loadTerraformIndex(context.Background(), nil, config)
+	if err != nil {
+		t.Fatalf("loadTerraformIndex() error = %v", err)
+	}
+
+	resources := []ResourceInfo{
+		{ResourceType: "VCN", OCID: "ocid1.vcn.oc1.ap-tokyo-1.managed", AdditionalInfo: map[string]interface{}{"cidr_block": "10.0.0.0/16"}},
+		{ResourceType: "ComputeInstance", OCID: "ocid1.instance.oc1.ap-tokyo-1.blue"},
+		{ResourceType: "ComputeInstance", OCID: "ocid1.instance.oc1.ap-tokyo-1.clickops", AdditionalInfo: map[string]interface{}{}},
+	}
+	index.apply(resources)
+
+	if info := resources[0].AdditionalInfo; info["managed_by"] != ManagedByTerraform || info["terraform_source"] != stateFile ||
+		info["terraform_address"] != "oci_core_vcn.main" || info["cidr_block"] == nil {
+		t.Errorf("VCN AdditionalInfo = %v", info)
+	}
+	if address := resources[1].AdditionalInfo["terraform_address"]; address != `module.app.oci_core_instance.web["blue"]` {
+		t.Errorf("terraform_address = %v", address)
+	}
+	if info := resources[2].AdditionalInfo; info["managed_by"] != ManagedByManual || info["terraform_source"] != nil {
+		t.Errorf("unmanaged instance AdditionalInfo = %v", info)
+	}
+}
+
+func TestLoadTerraformIndex_Errors(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+
+	missing := TerraformConfig{Enabled: true, SkipResourceManager: true, StateFiles: []string{filepath.Join(t.TempDir(), "missing.tfstate")}}
+	if _, err := loadTerraformIndex(context.Background(), nil, missing); err == nil {
+		t.Errorf("loadTerraformIndex() expected error for a missing state file")
+	}
+
+	// Without any state, nothing is marked rather than marking everything as manual
+	index, err := loadTerraformIndex(context.Background(), nil, TerraformConfig{Enabled: true, SkipResourceManager: true})
+	if err != nil {
+		t.Fatalf("loadTerraformIndex() error = %v", err)
+	}
+	resources := []ResourceInfo{{OCID: "ocid1.vcn.oc1..a"}}
+	index.apply(resources)
+	if resources[0].AdditionalInfo != nil {
+		t.Errorf("AdditionalInfo = %v, expected no managed_by without states", resources[0].AdditionalInfo)
+	}
+}
@@ -0,0 +1,98 @@
+package ocidump
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// openMetricsResourceCountName is the metric family exposing resource counts
+const openMetricsResourceCountName = "oci_resources"
+
+// globalRegion is the region label used for resources whose OCID has no region segment
+const globalRegion = "global"
+
+// resourceCountKey identifies a single resource count series
+type resourceCountKey struct {
+	ResourceType    string
+	CompartmentName string
+	CompartmentID   string
+	Region          string
+}
+
+// regionFromOCID extracts the region segment from an OCID (ocid1.<type>.<realm>.<region>.<id>)
+func regionFromOCID(ocid string) string {
+	parts := strings.Split(ocid, ".")
+	if len(parts) < 5 || parts[3] == "" {
+		return globalRegion
+	}
+	return parts[3]
+}
+
+// countResources aggregates resources by type, compartment and region
+func countResources(resources []ResourceInfo) map[resourceCountKey]int {
+	counts := make(map[resourceCountKey]int)
+	for _, resource := range resources {
+		key := resourceCountKey{
+			ResourceType:    resource.ResourceType,
+			CompartmentName: resource.CompartmentName,
+			CompartmentID:   resource.CompartmentID,
+			Region:          regionFromOCID(resource.OCID),
+		}
+		counts[key]++
+	}
+	return counts
+}
+
+// escapeOpenMetricsLabel escapes a label value according to the OpenMetrics text format
+func escapeOpenMetricsLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return value
+}
+
+// outputOpenMetrics outputs resource counts per type, compartment and region in OpenMetrics text format
+func outputOpenMetrics(resources []ResourceInfo, w io.Writer) error {
+	counts := countResources(resources)
+
+	// Sort series so the exposition is stable between runs
+	keys := make([]resourceCountKey, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ResourceType != keys[j].ResourceType {
+			return keys[i].ResourceType < keys[j].ResourceType
+		}
+		if keys[i].CompartmentName != keys[j].CompartmentName {
+			return keys[i].CompartmentName < keys[j].CompartmentName
+		}
+		if keys[i].CompartmentID != keys[j].CompartmentID {
+			return keys[i].CompartmentID < keys[j].CompartmentID
+		}
+		return keys[i].Region < keys[j].Region
+	})
+
+	if _, err := fmt.Fprintf(w, "# HELP %s Number of discovered OCI resources.\n", openMetricsResourceCountName); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "# TYPE %s gauge\n", openMetricsResourceCountName); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "%s{resource_type=\"%s\",compartment_name=\"%s\",compartment_id=\"%s\",region=\"%s\"} %d\n",
+			openMetricsResourceCountName,
+			escapeOpenMetricsLabel(key.ResourceType),
+			escapeOpenMetricsLabel(key.CompartmentName),
+			escapeOpenMetricsLabel(key.CompartmentID),
+			escapeOpenMetricsLabel(key.Region),
+			counts[key],
+		); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "# EOF")
+	return err
+}
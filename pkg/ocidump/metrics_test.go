@@ -0,0 +1,52 @@
+package ocidump
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegionFromOCID(t *testing.T) {
+	tests := []struct {
+		ocid     string
+		expected string
+	}{
+		{"ocid1.instance.oc1.ap-tokyo-1.abc123", "ap-tokyo-1"},
+		{"ocid1.vcn.oc1.iad.abc123", "iad"},
+		{"ocid1.compartment.oc1..abc123", "global"},
+		{"invalid", "global"},
+		{"", "global"},
+	}
+
+	for _, tt := range tests {
+		if got := regionFromOCID(tt.ocid); got != tt.expected {
+			t.Errorf("regionFromOCID(%q) = %q, want %q", tt.ocid, got, tt.expected)
+		}
+	}
+}
+
+func TestOutputOpenMetrics(t *testing.T) {
+	resources := []ResourceInfo{
+		{ResourceType: "VCN", CompartmentName: "network", CompartmentID: "ocid1.compartment.oc1..net", OCID: "ocid1.vcn.oc1.ap-tokyo-1.a"},
+		{ResourceType: "ComputeInstance", CompartmentName: "app", CompartmentID: "ocid1.compartment.oc1..app", OCID: "ocid1.instance.oc1.ap-tokyo-1.a"},
+		{ResourceType: "ComputeInstance", CompartmentName: "app", CompartmentID: "ocid1.compartment.oc1..app", OCID: "ocid1.instance.oc1.ap-tokyo-1.b"},
+		{ResourceType: "ComputeInstance", CompartmentName: "app", CompartmentID: "ocid1.compartment.oc1..app", OCID: "ocid1.instance.oc1.ap-osaka-1.c"},
+		{ResourceType: "Compartment", CompartmentName: "say \"hi\"", CompartmentID: "ocid1.tenancy.oc1..root", OCID: "ocid1.compartment.oc1..d"},
+	}
+
+	var buf strings.Builder
+	if err := outputOpenMetrics(resources, &buf); err != nil {
+		t.Fatalf("outputOpenMetrics() error = %v, want nil", err)
+	}
+
+	expected := `# HELP oci_resources Number of discovered OCI resources.
+# TYPE oci_resources gauge
+oci_resources{resource_type="Compartment",compartment_name="say \"hi\"",compartment_id="ocid1.tenancy.oc1..root",region="global"} 1
+oci_resources{resource_type="ComputeInstance",compartment_name="app",compartment_id="ocid1.compartment.oc1..app",region="ap-osaka-1"} 1
+oci_resources{resource_type="ComputeInstance",compartment_name="app",compartment_id="ocid1.compartment.oc1..app",region="ap-tokyo-1"} 2
+oci_resources{resource_type="VCN",compartment_name="network",compartment_id="ocid1.compartment.oc1..net",region="ap-tokyo-1"} 1
+# EOF
+`
+	if buf.String() != expected {
+		t.Errorf("outputOpenMetrics() output mismatch\ngot:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
@@ -0,0 +1,95 @@
+package ocidump
+
+import (
+	"strings"
+)
+
+// AddResourceNames caches the names of discovered resources so references to them can be resolved
+func (c *ResourceNameCache) AddResourceNames(resources []ResourceInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, resource := range resources {
+		if resource.OCID != "" && resource.ResourceName != "" {
+			c.cache[resource.OCID] = resource.ResourceName
+		}
+	}
+}
+
+// LookupName returns the cached name of an OCID without calling the API
+func (c *ResourceNameCache) LookupName(ocid string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	name, ok := c.cache[ocid]
+	return name, ok
+}
+
+// resolveRelationshipNames adds a *_name field next to every *_id field of AdditionalInfo holding
+// the OCID of a resource or compartment with a known name, e.g. vcn_name for vcn_id, and a *_names
+// list next to *_ids lists whose OCIDs all have known names. Existing fields are never overwritten.
+func resolveRelationshipNames(resources []ResourceInfo, cache *ResourceNameCache) {
+	if cache == nil {
+		cache = &ResourceNameCache{cache: make(map[string]string)}
+	}
+	cache.AddResourceNames(resources)
+
+	resolved := 0
+	for _, resource := range resources {
+		names := make(map[string]interface{})
+		for key, value := range resource.AdditionalInfo {
+			if prefix, ok := strings.CutSuffix(key, "_ids"); ok {
+				if list := resolveNameList(value, cache); list != nil {
+					names[prefix+"_names"] = list
+				}
+				continue
+			}
+			if prefix, ok := strings.CutSuffix(key, "_id"); ok {
+				if ocid, isString := value.(string); isString && strings.HasPrefix(ocid, "ocid1.") {
+					if name, found := cache.LookupName(ocid); found {
+						names[prefix+"_name"] = name
+					}
+				}
+			}
+		}
+
+		for key, name := range names {
+			if _, exists := resource.AdditionalInfo[key]; !exists {
+				resource.AdditionalInfo[key] = name
+				resolved++
+			}
+		}
+	}
+
+	logger.Debug("Resolved %d relationship names", resolved)
+}
+
+// resolveNameList returns the names of a list of OCIDs, or nil unless every OCID has a known name
+func resolveNameList(value interface{}, cache *ResourceNameCache) []string {
+	var ocids []string
+	switch v := value.(type) {
+	case []string:
+		ocids = v
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil
+			}
+			ocids = append(ocids, s)
+		}
+	}
+	if len(ocids) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(ocids))
+	for _, ocid := range ocids {
+		name, ok := cache.LookupName(ocid)
+		if !ok {
+			return nil
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,55 @@
+package ocidump
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveRelationshipNames(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	cache := &ResourceNameCache{cache: map[string]string{"ocid1.compartment.oc1..prod": "prod"}}
+
+	resources := []ResourceInfo{
+		{ResourceType: "VCN", ResourceName: "main", OCID: "ocid1.vcn.oc1..vcn", AdditionalInfo: map[string]interface{}{}},
+		{ResourceType: "Subnet", ResourceName: "app", OCID: "ocid1.subnet.oc1..app", AdditionalInfo: map[string]interface{}{}},
+		{ResourceType: "Subnet", ResourceName: "db", OCID: "ocid1.subnet.oc1..db", AdditionalInfo: map[string]interface{}{
+			"vcn_id": "ocid1.vcn.oc1..vcn",
+		}},
+		{ResourceType: "LoadBalancer", ResourceName: "lb", OCID: "ocid1.loadbalancer.oc1..lb", AdditionalInfo: map[string]interface{}{
+			"subnet_ids": []string{"ocid1.subnet.oc1..app", "ocid1.subnet.oc1..db"},
+		}},
+		{ResourceType: "PrivateIp", ResourceName: "10.0.0.2", OCID: "ocid1.privateip.oc1..ip", AdditionalInfo: map[string]interface{}{
+			"subnet_id":   "ocid1.subnet.oc1..app",
+			"subnet_name": "kept",
+			"vnic_id":     "ocid1.vnic.oc1..unknown",
+		}},
+		{ResourceType: "Policy", ResourceName: "admins", OCID: "ocid1.policy.oc1..p", AdditionalInfo: map[string]interface{}{
+			"attached_compartment_id": "ocid1.compartment.oc1..prod",
+			"instance_pool_ids":       []interface{}{"ocid1.instancepool.oc1..unknown"},
+		}},
+	}
+
+	resolveRelationshipNames(resources, cache)
+
+	if got := resources[2].AdditionalInfo["vcn_name"]; got != "main" {
+		t.Errorf("vcn_name = %v, expected main", got)
+	}
+	if got := resources[3].AdditionalInfo["subnet_names"]; !reflect.DeepEqual(got, []string{"app", "db"}) {
+		t.Errorf("subnet_names = %v, expected [app db]", got)
+	}
+	if got := resources[4].AdditionalInfo["subnet_name"]; got != "kept" {
+		t.Errorf("existing subnet_name was overwritten with %v", got)
+	}
+	if _, ok := resources[4].AdditionalInfo["vnic_name"]; ok {
+		t.Errorf("vnic_name added for a resource outside the dump")
+	}
+	if got := resources[5].AdditionalInfo["attached_compartment_name"]; got != "prod" {
+		t.Errorf("attached_compartment_name = %v, expected prod", got)
+	}
+	if _, ok := resources[5].AdditionalInfo["instance_pool_names"]; ok {
+		t.Errorf("instance_pool_names added for unresolved OCIDs")
+	}
+	if name, ok := cache.LookupName("ocid1.loadbalancer.oc1..lb"); !ok || name != "lb" {
+		t.Errorf("LookupName() = %q, %v, expected discovered resource names to be cached", name, ok)
+	}
+}
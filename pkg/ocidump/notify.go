@@ -0,0 +1,194 @@
+package ocidump
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NotifyConfig holds the webhooks notified about diff results and the targets notified
+// when a run completes
+type NotifyConfig struct {
+	Webhooks   []WebhookConfig        `yaml:"webhooks"`
+	Completion CompletionNotifyConfig `yaml:"completion"`
+}
+
+// WebhookConfig describes a single webhook target
+type WebhookConfig struct {
+	URL            string         `yaml:"url"`             // Webhook URL
+	Type           string         `yaml:"type"`            // Payload type: json (default), slack, teams
+	Thresholds     DiffThresholds `yaml:"thresholds"`      // Notify only when a count exceeds its threshold
+	IncludeDetails bool           `yaml:"include_details"` // json only: post the full diff result instead of the summary
+}
+
+// DiffThresholds are the change counts that must be exceeded before a webhook is notified
+// With the zero value any change triggers a notification
+type DiffThresholds struct {
+	Added    int `yaml:"added"`
+	Removed  int `yaml:"removed"`
+	Modified int `yaml:"modified"`
+	Moved    int `yaml:"moved"`
+}
+
+// webhookTypes lists the supported payload types
+var webhookTypes = []string{"json", "slack", "teams"}
+
+// webhookTimeout bounds a single webhook request
+const webhookTimeout = 30 * time.Second
+
+// Validate checks the configured webhooks
+func (c NotifyConfig) Validate() error {
+	var problems []error
+	for i, webhook := range c.Webhooks {
+		target, err := url.Parse(webhook.URL)
+		if err != nil || target.Host == "" || (target.Scheme != "https" && target.Scheme != "http") {
+			problems = append(problems, fmt.Errorf("invalid webhook url at notify.webhooks[%d], must be an http(s) URL", i))
+		}
+		if webhook.Type != "" && !contains(webhookTypes, webhook.Type) {
+			problems = append(problems, fmt.Errorf("invalid webhook type '%s', must be one of: %v", webhook.Type, webhookTypes))
+		}
+		if webhook.Thresholds.Added < 0 || webhook.Thresholds.Removed < 0 || webhook.Thresholds.Modified < 0 || webhook.Thresholds.Moved < 0 {
+			problems = append(problems, fmt.Errorf("webhook thresholds must not be negative"))
+		}
+	}
+	problems = append(problems, splitErrors(c.Completion.Validate())...)
+	return errors.Join(problems...)
+}
+
+// Exceeded reports whether any count in the summary is above its threshold
+func (t DiffThresholds) Exceeded(summary DiffSummary) bool {
+	return summary.Added > t.Added || summary.Removed > t.Removed || summary.Modified > t.Modified || summary.Moved > t.Moved
+}
+
+// NotifyDiff posts the diff result to every webhook whose thresholds are exceeded
+// All webhooks are attempted; failures are returned together
+func NotifyDiff(ctx context.Context, config NotifyConfig, result *DiffResult) error {
+	var errs []error
+	for _, webhook := range config.Webhooks {
+		if !webhook.Thresholds.Exceeded(result.Summary) {
+			logger.Debug("Diff below webhook thresholds, skipping %s", redactURL(webhook.URL))
+			continue
+		}
+		if err := postWebhook(ctx, webhook, result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postWebhook sends a single notification
+func postWebhook(ctx context.Context, webhook WebhookConfig, result *DiffResult) error {
+	logger.Info("Sending diff notification to %s", redactURL(webhook.URL))
+	return postJSON(ctx, webhook.URL, webhookPayload(webhook, result))
+}
+
+// postJSON posts a JSON payload to a webhook URL
+func postJSON(ctx context.Context, targetURL string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The URL often embeds a secret token, so only the redacted form is reported
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to notify webhook %s: %w", redactURL(targetURL), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook %s failed with status %s: %s", redactURL(targetURL), resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// webhookPayload builds the request body for the webhook type
+func webhookPayload(webhook WebhookConfig, result *DiffResult) interface{} {
+	switch webhook.Type {
+	case "slack":
+		return map[string]interface{}{"text": diffNotificationText(result, "*", "\n")}
+	case "teams":
+		// Incoming webhooks created with Teams workflows accept Adaptive Cards
+		return map[string]interface{}{
+			"type": "message",
+			"attachments": []map[string]interface{}{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]interface{}{{
+						"type": "TextBlock",
+						"text": diffNotificationText(result, "**", "\n\n"),
+						"wrap": true,
+					}},
+				},
+			}},
+		}
+	default:
+		if webhook.IncludeDetails {
+			return map[string]interface{}{"event": "diff", "result": result}
+		}
+		return map[string]interface{}{
+			"event":     "diff",
+			"timestamp": result.Timestamp,
+			"old_file":  result.OldFile,
+			"new_file":  result.NewFile,
+			"summary":   result.Summary,
+		}
+	}
+}
+
+// diffNotificationText renders a short markdown summary of the diff for chat webhooks
+func diffNotificationText(result *DiffResult, bold, newline string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sOCI resource changes detected%s%s", bold, bold, newline)
+	fmt.Fprintf(&b, "Added: %d, Removed: %d, Modified: %d, Moved: %d (%d → %d resources)",
+		result.Summary.Added, result.Summary.Removed, result.Summary.Modified, result.Summary.Moved, result.Summary.TotalOld, result.Summary.TotalNew)
+
+	var resourceTypes []string
+	for resourceType, stats := range result.Summary.ByResourceType {
+		if stats.Added+stats.Removed+stats.Modified+stats.Moved > 0 {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Strings(resourceTypes)
+	for _, resourceType := range resourceTypes {
+		stats := result.Summary.ByResourceType[resourceType]
+		fmt.Fprintf(&b, "%s• %s: +%d, -%d, ~%d, >%d", newline, resourceType, stats.Added, stats.Removed, stats.Modified, stats.Moved)
+	}
+
+	fmt.Fprintf(&b, "%s%s → %s", newline, result.OldFile, result.NewFile)
+	return b.String()
+}
+
+// redactURL strips the path and query of a webhook URL, which usually carry the secret token
+func redactURL(rawURL string) string {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return "webhook"
+	}
+	return target.Scheme + "://" + target.Host + "/..."
+}
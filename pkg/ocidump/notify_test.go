@@ -0,0 +1,120 @@
+package ocidump
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNotifyConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  NotifyConfig
+		wantErr bool
+	}{
+		{"empty", NotifyConfig{}, false},
+		{"generic", NotifyConfig{Webhooks: []WebhookConfig{{URL: "https://example.com/hook"}}}, false},
+		{"slack", NotifyConfig{Webhooks: []WebhookConfig{{URL: "https://hooks.slack.com/services/T/B/X", Type: "slack"}}}, false},
+		{"missing url", NotifyConfig{Webhooks: []WebhookConfig{{Type: "teams"}}}, true},
+		{"unsupported scheme", NotifyConfig{Webhooks: []WebhookConfig{{URL: "ftp://example.com"}}}, true},
+		{"unknown type", NotifyConfig{Webhooks: []WebhookConfig{{URL: "https://example.com", Type: "discord"}}}, true},
+		{"negative threshold", NotifyConfig{Webhooks: []WebhookConfig{{URL: "https://example.com", Thresholds: DiffThresholds{Added: -1}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiffThresholds_Exceeded(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds DiffThresholds
+		summary    DiffSummary
+		expected   bool
+	}{
+		{"no changes", DiffThresholds{}, DiffSummary{Unchanged: 10}, false},
+		{"any change", DiffThresholds{}, DiffSummary{Modified: 1}, true},
+		{"below thresholds", DiffThresholds{Added: 5, Removed: 5, Modified: 5}, DiffSummary{Added: 5, Removed: 1}, false},
+		{"removed above threshold", DiffThresholds{Added: 5, Removed: 0, Modified: 5}, DiffSummary{Removed: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.thresholds.Exceeded(tt.summary); got != tt.expected {
+				t.Errorf("Exceeded() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNotifyDiff(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q, expected application/json", r.Header.Get("Content-Type"))
+		}
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]interface{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("invalid JSON payload: %v", err)
+		}
+		bodies[r.URL.Path] = body
+		if r.URL.Path == "/failing" {
+			http.Error(w, "invalid token", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	result := &DiffResult{
+		Summary: DiffSummary{
+			TotalOld: 2, TotalNew: 3, Added: 1,
+			ByResourceType: map[string]DiffStats{"VCN": {Added: 1}, "Subnet": {Unchanged: 2}},
+		},
+		OldFile: "old.json",
+		NewFile: "new.json",
+	}
+	config := NotifyConfig{Webhooks: []WebhookConfig{
+		{URL: server.URL + "/failing"},
+		{URL: server.URL + "/generic"},
+		{URL: server.URL + "/slack", Type: "slack"},
+		{URL: server.URL + "/teams", Type: "teams"},
+		{URL: server.URL + "/quiet", Thresholds: DiffThresholds{Added: 10}},
+	}}
+
+	err := NotifyDiff(context.Background(), config, result)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("NotifyDiff() error = %v, expected the failing webhook to be reported", err)
+	}
+
+	// The failing webhook comes first; the remaining ones must still be notified
+	if summary, ok := bodies["/generic"]["summary"].(map[string]interface{}); !ok || summary["added"] != float64(1) {
+		t.Errorf("generic payload = %v, expected the diff summary", bodies["/generic"])
+	}
+	if text, _ := bodies["/slack"]["text"].(string); !strings.Contains(text, "VCN: +1, -0, ~0") || strings.Contains(text, "Subnet") {
+		t.Errorf("slack text = %q, expected changed resource types only", text)
+	}
+	if attachments, ok := bodies["/teams"]["attachments"].([]interface{}); !ok || len(attachments) != 1 {
+		t.Errorf("teams payload = %v, expected one adaptive card attachment", bodies["/teams"])
+	}
+	if _, notified := bodies["/quiet"]; notified {
+		t.Errorf("webhook below its thresholds was notified")
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	if got := redactURL("https://hooks.slack.com/services/T000/B000/secret"); got != "https://hooks.slack.com/..." {
+		t.Errorf("redactURL() = %q", got)
+	}
+}
@@ -0,0 +1,234 @@
+package ocidump
+
+import (
+	"context"
+
+	"github.com/oracle/oci-go-sdk/v65/apigateway"
+	"github.com/oracle/oci-go-sdk/v65/blockchain"
+	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/oracle/oci-go-sdk/v65/databasemanagement"
+	"github.com/oracle/oci-go-sdk/v65/filestorage"
+	"github.com/oracle/oci-go-sdk/v65/functions"
+	"github.com/oracle/oci-go-sdk/v65/healthchecks"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/identitydomains"
+	"github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/loadbalancer"
+	"github.com/oracle/oci-go-sdk/v65/networkloadbalancer"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/opensearch"
+	"github.com/oracle/oci-go-sdk/v65/opsi"
+	"github.com/oracle/oci-go-sdk/v65/osmanagementhub"
+	"github.com/oracle/oci-go-sdk/v65/streaming"
+)
+
+// The interfaces below list the operations discovery uses on each OCI service client.
+// OCIClients holds them instead of the SDK clients so tests can replace a service with a fake
+// that serves canned, paginated responses. Add a method here when discovery starts calling it.
+
+// ComputeAPI is the subset of core.ComputeClient used by discovery
+type ComputeAPI interface {
+	ListBootVolumeAttachments(ctx context.Context, request core.ListBootVolumeAttachmentsRequest) (core.ListBootVolumeAttachmentsResponse, error)
+	ListInstances(ctx context.Context, request core.ListInstancesRequest) (core.ListInstancesResponse, error)
+	ListVnicAttachments(ctx context.Context, request core.ListVnicAttachmentsRequest) (core.ListVnicAttachmentsResponse, error)
+	ListVolumeAttachments(ctx context.Context, request core.ListVolumeAttachmentsRequest) (core.ListVolumeAttachmentsResponse, error)
+}
+
+// VirtualNetworkAPI is the subset of core.VirtualNetworkClient used by discovery
+type VirtualNetworkAPI interface {
+	GetPrivateIp(ctx context.Context, request core.GetPrivateIpRequest) (core.GetPrivateIpResponse, error)
+	GetVnic(ctx context.Context, request core.GetVnicRequest) (core.GetVnicResponse, error)
+	ListDrgs(ctx context.Context, request core.ListDrgsRequest) (core.ListDrgsResponse, error)
+	ListLocalPeeringGateways(ctx context.Context, request core.ListLocalPeeringGatewaysRequest) (core.ListLocalPeeringGatewaysResponse, error)
+	ListPrivateIps(ctx context.Context, request core.ListPrivateIpsRequest) (core.ListPrivateIpsResponse, error)
+	ListSubnets(ctx context.Context, request core.ListSubnetsRequest) (core.ListSubnetsResponse, error)
+	ListVcns(ctx context.Context, request core.ListVcnsRequest) (core.ListVcnsResponse, error)
+}
+
+// BlockStorageAPI is the subset of core.BlockstorageClient used by discovery
+type BlockStorageAPI interface {
+	ListBootVolumeBackups(ctx context.Context, request core.ListBootVolumeBackupsRequest) (core.ListBootVolumeBackupsResponse, error)
+	ListBootVolumes(ctx context.Context, request core.ListBootVolumesRequest) (core.ListBootVolumesResponse, error)
+	ListVolumeBackups(ctx context.Context, request core.ListVolumeBackupsRequest) (core.ListVolumeBackupsResponse, error)
+	ListVolumeGroupBackups(ctx context.Context, request core.ListVolumeGroupBackupsRequest) (core.ListVolumeGroupBackupsResponse, error)
+	ListVolumeGroups(ctx context.Context, request core.ListVolumeGroupsRequest) (core.ListVolumeGroupsResponse, error)
+	ListVolumes(ctx context.Context, request core.ListVolumesRequest) (core.ListVolumesResponse, error)
+}
+
+// IdentityAPI is the subset of identity.IdentityClient used by discovery
+type IdentityAPI interface {
+	GetCompartment(ctx context.Context, request identity.GetCompartmentRequest) (identity.GetCompartmentResponse, error)
+	ListAvailabilityDomains(ctx context.Context, request identity.ListAvailabilityDomainsRequest) (identity.ListAvailabilityDomainsResponse, error)
+	ListCompartments(ctx context.Context, request identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error)
+	ListDomains(ctx context.Context, request identity.ListDomainsRequest) (identity.ListDomainsResponse, error)
+	ListDynamicGroups(ctx context.Context, request identity.ListDynamicGroupsRequest) (identity.ListDynamicGroupsResponse, error)
+	ListGroups(ctx context.Context, request identity.ListGroupsRequest) (identity.ListGroupsResponse, error)
+	ListPolicies(ctx context.Context, request identity.ListPoliciesRequest) (identity.ListPoliciesResponse, error)
+	ListTagNamespaces(ctx context.Context, request identity.ListTagNamespacesRequest) (identity.ListTagNamespacesResponse, error)
+	ListTags(ctx context.Context, request identity.ListTagsRequest) (identity.ListTagsResponse, error)
+	ListUsers(ctx context.Context, request identity.ListUsersRequest) (identity.ListUsersResponse, error)
+}
+
+// IdentityDomainsAPI is the subset of identitydomains.IdentityDomainsClient used by discovery
+type IdentityDomainsAPI interface {
+	ListGroups(ctx context.Context, request identitydomains.ListGroupsRequest) (identitydomains.ListGroupsResponse, error)
+	ListUsers(ctx context.Context, request identitydomains.ListUsersRequest) (identitydomains.ListUsersResponse, error)
+}
+
+// ObjectStorageAPI is the subset of objectstorage.ObjectStorageClient used by discovery
+type ObjectStorageAPI interface {
+	GetNamespace(ctx context.Context, request objectstorage.GetNamespaceRequest) (objectstorage.GetNamespaceResponse, error)
+	GetObjectLifecyclePolicy(ctx context.Context, request objectstorage.GetObjectLifecyclePolicyRequest) (objectstorage.GetObjectLifecyclePolicyResponse, error)
+	ListBuckets(ctx context.Context, request objectstorage.ListBucketsRequest) (objectstorage.ListBucketsResponse, error)
+	ListReplicationPolicies(ctx context.Context, request objectstorage.ListReplicationPoliciesRequest) (objectstorage.ListReplicationPoliciesResponse, error)
+}
+
+// ContainerEngineAPI is the subset of containerengine.ContainerEngineClient used by discovery
+type ContainerEngineAPI interface {
+	ListClusters(ctx context.Context, request containerengine.ListClustersRequest) (containerengine.ListClustersResponse, error)
+}
+
+// LoadBalancerAPI is the subset of loadbalancer.LoadBalancerClient used by discovery
+type LoadBalancerAPI interface {
+	ListCertificates(ctx context.Context, request loadbalancer.ListCertificatesRequest) (loadbalancer.ListCertificatesResponse, error)
+	ListLoadBalancers(ctx context.Context, request loadbalancer.ListLoadBalancersRequest) (loadbalancer.ListLoadBalancersResponse, error)
+}
+
+// DatabaseAPI is the subset of database.DatabaseClient used by discovery
+type DatabaseAPI interface {
+	ListAutonomousDatabases(ctx context.Context, request database.ListAutonomousDatabasesRequest) (database.ListAutonomousDatabasesResponse, error)
+	ListCloudExadataInfrastructures(ctx context.Context, request database.ListCloudExadataInfrastructuresRequest) (database.ListCloudExadataInfrastructuresResponse, error)
+	ListDatabases(ctx context.Context, request database.ListDatabasesRequest) (database.ListDatabasesResponse, error)
+	ListDbHomes(ctx context.Context, request database.ListDbHomesRequest) (database.ListDbHomesResponse, error)
+	ListDbNodes(ctx context.Context, request database.ListDbNodesRequest) (database.ListDbNodesResponse, error)
+	ListDbServers(ctx context.Context, request database.ListDbServersRequest) (database.ListDbServersResponse, error)
+	ListDbSystems(ctx context.Context, request database.ListDbSystemsRequest) (database.ListDbSystemsResponse, error)
+	ListExadataInfrastructures(ctx context.Context, request database.ListExadataInfrastructuresRequest) (database.ListExadataInfrastructuresResponse, error)
+	ListExternalContainerDatabases(ctx context.Context, request database.ListExternalContainerDatabasesRequest) (database.ListExternalContainerDatabasesResponse, error)
+	ListExternalNonContainerDatabases(ctx context.Context, request database.ListExternalNonContainerDatabasesRequest) (database.ListExternalNonContainerDatabasesResponse, error)
+	ListExternalPluggableDatabases(ctx context.Context, request database.ListExternalPluggableDatabasesRequest) (database.ListExternalPluggableDatabasesResponse, error)
+	ListVmClusters(ctx context.Context, request database.ListVmClustersRequest) (database.ListVmClustersResponse, error)
+}
+
+// APIGatewayAPI is the subset of apigateway.GatewayClient used by discovery
+type APIGatewayAPI interface {
+	ListGateways(ctx context.Context, request apigateway.ListGatewaysRequest) (apigateway.ListGatewaysResponse, error)
+}
+
+// FunctionsAPI is the subset of functions.FunctionsManagementClient used by discovery
+type FunctionsAPI interface {
+	ListApplications(ctx context.Context, request functions.ListApplicationsRequest) (functions.ListApplicationsResponse, error)
+	ListFunctions(ctx context.Context, request functions.ListFunctionsRequest) (functions.ListFunctionsResponse, error)
+}
+
+// FileStorageAPI is the subset of filestorage.FileStorageClient used by discovery
+type FileStorageAPI interface {
+	ListExports(ctx context.Context, request filestorage.ListExportsRequest) (filestorage.ListExportsResponse, error)
+	ListFileSystems(ctx context.Context, request filestorage.ListFileSystemsRequest) (filestorage.ListFileSystemsResponse, error)
+	ListMountTargets(ctx context.Context, request filestorage.ListMountTargetsRequest) (filestorage.ListMountTargetsResponse, error)
+	ListSnapshots(ctx context.Context, request filestorage.ListSnapshotsRequest) (filestorage.ListSnapshotsResponse, error)
+}
+
+// NetworkLoadBalancerAPI is the subset of networkloadbalancer.NetworkLoadBalancerClient used by discovery
+type NetworkLoadBalancerAPI interface {
+	ListNetworkLoadBalancers(ctx context.Context, request networkloadbalancer.ListNetworkLoadBalancersRequest) (networkloadbalancer.ListNetworkLoadBalancersResponse, error)
+}
+
+// StreamingAPI is the subset of streaming.StreamAdminClient used by discovery
+type StreamingAPI interface {
+	GetStream(ctx context.Context, request streaming.GetStreamRequest) (streaming.GetStreamResponse, error)
+	GetStreamPool(ctx context.Context, request streaming.GetStreamPoolRequest) (streaming.GetStreamPoolResponse, error)
+	ListStreamPools(ctx context.Context, request streaming.ListStreamPoolsRequest) (streaming.ListStreamPoolsResponse, error)
+	ListStreams(ctx context.Context, request streaming.ListStreamsRequest) (streaming.ListStreamsResponse, error)
+}
+
+// OpenSearchAPI is the subset of opensearch.OpensearchClusterClient used by discovery
+type OpenSearchAPI interface {
+	ListOpensearchClusters(ctx context.Context, request opensearch.ListOpensearchClustersRequest) (opensearch.ListOpensearchClustersResponse, error)
+}
+
+// OpenSearchBackupAPI is the subset of opensearch.OpensearchClusterBackupClient used by discovery
+type OpenSearchBackupAPI interface {
+	ListOpensearchClusterBackups(ctx context.Context, request opensearch.ListOpensearchClusterBackupsRequest) (opensearch.ListOpensearchClusterBackupsResponse, error)
+}
+
+// QuotasAPI is the subset of limits.QuotasClient used by discovery
+type QuotasAPI interface {
+	GetQuota(ctx context.Context, request limits.GetQuotaRequest) (limits.GetQuotaResponse, error)
+	ListQuotas(ctx context.Context, request limits.ListQuotasRequest) (limits.ListQuotasResponse, error)
+}
+
+// ComputeManagementAPI is the subset of core.ComputeManagementClient used by discovery
+type ComputeManagementAPI interface {
+	GetInstanceConfiguration(ctx context.Context, request core.GetInstanceConfigurationRequest) (core.GetInstanceConfigurationResponse, error)
+	GetInstancePool(ctx context.Context, request core.GetInstancePoolRequest) (core.GetInstancePoolResponse, error)
+	ListClusterNetworks(ctx context.Context, request core.ListClusterNetworksRequest) (core.ListClusterNetworksResponse, error)
+	ListInstanceConfigurations(ctx context.Context, request core.ListInstanceConfigurationsRequest) (core.ListInstanceConfigurationsResponse, error)
+	ListInstancePools(ctx context.Context, request core.ListInstancePoolsRequest) (core.ListInstancePoolsResponse, error)
+}
+
+// HealthChecksAPI is the subset of healthchecks.HealthChecksClient used by discovery
+type HealthChecksAPI interface {
+	GetHttpMonitor(ctx context.Context, request healthchecks.GetHttpMonitorRequest) (healthchecks.GetHttpMonitorResponse, error)
+	GetPingMonitor(ctx context.Context, request healthchecks.GetPingMonitorRequest) (healthchecks.GetPingMonitorResponse, error)
+	ListHttpMonitors(ctx context.Context, request healthchecks.ListHttpMonitorsRequest) (healthchecks.ListHttpMonitorsResponse, error)
+	ListPingMonitors(ctx context.Context, request healthchecks.ListPingMonitorsRequest) (healthchecks.ListPingMonitorsResponse, error)
+}
+
+// ManagedInstanceAPI is the subset of osmanagementhub.ManagedInstanceClient used by discovery
+type ManagedInstanceAPI interface {
+	ListManagedInstances(ctx context.Context, request osmanagementhub.ListManagedInstancesRequest) (osmanagementhub.ListManagedInstancesResponse, error)
+}
+
+// ManagedInstanceGroupAPI is the subset of osmanagementhub.ManagedInstanceGroupClient used by discovery
+type ManagedInstanceGroupAPI interface {
+	ListManagedInstanceGroups(ctx context.Context, request osmanagementhub.ListManagedInstanceGroupsRequest) (osmanagementhub.ListManagedInstanceGroupsResponse, error)
+}
+
+// OperationsInsightsAPI is the subset of opsi.OperationsInsightsClient used by discovery
+type OperationsInsightsAPI interface {
+	ListDatabaseInsights(ctx context.Context, request opsi.ListDatabaseInsightsRequest) (opsi.ListDatabaseInsightsResponse, error)
+	ListHostInsights(ctx context.Context, request opsi.ListHostInsightsRequest) (opsi.ListHostInsightsResponse, error)
+}
+
+// DbManagementAPI is the subset of databasemanagement.DbManagementClient used by discovery
+type DbManagementAPI interface {
+	ListManagedDatabases(ctx context.Context, request databasemanagement.ListManagedDatabasesRequest) (databasemanagement.ListManagedDatabasesResponse, error)
+	ListExternalExadataInfrastructures(ctx context.Context, request databasemanagement.ListExternalExadataInfrastructuresRequest) (databasemanagement.ListExternalExadataInfrastructuresResponse, error)
+	ListExternalExadataStorageServers(ctx context.Context, request databasemanagement.ListExternalExadataStorageServersRequest) (databasemanagement.ListExternalExadataStorageServersResponse, error)
+}
+
+// BlockchainPlatformAPI is the subset of blockchain.BlockchainPlatformClient used by discovery
+type BlockchainPlatformAPI interface {
+	GetBlockchainPlatform(ctx context.Context, request blockchain.GetBlockchainPlatformRequest) (blockchain.GetBlockchainPlatformResponse, error)
+	ListBlockchainPlatforms(ctx context.Context, request blockchain.ListBlockchainPlatformsRequest) (blockchain.ListBlockchainPlatformsResponse, error)
+}
+
+// The SDK clients implement the interfaces
+var (
+	_ ComputeAPI              = core.ComputeClient{}
+	_ VirtualNetworkAPI       = core.VirtualNetworkClient{}
+	_ BlockStorageAPI         = core.BlockstorageClient{}
+	_ IdentityAPI             = identity.IdentityClient{}
+	_ ObjectStorageAPI        = objectstorage.ObjectStorageClient{}
+	_ ContainerEngineAPI      = containerengine.ContainerEngineClient{}
+	_ LoadBalancerAPI         = loadbalancer.LoadBalancerClient{}
+	_ DatabaseAPI             = database.DatabaseClient{}
+	_ APIGatewayAPI           = apigateway.GatewayClient{}
+	_ FunctionsAPI            = functions.FunctionsManagementClient{}
+	_ FileStorageAPI          = filestorage.FileStorageClient{}
+	_ NetworkLoadBalancerAPI  = networkloadbalancer.NetworkLoadBalancerClient{}
+	_ StreamingAPI            = streaming.StreamAdminClient{}
+	_ OpenSearchAPI           = opensearch.OpensearchClusterClient{}
+	_ OpenSearchBackupAPI     = opensearch.OpensearchClusterBackupClient{}
+	_ QuotasAPI               = limits.QuotasClient{}
+	_ ComputeManagementAPI    = core.ComputeManagementClient{}
+	_ HealthChecksAPI         = healthchecks.HealthChecksClient{}
+	_ ManagedInstanceAPI      = osmanagementhub.ManagedInstanceClient{}
+	_ ManagedInstanceGroupAPI = osmanagementhub.ManagedInstanceGroupClient{}
+	_ OperationsInsightsAPI   = opsi.OperationsInsightsClient{}
+	_ DbManagementAPI         = databasemanagement.DbManagementClient{}
+	_ BlockchainPlatformAPI   = blockchain.BlockchainPlatformClient{}
+)
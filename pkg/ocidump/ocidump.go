@@ -0,0 +1,200 @@
+// Package ocidump discovers OCI resources in a tenancy and encodes, filters and diffs the results.
+//
+// It is the engine behind the oci-resource-dump command and can be embedded in other programs:
+//
+//	resources, err := ocidump.Discover(ctx, ocidump.Options{
+//		Filters: ocidump.FilterConfig{IncludeResourceTypes: []string{"vcns", "subnets"}},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	return ocidump.OutputResources(resources, "json", ocidump.CSVDialect{})
+//
+// Discovery authenticates with instance principals. Logging is silent unless SetLogger is called.
+package ocidump
+
+import (
+	"context"
+	"fmt"
+)
+
+// Resource is a discovered OCI resource
+type Resource = ResourceInfo
+
+// Options configures a discovery run
+type Options struct {
+	Filters      FilterConfig // Compartment, resource type, name, lifecycle and creation time filters
+	Detail       bool         // Fetch per-resource details that require additional API calls
+	ShowProgress bool         // Render a progress bar on stderr
+	Mode         string       // DiscoveryModeFull (default) or DiscoveryModeSearch
+	PageSize     int          // Items requested per page of list operations (0 = DefaultPageSize)
+	Cost         CostConfig   // Attach the cost reported by the Usage API when Cost.Enabled is set
+
+	// CircuitBreakerThreshold skips the resource types of a service for the rest of the run once
+	// they have failed this many times in a row (0 = DefaultCircuitBreakerThreshold, -1 = never)
+	CircuitBreakerThreshold int
+
+	// HTTP tunes the connection pool, timeouts and proxy of the HTTP client shared by all OCI clients
+	HTTP HTTPConfig
+
+	// RateLimits throttles the OCI API requests of each service across all concurrent discoveries
+	RateLimits RateLimitConfig
+
+	// Utilization adds summary metrics from the Monitoring API when Utilization.Enabled is set.
+	// Metrics are not added to resources sent to Sink.
+	Utilization UtilizationConfig
+
+	// Terraform marks each resource with managed_by=terraform|manual when Terraform.Enabled is set
+	Terraform TerraformConfig
+
+	// Sink, when set, receives resources as each compartment and resource type completes
+	// and Discover returns no resources. Volume attachments are not resolved in this mode.
+	Sink ResourceSink
+
+	// Checkpoint, when set, records completed compartments and resource types as discovery
+	// runs and skips those already recorded; their resources are included in the result
+	Checkpoint *Checkpoint
+
+	// Cache, when set, reuses recent results of each compartment and resource type instead of
+	// calling the OCI APIs, and stores the results it had to discover
+	Cache *ResultCache
+
+	// CompartmentTree, when set, reuses the compartment names stored by a recent run instead of
+	// listing all compartments again, and stores the tree it had to list
+	CompartmentTree *CompartmentTreeCache
+
+	// Stats, when set, is filled with the counts of the run, e.g. for the audit log
+	Stats *DiscoveryStats
+}
+
+// DiscoveryStats summarizes a discovery run
+type DiscoveryStats struct {
+	TenancyID      string   // Tenancy of the authenticated principal
+	Compartments   int      // Active compartments processed after filtering
+	CompartmentIDs []string // OCIDs of the processed compartments
+	Resources      int      // Resources discovered, including those restored from a checkpoint
+	Errors         int      // Resource type discoveries that failed after retries
+}
+
+// Discover initializes the OCI clients and discovers all resources matching the options
+func Discover(ctx context.Context, opts Options) ([]Resource, error) {
+	if err := ValidateFilterConfig(opts.Filters); err != nil {
+		return nil, fmt.Errorf("invalid filter configuration: %w", err)
+	}
+	if opts.Mode != "" && !contains(DiscoveryModes, opts.Mode) {
+		return nil, fmt.Errorf("invalid discovery mode '%s', must be one of: %v", opts.Mode, DiscoveryModes)
+	}
+	if err := opts.Cost.Validate(); err != nil {
+		return nil, err
+	}
+	if err := opts.Utilization.Validate(); err != nil {
+		return nil, err
+	}
+	if err := opts.RateLimits.Validate(); err != nil {
+		return nil, err
+	}
+	if err := opts.HTTP.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.PageSize < 0 {
+		return nil, fmt.Errorf("page size must not be negative, got: %d", opts.PageSize)
+	}
+	if opts.CircuitBreakerThreshold < -1 {
+		return nil, fmt.Errorf("circuit breaker threshold must be -1 (disabled) or more, got: %d", opts.CircuitBreakerThreshold)
+	}
+
+	logger.Debug("Initializing OCI clients with instance principal authentication")
+	clients, err := InitOCIClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing OCI clients: %w", err)
+	}
+	defer clients.Close()
+	clients.Options = DiscoveryOptions{Detail: opts.Detail, Mode: opts.Mode, PageSize: opts.PageSize, CircuitBreakerThreshold: opts.CircuitBreakerThreshold}
+	transport, err := NewHTTPTransport(opts.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring HTTP client: %w", err)
+	}
+	transport.applyClients(clients)
+	clients.Transport = transport
+	if limiter := NewRateLimiter(opts.RateLimits); limiter != nil {
+		limiter.limitClients(clients)
+		clients.RateLimiter = limiter
+	}
+	// The cache holds a copy of the identity client from before the updates above
+	clients.CompartmentCache = NewResourceNameCache(clients.IdentityClient)
+	clients.CompartmentCache.pageLimit = clients.pageLimit(maxPageSize)
+	logger.Verbose("OCI clients initialized successfully")
+
+	preloadCompartmentNames(ctx, clients, opts.CompartmentTree)
+
+	// Enrichment data is fetched up front so streamed resources can carry it as well
+	var enrichers []func([]ResourceInfo)
+	if opts.Cost.Enabled {
+		costs, err := fetchCosts(ctx, clients, opts.Cost)
+		if err != nil {
+			logger.Error("Warning: Could not fetch costs, resources are written without cost: %v", err)
+		} else {
+			enrichers = append(enrichers, func(resources []ResourceInfo) {
+				costs.apply(resources, opts.Cost.groupBy())
+			})
+		}
+	}
+	if opts.Terraform.Enabled {
+		index, err := loadTerraformIndex(ctx, clients, opts.Terraform)
+		if err != nil {
+			return nil, err
+		}
+		enrichers = append(enrichers, index.apply)
+	}
+	enrich := func(resources []ResourceInfo) {
+		for _, enricher := range enrichers {
+			enricher(resources)
+		}
+	}
+
+	sink := opts.Sink
+	if sink != nil && len(enrichers) > 0 {
+		sink = func(resources []ResourceInfo) error {
+			enrich(resources)
+			return opts.Sink(resources)
+		}
+	}
+
+	if opts.Stats != nil {
+		if tenancyID, err := clients.ConfigProvider.TenancyOCID(); err == nil {
+			opts.Stats.TenancyID = tenancyID
+		}
+	}
+
+	resources, err := discoverAllResourcesWithProgress(ctx, clients, opts.ShowProgress, opts.Filters, sink, opts.Checkpoint, opts.Cache, opts.Stats)
+	if err != nil {
+		return nil, err
+	}
+	enrich(resources)
+	if opts.Utilization.Enabled && opts.Sink == nil {
+		enrichUtilization(ctx, clients, resources, opts.Utilization)
+	}
+	return resources, nil
+}
+
+// preloadCompartmentNames fills the compartment name cache for the whole tenancy, from store when set
+// Failures are logged and ignored because individual lookups still work
+func preloadCompartmentNames(ctx context.Context, clients *OCIClients, store *CompartmentTreeCache) {
+	logger.Debug("Preloading compartment names...")
+
+	tenancyID, err := clients.ConfigProvider.TenancyOCID()
+	if err != nil {
+		logger.Verbose("Warning: Could not get tenancy ID for compartment preload: %v", err)
+		return
+	}
+
+	if store != nil {
+		clients.CompartmentCache.SetStore(store, tenancyID)
+	}
+	if err := clients.CompartmentCache.PreloadCompartmentNames(ctx, tenancyID); err != nil {
+		logger.Verbose("Warning: Could not preload all compartment names: %v", err)
+		return
+	}
+	totalEntries, _ := clients.CompartmentCache.GetCacheStats()
+	logger.Verbose("Preloaded %d compartment names into cache", totalEntries)
+}
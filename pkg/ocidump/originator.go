@@ -0,0 +1,112 @@
+package ocidump
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Originator classes assigned to resources based on their creating principal
+const (
+	OriginatorHuman      = "human"
+	OriginatorAutomation = "automation"
+	OriginatorTerraform  = "terraform"
+	OriginatorUnknown    = "unknown"
+)
+
+// OriginatorConfig holds the principal patterns used to classify resource originators
+type OriginatorConfig struct {
+	TerraformPatterns  []string `yaml:"terraform_patterns"`  // Regex patterns matching Terraform / Resource Manager principals
+	AutomationPatterns []string `yaml:"automation_patterns"` // Regex patterns matching automation accounts and principals
+}
+
+// CompiledOriginatorPatterns holds compiled originator regex patterns for efficient matching
+type CompiledOriginatorPatterns struct {
+	Terraform  []*regexp.Regexp
+	Automation []*regexp.Regexp
+}
+
+// getDefaultOriginatorConfig returns the default principal patterns
+func getDefaultOriginatorConfig() OriginatorConfig {
+	return OriginatorConfig{
+		TerraformPatterns: []string{
+			"(?i)terraform",
+			"(?i)ormstack",
+		},
+		AutomationPatterns: []string{
+			`^ocid1\.(instance|fnfunc|dynamicgroup)\.`, // Instance / resource principals
+			// Terms must form a whole segment of the principal name, so "talbot" stays human
+			`(?i)(^|[/._@-])(bot|automation|svc|service-account)([/._@-]|$)`,
+		},
+	}
+}
+
+// CompileOriginatorPatterns compiles originator patterns for efficient matching
+func CompileOriginatorPatterns(config OriginatorConfig) (*CompiledOriginatorPatterns, error) {
+	compiled := &CompiledOriginatorPatterns{}
+	var problems []error
+
+	for _, pattern := range config.TerraformPatterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("invalid terraform originator pattern '%s': %v", pattern, err))
+			continue
+		}
+		compiled.Terraform = append(compiled.Terraform, regex)
+	}
+
+	for _, pattern := range config.AutomationPatterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("invalid automation originator pattern '%s': %v", pattern, err))
+			continue
+		}
+		compiled.Automation = append(compiled.Automation, regex)
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return compiled, nil
+}
+
+// ClassifyOriginator classifies a creating principal as human, automation, or terraform
+// Terraform patterns are checked first because Terraform usually runs under automation accounts
+func ClassifyOriginator(createdBy string, compiled *CompiledOriginatorPatterns) string {
+	if createdBy == "" {
+		return OriginatorUnknown
+	}
+
+	for _, regex := range compiled.Terraform {
+		if regex.MatchString(createdBy) {
+			return OriginatorTerraform
+		}
+	}
+
+	for _, regex := range compiled.Automation {
+		if regex.MatchString(createdBy) {
+			return OriginatorAutomation
+		}
+	}
+
+	return OriginatorHuman
+}
+
+// ApplyOriginatorClassification annotates resources carrying a created_by enrichment with an originator_class
+func ApplyOriginatorClassification(resources []ResourceInfo, compiled *CompiledOriginatorPatterns) {
+	for _, resource := range resources {
+		createdBy, ok := resource.AdditionalInfo["created_by"].(string)
+		if !ok || createdBy == "" {
+			continue
+		}
+		resource.AdditionalInfo["originator_class"] = ClassifyOriginator(createdBy, compiled)
+	}
+}
+
+// getOriginatorClass returns the originator class recorded on a resource
+func getOriginatorClass(resource ResourceInfo) string {
+	if class, ok := resource.AdditionalInfo["originator_class"].(string); ok && class != "" {
+		return class
+	}
+	return OriginatorUnknown
+}
@@ -0,0 +1,114 @@
+package ocidump
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+func TestClassifyOriginator(t *testing.T) {
+	compiled, err := CompileOriginatorPatterns(getDefaultOriginatorConfig())
+	if err != nil {
+		t.Fatalf("CompileOriginatorPatterns() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		createdBy string
+		expected  string
+	}{
+		{"empty principal", "", OriginatorUnknown},
+		{"human user", "oracleidentitycloudservice/alice@example.com", OriginatorHuman},
+		{"terraform user", "oracleidentitycloudservice/terraform-ci@example.com", OriginatorTerraform},
+		{"resource manager stack", "ocid1.ormstack.oc1..aaaa", OriginatorTerraform},
+		{"instance principal", "ocid1.instance.oc1.ap-tokyo-1.aaaa", OriginatorAutomation},
+		{"automation account", "default/svc-backup", OriginatorAutomation},
+		{"bot account", "default/deploy-bot@example.com", OriginatorAutomation},
+		{"automation segment", "oracleidentitycloudservice/automation.runner", OriginatorAutomation},
+		{"human name containing bot", "default/jane.abbott@corp.com", OriginatorHuman},
+		{"human surname ending in bot", "default/talbot", OriginatorHuman},
+		{"human name starting with bot", "default/botha@example.com", OriginatorHuman},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ClassifyOriginator(tt.createdBy, compiled)
+			if result != tt.expected {
+				t.Errorf("ClassifyOriginator(%q) = %q, want %q", tt.createdBy, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompileOriginatorPatterns_InvalidPattern(t *testing.T) {
+	config := OriginatorConfig{
+		AutomationPatterns: []string{"[invalid-regex"},
+	}
+
+	_, err := CompileOriginatorPatterns(config)
+	if err == nil {
+		t.Error("CompileOriginatorPatterns() error = nil, want error for invalid regex")
+	}
+}
+
+func TestApplyOriginatorClassification(t *testing.T) {
+	compiled, err := CompileOriginatorPatterns(getDefaultOriginatorConfig())
+	if err != nil {
+		t.Fatalf("CompileOriginatorPatterns() error = %v", err)
+	}
+
+	resources := []ResourceInfo{
+		{OCID: "ocid1.instance.oc1..test1", AdditionalInfo: map[string]interface{}{"created_by": "terraform-ci"}},
+		{OCID: "ocid1.instance.oc1..test2", AdditionalInfo: map[string]interface{}{}},
+	}
+
+	ApplyOriginatorClassification(resources, compiled)
+
+	if class := resources[0].AdditionalInfo["originator_class"]; class != OriginatorTerraform {
+		t.Errorf("originator_class = %v, want %s", class, OriginatorTerraform)
+	}
+	if _, exists := resources[1].AdditionalInfo["originator_class"]; exists {
+		t.Error("originator_class should not be set when created_by is missing")
+	}
+}
+
+func TestApplyOriginatorClassification_DiscoveredResources(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	createdBy := func(vcn core.Vcn, principal string) core.Vcn {
+		vcn.DefinedTags = map[string]map[string]interface{}{"Oracle-Tags": {"CreatedBy": principal}}
+		return vcn
+	}
+	network := &fakeVirtualNetwork{vcns: []core.Vcn{
+		createdBy(fakeVcn("ocid1.vcn.oc1..tf", "vcn-tf", prod, core.VcnLifecycleStateAvailable), "ormstack-ci"),
+		createdBy(fakeVcn("ocid1.vcn.oc1..human", "vcn-human", prod, core.VcnLifecycleStateAvailable), "oracleidentitycloudservice/alice@example.com"),
+		fakeVcn("ocid1.vcn.oc1..untagged", "vcn-untagged", prod, core.VcnLifecycleStateAvailable),
+	}}
+	clients := newFakeOCIClients(&fakeIdentity{compartments: []identity.Compartment{fakeCompartment(prod, "prod")}}, network, &fakeCompute{})
+
+	resources, err := discoverVCNs(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverVCNs() error = %v", err)
+	}
+	compiled, err := CompileOriginatorPatterns(getDefaultOriginatorConfig())
+	if err != nil {
+		t.Fatalf("CompileOriginatorPatterns() error = %v", err)
+	}
+	ApplyOriginatorClassification(resources, compiled)
+
+	classes := make(map[string]interface{})
+	for _, resource := range resources {
+		classes[resource.ResourceName] = resource.AdditionalInfo["originator_class"]
+	}
+	if classes["vcn-tf"] != OriginatorTerraform || classes["vcn-human"] != OriginatorHuman || classes["vcn-untagged"] != nil {
+		t.Errorf("originator classes = %v", classes)
+	}
+
+	result := DiffResources(nil, resources, "old.json", "new.json", DiffConfig{})
+	byOriginator := result.Summary.ByOriginator
+	if byOriginator[OriginatorTerraform].Added != 1 || byOriginator[OriginatorHuman].Added != 1 || byOriginator[OriginatorUnknown].Added != 1 {
+		t.Errorf("by_originator = %+v", byOriginator)
+	}
+}
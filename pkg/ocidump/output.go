@@ -0,0 +1,269 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// outputJSON outputs resources in JSON format with pretty printing and sorted fields
+func outputJSON(resources []ResourceInfo) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(resources)
+}
+
+// outputCSV outputs resources in CSV format with headers and improved formatting
+func outputCSV(resources []ResourceInfo, dialect CSVDialect) error {
+	return writeCSV(resources, os.Stdout, dialect)
+}
+
+// writeCSV writes resources as CSV using the given dialect
+func writeCSV(resources []ResourceInfo, w io.Writer, dialect CSVDialect) error {
+	writer, err := newCSVResourceWriter(w, dialect)
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(resources); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// outputTSV outputs resources in TSV (Tab-Separated Values) format with improved formatting
+func outputTSV(resources []ResourceInfo) error {
+	// Write header
+	fmt.Println("ResourceType\tCompartmentName\tResourceName\tOCID\tCompartmentID\tTimeCreated\tLifecycleState\tAdditionalInfo\tFreeformTags\tDefinedTags")
+
+	// Write data
+	for _, resource := range resources {
+		additionalInfoFormatted := formatAdditionalInfo(resource.AdditionalInfo)
+		fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			escapeTSVField(resource.ResourceType),
+			escapeTSVField(resource.CompartmentName),
+			escapeTSVField(resource.ResourceName),
+			escapeTSVField(resource.OCID),
+			escapeTSVField(resource.CompartmentID),
+			escapeTSVField(resource.TimeCreated),
+			escapeTSVField(resource.LifecycleState),
+			escapeTSVField(additionalInfoFormatted),
+			escapeTSVField(formatFreeformTags(resource.FreeformTags)),
+			escapeTSVField(formatDefinedTags(resource.DefinedTags)),
+		)
+	}
+
+	return nil
+}
+
+// NDJSONWriter writes resources as newline-delimited JSON, one object per line.
+// It is safe for concurrent use so discovery workers can stream resources as they are found.
+type NDJSONWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	count   int
+}
+
+// NewNDJSONWriter creates an NDJSON writer on top of the given writer
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &NDJSONWriter{encoder: encoder}
+}
+
+// Write writes each resource as a single JSON line
+func (w *NDJSONWriter) Write(resources []ResourceInfo) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, resource := range resources {
+		if err := w.encoder.Encode(resource); err != nil {
+			return err
+		}
+		w.count++
+	}
+	return nil
+}
+
+// Count returns the number of resources written so far
+func (w *NDJSONWriter) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count
+}
+
+// Close completes the output; NDJSON needs no trailer
+func (w *NDJSONWriter) Close() error {
+	return nil
+}
+
+// outputNDJSON outputs resources in newline-delimited JSON format
+func outputNDJSON(resources []ResourceInfo, w io.Writer) error {
+	return NewNDJSONWriter(w).Write(resources)
+}
+
+// OutputResources routes output to the appropriate format function (stdout)
+func OutputResources(resources []ResourceInfo, format string, csvDialect CSVDialect) error {
+	switch format {
+	case "json":
+		return outputJSON(resources)
+	case "ndjson":
+		return outputNDJSON(resources, os.Stdout)
+	case "openmetrics":
+		return outputOpenMetrics(resources, os.Stdout)
+	case "csv":
+		return outputCSV(resources, csvDialect)
+	case "tsv":
+		return outputTSV(resources)
+	case "dot", "mermaid":
+		return WriteGraph(BuildResourceGraph(resources, false), format, os.Stdout)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// stdoutPath is the output path that explicitly selects standard output
+const stdoutPath = "-"
+
+// IsStdoutPath checks if an output path refers to standard output (empty or "-")
+func IsStdoutPath(path string) bool {
+	return path == "" || path == stdoutPath
+}
+
+// CloseOutputFile flushes file contents to disk before closing it
+func CloseOutputFile(file *os.File) error {
+	syncErr := file.Sync()
+	closeErr := file.Close()
+	if syncErr != nil {
+		return fmt.Errorf("failed to sync output file: %w", syncErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close output file: %w", closeErr)
+	}
+	return nil
+}
+
+// CopyFileToStdout writes the contents of a written output file to stdout
+func CopyFileToStdout(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(os.Stdout, file)
+	return err
+}
+
+// OutputResourcesToFile outputs resources to a file in the specified format
+func OutputResourcesToFile(resources []ResourceInfo, format, filename string, csvDialect CSVDialect) error {
+	if IsStdoutPath(filename) {
+		return OutputResources(resources, format, csvDialect)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	switch format {
+	case "json":
+		err = outputJSONToFile(resources, file)
+	case "ndjson":
+		err = outputNDJSON(resources, file)
+	case "openmetrics":
+		err = outputOpenMetrics(resources, file)
+	case "csv":
+		err = outputCSVToFile(resources, file, csvDialect)
+	case "tsv":
+		err = outputTSVToFile(resources, file)
+	case "dot", "mermaid":
+		err = WriteGraph(BuildResourceGraph(resources, false), format, file)
+	default:
+		err = fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return CloseOutputFile(file)
+}
+
+// outputJSONToFile outputs resources in JSON format to a file with improved formatting
+func outputJSONToFile(resources []ResourceInfo, file *os.File) error {
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(resources)
+}
+
+// outputCSVToFile outputs resources in CSV format to a file with improved formatting
+func outputCSVToFile(resources []ResourceInfo, file *os.File, dialect CSVDialect) error {
+	return writeCSV(resources, file, dialect)
+}
+
+// outputTSVToFile outputs resources in TSV format to a file with improved formatting
+func outputTSVToFile(resources []ResourceInfo, file *os.File) error {
+	// Write header
+	if _, err := fmt.Fprintln(file, "ResourceType\tCompartmentName\tResourceName\tOCID\tCompartmentID\tTimeCreated\tLifecycleState\tAdditionalInfo\tFreeformTags\tDefinedTags"); err != nil {
+		return err
+	}
+
+	// Write data
+	for _, resource := range resources {
+		additionalInfoFormatted := formatAdditionalInfo(resource.AdditionalInfo)
+		if _, err := fmt.Fprintf(file, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			escapeTSVField(resource.ResourceType),
+			escapeTSVField(resource.CompartmentName),
+			escapeTSVField(resource.ResourceName),
+			escapeTSVField(resource.OCID),
+			escapeTSVField(resource.CompartmentID),
+			escapeTSVField(resource.TimeCreated),
+			escapeTSVField(resource.LifecycleState),
+			escapeTSVField(additionalInfoFormatted),
+			escapeTSVField(formatFreeformTags(resource.FreeformTags)),
+			escapeTSVField(formatDefinedTags(resource.DefinedTags)),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatFreeformTags formats freeform tags as sorted key=value pairs separated by semicolons
+func formatFreeformTags(tags map[string]string) string {
+	parts := make([]string, 0, len(tags))
+	for key, value := range tags {
+		parts = append(parts, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ";")
+}
+
+// formatDefinedTags formats defined tags as sorted namespace.key=value pairs separated by semicolons
+func formatDefinedTags(tags map[string]map[string]interface{}) string {
+	var parts []string
+	for namespace, namespaceTags := range tags {
+		for key, value := range namespaceTags {
+			parts = append(parts, fmt.Sprintf("%s.%s=%v", namespace, key, value))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ";")
+}
+
+// escapeTSVField escapes tab characters and newlines in TSV fields
+func escapeTSVField(field string) string {
+	// Replace tabs with spaces and newlines with spaces for TSV compatibility
+	field = strings.ReplaceAll(field, "\t", " ")
+	field = strings.ReplaceAll(field, "\n", " ")
+	field = strings.ReplaceAll(field, "\r", " ")
+	return field
+}
@@ -1,4 +1,4 @@
-package main
+package ocidump
 
 import (
 	"encoding/csv"
@@ -84,7 +84,7 @@ func TestOutputCSV(t *testing.T) {
 	}
 
 	// outputCSV関数はstdoutに直接出力するため、エラーがないことのみ確認
-	err := outputCSV(resources)
+	err := outputCSV(resources, CSVDialect{})
 	if err != nil {
 		t.Errorf("outputCSV() error = %v, want nil", err)
 	}
@@ -164,6 +164,19 @@ func TestFormatAdditionalInfo(t *testing.T) {
 			},
 			expected: "count: 5, enabled: true, name: test", // Updated to match actual output format
 		},
+		{
+			name: "resolved names before other keys",
+			input: map[string]interface{}{
+				"availability_domain": "AD-1",
+				"fault_domain":        "FAULT-DOMAIN-1",
+				"image_id":            "ocid1.image.oc1..img",
+				"subnet_id":           "ocid1.subnet.oc1..app",
+				"subnet_name":         "app",
+				"nsg_ids":             []string{"ocid1.nsg.oc1..web"},
+				"nsg_names":           []string{"web"},
+			},
+			expected: "nsg_names: [web], subnet_name: app, availability_domain: AD-1, fault_domain: FAULT-DOMAIN-1, image_id: ocid1.image.oc1..img",
+		},
 	}
 
 	for _, tt := range tests {
@@ -271,6 +284,8 @@ func TestOutputCSVToFile(t *testing.T) {
 			ResourceName:    "web-lb",
 			OCID:            "ocid1.loadbalancer.oc1.ap-tokyo-1.test123",
 			CompartmentID:   "ocid1.compartment.oc1..test456",
+			TimeCreated:     "2024-05-01T09:30:00Z",
+			LifecycleState:  "ACTIVE",
 			AdditionalInfo:  map[string]interface{}{"shape": "100Mbps", "ip_version": "IPv4"},
 		},
 	}
@@ -284,7 +299,7 @@ func TestOutputCSVToFile(t *testing.T) {
 	defer tmpFile.Close()
 
 	// Test outputCSVToFile
-	err = outputCSVToFile(resources, tmpFile)
+	err = outputCSVToFile(resources, tmpFile, CSVDialect{})
 	if err != nil {
 		t.Errorf("outputCSVToFile() error = %v, want nil", err)
 	}
@@ -298,7 +313,7 @@ func TestOutputCSVToFile(t *testing.T) {
 	}
 
 	// Validate header row
-	expectedHeaders := []string{"ResourceType", "CompartmentName", "ResourceName", "OCID", "CompartmentID", "AdditionalInfo"}
+	expectedHeaders := []string{"ResourceType", "CompartmentName", "ResourceName", "OCID", "CompartmentID", "TimeCreated", "LifecycleState", "AdditionalInfo", "FreeformTags", "DefinedTags"}
 	if len(records) < 2 {
 		t.Fatalf("Expected at least 2 records (header + data), got %d", len(records))
 	}
@@ -325,6 +340,44 @@ func TestOutputCSVToFile(t *testing.T) {
 	if dataRow[2] != "web-lb" {
 		t.Errorf("ResourceName = %q, want %q", dataRow[2], "web-lb")
 	}
+	if dataRow[5] != "2024-05-01T09:30:00Z" || dataRow[6] != "ACTIVE" {
+		t.Errorf("TimeCreated, LifecycleState = %q, %q, want %q, %q", dataRow[5], dataRow[6], "2024-05-01T09:30:00Z", "ACTIVE")
+	}
+}
+
+// TestWriteCSV_RelationshipNames tests that names resolved for related resources are written
+func TestWriteCSV_RelationshipNames(t *testing.T) {
+	resources := []ResourceInfo{{
+		ResourceType: "ComputeInstance",
+		ResourceName: "web-1",
+		OCID:         "ocid1.instance.oc1..web",
+		AdditionalInfo: map[string]interface{}{
+			"shape":               "VM.Standard.E4.Flex",
+			"availability_domain": "AD-1",
+			"boot_volume_id":      "ocid1.bootvolume.oc1..boot",
+			"fault_domain":        "FAULT-DOMAIN-1",
+			"image_id":            "ocid1.image.oc1..img",
+			"subnet_id":           "ocid1.subnet.oc1..app",
+			"vcn_id":              "ocid1.vcn.oc1..main",
+		},
+	}}
+	cache := &ResourceNameCache{cache: map[string]string{"ocid1.subnet.oc1..app": "app-subnet", "ocid1.vcn.oc1..main": "main-vcn"}}
+	resolveRelationshipNames(resources, cache)
+
+	var out strings.Builder
+	if err := writeCSV(resources, &out, CSVDialect{}); err != nil {
+		t.Fatalf("writeCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	additionalInfo := records[1][7]
+	for _, want := range []string{"subnet_name: app-subnet", "vcn_name: main-vcn"} {
+		if !strings.Contains(additionalInfo, want) {
+			t.Errorf("AdditionalInfo = %q, want it to contain %q", additionalInfo, want)
+		}
+	}
 }
 
 // TestOutputTSVToFile tests TSV output to file with tab separation validation
@@ -336,6 +389,8 @@ func TestOutputTSVToFile(t *testing.T) {
 			ResourceName:    "main-db",
 			OCID:            "ocid1.dbsystem.oc1.ap-tokyo-1.test123",
 			CompartmentID:   "ocid1.compartment.oc1..test456",
+			TimeCreated:     "2023-11-20T00:00:00Z",
+			LifecycleState:  "AVAILABLE",
 			AdditionalInfo:  map[string]interface{}{"shape": "VM.Standard2.4", "edition": "ENTERPRISE_EDITION"},
 		},
 	}
@@ -368,7 +423,7 @@ func TestOutputTSVToFile(t *testing.T) {
 
 	// Validate header line
 	headerFields := strings.Split(lines[0], "\t")
-	expectedHeaders := []string{"ResourceType", "CompartmentName", "ResourceName", "OCID", "CompartmentID", "AdditionalInfo"}
+	expectedHeaders := []string{"ResourceType", "CompartmentName", "ResourceName", "OCID", "CompartmentID", "TimeCreated", "LifecycleState", "AdditionalInfo", "FreeformTags", "DefinedTags"}
 
 	if len(headerFields) != len(expectedHeaders) {
 		t.Errorf("Expected %d header fields, got %d", len(expectedHeaders), len(headerFields))
@@ -382,8 +437,8 @@ func TestOutputTSVToFile(t *testing.T) {
 
 	// Validate data line
 	dataFields := strings.Split(lines[1], "\t")
-	if len(dataFields) < 6 {
-		t.Fatalf("Expected at least 6 data fields, got %d", len(dataFields))
+	if len(dataFields) < 8 {
+		t.Fatalf("Expected at least 8 data fields, got %d", len(dataFields))
 	}
 
 	if dataFields[0] != "DatabaseSystem" {
@@ -395,4 +450,151 @@ func TestOutputTSVToFile(t *testing.T) {
 	if dataFields[2] != "main-db" {
 		t.Errorf("ResourceName = %q, want %q", dataFields[2], "main-db")
 	}
+	if dataFields[5] != "2023-11-20T00:00:00Z" || dataFields[6] != "AVAILABLE" {
+		t.Errorf("TimeCreated, LifecycleState = %q, %q, want %q, %q", dataFields[5], dataFields[6], "2023-11-20T00:00:00Z", "AVAILABLE")
+	}
+}
+
+// TestIsStdoutPath tests recognition of stdout output paths
+func TestIsStdoutPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"", true},
+		{"-", true},
+		{"resources.json", false},
+		{"./-", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsStdoutPath(tt.path); got != tt.want {
+			t.Errorf("IsStdoutPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestOutputResourcesToFile tests that file output is fully written and closed
+func TestOutputResourcesToFile(t *testing.T) {
+	resources := []ResourceInfo{
+		{
+			ResourceType:    "VCN",
+			CompartmentName: "network",
+			ResourceName:    "main-vcn",
+			OCID:            "ocid1.vcn.oc1.ap-tokyo-1.test789",
+			CompartmentID:   "ocid1.compartment.oc1..test321",
+			AdditionalInfo:  map[string]interface{}{"cidr_block": "10.0.0.0/16"},
+		},
+	}
+
+	filename := t.TempDir() + "/resources.json"
+	if err := OutputResourcesToFile(resources, "json", filename, CSVDialect{}); err != nil {
+		t.Fatalf("OutputResourcesToFile() error = %v, want nil", err)
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	var parsedResources []ResourceInfo
+	if err := json.Unmarshal(content, &parsedResources); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(parsedResources) != 1 || parsedResources[0].ResourceName != "main-vcn" {
+		t.Errorf("Unexpected output resources: %+v", parsedResources)
+	}
+
+	if err := OutputResourcesToFile(resources, "xml", t.TempDir()+"/resources.xml", CSVDialect{}); err == nil {
+		t.Error("OutputResourcesToFile() with unsupported format should return error")
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf strings.Builder
+	writer := NewNDJSONWriter(&buf)
+
+	batches := [][]ResourceInfo{
+		{
+			{ResourceType: "VCN", ResourceName: "main-vcn", OCID: "ocid1.vcn.oc1.ap-tokyo-1.test1"},
+			{ResourceType: "Subnet", ResourceName: "public <subnet>", OCID: "ocid1.subnet.oc1.ap-tokyo-1.test2"},
+		},
+		{
+			{ResourceType: "ComputeInstance", ResourceName: "web-1", OCID: "ocid1.instance.oc1.ap-tokyo-1.test3"},
+		},
+	}
+	for _, batch := range batches {
+		if err := writer.Write(batch); err != nil {
+			t.Fatalf("Write() error = %v, want nil", err)
+		}
+	}
+
+	if writer.Count() != 3 {
+		t.Errorf("Count() = %d, want 3", writer.Count())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[1], "public <subnet>") {
+		t.Errorf("HTML characters should not be escaped, got %s", lines[1])
+	}
+	for i, line := range lines {
+		var resource ResourceInfo
+		if err := json.Unmarshal([]byte(line), &resource); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i, err)
+		}
+	}
+}
+
+func TestFormatTags(t *testing.T) {
+	freeform := map[string]string{"team": "platform", "env": "prod"}
+	if got := formatFreeformTags(freeform); got != "env=prod;team=platform" {
+		t.Errorf("formatFreeformTags() = %q, want %q", got, "env=prod;team=platform")
+	}
+	if got := formatFreeformTags(nil); got != "" {
+		t.Errorf("formatFreeformTags(nil) = %q, want empty", got)
+	}
+
+	defined := map[string]map[string]interface{}{
+		"Oracle-Tags": {"CreatedBy": "user@example.com"},
+		"Finance":     {"CostCenter": "1234"},
+	}
+	expected := "Finance.CostCenter=1234;Oracle-Tags.CreatedBy=user@example.com"
+	if got := formatDefinedTags(defined); got != expected {
+		t.Errorf("formatDefinedTags() = %q, want %q", got, expected)
+	}
+}
+
+func TestCopyFileToStdout(t *testing.T) {
+	filename := t.TempDir() + "/resources.json"
+	if err := os.WriteFile(filename, []byte(`[{"resource_type":"VCN"}]`+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write output file: %v", err)
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	copyErr := CopyFileToStdout(filename)
+	os.Stdout = stdout
+	writer.Close()
+
+	if copyErr != nil {
+		t.Fatalf("CopyFileToStdout() error = %v, want nil", copyErr)
+	}
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read stdout: %v", err)
+	}
+	if string(output) != `[{"resource_type":"VCN"}]`+"\n" {
+		t.Errorf("CopyFileToStdout() wrote %q", string(output))
+	}
+
+	if err := CopyFileToStdout(t.TempDir() + "/missing.json"); err == nil {
+		t.Error("CopyFileToStdout() error = nil, want error for missing file")
+	}
 }
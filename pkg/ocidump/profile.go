@@ -0,0 +1,134 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"time"
+)
+
+// ProfileConfig selects the profiles written or served while the tool runs
+type ProfileConfig struct {
+	PprofAddr string // Serve the net/http/pprof endpoints under /debug/pprof/ on this address
+	CPUFile   string // Write a CPU profile of the whole run to this file
+	MemFile   string // Write a heap profile to this file when the run ends
+}
+
+// Profiler collects the profiles of a run; Stop writes them
+type Profiler struct {
+	config  ProfileConfig
+	cpuFile *os.File
+	server  *http.Server
+	addr    string // Address the pprof server listens on
+}
+
+// StartProfiling starts the profiles selected by config
+// Nothing is started when config is empty; Stop is safe to call on the result either way.
+func StartProfiling(config ProfileConfig) (*Profiler, error) {
+	p := &Profiler{config: config}
+
+	if config.PprofAddr != "" {
+		listener, err := net.Listen("tcp", config.PprofAddr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen for pprof on %s: %w", config.PprofAddr, err)
+		}
+		p.addr = listener.Addr().String()
+		server := &http.Server{Handler: pprofHandler(), ReadHeaderTimeout: 10 * time.Second}
+		p.server = server
+		go func() {
+			if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Warning: pprof server stopped: %v", err)
+			}
+		}()
+		logger.Info("Serving pprof on http://%s/debug/pprof/", p.addr)
+	}
+
+	if config.CPUFile != "" {
+		file, err := os.Create(config.CPUFile)
+		if err != nil {
+			p.Stop()
+			return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := runtimepprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			p.Stop()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		p.cpuFile = file
+	}
+
+	return p, nil
+}
+
+// pprofHandler serves the pprof endpoints without registering them on http.DefaultServeMux
+func pprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// Stop ends the CPU profile, writes the heap profile and shuts the pprof server down
+// It returns the first error; the remaining profiles are still written.
+func (p *Profiler) Stop() error {
+	if p == nil {
+		return nil
+	}
+	var errs []error
+
+	if p.cpuFile != nil {
+		runtimepprof.StopCPUProfile()
+		if err := p.cpuFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write CPU profile: %w", err))
+		} else {
+			logger.Verbose("CPU profile written to %s", p.config.CPUFile)
+		}
+		p.cpuFile = nil
+	}
+
+	if p.config.MemFile != "" {
+		if err := writeHeapProfile(p.config.MemFile); err != nil {
+			errs = append(errs, err)
+		} else {
+			logger.Verbose("Heap profile written to %s", p.config.MemFile)
+		}
+		p.config.MemFile = ""
+	}
+
+	if p.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := p.server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop pprof server: %w", err))
+		}
+		p.server = nil
+	}
+
+	return errors.Join(errs...)
+}
+
+// writeHeapProfile writes the live heap after a garbage collection
+func writeHeapProfile(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create heap profile: %w", err)
+	}
+	runtime.GC()
+	if err := runtimepprof.WriteHeapProfile(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write heap profile: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write heap profile: %w", err)
+	}
+	return nil
+}
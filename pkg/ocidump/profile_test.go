@@ -0,0 +1,72 @@
+package ocidump
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStartProfiling(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	dir := t.TempDir()
+	config := ProfileConfig{
+		PprofAddr: "127.0.0.1:0",
+		CPUFile:   filepath.Join(dir, "cpu.pprof"),
+		MemFile:   filepath.Join(dir, "mem.pprof"),
+	}
+	profiler, err := StartProfiling(config)
+	if err != nil {
+		t.Fatalf("StartProfiling() error = %v", err)
+	}
+
+	resp, err := http.Get("http://" + profiler.addr + "/debug/pprof/")
+	if err != nil {
+		t.Fatalf("pprof index request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "goroutine") {
+		t.Errorf("pprof index = %d %q", resp.StatusCode, body)
+	}
+
+	if err := profiler.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	for _, file := range []string{config.CPUFile, config.MemFile} {
+		if info, err := os.Stat(file); err != nil || info.Size() == 0 {
+			t.Errorf("profile %s not written: %v", file, err)
+		}
+	}
+	if _, err := http.Get("http://" + profiler.addr + "/debug/pprof/"); err == nil {
+		t.Error("pprof server still running after Stop")
+	}
+
+	// Stopping twice and stopping nothing are no-ops
+	if err := profiler.Stop(); err != nil {
+		t.Errorf("second Stop() error = %v", err)
+	}
+	var nilProfiler *Profiler
+	if err := nilProfiler.Stop(); err != nil {
+		t.Errorf("nil Stop() error = %v", err)
+	}
+}
+
+func TestStartProfiling_Errors(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	if _, err := StartProfiling(ProfileConfig{CPUFile: filepath.Join(t.TempDir(), "missing", "cpu.pprof")}); err == nil {
+		t.Error("expected an error for an uncreatable CPU profile")
+	}
+	if _, err := StartProfiling(ProfileConfig{PprofAddr: "invalid-address"}); err == nil {
+		t.Error("expected an error for an invalid pprof address")
+	}
+
+	// The CPU profile was released by the failed start
+	profiler, err := StartProfiling(ProfileConfig{CPUFile: filepath.Join(t.TempDir(), "cpu.pprof")})
+	if err != nil {
+		t.Fatalf("StartProfiling() after a failed start error = %v", err)
+	}
+	profiler.Stop()
+}
@@ -0,0 +1,73 @@
+package ocidump
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// protoFieldPattern matches a field of a proto3 message, capturing its type and name
+var protoFieldPattern = regexp.MustCompile(`^\s*(repeated\s+)?(map<[^>]+>|[\w.]+)\s+(\w+)\s*=\s*\d+;`)
+
+// protoMessageFields returns the fields of a message in a proto file, keyed by name with the type as value
+func protoMessageFields(t *testing.T, filename, message string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", filename, err)
+	}
+
+	fields := make(map[string]string)
+	inMessage := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "message "+message+" {") {
+			inMessage = true
+			continue
+		}
+		if !inMessage {
+			continue
+		}
+		if strings.HasPrefix(line, "}") {
+			return fields
+		}
+		if match := protoFieldPattern.FindStringSubmatch(line); match != nil {
+			fields[match[3]] = match[1] + match[2]
+		}
+	}
+	t.Fatalf("Message %s not found in %s", message, filename)
+	return nil
+}
+
+func TestProtoFilters_MatchFilterConfig(t *testing.T) {
+	fields := protoMessageFields(t, "../../proto/ocidump/v1/ocidump.proto", "Filters")
+
+	protoTypes := map[reflect.Kind]string{
+		reflect.String:  "string",
+		reflect.Bool:    "bool",
+		reflect.Float64: "double",
+		reflect.Slice:   "repeated string",
+		reflect.Map:     "map<string, string>",
+	}
+
+	// Every setting of the filters section must be available over RPC with a matching type
+	configType := reflect.TypeOf(FilterConfig{})
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		name := field.Tag.Get("yaml")
+		protoType, ok := fields[name]
+		if !ok {
+			t.Errorf("Filters message has no field %s for FilterConfig.%s", name, field.Name)
+			continue
+		}
+		if expected := protoTypes[field.Type.Kind()]; protoType != expected {
+			t.Errorf("Filters.%s has type %q, want %q for FilterConfig.%s", name, protoType, expected, field.Name)
+		}
+		delete(fields, name)
+	}
+
+	for name := range fields {
+		t.Errorf("Filters.%s has no FilterConfig field", name)
+	}
+}
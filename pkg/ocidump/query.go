@@ -0,0 +1,120 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// Query is a compiled JMESPath expression applied to the final resource array by --query
+// Expressions are evaluated by go-jmespath, which implements the full JMESPath specification
+// and its built-in functions
+type Query struct {
+	expression string
+	compiled   *jmespath.JMESPath
+}
+
+// CompileQuery parses a JMESPath expression
+func CompileQuery(expression string) (*Query, error) {
+	compiled, err := jmespath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	return &Query{expression: expression, compiled: compiled}, nil
+}
+
+// String returns the source expression
+func (q *Query) String() string {
+	return q.expression
+}
+
+// Search evaluates the query against decoded JSON data
+func (q *Query) Search(data interface{}) (interface{}, error) {
+	result, err := q.compiled.Search(data)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	return result, nil
+}
+
+// QueryResources evaluates the query against the resources as they appear in the JSON output
+func QueryResources(query *Query, resources []ResourceInfo) (interface{}, error) {
+	if resources == nil {
+		resources = []ResourceInfo{}
+	}
+	encoded, err := json.Marshal(resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resources for query: %w", err)
+	}
+	var data interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode resources for query: %w", err)
+	}
+	return query.Search(data)
+}
+
+// Matches evaluates the query against a single resource as it appears in the JSON output and
+// reports whether the result is truthy
+func (q *Query) Matches(resource ResourceInfo) (bool, error) {
+	encoded, err := json.Marshal(resource)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode resource for query: %w", err)
+	}
+	var data interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return false, fmt.Errorf("failed to decode resource for query: %w", err)
+	}
+	result, err := q.Search(data)
+	if err != nil {
+		return false, err
+	}
+	return isQueryTruthy(result), nil
+}
+
+// OutputQueryResult writes the query result as JSON to stdout or to a file
+func OutputQueryResult(result interface{}, filename string) error {
+	if IsStdoutPath(filename) {
+		return writeQueryResult(result, os.Stdout)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := writeQueryResult(result, file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return CloseOutputFile(file)
+}
+
+// writeQueryResult encodes the query result with the same formatting as the json output
+func writeQueryResult(result interface{}, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(result)
+}
+
+// isQueryTruthy reports whether a value is true in the JMESPath sense
+// false, null, "", [] and {} are false; everything else (including 0) is true
+func isQueryTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
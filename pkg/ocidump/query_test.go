@@ -0,0 +1,120 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testQueryResources() []ResourceInfo {
+	return []ResourceInfo{
+		{ResourceType: "ComputeInstance", ResourceName: "web-1", OCID: "ocid1.instance.oc1..a", CompartmentName: "app",
+			LifecycleState: "RUNNING", TimeCreated: "2024-01-10T00:00:00Z", FreeformTags: map[string]string{"env": "prod"},
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard.E4.Flex", "ocpus": 2}},
+		{ResourceType: "ComputeInstance", ResourceName: "web-2", OCID: "ocid1.instance.oc1..b", CompartmentName: "app",
+			LifecycleState: "STOPPED", TimeCreated: "2023-06-01T00:00:00Z",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard.E4.Flex", "ocpus": 4}},
+		{ResourceType: "VCN", ResourceName: "main", OCID: "ocid1.vcn.oc1..c", CompartmentName: "network",
+			LifecycleState: "AVAILABLE", TimeCreated: "2022-03-15T00:00:00Z"},
+	}
+}
+
+func runTestQuery(t *testing.T, expression string) interface{} {
+	t.Helper()
+	query, err := CompileQuery(expression)
+	if err != nil {
+		t.Fatalf("CompileQuery(%q) error = %v, want nil", expression, err)
+	}
+	result, err := QueryResources(query, testQueryResources())
+	if err != nil {
+		t.Fatalf("QueryResources(%q) error = %v, want nil", expression, err)
+	}
+	return result
+}
+
+func TestQueryResources(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"[].ocid", `["ocid1.instance.oc1..a","ocid1.instance.oc1..b","ocid1.vcn.oc1..c"]`},
+		{"[*].resource_name", `["web-1","web-2","main"]`},
+		{"[0].resource_name", `"web-1"`},
+		{"[-1].resource_type", `"VCN"`},
+		{"[1:].resource_name", `["web-2","main"]`},
+		{"[::-1].resource_name", `["main","web-2","web-1"]`},
+		{"[?resource_type=='ComputeInstance'].resource_name", `["web-1","web-2"]`},
+		{"[?lifecycle_state!='RUNNING' && resource_type=='ComputeInstance'].resource_name", `["web-2"]`},
+		{"[?additional_info.ocpus > `2`].resource_name", `["web-2"]`},
+		{"[0].merge(freeform_tags, `{\"owner\": \"ops\"}`)", `{"env":"prod","owner":"ops"}`},
+		{"map(&ceil(additional_info.ocpus), [?additional_info])", `[2,4]`},
+		{"[?freeform_tags.env=='prod'].ocid", `["ocid1.instance.oc1..a"]`},
+		{"[?!freeform_tags].resource_name", `["web-2","main"]`},
+		{"[].{name: resource_name, state: lifecycle_state}[0]", `{"name":"web-1","state":"RUNNING"}`},
+		{"[].[resource_name, compartment_name] | [2]", `["main","network"]`},
+		{"length([?resource_type=='VCN'])", `1`},
+		{"sort_by(@, &time_created)[].resource_name", `["main","web-2","web-1"]`},
+		{"max_by(@, &time_created).resource_name", `"web-1"`},
+		{"sum([].additional_info.ocpus)", `6`},
+		{"[?starts_with(resource_name, 'web')] | length(@)", `2`},
+		{"join(', ', sort([].compartment_name))", `"app, app, network"`},
+		{"[0].keys(freeform_tags)", `["env"]`},
+		{"[].additional_info.shape", `["VM.Standard.E4.Flex","VM.Standard.E4.Flex"]`},
+		{"[?resource_type=='DbSystem']", `[]`},
+		{"[0].\"resource_type\"", `"ComputeInstance"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			result := runTestQuery(t, tt.expression)
+
+			var expected interface{}
+			if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+				t.Fatalf("invalid expected JSON %q: %v", tt.expected, err)
+			}
+			if !reflect.DeepEqual(result, expected) {
+				actual, _ := json.Marshal(result)
+				t.Errorf("query %q = %s, want %s", tt.expression, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompileQuery_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"[?resource_type=='VCN'",
+		"resource_type = 'VCN'",
+		"[].{name}",
+		"'unterminated",
+	}
+
+	for _, expression := range tests {
+		if _, err := CompileQuery(expression); err == nil {
+			t.Errorf("CompileQuery(%q) error = nil, want syntax error", expression)
+		} else if !strings.Contains(err.Error(), "query") {
+			t.Errorf("CompileQuery(%q) error = %v, want query error", expression, err)
+		}
+	}
+}
+
+func TestQueryResources_FunctionTypeError(t *testing.T) {
+	query, err := CompileQuery("length(`1`)")
+	if err != nil {
+		t.Fatalf("CompileQuery error = %v, want nil", err)
+	}
+	if _, err := QueryResources(query, nil); err == nil {
+		t.Error("QueryResources error = nil, want type error for length(number)")
+	}
+}
+
+func TestQueryResources_UnknownFunction(t *testing.T) {
+	query, err := CompileQuery("unknown_function(@)")
+	if err != nil {
+		t.Fatalf("CompileQuery error = %v, want nil", err)
+	}
+	if _, err := QueryResources(query, nil); err == nil || !strings.Contains(err.Error(), "unknown_function") {
+		t.Errorf("QueryResources error = %v, want unknown function error", err)
+	}
+}
@@ -0,0 +1,232 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// RateLimitConfig holds the request rates allowed per OCI service
+// Services are named by their SDK package: core (compute, networking and block storage),
+// identity, database, objectstorage, containerengine, loadbalancer, resourcesearch, ...
+type RateLimitConfig struct {
+	Default  float64            `yaml:"default"`  // Requests per second of services without their own rate (0 = unlimited)
+	Burst    int                `yaml:"burst"`    // Requests allowed at once before the rate applies (default: 1)
+	Services map[string]float64 `yaml:"services"` // Requests per second per service, e.g. core: 10 (0 = unlimited)
+
+	Adaptive       bool `yaml:"adaptive"`        // Adjust concurrent requests per service to 429 responses
+	MaxConcurrency int  `yaml:"max_concurrency"` // Upper bound of concurrent requests per service when adaptive (default: 16)
+}
+
+// Default upper bound of concurrent requests per service with adaptive concurrency
+const DefaultMaxConcurrency = 16
+
+// maxConcurrency returns the upper bound of concurrent requests per service
+func (c RateLimitConfig) maxConcurrency() int {
+	if c.MaxConcurrency > 0 {
+		return c.MaxConcurrency
+	}
+	return DefaultMaxConcurrency
+}
+
+// Enabled reports whether any service is rate limited or adaptive concurrency is on
+func (c RateLimitConfig) Enabled() bool {
+	if c.Default > 0 || c.Adaptive {
+		return true
+	}
+	for _, rate := range c.Services {
+		if rate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the request rates
+func (c RateLimitConfig) Validate() error {
+	var problems []error
+	if c.Default < 0 {
+		problems = append(problems, fmt.Errorf("rate_limit default must not be negative, got: %v", c.Default))
+	}
+	if c.Burst < 0 {
+		problems = append(problems, fmt.Errorf("rate_limit burst must not be negative, got: %d", c.Burst))
+	}
+	if c.MaxConcurrency < 0 {
+		problems = append(problems, fmt.Errorf("rate_limit max_concurrency must not be negative, got: %d", c.MaxConcurrency))
+	}
+	for _, service := range slices.Sorted(maps.Keys(c.Services)) {
+		if rate := c.Services[service]; rate < 0 {
+			problems = append(problems, fmt.Errorf("rate_limit for service '%s' must not be negative, got: %v", service, rate))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// RateLimiter throttles OCI API requests with a token bucket per service
+// All clients of a service share its bucket, so concurrent discoveries together stay within
+// the configured rate instead of triggering bursts of 429 responses in shared tenancies.
+// With adaptive concurrency it also limits the requests in flight per service, halving the
+// limit when the service responds with 429 and raising it again while requests succeed.
+type RateLimiter struct {
+	config  RateLimitConfig
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	limits  map[string]*adaptiveLimit
+}
+
+// NewRateLimiter returns a rate limiter for the configured rates, or nil when none is configured
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if !config.Enabled() {
+		return nil
+	}
+	return &RateLimiter{config: config, buckets: make(map[string]*tokenBucket), limits: make(map[string]*adaptiveLimit)}
+}
+
+// workers returns how many compartments are discovered at once
+// Adaptive concurrency keeps each service within its limit, so more compartments can run in parallel.
+func (l *RateLimiter) workers(defaultWorkers int) int {
+	if l == nil || !l.config.Adaptive {
+		return defaultWorkers
+	}
+	return max(defaultWorkers, l.config.maxConcurrency())
+}
+
+// Wait blocks until a request to the service is allowed or the context is done
+func (l *RateLimiter) Wait(ctx context.Context, service string) error {
+	if l == nil {
+		return nil
+	}
+	bucket := l.bucket(service)
+	if bucket == nil {
+		return nil
+	}
+	return bucket.wait(ctx)
+}
+
+// bucket returns the bucket of a service, or nil when the service is not limited
+func (l *RateLimiter) bucket(service string) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if bucket, ok := l.buckets[service]; ok {
+		return bucket
+	}
+
+	rate, ok := l.config.Services[service]
+	if !ok {
+		rate = l.config.Default
+	}
+	var bucket *tokenBucket
+	if rate > 0 {
+		bucket = newTokenBucket(rate, max(l.config.Burst, 1))
+		logger.Debug("Rate limiting %s requests to %v per second", service, rate)
+	}
+	l.buckets[service] = bucket
+	return bucket
+}
+
+// concurrency returns the adaptive concurrency limit of a service, or nil when it is not enabled
+func (l *RateLimiter) concurrency(service string) *adaptiveLimit {
+	if !l.config.Adaptive {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limit, ok := l.limits[service]
+	if !ok {
+		limit = newAdaptiveLimit(service, l.config.maxConcurrency())
+		l.limits[service] = limit
+	}
+	return limit
+}
+
+// Limit makes an SDK client wait for the rate limiter before every request, including retries
+// Discoverers that create their own clients call it with the SDK package name of the client.
+func (l *RateLimiter) Limit(baseClient *common.BaseClient, service string) {
+	if l == nil {
+		return
+	}
+	if l.config.Adaptive && baseClient.HTTPClient != nil {
+		baseClient.HTTPClient = &adaptiveDispatcher{dispatcher: baseClient.HTTPClient, service: service, limiter: l}
+	}
+	interceptor := baseClient.Interceptor
+	baseClient.Interceptor = func(request *http.Request) error {
+		if err := l.Wait(request.Context(), service); err != nil {
+			return err
+		}
+		if interceptor != nil {
+			return interceptor(request)
+		}
+		return nil
+	}
+}
+
+// limitClients applies the rate limiter to the SDK service clients of clients
+// The service of a client is the name of its SDK package.
+func (l *RateLimiter) limitClients(clients *OCIClients) {
+	if l == nil {
+		return
+	}
+	clients.updateBaseClients(l.Limit)
+}
+
+// tokenBucket allows rate requests per second with bursts of up to burst requests
+// Requests reserve a token right away and wait until it is available, so waiting
+// requests are served in order.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), now: time.Now}
+}
+
+// reserve takes a token and returns how long to wait until it is available
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+package ocidump
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	bucket := newTokenBucket(2, 2)
+	bucket.now = func() time.Time { return now }
+
+	// The burst is available at once, then requests are spaced by 1/rate
+	expected := []time.Duration{0, 0, 500 * time.Millisecond, time.Second}
+	for i, want := range expected {
+		if got := bucket.reserve(); got != want {
+			t.Errorf("reserve %d waits %v, expected %v", i, got, want)
+		}
+	}
+
+	// Tokens refill at the rate, up to the burst
+	now = now.Add(10 * time.Second)
+	for i := 0; i < 2; i++ {
+		if got := bucket.reserve(); got != 0 {
+			t.Errorf("reserve after refill waits %v, expected 0", got)
+		}
+	}
+	if got := bucket.reserve(); got != 500*time.Millisecond {
+		t.Errorf("reserve beyond burst waits %v, expected 500ms", got)
+	}
+}
+
+func TestTokenBucket_WaitCancelled(t *testing.T) {
+	bucket := newTokenBucket(0.001, 1)
+	if err := bucket.wait(context.Background()); err != nil {
+		t.Fatalf("first wait failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := bucket.wait(ctx); err != context.Canceled {
+		t.Errorf("wait = %v, expected context.Canceled", err)
+	}
+	// The cancelled reservation is returned, so the next request waits for one token only
+	if delay := bucket.reserve(); delay > 1001*time.Second {
+		t.Errorf("reserve after cancel waits %v", delay)
+	}
+}
+
+func TestRateLimiter_Services(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	if NewRateLimiter(RateLimitConfig{Services: map[string]float64{"core": 0}}) != nil {
+		t.Error("expected no rate limiter without any rate")
+	}
+
+	limiter := NewRateLimiter(RateLimitConfig{Default: 5, Services: map[string]float64{"core": 10, "identity": 0}})
+	if bucket := limiter.bucket("core"); bucket == nil || bucket.rate != 10 || bucket.burst != 1 {
+		t.Errorf("core bucket = %+v, expected rate 10 and burst 1", bucket)
+	}
+	if bucket := limiter.bucket("database"); bucket == nil || bucket.rate != 5 {
+		t.Errorf("database bucket = %+v, expected the default rate 5", bucket)
+	}
+	if limiter.bucket("identity") != nil {
+		t.Error("identity has rate 0 and must not be limited")
+	}
+	if limiter.bucket("core") != limiter.bucket("core") {
+		t.Error("clients of a service must share one bucket")
+	}
+
+	var nilLimiter *RateLimiter
+	if err := nilLimiter.Wait(context.Background(), "core"); err != nil {
+		t.Errorf("nil limiter Wait = %v", err)
+	}
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	limiter := NewRateLimiter(RateLimitConfig{Default: 0.001})
+
+	called := false
+	baseClient := common.BaseClient{Interceptor: func(*http.Request) error {
+		called = true
+		return nil
+	}}
+	limiter.Limit(&baseClient, "usageapi")
+
+	request, _ := http.NewRequest(http.MethodGet, "https://usageapi.example.com", nil)
+	if err := baseClient.Interceptor(request); err != nil || !called {
+		t.Fatalf("first request: err = %v, previous interceptor called = %v", err, called)
+	}
+
+	// The second request has to wait for the next token and gives up with its context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := baseClient.Interceptor(request.WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Errorf("second request = %v, expected context.DeadlineExceeded", err)
+	}
+}
+
+func TestRateLimiter_LimitClients(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	networkFake := &fakeVirtualNetwork{}
+	clients := &OCIClients{ComputeClient: core.ComputeClient{}, VirtualNetworkClient: networkFake}
+
+	limiter := NewRateLimiter(RateLimitConfig{Services: map[string]float64{"core": 1}})
+	limiter.limitClients(clients)
+
+	compute, ok := clients.ComputeClient.(core.ComputeClient)
+	if !ok || compute.Interceptor == nil {
+		t.Fatalf("compute client not limited: %#v", clients.ComputeClient)
+	}
+	if clients.VirtualNetworkClient != networkFake {
+		t.Error("clients that are not SDK clients must be left alone")
+	}
+	if len(limiter.buckets) != 0 {
+		t.Error("buckets must be created on the first request")
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://iaas.example.com", nil)
+	if err := compute.Interceptor(request); err != nil {
+		t.Fatal(err)
+	}
+	if limiter.buckets["core"] == nil {
+		t.Errorf("requests of the compute client must use the core bucket, buckets: %v", limiter.buckets)
+	}
+}
+
+func TestRateLimitConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RateLimitConfig
+		wantErr bool
+	}{
+		{"empty", RateLimitConfig{}, false},
+		{"rates", RateLimitConfig{Default: 5, Burst: 3, Services: map[string]float64{"core": 10, "database": 0.5}}, false},
+		{"negative default", RateLimitConfig{Default: -1}, true},
+		{"negative burst", RateLimitConfig{Burst: -1}, true},
+		{"negative service", RateLimitConfig{Services: map[string]float64{"core": -2}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
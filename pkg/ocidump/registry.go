@@ -0,0 +1,114 @@
+package ocidump
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Discoverer lists the resources of a single resource type in a compartment
+type Discoverer interface {
+	Discover(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error)
+}
+
+// DiscovererFunc adapts an ordinary function to the Discoverer interface
+type DiscovererFunc func(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error)
+
+// Discover calls f(ctx, clients, compartmentID)
+func (f DiscovererFunc) Discover(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+	return f(ctx, clients, compartmentID)
+}
+
+// RegisterOption configures how a registered resource type is exposed to filters
+type RegisterOption func(*registration)
+
+type registration struct {
+	aliases []string
+	optIn   bool
+}
+
+// WithAlias adds a CLI-friendly name (e.g. "my_widgets") usable in --resource-types
+// The first alias is used as the display name of the resource type
+func WithAlias(alias string) RegisterOption {
+	return func(r *registration) {
+		r.aliases = append(r.aliases, strings.ToLower(alias))
+	}
+}
+
+// OptIn marks the resource type as discovered only when explicitly listed in the include filter
+func OptIn() RegisterOption {
+	return func(r *registration) {
+		r.optIn = true
+	}
+}
+
+var (
+	registryMu  sync.RWMutex
+	discoverers = make(map[string]Discoverer)
+)
+
+// Register makes a discoverer available under the given internal resource type name (e.g. "VCNs").
+// It is intended to be called from init functions, so resource types can be added from other
+// packages or from files guarded by build tags without modifying discovery.go.
+// Register panics if the name is empty, already registered, or the discoverer is nil.
+func Register(name string, discoverer Discoverer, opts ...RegisterOption) {
+	if name == "" {
+		panic("ocidump: Register called with empty resource type name")
+	}
+	if discoverer == nil {
+		panic(fmt.Sprintf("ocidump: Register discoverer for %s is nil", name))
+	}
+
+	var reg registration
+	for _, opt := range opts {
+		opt(&reg)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := discoverers[name]; exists {
+		panic(fmt.Sprintf("ocidump: Register called twice for resource type %s", name))
+	}
+	discoverers[name] = discoverer
+
+	if !stringInSlice(name, supportedResourceTypes) {
+		supportedResourceTypes = append(supportedResourceTypes, name)
+	}
+	for _, alias := range reg.aliases {
+		resourceTypeAliases[alias] = name
+		if _, exists := reverseResourceTypeAliases[name]; !exists {
+			reverseResourceTypeAliases[name] = alias
+		}
+	}
+	if reg.optIn {
+		optInResourceTypes[name] = true
+	}
+}
+
+// RegisteredResourceTypes returns the sorted names of all registered resource types
+func RegisteredResourceTypes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(discoverers))
+	for name := range discoverers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// registeredDiscoverers returns a snapshot of the registry for a discovery run
+func registeredDiscoverers() map[string]Discoverer {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	snapshot := make(map[string]Discoverer, len(discoverers))
+	for name, discoverer := range discoverers {
+		snapshot[name] = discoverer
+	}
+	return snapshot
+}
@@ -0,0 +1,72 @@
+package ocidump
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	discoverer := DiscovererFunc(func(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+		return []ResourceInfo{{ResourceType: "TestWidget", OCID: "ocid1.widget.oc1..test", CompartmentID: compartmentID}}, nil
+	})
+	Register("TestWidgets", discoverer, WithAlias("test_widgets"), OptIn())
+
+	if !stringInSlice("TestWidgets", RegisteredResourceTypes()) {
+		t.Fatal("RegisteredResourceTypes() does not include TestWidgets")
+	}
+	if !isValidResourceType("test_widgets") {
+		t.Error("isValidResourceType(test_widgets) = false, want true")
+	}
+	if got := normalizeResourceType("TEST_WIDGETS"); got != "TestWidgets" {
+		t.Errorf("normalizeResourceType(TEST_WIDGETS) = %q, want TestWidgets", got)
+	}
+
+	// Opt-in types are skipped unless explicitly included
+	if ApplyResourceTypeFilter("TestWidgets", FilterConfig{}) {
+		t.Error("ApplyResourceTypeFilter() = true for opt-in type without include filter, want false")
+	}
+	if !ApplyResourceTypeFilter("TestWidgets", FilterConfig{IncludeResourceTypes: []string{"test_widgets"}}) {
+		t.Error("ApplyResourceTypeFilter() = false for explicitly included opt-in type, want true")
+	}
+
+	resources, err := registeredDiscoverers()["TestWidgets"].Discover(context.Background(), nil, "ocid1.compartment.oc1..test")
+	if err != nil || len(resources) != 1 || resources[0].CompartmentID != "ocid1.compartment.oc1..test" {
+		t.Errorf("registered discoverer returned %v, %v", resources, err)
+	}
+}
+
+func TestRegister_BuiltinTypes(t *testing.T) {
+	registered := RegisteredResourceTypes()
+	for _, name := range []string{"ComputeInstances", "VCNs", "PrivateIps"} {
+		if !stringInSlice(name, registered) {
+			t.Errorf("built-in resource type %s is not registered", name)
+		}
+	}
+}
+
+func TestRegister_Panics(t *testing.T) {
+	noop := DiscovererFunc(func(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+		return nil, nil
+	})
+
+	tests := []struct {
+		name       string
+		typeName   string
+		discoverer Discoverer
+	}{
+		{"duplicate", "VCNs", noop},
+		{"empty name", "", noop},
+		{"nil discoverer", "NilWidgets", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", tt.typeName)
+				}
+			}()
+			Register(tt.typeName, tt.discoverer)
+		})
+	}
+}
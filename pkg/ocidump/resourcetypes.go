@@ -0,0 +1,188 @@
+package ocidump
+
+import "sort"
+
+// ResourceTypeInfo describes a resource type accepted by the resource type filters
+type ResourceTypeInfo struct {
+	Name        string   `json:"name"`              // internal name, e.g. "ComputeInstances"
+	Alias       string   `json:"alias,omitempty"`   // CLI alias, e.g. "compute_instances"; types without one are named by Name
+	Aliases     []string `json:"aliases,omitempty"` // short aliases kept for compatibility
+	Groups      []string `json:"groups,omitempty"`  // groups the type belongs to
+	Permissions []string `json:"permissions"`       // IAM policy verbs and resource types discovery needs, if known
+	Default     bool     `json:"default"`           // discovered without include_resource_types
+}
+
+// resourceTypePermissions lists the IAM policy verbs and resource types each discovery needs,
+// as used in "allow group <group> to <verb> <resource-type> in tenancy"
+var resourceTypePermissions = map[string][]string{
+	"ComputeInstances":              {"inspect instances", "read vnic-attachments", "read vnics"},
+	"VCNs":                          {"inspect vcns"},
+	"Subnets":                       {"inspect subnets"},
+	"BlockVolumes":                  {"inspect volumes"},
+	"BootVolumes":                   {"inspect volumes"},
+	"BlockVolumeBackups":            {"inspect volume-backups"},
+	"BootVolumeBackups":             {"inspect boot-volume-backups"},
+	"ObjectStorageBuckets":          {"read objectstorage-namespaces", "read buckets"},
+	"OKEClusters":                   {"inspect clusters"},
+	"LoadBalancers":                 {"read load-balancers"},
+	"DatabaseSystems":               {"inspect db-systems"},
+	"DRGs":                          {"inspect drgs"},
+	"LocalPeeringGateways":          {"inspect local-peering-gateways"},
+	"AutonomousDatabases":           {"inspect autonomous-databases"},
+	"ExadataInfrastructures":        {"inspect exadata-infrastructures"},
+	"CloudExadataInfrastructures":   {"inspect cloud-exadata-infrastructures"},
+	"VmClusters":                    {"inspect vmclusters"},
+	"Databases":                     {"inspect vmclusters", "inspect databases"},
+	"DbHomes":                       {"inspect db-homes"},
+	"DbNodes":                       {"inspect db-systems", "inspect db-nodes"},
+	"Functions":                     {"inspect fn-app", "inspect fn-function"},
+	"APIGateways":                   {"inspect api-gateways"},
+	"FileStorageSystems":            {"inspect file-systems"},
+	"NetworkLoadBalancers":          {"inspect network-load-balancers"},
+	"Streams":                       {"read streams"},
+	"Users":                         {"inspect users", "inspect domains"},
+	"Groups":                        {"inspect groups", "inspect domains"},
+	"DynamicGroups":                 {"inspect dynamic-groups"},
+	"Policies":                      {"inspect policies"},
+	"OpenSearchClusters":            {"inspect opensearch-clusters"},
+	"OpenSearchClusterBackups":      {"inspect opensearch-cluster-backups"},
+	"Quotas":                        {"read quota"},
+	"TagNamespaces":                 {"inspect tag-namespaces"},
+	"TagDefinitions":                {"inspect tag-namespaces"},
+	"IdentityDomains":               {"inspect domains"},
+	"InstancePools":                 {"read instance-pools"},
+	"InstanceConfigurations":        {"read instance-configurations"},
+	"ClusterNetworks":               {"inspect cluster-networks"},
+	"VolumeGroups":                  {"inspect volume-groups"},
+	"VolumeGroupBackups":            {"inspect volume-group-backups"},
+	"MountTargets":                  {"inspect mount-targets"},
+	"FileStorageExports":            {"inspect export-sets"},
+	"FileStorageSnapshots":          {"inspect file-systems"},
+	"StreamPools":                   {"read stream-pools"},
+	"HealthChecks":                  {"read health-check-monitor"},
+	"ManagedInstances":              {"inspect osmh-managed-instances", "inspect instances"},
+	"ManagedInstanceGroups":         {"inspect osmh-managed-instance-groups"},
+	"DatabaseInsights":              {"inspect opsi-database-insights"},
+	"HostInsights":                  {"inspect opsi-host-insights"},
+	"ManagedDatabases":              {"inspect dbmgmt-managed-databases"},
+	"ExternalContainerDatabases":    {"inspect external-container-databases"},
+	"ExternalPluggableDatabases":    {"inspect external-pluggable-databases"},
+	"ExternalNonContainerDatabases": {"inspect external-non-container-databases"},
+	"ExadataDbServers":              {"inspect exadata-infrastructures", "inspect cloud-exadata-infrastructures", "inspect db-servers"},
+	"ExadataStorageServers":         {"inspect dbmgmt-family"},
+	"BlockchainPlatforms":           {"read blockchain-platforms"},
+	"PrivateIps":                    {"inspect subnets", "inspect private-ips"},
+	"Vnics":                         {"inspect vnic-attachments", "inspect subnets", "inspect private-ips", "read vnics"},
+}
+
+// resourceTypeServices maps each built-in resource type to the service that lists it, named like
+// the rate limiter names services: by the SDK package of the client
+var resourceTypeServices = map[string]string{
+	"ComputeInstances":              "core",
+	"VCNs":                          "core",
+	"Subnets":                       "core",
+	"BlockVolumes":                  "core",
+	"BootVolumes":                   "core",
+	"BlockVolumeBackups":            "core",
+	"BootVolumeBackups":             "core",
+	"ObjectStorageBuckets":          "objectstorage",
+	"OKEClusters":                   "containerengine",
+	"LoadBalancers":                 "loadbalancer",
+	"DatabaseSystems":               "database",
+	"DRGs":                          "core",
+	"LocalPeeringGateways":          "core",
+	"AutonomousDatabases":           "database",
+	"ExadataInfrastructures":        "database",
+	"CloudExadataInfrastructures":   "database",
+	"VmClusters":                    "database",
+	"Databases":                     "database",
+	"DbHomes":                       "database",
+	"DbNodes":                       "database",
+	"Functions":                     "functions",
+	"APIGateways":                   "apigateway",
+	"FileStorageSystems":            "filestorage",
+	"NetworkLoadBalancers":          "networkloadbalancer",
+	"Streams":                       "streaming",
+	"Users":                         "identity",
+	"Groups":                        "identity",
+	"DynamicGroups":                 "identity",
+	"Policies":                      "identity",
+	"OpenSearchClusters":            "opensearch",
+	"OpenSearchClusterBackups":      "opensearch",
+	"Quotas":                        "limits",
+	"TagNamespaces":                 "identity",
+	"TagDefinitions":                "identity",
+	"IdentityDomains":               "identity",
+	"InstancePools":                 "core",
+	"InstanceConfigurations":        "core",
+	"ClusterNetworks":               "core",
+	"VolumeGroups":                  "core",
+	"VolumeGroupBackups":            "core",
+	"MountTargets":                  "filestorage",
+	"FileStorageExports":            "filestorage",
+	"FileStorageSnapshots":          "filestorage",
+	"StreamPools":                   "streaming",
+	"HealthChecks":                  "healthchecks",
+	"ManagedInstances":              "osmanagementhub",
+	"ManagedInstanceGroups":         "osmanagementhub",
+	"DatabaseInsights":              "opsi",
+	"HostInsights":                  "opsi",
+	"ManagedDatabases":              "databasemanagement",
+	"ExternalContainerDatabases":    "database",
+	"ExternalPluggableDatabases":    "database",
+	"ExternalNonContainerDatabases": "database",
+	"ExadataDbServers":              "database",
+	"ExadataStorageServers":         "databasemanagement",
+	"BlockchainPlatforms":           "blockchain",
+	"PrivateIps":                    "core",
+	"Vnics":                         "core",
+}
+
+// resourceTypeService returns the service that lists a resource type
+// Registered resource types of other packages are treated as a service of their own.
+func resourceTypeService(resourceType string) string {
+	if service, ok := resourceTypeServices[resourceType]; ok {
+		return service
+	}
+	return resourceType
+}
+
+// FilterName returns the name to use for the resource type in --resource-types
+func (i ResourceTypeInfo) FilterName() string {
+	if i.Alias != "" {
+		return i.Alias
+	}
+	return i.Name
+}
+
+// SupportedResourceTypes returns every resource type accepted by the resource type filters,
+// including registered ones, sorted by internal name
+func SupportedResourceTypes() []ResourceTypeInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]ResourceTypeInfo, 0, len(supportedResourceTypes))
+	for _, name := range supportedResourceTypes {
+		info := ResourceTypeInfo{
+			Name:        name,
+			Alias:       reverseResourceTypeAliases[name],
+			Permissions: resourceTypePermissions[name],
+			Default:     !optInResourceTypes[name],
+		}
+		for alias, target := range resourceTypeAliases {
+			if target == name && alias != info.Alias {
+				info.Aliases = append(info.Aliases, alias)
+			}
+		}
+		sort.Strings(info.Aliases)
+		for _, group := range getResourceTypeGroupNames() {
+			if stringInSlice(name, resourceTypeGroups[group]) {
+				info.Groups = append(info.Groups, group)
+			}
+		}
+		types = append(types, info)
+	}
+
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types
+}
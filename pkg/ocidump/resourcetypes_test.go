@@ -0,0 +1,35 @@
+package ocidump
+
+import "testing"
+
+func TestSupportedResourceTypes(t *testing.T) {
+	types := SupportedResourceTypes()
+	if len(types) != len(supportedResourceTypes) {
+		t.Fatalf("SupportedResourceTypes() returned %d types, want %d", len(types), len(supportedResourceTypes))
+	}
+
+	byName := make(map[string]ResourceTypeInfo)
+	for i, info := range types {
+		if _, builtin := builtinDiscoverers[info.Name]; builtin && len(info.Permissions) == 0 {
+			t.Errorf("%s has no permissions", info.Name)
+		}
+		if i > 0 && types[i-1].Name >= info.Name {
+			t.Errorf("types are not sorted by name: %s before %s", types[i-1].Name, info.Name)
+		}
+		byName[info.Name] = info
+	}
+
+	storage := byName["ObjectStorageBuckets"]
+	if storage.Alias != "object_storage_buckets" || len(storage.Aliases) != 1 || storage.Aliases[0] != "object_storage" {
+		t.Errorf("unexpected aliases for ObjectStorageBuckets: %+v", storage)
+	}
+	if len(storage.Groups) != 1 || storage.Groups[0] != "storage" || !storage.Default {
+		t.Errorf("unexpected groups or default for ObjectStorageBuckets: %+v", storage)
+	}
+	if bootVolumes := byName["BootVolumes"]; bootVolumes.Alias != "" || bootVolumes.FilterName() != "BootVolumes" {
+		t.Errorf("unexpected alias for BootVolumes: %+v", bootVolumes)
+	}
+	if byName["Policies"].Default {
+		t.Error("Policies should be opt-in")
+	}
+}
@@ -0,0 +1,189 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// maxRetryAfter caps the delay a Retry-After header can ask for, so a misbehaving endpoint
+// cannot stall a discovery for long
+const maxRetryAfter = 2 * time.Minute
+
+// isTransientError checks if the error is transient and should be retried
+// OCI service errors are transient when throttled (429) or when the service failed (500, 502, 503, 504);
+// other service errors, e.g. 400, 401, 404 or 409, fail the same way on every attempt.
+// Errors that never reached the service are transient when the network failed.
+func isTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var serviceErr common.ServiceError
+	if errors.As(err, &serviceErr) {
+		switch serviceErr.GetHTTPStatusCode() {
+		case http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryDelay returns how long to wait before the next attempt of a failed operation
+// A Retry-After the service sent with the failed response is used as is; otherwise the delay
+// grows exponentially with the attempt (up to 30 seconds) with 10% jitter.
+func retryDelay(err error, attempt int) time.Duration {
+	if delay, ok := retryAfters.take(err); ok {
+		return delay
+	}
+
+	backoff := time.Duration(math.Min(math.Pow(2, float64(attempt)), 30)) * time.Second
+	jitter := time.Duration(float64(backoff) * 0.1 * (2*rand.Float64() - 1))
+	sleepTime := backoff + jitter
+	if sleepTime < 0 {
+		sleepTime = backoff
+	}
+	return sleepTime
+}
+
+// withRetryAndProgress executes an operation with retry logic and progress tracking
+func withRetryAndProgress(ctx context.Context, operation func() error, maxRetries int, operationName string, progressTracker interface{}) error {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		err := operation()
+		if err == nil {
+			return nil
+		}
+
+		// Don't retry if the error is not transient
+		if !isTransientError(err) {
+			return err
+		}
+
+		if attempt == maxRetries {
+			return fmt.Errorf("operation '%s' failed after %d attempts: %w", operationName, maxRetries+1, err)
+		}
+
+		sleepTime := retryDelay(err, attempt)
+		logger.Verbose("Retrying %s in %v (attempt %d/%d): %v", operationName, sleepTime, attempt+1, maxRetries+1, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sleepTime):
+		}
+	}
+	return nil
+}
+
+// withRetry executes an operation with retry logic for backward compatibility
+func withRetry(ctx context.Context, operation func() error, maxRetries int, operationName string) error {
+	return withRetryAndProgress(ctx, operation, maxRetries, operationName, nil)
+}
+
+// retryAfters holds the Retry-After of failed responses until their errors are retried
+var retryAfters = &retryAfterHints{delays: make(map[string]retryAfterHint)}
+
+// retryAfterHints maps the opc-request-id of failed responses to their Retry-After
+// The SDK turns a failed response into a ServiceError without its headers, but keeps the request
+// ID, so the transport records the Retry-After under it for the retry loop to look up.
+type retryAfterHints struct {
+	mu     sync.Mutex
+	delays map[string]retryAfterHint
+}
+
+type retryAfterHint struct {
+	delay    time.Duration
+	recorded time.Time
+}
+
+// record keeps the Retry-After of a response that failed with a transient status
+// Hints that were never taken, e.g. of operations without retries, are dropped once stale.
+func (h *retryAfterHints) record(response *http.Response, now time.Time) {
+	if response == nil || response.StatusCode < 400 {
+		return
+	}
+	requestID := response.Header.Get("opc-request-id")
+	delay, ok := parseRetryAfter(response.Header.Get("Retry-After"), now)
+	if requestID == "" || !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for id, hint := range h.delays {
+		if now.Sub(hint.recorded) > maxRetryAfter {
+			delete(h.delays, id)
+		}
+	}
+	h.delays[requestID] = retryAfterHint{delay: delay, recorded: now}
+}
+
+// take returns and forgets the Retry-After of the response that failed with err
+func (h *retryAfterHints) take(err error) (time.Duration, bool) {
+	var serviceErr common.ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.GetOpcRequestID() == "" {
+		return 0, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hint, ok := h.delays[serviceErr.GetOpcRequestID()]
+	delete(h.delays, serviceErr.GetOpcRequestID())
+	return hint.delay, ok
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or as an HTTP date, capped at maxRetryAfter
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = max(date.Sub(now), 0)
+	} else {
+		return 0, false
+	}
+	return min(delay, maxRetryAfter), true
+}
+
+// retryAfterTransport records the Retry-After of failed responses for withRetryAndProgress
+type retryAfterTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *retryAfterTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.transport.RoundTrip(request)
+	if err == nil {
+		retryAfters.record(response, time.Now())
+	}
+	return response, err
+}
+
+// Delegate returns the wrapped transport, so idle connections can still be closed
+func (t *retryAfterTransport) Delegate() http.RoundTripper {
+	return t.transport
+}
@@ -0,0 +1,202 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeServiceError is an OCI service error with a status code and request ID
+type fakeServiceError struct {
+	status    int
+	requestID string
+}
+
+func (e fakeServiceError) Error() string {
+	return fmt.Sprintf("Error returned by service. Http Status Code: %d. Opc request id: %s", e.status, e.requestID)
+}
+func (e fakeServiceError) GetHTTPStatusCode() int  { return e.status }
+func (e fakeServiceError) GetMessage() string      { return http.StatusText(e.status) }
+func (e fakeServiceError) GetCode() string         { return "Fake" }
+func (e fakeServiceError) GetOpcRequestID() string { return e.requestID }
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"throttled", fakeServiceError{status: 429}, true},
+		{"internal error", fakeServiceError{status: 500}, true},
+		{"unavailable", fakeServiceError{status: 503}, true},
+		{"gateway timeout", fakeServiceError{status: 504}, true},
+		{"wrapped unavailable", fmt.Errorf("failed to list VCNs: %w", fakeServiceError{status: 503}), true},
+		{"not found", fakeServiceError{status: 404}, false},
+		{"not authorized", fakeServiceError{status: 401}, false},
+		{"conflict", fakeServiceError{status: 409}, false},
+		{"request timeout", &url.Error{Op: "Get", URL: "https://iaas.example.com", Err: &net.OpError{Op: "dial", Err: errors.New("i/o timeout")}}, true},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{"truncated response", io.ErrUnexpectedEOF, true},
+		{"canceled", context.Canceled, false},
+		{"message mentioning 503", errors.New("invalid display name 503"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRetriableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"not found", fakeServiceError{status: 404}, true},
+		{"not authorized", fakeServiceError{status: 401}, true},
+		{"forbidden", fakeServiceError{status: 403}, true},
+		{"wrapped not found", fmt.Errorf("failed to list buckets: %w", fakeServiceError{status: 404}), true},
+		{"bad request", fakeServiceError{status: 400}, false},
+		{"unavailable", fakeServiceError{status: 503}, false},
+		{"message mentioning does not exist", errors.New("subnet does not exist in the cached VCN map"), false},
+		{"message mentioning NotAuthorized", fmt.Errorf("parse: %w", errors.New("NotAuthorizedOrNotFound")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetriableError(tt.err); got != tt.want {
+				t.Errorf("isRetriableError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"0", 0, true},
+		{"-1", 0, false},
+		{"3600", maxRetryAfter, true},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryAfterTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("opc-request-id", "throttled-request")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport, err := NewHTTPTransport(HTTPConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := transport.client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	delay, ok := retryAfters.take(fakeServiceError{status: 429, requestID: "throttled-request"})
+	if !ok || delay != 7*time.Second {
+		t.Errorf("recorded Retry-After = %v, %v, want 7s", delay, ok)
+	}
+	if _, ok := retryAfters.take(fakeServiceError{status: 429, requestID: "throttled-request"}); ok {
+		t.Error("a Retry-After must only be used for one retry")
+	}
+	if _, ok := retryAfters.take(errors.New("throttled")); ok {
+		t.Error("errors without a request ID have no Retry-After")
+	}
+}
+
+func TestRetryAfterHints_DropsStaleHints(t *testing.T) {
+	hints := &retryAfterHints{delays: make(map[string]retryAfterHint)}
+	response := func(requestID string) *http.Response {
+		header := http.Header{}
+		header.Set("opc-request-id", requestID)
+		header.Set("Retry-After", "1")
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header}
+	}
+
+	now := time.Now()
+	hints.record(response("old"), now)
+	hints.record(&http.Response{StatusCode: http.StatusOK, Header: response("ok").Header}, now)
+	hints.record(response("new"), now.Add(maxRetryAfter+time.Second))
+
+	if _, ok := hints.delays["old"]; ok {
+		t.Error("hints older than maxRetryAfter should be dropped")
+	}
+	if _, ok := hints.delays["ok"]; ok {
+		t.Error("successful responses should not be recorded")
+	}
+	if _, ok := hints.delays["new"]; !ok {
+		t.Error("the latest hint should be recorded")
+	}
+}
+
+func TestWithRetryAndProgress(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+
+	// A Retry-After of zero retries right away instead of backing off for a second
+	header := http.Header{}
+	header.Set("opc-request-id", "retry-now")
+	header.Set("Retry-After", "0")
+	retryAfters.record(&http.Response{StatusCode: http.StatusTooManyRequests, Header: header}, time.Now())
+
+	attempts := 0
+	start := time.Now()
+	err := withRetryAndProgress(context.Background(), func() error {
+		attempts++
+		if attempts == 1 {
+			return fakeServiceError{status: 429, requestID: "retry-now"}
+		}
+		return nil
+	}, 3, "throttled operation", nil)
+	if err != nil || attempts != 2 {
+		t.Errorf("withRetryAndProgress() = %v after %d attempts, want success after 2", err, attempts)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("retry waited %v despite Retry-After: 0", elapsed)
+	}
+
+	// Errors that fail the same way again are returned without retrying
+	attempts = 0
+	err = withRetryAndProgress(context.Background(), func() error {
+		attempts++
+		return fakeServiceError{status: 404, requestID: "missing"}
+	}, 3, "missing resource", nil)
+	if err == nil || attempts != 1 {
+		t.Errorf("withRetryAndProgress() = %v after %d attempts, want the error after 1", err, attempts)
+	}
+}
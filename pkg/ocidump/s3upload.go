@@ -0,0 +1,266 @@
+package ocidump
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3UploadConfig holds the settings for uploading output to an S3-compatible endpoint
+// Credentials fall back to the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+// environment variables so they do not have to be stored in the configuration file
+type S3UploadConfig struct {
+	Endpoint        string `yaml:"endpoint"`          // e.g. https://s3.ap-northeast-1.amazonaws.com or https://minio.example.com:9000
+	Bucket          string `yaml:"bucket"`            // Target bucket (empty = upload disabled)
+	Key             string `yaml:"key"`               // Object key (empty = output file name)
+	Region          string `yaml:"region"`            // Signing region (default: us-east-1)
+	PathStyle       bool   `yaml:"path_style"`        // Use path-style URLs (endpoint/bucket/key)
+	AccessKeyID     string `yaml:"access_key_id"`     // Access key (default: $AWS_ACCESS_KEY_ID)
+	SecretAccessKey string `yaml:"secret_access_key"` // Secret key (default: $AWS_SECRET_ACCESS_KEY)
+}
+
+// UploadConfig holds the optional upload targets for the output file
+type UploadConfig struct {
+	S3 S3UploadConfig `yaml:"s3"`
+}
+
+// s3Credentials holds the resolved credentials used to sign requests
+type s3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// s3DefaultRegion is the signing region used when none is configured
+const s3DefaultRegion = "us-east-1"
+
+// s3UploadTimeout bounds the duration of a single upload request
+const s3UploadTimeout = 5 * time.Minute
+
+// Enabled reports whether an S3 upload target is configured
+func (c S3UploadConfig) Enabled() bool {
+	return c.Bucket != ""
+}
+
+// Validate checks that the S3 upload configuration is complete
+func (c S3UploadConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	var problems []error
+	if c.Endpoint == "" {
+		problems = append(problems, fmt.Errorf("s3 upload requires an endpoint"))
+	} else if endpoint, err := url.Parse(c.Endpoint); err != nil || endpoint.Host == "" || (endpoint.Scheme != "https" && endpoint.Scheme != "http") {
+		problems = append(problems, fmt.Errorf("invalid s3 endpoint '%s', must be an http(s) URL", c.Endpoint))
+	}
+	if _, err := c.credentials(); err != nil {
+		problems = append(problems, err)
+	}
+	return errors.Join(problems...)
+}
+
+// credentials resolves the credentials from the configuration or the environment
+func (c S3UploadConfig) credentials() (s3Credentials, error) {
+	creds := s3Credentials{
+		AccessKeyID:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" {
+		creds.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if creds.SecretAccessKey == "" {
+		creds.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return creds, fmt.Errorf("s3 upload requires access_key_id and secret_access_key (or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY)")
+	}
+	return creds, nil
+}
+
+// objectURL builds the URL of the target object in path-style or virtual-hosted-style
+func (c S3UploadConfig) objectURL(key string) (*url.URL, error) {
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint '%s': %w", c.Endpoint, err)
+	}
+
+	objectURL := *endpoint
+	basePath := strings.TrimSuffix(endpoint.Path, "/")
+	if c.PathStyle {
+		objectURL.Path = basePath + "/" + c.Bucket + "/" + key
+	} else {
+		objectURL.Host = c.Bucket + "." + endpoint.Host
+		objectURL.Path = basePath + "/" + key
+	}
+	return &objectURL, nil
+}
+
+// UploadFileToS3 uploads a local file to the configured S3-compatible bucket
+// The file is streamed: it is read once to compute the payload hash and again while uploading,
+// so large dumps are never held in memory.
+func UploadFileToS3(ctx context.Context, config S3UploadConfig, filename, contentType string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read output file for upload: %w", err)
+	}
+	defer file.Close()
+
+	key := config.Key
+	if key == "" {
+		key = filepath.Base(filename)
+	}
+	return putS3Object(ctx, config, key, file, contentType)
+}
+
+// putS3Object uploads the content of body as an object with the given key to the configured bucket
+// body is read twice, once for the payload hash the signature covers and once for the request.
+func putS3Object(ctx context.Context, config S3UploadConfig, key string, body io.ReadSeeker, contentType string) error {
+	creds, err := config.credentials()
+	if err != nil {
+		return err
+	}
+
+	objectURL, err := config.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, body)
+	if err != nil {
+		return fmt.Errorf("failed to read upload content: %w", err)
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read upload content: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s3UploadTimeout)
+	defer cancel()
+
+	// The body is wrapped so the transport does not close it; the caller owns it
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), io.NopCloser(body))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	region := config.Region
+	if region == "" {
+		region = s3DefaultRegion
+	}
+	signS3Request(req, hex.EncodeToString(hash.Sum(nil)), creds, region, time.Now())
+
+	logger.Info("Uploading to %s", objectURL.Redacted())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload to s3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 upload failed with status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	logger.Verbose("Uploaded %d bytes to s3://%s/%s", size, config.Bucket, key)
+	return nil
+}
+
+// OutputContentType returns the MIME type of an output format
+func OutputContentType(format string) string {
+	switch format {
+	case "json":
+		return "application/json"
+	case "ndjson":
+		return "application/x-ndjson"
+	case "csv":
+		return "text/csv; charset=utf-8"
+	case "tsv":
+		return "text/tab-separated-values; charset=utf-8"
+	case "openmetrics":
+		return "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	case "dot":
+		return "text/vnd.graphviz; charset=utf-8"
+	case "mermaid":
+		return "text/plain; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// signS3Request signs a request with AWS Signature Version 4 for the s3 service
+// All headers already set on the request, plus host and the x-amz-* headers added here, are signed
+func signS3Request(req *http.Request, payloadHash string, creds s3Credentials, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	// Canonical headers: lower-case names, sorted, with trimmed values
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 computes HMAC-SHA256 of data with the given key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
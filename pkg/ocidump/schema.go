@@ -0,0 +1,221 @@
+package ocidump
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// dumpSchemaJSON is the published JSON Schema describing the dump file format
+//
+//go:embed oci-resource-dump.schema.json
+var dumpSchemaJSON []byte
+
+// jsonSchema is the subset of JSON Schema used by the dump schema
+// Supported keywords: type, required, properties, additionalProperties (boolean), items, minLength, pattern
+type jsonSchema struct {
+	Type                 interface{}            `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	MinLength            *int                   `json:"minLength"`
+	Pattern              string                 `json:"pattern"`
+}
+
+// SchemaViolation describes a single location in a document that does not match the schema
+type SchemaViolation struct {
+	Path    string
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// SchemaValidationError is returned when a dump does not conform to the schema
+type SchemaValidationError struct {
+	Violations []SchemaViolation
+}
+
+// maxReportedViolations limits how many violations are included in the error message
+const maxReportedViolations = 10
+
+func (e *SchemaValidationError) Error() string {
+	var messages []string
+	for i, violation := range e.Violations {
+		if i == maxReportedViolations {
+			messages = append(messages, fmt.Sprintf("... and %d more", len(e.Violations)-maxReportedViolations))
+			break
+		}
+		messages = append(messages, violation.String())
+	}
+	return fmt.Sprintf("dump does not match schema: %s", strings.Join(messages, "; "))
+}
+
+// DumpSchema returns the published JSON Schema describing the dump file format
+func DumpSchema() []byte {
+	return append([]byte(nil), dumpSchemaJSON...)
+}
+
+// loadDumpSchema parses the embedded dump schema
+func loadDumpSchema() (*jsonSchema, error) {
+	var schema jsonSchema
+	if err := json.Unmarshal(dumpSchemaJSON, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// ValidateDump checks that data is valid JSON and conforms to the dump schema
+func ValidateDump(data []byte) error {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			line, column := offsetToLineColumn(data, syntaxErr.Offset)
+			return fmt.Errorf("invalid JSON at line %d, column %d: %v", line, column, err)
+		}
+		return fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	schema, err := loadDumpSchema()
+	if err != nil {
+		return err
+	}
+
+	var violations []SchemaViolation
+	validateSchemaValue(schema, document, "$", &violations)
+	if len(violations) > 0 {
+		return &SchemaValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// ValidateDumpFile validates a dump file against the schema
+func ValidateDumpFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	return ValidateDump(data)
+}
+
+// offsetToLineColumn converts a byte offset into a 1-based line and column
+func offsetToLineColumn(data []byte, offset int64) (int, int) {
+	line, column := 1, 1
+	for i := int64(0); i < offset-1 && i < int64(len(data)); i++ {
+		if data[i] == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+// validateSchemaValue validates a decoded JSON value against a schema, collecting violations
+func validateSchemaValue(schema *jsonSchema, value interface{}, path string, violations *[]SchemaViolation) {
+	if schema == nil {
+		return
+	}
+
+	if types := schemaTypes(schema.Type); len(types) > 0 && !contains(types, jsonTypeOf(value)) {
+		*violations = append(*violations, SchemaViolation{
+			Path:    path,
+			Message: fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), jsonTypeOf(value)),
+		})
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if schema.MinLength != nil && len(v) < *schema.MinLength {
+			*violations = append(*violations, SchemaViolation{
+				Path:    path,
+				Message: fmt.Sprintf("must be at least %d characters long", *schema.MinLength),
+			})
+		}
+		if schema.Pattern != "" {
+			if matched, err := regexp.MatchString(schema.Pattern, v); err == nil && !matched {
+				*violations = append(*violations, SchemaViolation{
+					Path:    path,
+					Message: fmt.Sprintf("value %q does not match pattern %q", v, schema.Pattern),
+				})
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			validateSchemaValue(schema.Items, item, fmt.Sprintf("%s[%d]", path, i), violations)
+		}
+	case map[string]interface{}:
+		for _, field := range schema.Required {
+			if _, ok := v[field]; !ok {
+				*violations = append(*violations, SchemaViolation{
+					Path:    path,
+					Message: fmt.Sprintf("missing required field %q", field),
+				})
+			}
+		}
+
+		fields := make([]string, 0, len(v))
+		for field := range v {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			fieldSchema, ok := schema.Properties[field]
+			if !ok {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					*violations = append(*violations, SchemaViolation{
+						Path:    path,
+						Message: fmt.Sprintf("unexpected field %q", field),
+					})
+				}
+				continue
+			}
+			validateSchemaValue(fieldSchema, v[field], path+"."+field, violations)
+		}
+	}
+}
+
+// schemaTypes normalizes the "type" keyword, which may be a string or an array of strings
+func schemaTypes(schemaType interface{}) []string {
+	switch t := schemaType.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// jsonTypeOf returns the JSON Schema type name of a decoded JSON value
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
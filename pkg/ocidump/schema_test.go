@@ -0,0 +1,113 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDumpSchema_Embedded(t *testing.T) {
+	schema, err := loadDumpSchema()
+	if err != nil {
+		t.Fatalf("loadDumpSchema() error = %v, want nil", err)
+	}
+
+	// Every JSON field of ResourceInfo must be described by the schema
+	data, err := json.Marshal(ResourceInfo{})
+	if err != nil {
+		t.Fatalf("Failed to marshal ResourceInfo: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Failed to unmarshal ResourceInfo: %v", err)
+	}
+	for field := range fields {
+		if _, ok := schema.Items.Properties[field]; !ok {
+			t.Errorf("Schema does not describe ResourceInfo field %q", field)
+		}
+	}
+}
+
+func TestValidateDump(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantErr   bool
+		errSubstr string
+	}{
+		{
+			name:    "valid dump",
+			input:   `[{"resource_type": "VCN", "compartment_name": "net", "resource_name": "main", "ocid": "ocid1.vcn.oc1..a", "compartment_id": "ocid1.compartment.oc1..b", "additional_info": {"cidr_block": "10.0.0.0/16"}}]`,
+			wantErr: false,
+		},
+		{
+			name:    "null additional info",
+			input:   `[{"resource_type": "VCN", "ocid": "ocid1.vcn.oc1..a", "additional_info": null}]`,
+			wantErr: false,
+		},
+		{
+			name:    "empty dump",
+			input:   `[]`,
+			wantErr: false,
+		},
+		{
+			name:      "not an array",
+			input:     `{"resource_type": "VCN"}`,
+			wantErr:   true,
+			errSubstr: "$: expected array, got object",
+		},
+		{
+			name:      "missing ocid",
+			input:     `[{"resource_type": "VCN"}]`,
+			wantErr:   true,
+			errSubstr: `$[0]: missing required field "ocid"`,
+		},
+		{
+			name:      "wrong field type",
+			input:     `[{"resource_type": "VCN", "ocid": "ocid1.vcn.oc1..a", "resource_name": 42}]`,
+			wantErr:   true,
+			errSubstr: "$[0].resource_name: expected string, got number",
+		},
+		{
+			name:      "invalid ocid",
+			input:     `[{"resource_type": "VCN", "ocid": "vcn-1"}]`,
+			wantErr:   true,
+			errSubstr: "$[0].ocid",
+		},
+		{
+			name:      "syntax error",
+			input:     "[\n  {\"resource_type\": \"VCN\",}\n]",
+			wantErr:   true,
+			errSubstr: "invalid JSON at line 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDump([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateDump() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("ValidateDump() error = %q, want substring %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestValidateDump_ViolationLimit(t *testing.T) {
+	input := "[" + strings.Repeat(`{},`, maxReportedViolations) + "{}]"
+
+	err := ValidateDump([]byte(input))
+	var validationErr *SchemaValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateDump() error = %v, want *SchemaValidationError", err)
+	}
+	if len(validationErr.Violations) != 2*(maxReportedViolations+1) {
+		t.Errorf("Violations = %d, want %d", len(validationErr.Violations), 2*(maxReportedViolations+1))
+	}
+	if !strings.Contains(err.Error(), "... and 12 more") {
+		t.Errorf("Error() = %q, want truncated message", err.Error())
+	}
+}
@@ -0,0 +1,230 @@
+package ocidump
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/resourcesearch"
+)
+
+// Discovery modes
+const (
+	DiscoveryModeFull   = "full"   // Per-service List calls for every compartment and resource type (default)
+	DiscoveryModeSearch = "search" // Resource Search for searchable types, per-service discovery for the rest
+)
+
+// DiscoveryModes lists the supported discovery modes
+var DiscoveryModes = []string{DiscoveryModeFull, DiscoveryModeSearch}
+
+// searchResourceType maps a Resource Search type to the discovery name and ResourceType of this tool
+type searchResourceType struct {
+	name         string // internal discovery name, e.g. "VCNs"
+	resourceType string // ResourceInfo.ResourceType, e.g. "VCN"
+}
+
+// searchResourceTypes lists the resource types that can be enumerated with Resource Search
+// Resource types missing here have no search equivalent and are always discovered per service
+var searchResourceTypes = map[string]searchResourceType{
+	"Instance":                   {"ComputeInstances", "ComputeInstance"},
+	"Vcn":                        {"VCNs", "VCN"},
+	"Subnet":                     {"Subnets", "Subnet"},
+	"Volume":                     {"BlockVolumes", "BlockVolume"},
+	"BootVolume":                 {"BootVolumes", "BootVolume"},
+	"VolumeBackup":               {"BlockVolumeBackups", "BlockVolumeBackup"},
+	"BootVolumeBackup":           {"BootVolumeBackups", "BootVolumeBackup"},
+	"VolumeGroup":                {"VolumeGroups", "VolumeGroup"},
+	"VolumeGroupBackup":          {"VolumeGroupBackups", "VolumeGroupBackup"},
+	"Bucket":                     {"ObjectStorageBuckets", "ObjectStorageBucket"},
+	"ClustersCluster":            {"OKEClusters", "OKECluster"},
+	"LoadBalancer":               {"LoadBalancers", "LoadBalancer"},
+	"NetworkLoadBalancer":        {"NetworkLoadBalancers", "NetworkLoadBalancer"},
+	"Drg":                        {"DRGs", "DRG"},
+	"LocalPeeringGateway":        {"LocalPeeringGateways", "LocalPeeringGateway"},
+	"AutonomousDatabase":         {"AutonomousDatabases", "AutonomousDatabase"},
+	"ExadataInfrastructure":      {"ExadataInfrastructures", "ExadataInfrastructure"},
+	"CloudExadataInfrastructure": {"CloudExadataInfrastructures", "CloudExadataInfrastructure"},
+	"VmCluster":                  {"VmClusters", "VmCluster"},
+	"DbHome":                     {"DbHomes", "DbHome"},
+	"DbNode":                     {"DbNodes", "DbNode"},
+	"FunctionsFunction":          {"Functions", "Function"},
+	"ApiGateway":                 {"APIGateways", "APIGateway"},
+	"FileSystem":                 {"FileStorageSystems", "FileStorageSystem"},
+	"MountTarget":                {"MountTargets", "MountTarget"},
+	"Stream":                     {"Streams", "Stream"},
+	"StreamPool":                 {"StreamPools", "StreamPool"},
+	"User":                       {"Users", "User"},
+	"Group":                      {"Groups", "Group"},
+	"DynamicGroup":               {"DynamicGroups", "DynamicGroup"},
+	"Policy":                     {"Policies", "Policy"},
+	"TagNamespace":               {"TagNamespaces", "TagNamespace"},
+	"OpensearchCluster":          {"OpenSearchClusters", "OpenSearchCluster"},
+	"InstancePool":               {"InstancePools", "InstancePool"},
+	"InstanceConfiguration":      {"InstanceConfigurations", "InstanceConfiguration"},
+	"ClusterNetwork":             {"ClusterNetworks", "ClusterNetwork"},
+	"BlockchainPlatform":         {"BlockchainPlatforms", "BlockchainPlatform"},
+	"PrivateIp":                  {"PrivateIps", "PrivateIp"},
+}
+
+// detailResourceTypes are enriched by --detail and are discovered per service in search mode when it is set
+var detailResourceTypes = map[string]bool{
+	"LoadBalancers":        true,
+	"ObjectStorageBuckets": true,
+	"Streams":              true,
+}
+
+// searchPageLimit is the page size requested from Resource Search
+const searchPageLimit = 1000
+
+// searchDiscoverers runs a Resource Search for the searchable resource types that pass the filters
+// and returns discoverers in which those types are served from the search results
+// When the search cannot be used, the discoverers are returned unchanged so every type is discovered per service
+func searchDiscoverers(ctx context.Context, clients *OCIClients, discoverers map[string]Discoverer, filters FilterConfig) map[string]Discoverer {
+	client, err := resourcesearch.NewResourceSearchClientWithConfigurationProvider(clients.ConfigProvider)
+	if err != nil {
+		logger.Error("Warning: Could not create resource search client, using full discovery: %v", err)
+		return discoverers
+	}
+	clients.Transport.Apply(&client.BaseClient)
+	clients.RateLimiter.Limit(&client.BaseClient, "resourcesearch")
+
+	// Only query types the service knows; an unknown type would fail the whole query
+	supported, err := supportedSearchTypes(ctx, client)
+	if err != nil {
+		logger.Error("Warning: Could not list searchable resource types, using full discovery: %v", err)
+		return discoverers
+	}
+
+	var searchTypes []string
+	for searchType, mapping := range searchResourceTypes {
+		if !supported[searchType] {
+			logger.Debug("Resource search does not support %s, discovering %s per service", searchType, mapping.name)
+			continue
+		}
+		if _, registered := discoverers[mapping.name]; !registered || !ApplyResourceTypeFilter(mapping.name, filters) {
+			continue
+		}
+		if clients.Options.Detail && detailResourceTypes[mapping.name] {
+			continue
+		}
+		searchTypes = append(searchTypes, searchType)
+	}
+	if len(searchTypes) == 0 {
+		return discoverers
+	}
+	sort.Strings(searchTypes)
+
+	started := time.Now()
+	results, err := searchResources(ctx, client, searchQuery(searchTypes))
+	if err != nil {
+		logger.Error("Warning: %v, using full discovery", err)
+		return discoverers
+	}
+
+	// Group by compartment and resource type so the regular discovery loop can serve them
+	grouped := make(map[string][]ResourceInfo)
+	for _, summary := range results {
+		mapping, ok := searchResourceTypes[stringValue(summary.ResourceType)]
+		if !ok || summary.CompartmentId == nil {
+			continue
+		}
+		if state := strings.ToUpper(stringValue(summary.LifecycleState)); state == "TERMINATED" || state == "DELETED" {
+			continue
+		}
+		key := checkpointKey(*summary.CompartmentId, mapping.name)
+		grouped[key] = append(grouped[key], resourceFromSearchSummary(ctx, clients, summary, mapping.resourceType))
+	}
+	logger.Info("Resource search returned %d resources of %d types in %v", len(results), len(searchTypes), time.Since(started).Round(time.Millisecond))
+
+	result := make(map[string]Discoverer, len(discoverers))
+	for name, discoverer := range discoverers {
+		result[name] = discoverer
+	}
+	for _, searchType := range searchTypes {
+		name := searchResourceTypes[searchType].name
+		result[name] = DiscovererFunc(func(ctx context.Context, clients *OCIClients, compartmentID string) ([]ResourceInfo, error) {
+			return grouped[checkpointKey(compartmentID, name)], nil
+		})
+	}
+	return result
+}
+
+// supportedSearchTypes returns the resource types known to the Resource Search service
+func supportedSearchTypes(ctx context.Context, client resourcesearch.ResourceSearchClient) (map[string]bool, error) {
+	supported := make(map[string]bool)
+	var page *string
+	for {
+		resp, err := client.ListResourceTypes(ctx, resourcesearch.ListResourceTypesRequest{Page: page, Limit: common.Int(searchPageLimit)})
+		if err != nil {
+			return nil, err
+		}
+		for _, resourceType := range resp.Items {
+			supported[stringValue(resourceType.Name)] = true
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+	return supported, nil
+}
+
+// searchQuery builds the structured query for the given Resource Search types
+func searchQuery(searchTypes []string) string {
+	return fmt.Sprintf("query %s resources", strings.Join(searchTypes, ", "))
+}
+
+// searchResources runs a structured query and returns all pages of results
+func searchResources(ctx context.Context, client resourcesearch.ResourceSearchClient, query string) ([]resourcesearch.ResourceSummary, error) {
+	logger.Debug("Running resource search: %s", query)
+
+	var results []resourcesearch.ResourceSummary
+	var page *string
+	for {
+		var resp resourcesearch.SearchResourcesResponse
+		operation := func() error {
+			var err error
+			resp, err = client.SearchResources(ctx, resourcesearch.SearchResourcesRequest{
+				SearchDetails: resourcesearch.StructuredSearchDetails{Query: common.String(query)},
+				Limit:         common.Int(searchPageLimit),
+				Page:          page,
+			})
+			return err
+		}
+		if err := withRetryAndProgress(ctx, operation, 3, "resource search", nil); err != nil {
+			return nil, fmt.Errorf("resource search failed: %w", err)
+		}
+
+		results = append(results, resp.Items...)
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+	return results, nil
+}
+
+// resourceFromSearchSummary converts a Resource Search result into a ResourceInfo
+// Search results carry no service-specific attributes; only the availability domain is kept
+func resourceFromSearchSummary(ctx context.Context, clients *OCIClients, summary resourcesearch.ResourceSummary, resourceType string) ResourceInfo {
+	additionalInfo := make(map[string]interface{})
+	if summary.AvailabilityDomain != nil && *summary.AvailabilityDomain != "" {
+		additionalInfo["availability_domain"] = *summary.AvailabilityDomain
+	}
+
+	return createResourceInfo(ctx, resourceType, stringValue(summary.DisplayName), stringValue(summary.Identifier),
+		stringValue(summary.CompartmentId), additionalInfo, clients.CompartmentCache).
+		withLifecycle(summary.TimeCreated, stringValue(summary.LifecycleState)).
+		withTags(summary.FreeformTags, summary.DefinedTags)
+}
+
+// stringValue dereferences an optional SDK string
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,69 @@
+package ocidump
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/resourcesearch"
+)
+
+func TestSearchResourceTypes_MatchBuiltinDiscoverers(t *testing.T) {
+	names := make(map[string]bool)
+	for searchType, mapping := range searchResourceTypes {
+		if _, ok := builtinDiscoverers[mapping.name]; !ok {
+			t.Errorf("search type %s maps to unknown discoverer %s", searchType, mapping.name)
+		}
+		if names[mapping.name] {
+			t.Errorf("discoverer %s is mapped by more than one search type", mapping.name)
+		}
+		names[mapping.name] = true
+	}
+	for name := range detailResourceTypes {
+		if _, ok := builtinDiscoverers[name]; !ok {
+			t.Errorf("detail resource type %s is not a builtin discoverer", name)
+		}
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	if got := searchQuery([]string{"Subnet", "Vcn"}); got != "query Subnet, Vcn resources" {
+		t.Errorf("searchQuery() = %q", got)
+	}
+}
+
+func TestResourceFromSearchSummary(t *testing.T) {
+	clients := &OCIClients{CompartmentCache: &CompartmentNameCache{
+		cache: map[string]string{"ocid1.compartment.oc1..prod": "prod"},
+	}}
+	created := time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)
+	summary := resourcesearch.ResourceSummary{
+		ResourceType:       common.String("Instance"),
+		Identifier:         common.String("ocid1.instance.oc1..a"),
+		CompartmentId:      common.String("ocid1.compartment.oc1..prod"),
+		DisplayName:        common.String("web-1"),
+		AvailabilityDomain: common.String("AD-1"),
+		LifecycleState:     common.String("RUNNING"),
+		TimeCreated:        &common.SDKTime{Time: created},
+		FreeformTags:       map[string]string{"env": "prod"},
+	}
+
+	resource := resourceFromSearchSummary(context.Background(), clients, summary, "ComputeInstance")
+
+	if resource.ResourceType != "ComputeInstance" || resource.ResourceName != "web-1" || resource.OCID != "ocid1.instance.oc1..a" {
+		t.Errorf("unexpected resource identity: %+v", resource)
+	}
+	if resource.CompartmentName != "prod" {
+		t.Errorf("CompartmentName = %q, expected prod", resource.CompartmentName)
+	}
+	if resource.AdditionalInfo["availability_domain"] != "AD-1" {
+		t.Errorf("AdditionalInfo = %v, expected availability_domain", resource.AdditionalInfo)
+	}
+	if resource.LifecycleState != "RUNNING" || resource.TimeCreated != "2025-01-15T06:00:00Z" {
+		t.Errorf("lifecycle = %q, %q", resource.LifecycleState, resource.TimeCreated)
+	}
+	if resource.FreeformTags["env"] != "prod" || resource.DefinedTags != nil {
+		t.Errorf("tags = %v, %v", resource.FreeformTags, resource.DefinedTags)
+	}
+}
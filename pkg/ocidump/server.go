@@ -0,0 +1,223 @@
+package ocidump
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultServerListen is the address the REST API listens on by default; only local clients can reach it
+const DefaultServerListen = "127.0.0.1:8080"
+
+// ServerConfig holds settings of the REST API server
+type ServerConfig struct {
+	Listen string `yaml:"listen"` // Address to listen on (default: 127.0.0.1:8080)
+	Token  string `yaml:"token"`  // Bearer token required by every endpoint except /healthz (default: OCI_DUMP_SERVER_TOKEN environment variable)
+
+	GRPCListen string `yaml:"grpc_listen"` // Address of the gRPC ResourceDumpService (default: not served)
+}
+
+// ListenAddr returns the configured listen address, defaulting to DefaultServerListen
+func (c ServerConfig) ListenAddr() string {
+	if c.Listen == "" {
+		return DefaultServerListen
+	}
+	return c.Listen
+}
+
+// BearerToken returns the configured token or the one from the environment
+func (c ServerConfig) BearerToken() string {
+	if c.Token != "" {
+		return c.Token
+	}
+	return os.Getenv("OCI_DUMP_SERVER_TOKEN")
+}
+
+// DiscoverFunc runs a discovery and returns the resources; used by the HTTP server
+type DiscoverFunc func(ctx context.Context) ([]ResourceInfo, error)
+
+// Snapshot is the result of a single discovery run held by the server
+type Snapshot struct {
+	Resources    []ResourceInfo
+	DiscoveredAt time.Time
+}
+
+// Server exposes discovery results over a REST API
+//
+//	GET  /resources  resources of the latest snapshot; query parameters: type, compartment (comma-separated)
+//	POST /dump       runs a discovery and stores the result as the latest snapshot
+//	GET  /diff       differences between the previous and the latest snapshot (detailed=true includes unchanged)
+//	GET  /healthz    liveness check
+//
+// Every endpoint except /healthz requires an "Authorization: Bearer <token>" header.
+// Only POST /dump runs a discovery, so read requests can never start one.
+type Server struct {
+	discover DiscoverFunc
+	token    string
+
+	dumpMu sync.Mutex // serializes discovery runs
+
+	mu       sync.RWMutex
+	current  *Snapshot
+	previous *Snapshot
+}
+
+// NewServer creates a server that runs discover on POST /dump and accepts requests carrying token
+// An empty token rejects every request except /healthz.
+func NewServer(discover DiscoverFunc, token string) *Server {
+	return &Server{discover: discover, token: token}
+}
+
+// LoadSnapshot sets the latest snapshot, e.g. from a dump file written by an earlier run
+func (s *Server) LoadSnapshot(resources []ResourceInfo, discoveredAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.previous = s.current
+	s.current = &Snapshot{Resources: resources, DiscoveredAt: discoveredAt}
+}
+
+// Handler returns the HTTP handler serving the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /resources", s.authorized(s.handleResources))
+	mux.HandleFunc("POST /dump", s.authorized(s.handleDump))
+	mux.HandleFunc("GET /diff", s.authorized(s.handleDiff))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return mux
+}
+
+// authorized wraps a handler so it only runs for requests carrying the server's bearer token
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="oci-resource-dump"`)
+			writeJSONError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// dump runs a discovery and rotates the snapshots
+func (s *Server) dump(ctx context.Context) (*Snapshot, error) {
+	s.dumpMu.Lock()
+	defer s.dumpMu.Unlock()
+
+	logger.Info("Starting resource discovery for API request")
+	resources, err := s.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resources == nil {
+		resources = []ResourceInfo{}
+	}
+
+	snapshot := &Snapshot{Resources: resources, DiscoveredAt: time.Now().UTC()}
+	s.mu.Lock()
+	s.previous = s.current
+	s.current = snapshot
+	s.mu.Unlock()
+
+	logger.Info("Discovery complete: %d resources", len(resources))
+	return snapshot, nil
+}
+
+// snapshots returns the previous and latest snapshots
+func (s *Server) snapshots() (*Snapshot, *Snapshot) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.previous, s.current
+}
+
+func (s *Server) handleResources(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("refresh") {
+		writeJSONError(w, http.StatusBadRequest, "refresh is not supported; run POST /dump to start a discovery")
+		return
+	}
+	_, snapshot := s.snapshots()
+	if snapshot == nil {
+		writeJSONError(w, http.StatusConflict, "no snapshot yet; run POST /dump first")
+		return
+	}
+
+	types := ParseResourceTypeList(r.URL.Query().Get("type"))
+	compartments := ParseCompartmentList(r.URL.Query().Get("compartment"))
+
+	resources := make([]ResourceInfo, 0, len(snapshot.Resources))
+	for _, resource := range snapshot.Resources {
+		if len(types) > 0 && !matchesAnyResourceType(resource.ResourceType, types) {
+			continue
+		}
+		if len(compartments) > 0 && !stringInSlice(resource.CompartmentID, compartments) && !stringInSlice(resource.CompartmentName, compartments) {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+
+	w.Header().Set("Last-Modified", snapshot.DiscoveredAt.Format(http.TimeFormat))
+	writeJSONResponse(w, http.StatusOK, resources)
+}
+
+func (s *Server) handleDump(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := s.dump(r.Context())
+	if err != nil {
+		writeJSONError(w, http.StatusBadGateway, "discovery failed: "+err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"resource_count": len(snapshot.Resources),
+		"discovered_at":  snapshot.DiscoveredAt.Format(time.RFC3339),
+	})
+}
+
+func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request) {
+	previous, current := s.snapshots()
+	if previous == nil || current == nil {
+		writeJSONError(w, http.StatusConflict, "diff requires two snapshots; run POST /dump first")
+		return
+	}
+
+	result := DiffResources(previous.Resources, current.Resources,
+		previous.DiscoveredAt.Format(time.RFC3339), current.DiscoveredAt.Format(time.RFC3339),
+		DiffConfig{Detailed: r.URL.Query().Get("detailed") == "true"})
+	writeJSONResponse(w, http.StatusOK, result)
+}
+
+// matchesAnyResourceType reports whether a resource type (e.g. "VCN") matches one of the requested
+// types, which may be CLI aliases ("vcns"), internal names ("VCNs") or resource types ("VCN")
+func matchesAnyResourceType(resourceType string, requested []string) bool {
+	singular := strings.ToLower(resourceType)
+	for _, name := range requested {
+		name = strings.ToLower(normalizeResourceType(name))
+		if name == singular || name == singular+"s" || name == singular+"es" ||
+			(strings.HasSuffix(singular, "y") && name == strings.TrimSuffix(singular, "y")+"ies") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSONResponse writes a value as a JSON response body
+func writeJSONResponse(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		logger.Verbose("Failed to write response: %v", err)
+	}
+}
+
+// writeJSONError writes an error response in the form {"error": "..."}
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSONResponse(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,195 @@
+package ocidump
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testServerToken is the bearer token of servers created by newTestServer
+const testServerToken = "test-token"
+
+func newTestServer(t *testing.T, runs [][]ResourceInfo) (*Server, *int) {
+	t.Helper()
+	logger = NewLogger(LogLevelSilent)
+	calls := 0
+	server := NewServer(func(ctx context.Context) ([]ResourceInfo, error) {
+		if calls >= len(runs) {
+			return nil, errors.New("no more test data")
+		}
+		calls++
+		return runs[calls-1], nil
+	}, testServerToken)
+	return server, &calls
+}
+
+func serveTestRequest(t *testing.T, handler http.Handler, method, target string, out interface{}) int {
+	t.Helper()
+	return serveTestRequestWithToken(t, handler, method, target, testServerToken, out)
+}
+
+func serveTestRequestWithToken(t *testing.T, handler http.Handler, method, target, token string, out interface{}) int {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	handler.ServeHTTP(recorder, request)
+	if out != nil {
+		if err := json.Unmarshal(recorder.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: failed to decode response %q: %v", method, target, recorder.Body.String(), err)
+		}
+	}
+	return recorder.Code
+}
+
+func TestServer_Resources(t *testing.T) {
+	resources := []ResourceInfo{
+		{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..a", CompartmentID: "ocid1.compartment.oc1..net", CompartmentName: "network"},
+		{ResourceType: "Subnet", OCID: "ocid1.subnet.oc1..b", CompartmentID: "ocid1.compartment.oc1..net", CompartmentName: "network"},
+		{ResourceType: "Policy", OCID: "ocid1.policy.oc1..c", CompartmentID: "ocid1.compartment.oc1..app", CompartmentName: "app"},
+	}
+	server, calls := newTestServer(t, [][]ResourceInfo{resources})
+	handler := server.Handler()
+
+	// Reads never start a discovery
+	var errorResponse map[string]string
+	if code := serveTestRequest(t, handler, http.MethodGet, "/resources", &errorResponse); code != http.StatusConflict {
+		t.Errorf("GET /resources without snapshot status = %d, want 409", code)
+	}
+	if code := serveTestRequest(t, handler, http.MethodGet, "/resources?refresh=true", &errorResponse); code != http.StatusBadRequest {
+		t.Errorf("GET /resources?refresh=true status = %d, want 400", code)
+	}
+	if *calls != 0 {
+		t.Fatalf("discovery ran %d times from GET requests, want 0", *calls)
+	}
+	if code := serveTestRequest(t, handler, http.MethodPost, "/dump", nil); code != http.StatusOK {
+		t.Fatalf("POST /dump status = %d, want 200", code)
+	}
+
+	tests := []struct {
+		target   string
+		expected []string
+	}{
+		{"/resources", []string{"ocid1.vcn.oc1..a", "ocid1.subnet.oc1..b", "ocid1.policy.oc1..c"}},
+		{"/resources?type=vcns", []string{"ocid1.vcn.oc1..a"}},
+		{"/resources?type=VCN,policies", []string{"ocid1.vcn.oc1..a", "ocid1.policy.oc1..c"}},
+		{"/resources?compartment=app", []string{"ocid1.policy.oc1..c"}},
+		{"/resources?type=subnets&compartment=ocid1.compartment.oc1..net", []string{"ocid1.subnet.oc1..b"}},
+		{"/resources?type=load_balancers", []string{}},
+	}
+
+	for _, tt := range tests {
+		var got []ResourceInfo
+		if code := serveTestRequest(t, handler, http.MethodGet, tt.target, &got); code != http.StatusOK {
+			t.Fatalf("GET %s status = %d, want 200", tt.target, code)
+		}
+		if len(got) != len(tt.expected) {
+			t.Errorf("GET %s returned %d resources, want %d", tt.target, len(got), len(tt.expected))
+			continue
+		}
+		for i, ocid := range tt.expected {
+			if got[i].OCID != ocid {
+				t.Errorf("GET %s [%d].ocid = %s, want %s", tt.target, i, got[i].OCID, ocid)
+			}
+		}
+	}
+
+	// The snapshot of the single dump is reused by every read
+	if *calls != 1 {
+		t.Errorf("discovery ran %d times, want 1", *calls)
+	}
+}
+
+func TestServer_DumpAndDiff(t *testing.T) {
+	server, _ := newTestServer(t, [][]ResourceInfo{
+		{{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..a"}},
+		{{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..a"}, {ResourceType: "Subnet", OCID: "ocid1.subnet.oc1..b"}},
+	})
+	handler := server.Handler()
+
+	var errorResponse map[string]string
+	if code := serveTestRequest(t, handler, http.MethodGet, "/diff", &errorResponse); code != http.StatusConflict {
+		t.Errorf("GET /diff without snapshots status = %d, want 409", code)
+	}
+
+	for i := 0; i < 2; i++ {
+		var dump map[string]interface{}
+		if code := serveTestRequest(t, handler, http.MethodPost, "/dump", &dump); code != http.StatusOK {
+			t.Fatalf("POST /dump status = %d, want 200", code)
+		}
+		if count := dump["resource_count"].(float64); int(count) != i+1 {
+			t.Errorf("POST /dump resource_count = %v, want %d", count, i+1)
+		}
+	}
+
+	var diff DiffResult
+	if code := serveTestRequest(t, handler, http.MethodGet, "/diff", &diff); code != http.StatusOK {
+		t.Fatalf("GET /diff status = %d, want 200", code)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].OCID != "ocid1.subnet.oc1..b" || len(diff.Removed) != 0 {
+		t.Errorf("GET /diff added = %v, removed = %v", diff.Added, diff.Removed)
+	}
+
+	// A failing discovery keeps the previous snapshots
+	if code := serveTestRequest(t, handler, http.MethodPost, "/dump", &errorResponse); code != http.StatusBadGateway {
+		t.Errorf("POST /dump with failing discovery status = %d, want 502", code)
+	}
+	if code := serveTestRequest(t, handler, http.MethodGet, "/diff", &diff); code != http.StatusOK {
+		t.Errorf("GET /diff after failed dump status = %d, want 200", code)
+	}
+
+	if code := serveTestRequest(t, handler, http.MethodGet, "/dump", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /dump status = %d, want 405", code)
+	}
+}
+
+func TestServer_LoadSnapshot(t *testing.T) {
+	server, calls := newTestServer(t, nil)
+	server.LoadSnapshot([]ResourceInfo{{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..a"}}, time.Now())
+
+	var got []ResourceInfo
+	if code := serveTestRequest(t, server.Handler(), http.MethodGet, "/resources", &got); code != http.StatusOK || len(got) != 1 {
+		t.Errorf("GET /resources from loaded snapshot = %d, %v", code, got)
+	}
+	if *calls != 0 {
+		t.Errorf("discovery ran %d times with a loaded snapshot, want 0", *calls)
+	}
+}
+
+func TestServer_Authentication(t *testing.T) {
+	server, calls := newTestServer(t, [][]ResourceInfo{{{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..a"}}})
+	handler := server.Handler()
+
+	for _, token := range []string{"", "wrong-token"} {
+		for _, endpoint := range []struct{ method, target string }{
+			{http.MethodGet, "/resources"},
+			{http.MethodPost, "/dump"},
+			{http.MethodGet, "/diff"},
+		} {
+			var errorResponse map[string]string
+			if code := serveTestRequestWithToken(t, handler, endpoint.method, endpoint.target, token, &errorResponse); code != http.StatusUnauthorized {
+				t.Errorf("%s %s with token %q status = %d, want 401", endpoint.method, endpoint.target, token, code)
+			}
+		}
+	}
+	if *calls != 0 {
+		t.Errorf("discovery ran %d times for unauthorized requests, want 0", *calls)
+	}
+
+	var health map[string]string
+	if code := serveTestRequestWithToken(t, handler, http.MethodGet, "/healthz", "", &health); code != http.StatusOK {
+		t.Errorf("GET /healthz without token status = %d, want 200", code)
+	}
+
+	// A server without a token rejects every request
+	open := NewServer(func(ctx context.Context) ([]ResourceInfo, error) { return nil, nil }, "")
+	if code := serveTestRequestWithToken(t, open.Handler(), http.MethodPost, "/dump", "", nil); code != http.StatusUnauthorized {
+		t.Errorf("POST /dump on server without token status = %d, want 401", code)
+	}
+}
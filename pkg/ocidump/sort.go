@@ -0,0 +1,87 @@
+package ocidump
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resourceSortKeys maps --sort-by keys to the comparison they perform
+// Each function returns a negative value, zero, or a positive value like strings.Compare
+var resourceSortKeys = map[string]func(a, b ResourceInfo) int{
+	"resource_type": func(a, b ResourceInfo) int {
+		return strings.Compare(a.ResourceType, b.ResourceType)
+	},
+	"compartment_name": func(a, b ResourceInfo) int {
+		return strings.Compare(a.CompartmentName, b.CompartmentName)
+	},
+	"name": func(a, b ResourceInfo) int {
+		return strings.Compare(a.ResourceName, b.ResourceName)
+	},
+	"ocid": func(a, b ResourceInfo) int {
+		return strings.Compare(a.OCID, b.OCID)
+	},
+	"lifecycle_state": func(a, b ResourceInfo) int {
+		return strings.Compare(a.LifecycleState, b.LifecycleState)
+	},
+	"time_created": compareTimeCreated,
+}
+
+// compareTimeCreated orders resources by their creation time (RFC3339),
+// placing resources without a creation time last
+func compareTimeCreated(a, b ResourceInfo) int {
+	timeA, timeB := a.TimeCreated, b.TimeCreated
+	switch {
+	case timeA == timeB:
+		return 0
+	case timeA == "":
+		return 1
+	case timeB == "":
+		return -1
+	default:
+		return strings.Compare(timeA, timeB)
+	}
+}
+
+// getSupportedSortKeys returns the list of valid --sort-by keys
+func getSupportedSortKeys() []string {
+	keys := make([]string, 0, len(resourceSortKeys))
+	for key := range resourceSortKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParseSortKeys parses a comma-separated list of sort keys and validates them
+func ParseSortKeys(sortBy string) ([]string, error) {
+	var keys []string
+	for _, key := range strings.Split(sortBy, ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if _, ok := resourceSortKeys[key]; !ok {
+			return nil, fmt.Errorf("invalid sort key '%s', must be one of: %v", key, getSupportedSortKeys())
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SortResources sorts resources by the given keys in order of priority
+// The OCID is used as a final tie-breaker so the output order is fully deterministic
+func SortResources(resources []ResourceInfo, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		for _, key := range keys {
+			if result := resourceSortKeys[key](resources[i], resources[j]); result != 0 {
+				return result < 0
+			}
+		}
+		return resources[i].OCID < resources[j].OCID
+	})
+}
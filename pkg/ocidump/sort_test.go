@@ -0,0 +1,65 @@
+package ocidump
+
+import (
+	"testing"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{"", nil, false},
+		{"resource_type", []string{"resource_type"}, false},
+		{" Compartment_Name , name ", []string{"compartment_name", "name"}, false},
+		{"resource_type,,time_created", []string{"resource_type", "time_created"}, false},
+		{"size", nil, true},
+	}
+
+	for _, tt := range tests {
+		keys, err := ParseSortKeys(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSortKeys(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if len(keys) != len(tt.expected) {
+			t.Errorf("ParseSortKeys(%q) = %v, want %v", tt.input, keys, tt.expected)
+			continue
+		}
+		for i := range keys {
+			if keys[i] != tt.expected[i] {
+				t.Errorf("ParseSortKeys(%q) = %v, want %v", tt.input, keys, tt.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestSortResources(t *testing.T) {
+	resources := []ResourceInfo{
+		{ResourceType: "VCN", CompartmentName: "prod", ResourceName: "b", OCID: "ocid1.vcn.oc1..2"},
+		{ResourceType: "ComputeInstance", CompartmentName: "prod", ResourceName: "web", OCID: "ocid1.instance.oc1..1",
+			TimeCreated: "2024-05-01T00:00:00Z"},
+		{ResourceType: "VCN", CompartmentName: "dev", ResourceName: "a", OCID: "ocid1.vcn.oc1..1"},
+		{ResourceType: "ComputeInstance", CompartmentName: "dev", ResourceName: "web", OCID: "ocid1.instance.oc1..0",
+			TimeCreated: "2023-01-01T00:00:00Z"},
+	}
+
+	SortResources(resources, []string{"compartment_name", "resource_type"})
+	expected := []string{"ocid1.instance.oc1..0", "ocid1.vcn.oc1..1", "ocid1.instance.oc1..1", "ocid1.vcn.oc1..2"}
+	for i, ocid := range expected {
+		if resources[i].OCID != ocid {
+			t.Errorf("SortResources(compartment_name,resource_type)[%d] = %s, want %s", i, resources[i].OCID, ocid)
+		}
+	}
+
+	// Resources without time_created come last, ties broken by OCID
+	SortResources(resources, []string{"time_created"})
+	expected = []string{"ocid1.instance.oc1..0", "ocid1.instance.oc1..1", "ocid1.vcn.oc1..1", "ocid1.vcn.oc1..2"}
+	for i, ocid := range expected {
+		if resources[i].OCID != ocid {
+			t.Errorf("SortResources(time_created)[%d] = %s, want %s", i, resources[i].OCID, ocid)
+		}
+	}
+}
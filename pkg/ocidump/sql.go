@@ -0,0 +1,368 @@
+package ocidump
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// SQLQuery is a compiled SELECT statement over the resources of one or more dumps
+//
+// Statements are run by SQLite on an in-memory database holding the resources table
+// (see SQLColumns), so the full SELECT syntax of SQLite is available, including joins,
+// subqueries, window functions and the JSON functions and operators.
+type SQLQuery struct {
+	statement string
+}
+
+// SQLDump is a dump loaded for SQL queries; Name is the value of the dump column
+type SQLDump struct {
+	Name      string
+	Resources []ResourceInfo
+}
+
+// SQLResult holds the column names and rows of a query
+// Values are nil (NULL), int64, float64 or string.
+type SQLResult struct {
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// SQLTableName is the name of the table holding the resources in SQL queries
+const SQLTableName = "resources"
+
+// SQLColumns lists the columns of the resources table
+// additional_info, freeform_tags, defined_tags and cost hold JSON text for the JSON functions.
+// A missing value is always NULL: an empty string, an empty map or a resource without cost.
+var SQLColumns = []string{
+	"dump",
+	"resource_type",
+	"compartment_name",
+	"resource_name",
+	"ocid",
+	"compartment_id",
+	"lifecycle_state",
+	"time_created",
+	"additional_info",
+	"freeform_tags",
+	"defined_tags",
+	"cost",
+}
+
+// SQLResultFormats lists the output formats of SQL query results
+var SQLResultFormats = []string{"table", "csv", "json"}
+
+// IsSQLResultFormat reports whether format is an output format of SQL query results
+func IsSQLResultFormat(format string) bool {
+	return contains(SQLResultFormats, format)
+}
+
+// CompileSQL checks that the statement is a single SELECT statement valid for the resources table
+func CompileSQL(statement string) (*SQLQuery, error) {
+	statement = strings.TrimRight(strings.TrimSpace(statement), "; \t\r\n")
+
+	ctx := context.Background()
+	db, conn, err := openSQLDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	defer conn.Close()
+
+	// Compiling the statement as a subquery rejects anything but one SELECT statement;
+	// the newline ends a trailing line comment
+	rows, err := conn.QueryContext(ctx, "SELECT * FROM (\n"+statement+"\n) LIMIT 0")
+	if err != nil {
+		return nil, fmt.Errorf("invalid SQL statement: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("invalid SQL statement: %w", err)
+	}
+
+	return &SQLQuery{statement: statement}, nil
+}
+
+// String returns the source statement
+func (q *SQLQuery) String() string {
+	return q.statement
+}
+
+// Run loads the resources of the dumps into the resources table and runs the statement
+func (q *SQLQuery) Run(dumps []SQLDump) (*SQLResult, error) {
+	ctx := context.Background()
+	db, conn, err := openSQLDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	defer conn.Close()
+
+	if err := loadSQLResources(ctx, conn, dumps); err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return nil, fmt.Errorf("failed to prepare query database: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, q.statement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := &SQLResult{Columns: columns}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			if blob, ok := value.([]byte); ok {
+				values[i] = string(blob)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// openSQLDatabase opens an in-memory database with the empty resources table
+// The single connection keeps the in-memory database alive; attaching database files is disabled.
+func openSQLDatabase(ctx context.Context) (*sql.DB, *sql.Conn, error) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open query database: %w", err)
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to open query database: %w", err)
+	}
+	if _, err := sqlite.Limit(conn, sqlite3.SQLITE_LIMIT_ATTACHED, 0); err != nil {
+		conn.Close()
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to open query database: %w", err)
+	}
+
+	columns := make([]string, len(SQLColumns))
+	for i, column := range SQLColumns {
+		columns[i] = column + " TEXT"
+	}
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (%s)", SQLTableName, strings.Join(columns, ", "))); err != nil {
+		conn.Close()
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to create %s table: %w", SQLTableName, err)
+	}
+	return db, conn, nil
+}
+
+// loadSQLResources inserts the resources of the dumps in one transaction
+func loadSQLResources(ctx context.Context, conn *sql.Conn, dumps []SQLDump) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to load resources: %w", err)
+	}
+	defer tx.Rollback()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(SQLColumns)), ", ")
+	insert, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s VALUES (%s)", SQLTableName, placeholders))
+	if err != nil {
+		return fmt.Errorf("failed to load resources: %w", err)
+	}
+	defer insert.Close()
+
+	for _, dump := range dumps {
+		for _, resource := range dump.Resources {
+			row, err := sqlResourceRow(dump.Name, resource)
+			if err != nil {
+				return err
+			}
+			if _, err := insert.ExecContext(ctx, row...); err != nil {
+				return fmt.Errorf("failed to load resources: %w", err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// sqlResourceRow returns the values of a resource in the order of SQLColumns
+func sqlResourceRow(dump string, resource ResourceInfo) ([]interface{}, error) {
+	row := []interface{}{
+		sqlNullIfEmpty(dump),
+		sqlNullIfEmpty(resource.ResourceType),
+		sqlNullIfEmpty(resource.CompartmentName),
+		sqlNullIfEmpty(resource.ResourceName),
+		sqlNullIfEmpty(resource.OCID),
+		sqlNullIfEmpty(resource.CompartmentID),
+		sqlNullIfEmpty(resource.LifecycleState),
+		sqlNullIfEmpty(resource.TimeCreated),
+	}
+	for _, value := range []interface{}{resource.AdditionalInfo, resource.FreeformTags, resource.DefinedTags, resource.Cost} {
+		text, err := sqlJSONText(value, "")
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, sqlNullIfEmpty(text))
+	}
+	return row, nil
+}
+
+// sqlJSONText encodes a value as compact JSON without HTML escaping; null and empty maps become empty
+func sqlJSONText(value interface{}, empty string) (string, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", fmt.Errorf("failed to encode resource for query: %w", err)
+	}
+	text := strings.TrimSuffix(b.String(), "\n")
+	if text == "null" || text == "{}" {
+		return empty, nil
+	}
+	return text, nil
+}
+
+func sqlNullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// sqlText converts a value to text; reals are written like SQLite, e.g. 3.0
+func sqlText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatFloat(v, 'f', 1, 64)
+		}
+		return strconv.FormatFloat(v, 'g', 15, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Output
+
+// OutputSQLResult writes the query result to stdout or to a file
+func OutputSQLResult(result *SQLResult, format, filename string) error {
+	if IsStdoutPath(filename) {
+		return writeSQLResult(result, format, os.Stdout)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := writeSQLResult(result, format, file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return CloseOutputFile(file)
+}
+
+// writeSQLResult writes an aligned table, CSV with a header row, or a JSON array of objects
+// NULL is written as NULL in tables, as an empty field in CSV and as null in JSON.
+func writeSQLResult(result *SQLResult, format string, w io.Writer) error {
+	switch format {
+	case "csv":
+		writer := csv.NewWriter(w)
+		if err := writer.Write(result.Columns); err != nil {
+			return err
+		}
+		for _, row := range result.Rows {
+			record := make([]string, len(row))
+			for i, value := range row {
+				record[i] = sqlText(value)
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "json":
+		var b bytes.Buffer
+		b.WriteString("[")
+		for i, row := range result.Rows {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n  {")
+			for j, value := range row {
+				if j > 0 {
+					b.WriteString(",")
+				}
+				name, _ := sqlJSONText(result.Columns[j], "")
+				encoded, err := sqlJSONText(value, "null")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(&b, "\n    %s: %s", name, encoded)
+			}
+			b.WriteString("\n  }")
+		}
+		if len(result.Rows) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("]\n")
+		_, err := w.Write(b.Bytes())
+		return err
+
+	default:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.Join(sanitizeSQLCells(result.Columns), "\t"))
+		for _, row := range result.Rows {
+			cells := make([]string, len(row))
+			for i, value := range row {
+				if value == nil {
+					cells[i] = "NULL"
+				} else {
+					cells[i] = sqlText(value)
+				}
+			}
+			fmt.Fprintln(writer, strings.Join(sanitizeSQLCells(cells), "\t"))
+		}
+		return writer.Flush()
+	}
+}
+
+// sanitizeSQLCells keeps table cells on one line and in their column
+func sanitizeSQLCells(cells []string) []string {
+	replacer := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	for i, cell := range cells {
+		cells[i] = replacer.Replace(cell)
+	}
+	return cells
+}
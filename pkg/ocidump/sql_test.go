@@ -0,0 +1,191 @@
+package ocidump
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func sqlTestDumps() []SQLDump {
+	return []SQLDump{
+		{Name: "old.json", Resources: []ResourceInfo{
+			{ResourceType: "ComputeInstance", CompartmentName: "prod", ResourceName: "web-1", OCID: "ocid1.instance.oc1..a", LifecycleState: "RUNNING",
+				AdditionalInfo: map[string]interface{}{"shape": "VM.Standard.E4.Flex", "ocpus": float64(2)}, FreeformTags: map[string]string{"env": "prod"}},
+		}},
+		{Name: "new.json", Resources: []ResourceInfo{
+			{ResourceType: "ComputeInstance", CompartmentName: "prod", ResourceName: "web-1", OCID: "ocid1.instance.oc1..a", LifecycleState: "RUNNING",
+				AdditionalInfo: map[string]interface{}{"shape": "VM.Standard.E4.Flex", "ocpus": float64(2)}, FreeformTags: map[string]string{"env": "prod"}},
+			{ResourceType: "ComputeInstance", CompartmentName: "prod", ResourceName: "web-2", OCID: "ocid1.instance.oc1..b", LifecycleState: "STOPPED",
+				AdditionalInfo: map[string]interface{}{"shape": "VM.Standard.E4.Flex", "ocpus": float64(4)}},
+			{ResourceType: "ComputeInstance", CompartmentName: "dev", ResourceName: "dev-1", OCID: "ocid1.instance.oc1..c",
+				AdditionalInfo: map[string]interface{}{"shape": "VM.Standard2.1"}},
+			{ResourceType: "VCN", CompartmentName: "prod", ResourceName: "prod-vcn", OCID: "ocid1.vcn.oc1..v",
+				AdditionalInfo: map[string]interface{}{"cidr_blocks": []interface{}{"10.0.0.0/16", "10.1.0.0/16"}},
+				DefinedTags:    map[string]map[string]interface{}{"Operations": {"CostCenter": "42"}}},
+		}},
+	}
+}
+
+func runSQL(t *testing.T, statement string) *SQLResult {
+	t.Helper()
+	query, err := CompileSQL(statement)
+	if err != nil {
+		t.Fatalf("CompileSQL(%q) failed: %v", statement, err)
+	}
+	result, err := query.Run(sqlTestDumps())
+	if err != nil {
+		t.Fatalf("Run(%q) failed: %v", statement, err)
+	}
+	return result
+}
+
+func TestSQLQuery_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		columns   []string
+		rows      [][]interface{}
+	}{
+		{
+			name:      "instances per shape per compartment",
+			statement: "SELECT compartment_name, additional_info->>'shape' AS shape, count(*) FROM resources WHERE resource_type = 'ComputeInstance' AND dump = 'new.json' GROUP BY 1, 2 ORDER BY 3 DESC",
+			columns:   []string{"compartment_name", "shape", "count(*)"},
+			rows:      [][]interface{}{{"prod", "VM.Standard.E4.Flex", int64(2)}, {"dev", "VM.Standard2.1", int64(1)}},
+		},
+		{
+			name:      "aggregates skip NULL",
+			statement: "select sum(json_extract(additional_info, '$.ocpus')), avg(additional_info->>'ocpus'), count(additional_info->>'ocpus'), max(resource_name) from resources where dump = 'new.json'",
+			columns:   []string{"sum(json_extract(additional_info, '$.ocpus'))", "avg(additional_info->>'ocpus')", "count(additional_info->>'ocpus')", "max(resource_name)"},
+			rows:      [][]interface{}{{int64(6), 3.0, int64(2), "web-2"}},
+		},
+		{
+			name:      "resources per dump with having",
+			statement: "SELECT dump, count(*) AS n FROM resources GROUP BY dump HAVING n > 1",
+			columns:   []string{"dump", "n"},
+			rows:      [][]interface{}{{"new.json", int64(4)}},
+		},
+		{
+			name:      "distinct with NULL first",
+			statement: "SELECT DISTINCT lifecycle_state FROM resources ORDER BY lifecycle_state",
+			columns:   []string{"lifecycle_state"},
+			rows:      [][]interface{}{{nil}, {"RUNNING"}, {"STOPPED"}},
+		},
+		{
+			name:      "like, in, is null and limit",
+			statement: "SELECT r.resource_name FROM resources AS r WHERE r.resource_name LIKE 'WEB%' AND lifecycle_state IN ('RUNNING', 'STOPPED') AND freeform_tags->>'env' IS NULL LIMIT 5",
+			columns:   []string{"resource_name"},
+			rows:      [][]interface{}{{"web-2"}},
+		},
+		{
+			name:      "json paths and functions",
+			statement: "SELECT json_array_length(additional_info, '$.cidr_blocks'), additional_info->'$.cidr_blocks[1]', defined_tags->>'$.Operations.CostCenter' = '42', upper(substr(resource_name, -3)), length(ocid) FROM resources WHERE resource_type GLOB 'V*'",
+			columns:   []string{"json_array_length(additional_info, '$.cidr_blocks')", "additional_info->'$.cidr_blocks[1]'", "defined_tags->>'$.Operations.CostCenter' = '42'", "upper(substr(resource_name, -3))", "length(ocid)"},
+			rows:      [][]interface{}{{int64(2), `"10.1.0.0/16"`, int64(1), "VCN", int64(16)}},
+		},
+		{
+			name:      "expressions without FROM",
+			statement: "SELECT 7 / 2, 7 / 2.0, 1 / 0, 'a' || 'b', CASE WHEN NULL THEN 1 ELSE 2 END, CAST('3.5' AS INTEGER), coalesce(NULL, 'x'), 2 BETWEEN 1 AND 3, NULL = NULL",
+			columns:   []string{"7 / 2", "7 / 2.0", "1 / 0", "'a' || 'b'", "CASE WHEN NULL THEN 1 ELSE 2 END", "CAST('3.5' AS INTEGER)", "coalesce(NULL, 'x')", "2 BETWEEN 1 AND 3", "NULL = NULL"},
+			rows:      [][]interface{}{{int64(3), 3.5, nil, "ab", int64(2), int64(3), "x", int64(1), nil}},
+		},
+		{
+			name:      "self join across dumps",
+			statement: "SELECT n.resource_name, o.lifecycle_state FROM resources n LEFT JOIN resources o ON o.ocid = n.ocid AND o.dump = 'old.json' WHERE n.dump = 'new.json' AND n.resource_type = 'ComputeInstance' ORDER BY 1",
+			columns:   []string{"resource_name", "lifecycle_state"},
+			rows:      [][]interface{}{{"dev-1", nil}, {"web-1", "RUNNING"}, {"web-2", nil}},
+		},
+		{
+			name:      "subquery",
+			statement: "SELECT resource_name FROM resources WHERE dump = 'new.json' AND ocid NOT IN (SELECT ocid FROM resources WHERE dump = 'old.json') ORDER BY 1;",
+			columns:   []string{"resource_name"},
+			rows:      [][]interface{}{{"dev-1"}, {"prod-vcn"}, {"web-2"}},
+		},
+		{
+			name:      "missing values are NULL",
+			statement: "SELECT compartment_id IS NULL, lifecycle_state IS NULL, freeform_tags IS NULL, defined_tags IS NULL, cost IS NULL FROM resources WHERE ocid = 'ocid1.instance.oc1..c'",
+			columns:   []string{"compartment_id IS NULL", "lifecycle_state IS NULL", "freeform_tags IS NULL", "defined_tags IS NULL", "cost IS NULL"},
+			rows:      [][]interface{}{{int64(1), int64(1), int64(1), int64(1), int64(1)}},
+		},
+		{
+			name:      "empty aggregate group",
+			statement: "SELECT count(*), sum(1) FROM resources WHERE 0",
+			columns:   []string{"count(*)", "sum(1)"},
+			rows:      [][]interface{}{{int64(0), nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runSQL(t, tt.statement)
+			if !reflect.DeepEqual(result.Columns, tt.columns) {
+				t.Errorf("columns = %q, expected %q", result.Columns, tt.columns)
+			}
+			if !reflect.DeepEqual(result.Rows, tt.rows) {
+				t.Errorf("rows = %#v, expected %#v", result.Rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestSQLQuery_Star(t *testing.T) {
+	result := runSQL(t, "SELECT * FROM resources WHERE ocid = 'ocid1.instance.oc1..c'")
+	if !reflect.DeepEqual(result.Columns, SQLColumns) {
+		t.Errorf("columns = %q, expected %q", result.Columns, SQLColumns)
+	}
+	if len(result.Rows) != 1 || result.Rows[0][8] != `{"shape":"VM.Standard2.1"}` || result.Rows[0][11] != nil {
+		t.Errorf("unexpected rows: %#v", result.Rows)
+	}
+}
+
+func TestCompileSQL_Errors(t *testing.T) {
+	tests := []struct {
+		statement string
+		message   string
+	}{
+		{"DELETE FROM resources", "syntax error"},
+		{"SELECT 1; DROP TABLE resources", "syntax error"},
+		{"ATTACH 'other.db' AS other", "syntax error"},
+		{"SELECT nope FROM resources", "no such column: nope"},
+		{"SELECT * FROM instances", "no such table: instances"},
+		{"SELECT count(*) FROM resources WHERE count(*) > 1", "misuse of aggregate"},
+		{"SELECT resource_type FROM resources ORDER BY 2", "ORDER BY term out of range"},
+		{"SELECT nosuch(ocid) FROM resources", "no such function: nosuch"},
+		{"SELECT 'unterminated", "unrecognized token"},
+		{"SELECT 1 + -- comment", "syntax error"},
+	}
+
+	for _, tt := range tests {
+		_, err := CompileSQL(tt.statement)
+		if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("CompileSQL(%q) error = %v, expected %q", tt.statement, err, tt.message)
+		}
+	}
+}
+
+func TestWriteSQLResult(t *testing.T) {
+	result := &SQLResult{Columns: []string{"name", "count"}, Rows: [][]interface{}{{"web\t1", int64(2)}, {nil, 1.5}}}
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"table", "name   count\nweb 1  2\nNULL   1.5\n"},
+		{"csv", "name,count\nweb\t1,2\n,1.5\n"},
+		{"json", "[\n  {\n    \"name\": \"web\\t1\",\n    \"count\": 2\n  },\n  {\n    \"name\": null,\n    \"count\": 1.5\n  }\n]\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := writeSQLResult(result, tt.format, &buf); err != nil {
+			t.Fatalf("writeSQLResult(%s) failed: %v", tt.format, err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("%s output = %q, expected %q", tt.format, buf.String(), tt.expected)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeSQLResult(&SQLResult{Columns: []string{"n"}}, "json", &buf); err != nil || buf.String() != "[]\n" {
+		t.Errorf("empty json output = %q, %v", buf.String(), err)
+	}
+}
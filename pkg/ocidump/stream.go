@@ -0,0 +1,239 @@
+package ocidump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// StreamFormats lists the output formats that can be written while discovery is running
+// Other formats need the complete inventory, e.g. the counts of openmetrics or the edges of a graph.
+var StreamFormats = []string{"json", "ndjson", "csv", "tsv"}
+
+// ResourceWriter writes resources incrementally, one batch at a time
+// Close completes the output, e.g. the closing bracket of a JSON array, and must be called once.
+type ResourceWriter interface {
+	Write(resources []ResourceInfo) error
+	Close() error
+	Count() int
+}
+
+// NewResourceWriter returns an incremental writer for one of StreamFormats
+// The output of a streamed run is the same as the output of OutputResources for the same resources.
+func NewResourceWriter(w io.Writer, format string, csvDialect CSVDialect) (ResourceWriter, error) {
+	switch format {
+	case "json":
+		return &jsonArrayWriter{w: w}, nil
+	case "ndjson":
+		return NewNDJSONWriter(w), nil
+	case "csv":
+		return newCSVResourceWriter(w, csvDialect)
+	case "tsv":
+		return newTSVResourceWriter(w)
+	default:
+		return nil, fmt.Errorf("output format %s cannot be streamed, supported formats: %v", format, StreamFormats)
+	}
+}
+
+// jsonArrayWriter writes an indented JSON array one element at a time
+type jsonArrayWriter struct {
+	mu    sync.Mutex
+	w     io.Writer
+	buf   bytes.Buffer
+	count int
+}
+
+func (w *jsonArrayWriter) Write(resources []ResourceInfo) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, resource := range resources {
+		w.buf.Reset()
+		if w.count == 0 {
+			w.buf.WriteString("[\n  ")
+		} else {
+			w.buf.WriteString(",\n  ")
+		}
+		encoder := json.NewEncoder(&w.buf)
+		encoder.SetIndent("  ", "  ")
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(resource); err != nil {
+			return err
+		}
+		// The separator of the next element or the closing bracket follows the element
+		w.buf.Truncate(w.buf.Len() - 1)
+		if _, err := w.w.Write(w.buf.Bytes()); err != nil {
+			return err
+		}
+		w.count++
+	}
+	return nil
+}
+
+func (w *jsonArrayWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	closing := "\n]\n"
+	if w.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(w.w, closing)
+	return err
+}
+
+func (w *jsonArrayWriter) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count
+}
+
+// csvResourceWriter writes the CSV header up front and a record per resource
+type csvResourceWriter struct {
+	mu     sync.Mutex
+	writer *csvRecordWriter
+	count  int
+}
+
+func newCSVResourceWriter(w io.Writer, dialect CSVDialect) (*csvResourceWriter, error) {
+	writer, err := newCSVRecordWriter(w, dialect)
+	if err != nil {
+		return nil, err
+	}
+	header := []string{"ResourceType", "CompartmentName", "ResourceName", "OCID", "CompartmentID", "TimeCreated", "LifecycleState", "AdditionalInfo", "FreeformTags", "DefinedTags"}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvResourceWriter{writer: writer}, nil
+}
+
+func (w *csvResourceWriter) Write(resources []ResourceInfo) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, resource := range resources {
+		record := []string{
+			resource.ResourceType,
+			resource.CompartmentName,
+			resource.ResourceName,
+			resource.OCID,
+			resource.CompartmentID,
+			resource.TimeCreated,
+			resource.LifecycleState,
+			formatAdditionalInfo(resource.AdditionalInfo),
+			formatFreeformTags(resource.FreeformTags),
+			formatDefinedTags(resource.DefinedTags),
+		}
+		if err := w.writer.Write(record); err != nil {
+			return err
+		}
+		w.count++
+	}
+	// Flush each batch so the buffered output stays small
+	return w.writer.Flush()
+}
+
+func (w *csvResourceWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writer.Flush()
+}
+
+func (w *csvResourceWriter) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count
+}
+
+// tsvResourceWriter writes the TSV header up front and a line per resource
+type tsvResourceWriter struct {
+	mu    sync.Mutex
+	w     io.Writer
+	count int
+}
+
+func newTSVResourceWriter(w io.Writer) (*tsvResourceWriter, error) {
+	if _, err := fmt.Fprintln(w, "ResourceType\tCompartmentName\tResourceName\tOCID\tCompartmentID\tTimeCreated\tLifecycleState\tAdditionalInfo\tFreeformTags\tDefinedTags"); err != nil {
+		return nil, err
+	}
+	return &tsvResourceWriter{w: w}, nil
+}
+
+func (w *tsvResourceWriter) Write(resources []ResourceInfo) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, resource := range resources {
+		if _, err := fmt.Fprintf(w.w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			escapeTSVField(resource.ResourceType),
+			escapeTSVField(resource.CompartmentName),
+			escapeTSVField(resource.ResourceName),
+			escapeTSVField(resource.OCID),
+			escapeTSVField(resource.CompartmentID),
+			escapeTSVField(resource.TimeCreated),
+			escapeTSVField(resource.LifecycleState),
+			escapeTSVField(formatAdditionalInfo(resource.AdditionalInfo)),
+			escapeTSVField(formatFreeformTags(resource.FreeformTags)),
+			escapeTSVField(formatDefinedTags(resource.DefinedTags)),
+		); err != nil {
+			return err
+		}
+		w.count++
+	}
+	return nil
+}
+
+func (w *tsvResourceWriter) Close() error {
+	return nil
+}
+
+func (w *tsvResourceWriter) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count
+}
+
+// streamBufferBatches is the number of discovered batches waiting for the writer
+// Discovery blocks when the writer falls behind, which bounds the memory of a streamed run.
+const streamBufferBatches = 64
+
+// NewStreamSink returns a sink that hands discovered resources to a writer running in the background
+// Discovery workers only wait for the writer when its buffer is full. Wait stops the writer once
+// discovery has finished and returns the first write error; after a write error the sink fails
+// so discovery stops early.
+func NewStreamSink(writer ResourceWriter) (sink ResourceSink, wait func() error) {
+	batches := make(chan []ResourceInfo, streamBufferBatches)
+	done := make(chan struct{})
+	var writeErr error
+
+	go func() {
+		defer close(done)
+		for batch := range batches {
+			if err := writer.Write(batch); err != nil {
+				writeErr = err
+				return
+			}
+		}
+	}()
+
+	sink = func(resources []ResourceInfo) error {
+		select {
+		case <-done:
+			return writeErr
+		default:
+		}
+		select {
+		case batches <- resources:
+			return nil
+		case <-done:
+			return writeErr
+		}
+	}
+	wait = func() error {
+		close(batches)
+		<-done
+		return writeErr
+	}
+	return sink, wait
+}
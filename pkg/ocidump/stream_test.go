@@ -0,0 +1,132 @@
+package ocidump
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func streamTestResources() []ResourceInfo {
+	return []ResourceInfo{
+		{ResourceType: "ComputeInstance", CompartmentName: "prod", ResourceName: "web<1>", OCID: "ocid1.instance.oc1..a",
+			AdditionalInfo: map[string]interface{}{"shape": "VM.Standard.E4.Flex"}, FreeformTags: map[string]string{"env": "prod"}},
+		{ResourceType: "VCN", CompartmentName: "prod", ResourceName: "vcn\tmain", OCID: "ocid1.vcn.oc1..v"},
+		{ResourceType: "Bucket", CompartmentName: "dev", ResourceName: "logs", OCID: "ocid1.bucket.oc1..b",
+			DefinedTags: map[string]map[string]interface{}{"Operations": {"CostCenter": "42"}}},
+	}
+}
+
+// bufferedOutput returns the output of the buffered writers of OutputResourcesToFile
+func bufferedOutput(t *testing.T, resources []ResourceInfo, format string) string {
+	t.Helper()
+	filename := t.TempDir() + "/out." + format
+	if err := OutputResourcesToFile(resources, format, filename, CSVDialect{}); err != nil {
+		t.Fatalf("OutputResourcesToFile(%s) failed: %v", format, err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestResourceWriter_MatchesBufferedOutput(t *testing.T) {
+	resources := streamTestResources()
+	for _, format := range StreamFormats {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer, err := NewResourceWriter(&buf, format, CSVDialect{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Batches arrive one resource type at a time
+			if err := writer.Write(resources[:1]); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Write(nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Write(resources[1:]); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if expected := bufferedOutput(t, resources, format); buf.String() != expected {
+				t.Errorf("streamed output differs from buffered output:\n%s\nexpected:\n%s", buf.String(), expected)
+			}
+			if writer.Count() != len(resources) {
+				t.Errorf("Count() = %d, expected %d", writer.Count(), len(resources))
+			}
+		})
+	}
+}
+
+func TestResourceWriter_Empty(t *testing.T) {
+	for _, format := range StreamFormats {
+		var buf bytes.Buffer
+		writer, err := NewResourceWriter(&buf, format, CSVDialect{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if expected := bufferedOutput(t, []ResourceInfo{}, format); buf.String() != expected {
+			t.Errorf("%s: empty streamed output = %q, expected %q", format, buf.String(), expected)
+		}
+	}
+
+	if _, err := NewResourceWriter(&bytes.Buffer{}, "openmetrics", CSVDialect{}); err == nil || !strings.Contains(err.Error(), "cannot be streamed") {
+		t.Errorf("expected an error for openmetrics, got %v", err)
+	}
+}
+
+func TestNewStreamSink(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+	sink, wait := NewStreamSink(writer)
+
+	for i := 0; i < 3*streamBufferBatches; i++ {
+		if err := sink([]ResourceInfo{{OCID: fmt.Sprintf("ocid1.test.oc1..%d", i)}}); err != nil {
+			t.Fatalf("sink failed: %v", err)
+		}
+	}
+	if err := wait(); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+	if writer.Count() != 3*streamBufferBatches {
+		t.Errorf("wrote %d resources, expected %d", writer.Count(), 3*streamBufferBatches)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.Contains(lines[0], `"ocid1.test.oc1..0"`) || !strings.Contains(lines[1], `"ocid1.test.oc1..1"`) {
+		t.Errorf("resources must be written in order, got: %q", lines[:2])
+	}
+}
+
+// failingResourceWriter fails every write
+type failingResourceWriter struct{}
+
+func (failingResourceWriter) Write([]ResourceInfo) error { return errors.New("disk full") }
+func (failingResourceWriter) Close() error               { return nil }
+func (failingResourceWriter) Count() int                 { return 0 }
+
+func TestNewStreamSink_WriteError(t *testing.T) {
+	sink, wait := NewStreamSink(failingResourceWriter{})
+
+	// The failure surfaces in the sink once the writer has stopped, so discovery stops early
+	var sinkErr error
+	for i := 0; i < 2*streamBufferBatches+2 && sinkErr == nil; i++ {
+		sinkErr = sink([]ResourceInfo{{OCID: "ocid1.test.oc1..a"}})
+	}
+	if sinkErr == nil || sinkErr.Error() != "disk full" {
+		t.Errorf("sink error = %v, expected disk full", sinkErr)
+	}
+	if err := wait(); err == nil || err.Error() != "disk full" {
+		t.Errorf("wait error = %v, expected disk full", err)
+	}
+}
@@ -0,0 +1,125 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// ResourceSummary holds resource counts used by the --summary report
+type ResourceSummary struct {
+	Total          int            `json:"total"`
+	ByResourceType map[string]int `json:"by_resource_type"`
+	ByCompartment  map[string]int `json:"by_compartment"`
+	ByRegion       map[string]int `json:"by_region"`
+}
+
+// SummaryFormats lists the output formats supported by the summary report
+var SummaryFormats = []string{"json", "csv", "tsv"}
+
+// BuildResourceSummary counts resources per type, compartment and region
+// Compartments are keyed by name, falling back to the compartment OCID when the name is unknown
+func BuildResourceSummary(resources []ResourceInfo) ResourceSummary {
+	summary := ResourceSummary{
+		Total:          len(resources),
+		ByResourceType: make(map[string]int),
+		ByCompartment:  make(map[string]int),
+		ByRegion:       make(map[string]int),
+	}
+
+	for _, resource := range resources {
+		summary.ByResourceType[resource.ResourceType]++
+
+		compartment := resource.CompartmentName
+		if compartment == "" {
+			compartment = resource.CompartmentID
+		}
+		summary.ByCompartment[compartment]++
+
+		summary.ByRegion[regionFromOCID(resource.OCID)]++
+	}
+
+	return summary
+}
+
+// summaryRows flattens the summary into (dimension, key, count) rows sorted by dimension and key
+func summaryRows(summary ResourceSummary) [][]string {
+	rows := [][]string{{"total", "", fmt.Sprintf("%d", summary.Total)}}
+
+	dimensions := []struct {
+		name   string
+		counts map[string]int
+	}{
+		{"resource_type", summary.ByResourceType},
+		{"compartment", summary.ByCompartment},
+		{"region", summary.ByRegion},
+	}
+	for _, dimension := range dimensions {
+		keys := make([]string, 0, len(dimension.counts))
+		for key := range dimension.counts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			rows = append(rows, []string{dimension.name, key, fmt.Sprintf("%d", dimension.counts[key])})
+		}
+	}
+
+	return rows
+}
+
+// writeSummary writes the summary report in the given format
+func writeSummary(summary ResourceSummary, format string, w io.Writer, csvDialect CSVDialect) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(summary)
+	case "csv":
+		writer, err := newCSVRecordWriter(w, csvDialect)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write([]string{"Dimension", "Key", "Count"}); err != nil {
+			return err
+		}
+		return writer.WriteAll(summaryRows(summary))
+	case "tsv":
+		if _, err := fmt.Fprintln(w, "Dimension\tKey\tCount"); err != nil {
+			return err
+		}
+		for _, row := range summaryRows(summary) {
+			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", row[0], escapeTSVField(row[1]), row[2]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported summary format: %s", format)
+	}
+}
+
+// OutputSummary writes the summary report to stdout or to a file
+func OutputSummary(resources []ResourceInfo, format, filename string, csvDialect CSVDialect) error {
+	summary := BuildResourceSummary(resources)
+
+	if IsStdoutPath(filename) {
+		return writeSummary(summary, format, os.Stdout, csvDialect)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := writeSummary(summary, format, file, csvDialect); err != nil {
+		file.Close()
+		return err
+	}
+
+	return CloseOutputFile(file)
+}
@@ -0,0 +1,79 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testSummaryResources() []ResourceInfo {
+	return []ResourceInfo{
+		{ResourceType: "ComputeInstance", CompartmentName: "app", OCID: "ocid1.instance.oc1.ap-tokyo-1.a"},
+		{ResourceType: "ComputeInstance", CompartmentName: "app", OCID: "ocid1.instance.oc1.ap-osaka-1.b"},
+		{ResourceType: "VCN", CompartmentName: "network", OCID: "ocid1.vcn.oc1.ap-tokyo-1.c"},
+		{ResourceType: "Compartment", CompartmentID: "ocid1.tenancy.oc1..root", OCID: "ocid1.compartment.oc1..d"},
+	}
+}
+
+func TestBuildResourceSummary(t *testing.T) {
+	summary := BuildResourceSummary(testSummaryResources())
+
+	if summary.Total != 4 {
+		t.Errorf("Total = %d, want 4", summary.Total)
+	}
+	if summary.ByResourceType["ComputeInstance"] != 2 || summary.ByResourceType["VCN"] != 1 {
+		t.Errorf("ByResourceType = %v", summary.ByResourceType)
+	}
+	if summary.ByCompartment["app"] != 2 || summary.ByCompartment["ocid1.tenancy.oc1..root"] != 1 {
+		t.Errorf("ByCompartment = %v, want compartment OCID fallback for unnamed compartments", summary.ByCompartment)
+	}
+	if summary.ByRegion["ap-tokyo-1"] != 2 || summary.ByRegion["ap-osaka-1"] != 1 || summary.ByRegion["global"] != 1 {
+		t.Errorf("ByRegion = %v", summary.ByRegion)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	summary := BuildResourceSummary(testSummaryResources())
+
+	var jsonOut strings.Builder
+	if err := writeSummary(summary, "json", &jsonOut, CSVDialect{}); err != nil {
+		t.Fatalf("writeSummary(json) error = %v, want nil", err)
+	}
+	var parsed ResourceSummary
+	if err := json.Unmarshal([]byte(jsonOut.String()), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON summary: %v", err)
+	}
+	if parsed.Total != 4 {
+		t.Errorf("JSON summary total = %d, want 4", parsed.Total)
+	}
+
+	var csvOut strings.Builder
+	if err := writeSummary(summary, "csv", &csvOut, CSVDialect{}); err != nil {
+		t.Fatalf("writeSummary(csv) error = %v, want nil", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	expectedStart := []string{
+		"Dimension,Key,Count",
+		"total,,4",
+		"resource_type,Compartment,1",
+		"resource_type,ComputeInstance,2",
+		"resource_type,VCN,1",
+	}
+	for i, expected := range expectedStart {
+		if i >= len(lines) || lines[i] != expected {
+			t.Errorf("CSV line %d = %q, want %q", i, lines[i], expected)
+		}
+	}
+
+	var tsvOut strings.Builder
+	if err := writeSummary(summary, "tsv", &tsvOut, CSVDialect{}); err != nil {
+		t.Fatalf("writeSummary(tsv) error = %v, want nil", err)
+	}
+	if !strings.Contains(tsvOut.String(), "region\tap-tokyo-1\t2\n") {
+		t.Errorf("TSV summary missing region row: %q", tsvOut.String())
+	}
+
+	if err := writeSummary(summary, "ndjson", &tsvOut, CSVDialect{}); err == nil {
+		t.Error("writeSummary(ndjson) error = nil, want unsupported format error")
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package ocidump
 
 import (
 	"context"
@@ -77,7 +77,7 @@ func TestSystemIntegration_CompartmentNameEndToEnd(t *testing.T) {
 			// Create temporary file for testing
 			tempFile := filepath.Join(t.TempDir(), fmt.Sprintf("test_output.%s", test.format))
 
-			err := outputResourcesToFile(resources, test.format, tempFile)
+			err := OutputResourcesToFile(resources, test.format, tempFile, CSVDialect{})
 			if err != nil {
 				t.Fatalf("Failed to output %s format: %v", test.format, err)
 			}
@@ -310,7 +310,7 @@ func TestSystemIntegration_OutputConsistency(t *testing.T) {
 	for _, format := range formats {
 		fileName := filepath.Join(tempDir, fmt.Sprintf("test.%s", format))
 
-		err := outputResourcesToFile(resources, format, fileName)
+		err := OutputResourcesToFile(resources, format, fileName, CSVDialect{})
 		if err != nil {
 			t.Fatalf("Failed to output %s format: %v", format, err)
 		}
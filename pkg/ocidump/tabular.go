@@ -0,0 +1,167 @@
+package ocidump
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tabularDumpHeader is the first column of the header of CSV and TSV dumps
+const tabularDumpHeader = "ResourceType"
+
+// additionalInfoKey finds the "key: " prefixes of the pairs written by formatAdditionalInfo
+var additionalInfoKey = regexp.MustCompile(`(?:^|, )([A-Za-z0-9_.]+): `)
+
+// tabularDumpDelimiter reports whether data is a CSV or TSV dump and returns its delimiter
+// The delimiter is the character after the first header column, so dumps written with any
+// CSV dialect are recognized.
+func tabularDumpDelimiter(data []byte) (rune, bool) {
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+	header := []byte(tabularDumpHeader)
+	if bytes.HasPrefix(data, []byte(`"`+tabularDumpHeader+`"`)) {
+		header = []byte(`"` + tabularDumpHeader + `"`) // written with --csv-quote all
+	}
+	if !bytes.HasPrefix(data, header) || len(data) <= len(header) {
+		return 0, false
+	}
+	delimiter := rune(data[len(header)])
+	if delimiter == '\r' || delimiter == '\n' {
+		return 0, false
+	}
+	return delimiter, true
+}
+
+// parseTabularDump reads the resources of a CSV or TSV dump
+// CSV and TSV keep the additional info as "key: value" text with the most important fields
+// only, so the reconstructed additional info is a subset of the original. Numbers and booleans
+// are converted back to the types JSON dumps decode to; other values stay strings.
+func parseTabularDump(data []byte, delimiter rune) ([]ResourceInfo, error) {
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+
+	var records [][]string
+	if delimiter == '\t' {
+		// TSV fields are not quoted; tabs and newlines in values were replaced by spaces
+		for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+			if line != "" {
+				records = append(records, strings.Split(line, "\t"))
+			}
+		}
+	} else {
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.Comma = delimiter
+		var err error
+		if records, err = reader.ReadAll(); err != nil {
+			return nil, fmt.Errorf("failed to decode CSV: %w", err)
+		}
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	for _, name := range []string{"ResourceType", "ResourceName", "OCID", "CompartmentID"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %s in the header", name)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	resources := make([]ResourceInfo, 0, len(records)-1)
+	for line, record := range records[1:] {
+		if len(record) != len(records[0]) {
+			return nil, fmt.Errorf("line %d: expected %d fields, got %d", line+2, len(records[0]), len(record))
+		}
+		resources = append(resources, ResourceInfo{
+			ResourceType:    field(record, "ResourceType"),
+			CompartmentName: field(record, "CompartmentName"),
+			ResourceName:    field(record, "ResourceName"),
+			OCID:            field(record, "OCID"),
+			CompartmentID:   field(record, "CompartmentID"),
+			TimeCreated:     field(record, "TimeCreated"),
+			LifecycleState:  field(record, "LifecycleState"),
+			AdditionalInfo:  parseAdditionalInfo(field(record, "AdditionalInfo")),
+			FreeformTags:    parseFreeformTags(field(record, "FreeformTags")),
+			DefinedTags:     parseDefinedTags(field(record, "DefinedTags")),
+		})
+	}
+	return resources, nil
+}
+
+// ReduceToTabularFields returns the resources as a CSV or TSV dump keeps them, so they can be
+// compared with resources read from such a dump: fields without a column are dropped, and only
+// the additional info fields the dump writes are kept.
+func ReduceToTabularFields(resources []ResourceInfo) ([]ResourceInfo, error) {
+	var buf bytes.Buffer
+	if err := writeCSV(resources, &buf, CSVDialect{}); err != nil {
+		return nil, fmt.Errorf("failed to encode resources: %w", err)
+	}
+	return parseTabularDump(buf.Bytes(), ',')
+}
+
+// parseAdditionalInfo reverses formatAdditionalInfo
+func parseAdditionalInfo(text string) map[string]interface{} {
+	info := make(map[string]interface{})
+	matches := additionalInfoKey.FindAllStringSubmatchIndex(text, -1)
+	for i, match := range matches {
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		info[text[match[2]:match[3]]] = parseInfoValue(text[match[1]:end])
+	}
+	return info
+}
+
+// parseInfoValue converts a value printed by formatValue back to a JSON-like value
+func parseInfoValue(value string) interface{} {
+	switch value {
+	case "<nil>":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number
+	}
+	return value
+}
+
+// parseFreeformTags reverses formatFreeformTags
+func parseFreeformTags(text string) map[string]string {
+	if text == "" {
+		return nil
+	}
+	tags := make(map[string]string)
+	for _, pair := range strings.Split(text, ";") {
+		key, value, _ := strings.Cut(pair, "=")
+		tags[key] = value
+	}
+	return tags
+}
+
+// parseDefinedTags reverses formatDefinedTags
+func parseDefinedTags(text string) map[string]map[string]interface{} {
+	if text == "" {
+		return nil
+	}
+	tags := make(map[string]map[string]interface{})
+	for _, pair := range strings.Split(text, ";") {
+		name, value, _ := strings.Cut(pair, "=")
+		namespace, key, _ := strings.Cut(name, ".")
+		if tags[namespace] == nil {
+			tags[namespace] = make(map[string]interface{})
+		}
+		tags[namespace][key] = value
+	}
+	return tags
+}
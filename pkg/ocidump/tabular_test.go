@@ -0,0 +1,145 @@
+package ocidump
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadResourcesFromFile_TabularDumps(t *testing.T) {
+	resources := []ResourceInfo{{
+		ResourceType:    "ComputeInstance",
+		CompartmentName: "prod",
+		ResourceName:    "web, primary",
+		OCID:            "ocid1.instance.oc1..web",
+		CompartmentID:   "ocid1.compartment.oc1..prod",
+		TimeCreated:     "2024-05-01T09:30:00Z",
+		LifecycleState:  "RUNNING",
+		AdditionalInfo:  map[string]interface{}{"shape": "VM.Standard.E4.Flex", "ocpus": 2, "is_pv_encryption_in_transit_enabled": true},
+		FreeformTags:    map[string]string{"env": "prod"},
+		DefinedTags:     map[string]map[string]interface{}{"Ops": {"owner": "team-a"}},
+	}}
+	want := ResourceInfo{
+		ResourceType:    "ComputeInstance",
+		CompartmentName: "prod",
+		ResourceName:    "web, primary",
+		OCID:            "ocid1.instance.oc1..web",
+		CompartmentID:   "ocid1.compartment.oc1..prod",
+		TimeCreated:     "2024-05-01T09:30:00Z",
+		LifecycleState:  "RUNNING",
+		AdditionalInfo:  map[string]interface{}{"shape": "VM.Standard.E4.Flex", "ocpus": float64(2), "is_pv_encryption_in_transit_enabled": true},
+		FreeformTags:    map[string]string{"env": "prod"},
+		DefinedTags:     map[string]map[string]interface{}{"Ops": {"owner": "team-a"}},
+	}
+
+	dir := t.TempDir()
+	write := func(name string, format func(*bytes.Buffer) error) string {
+		var buf bytes.Buffer
+		if err := format(&buf); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	files := map[string]string{
+		"csv": write("dump.csv", func(buf *bytes.Buffer) error { return writeCSV(resources, buf, CSVDialect{}) }),
+		"csv with dialect": write("dump-excel.csv", func(buf *bytes.Buffer) error {
+			return writeCSV(resources, buf, CSVDialect{Delimiter: ";", Quote: "all", BOM: true, CRLF: true})
+		}),
+		"tsv": write("dump.tsv", func(buf *bytes.Buffer) error {
+			writer, err := newTSVResourceWriter(buf)
+			if err != nil {
+				return err
+			}
+			return writer.Write(resources)
+		}),
+	}
+
+	for name, path := range files {
+		t.Run(name, func(t *testing.T) {
+			loaded, err := LoadResourcesFromFile(path)
+			if err != nil {
+				t.Fatalf("LoadResourcesFromFile() error = %v", err)
+			}
+			if len(loaded) != 1 || !reflect.DeepEqual(loaded[0], want) {
+				t.Errorf("LoadResourcesFromFile() = %+v, want %+v", loaded, want)
+			}
+		})
+	}
+}
+
+func TestParseAdditionalInfo(t *testing.T) {
+	got := parseAdditionalInfo("cidr_block: 10.0.0.0/16, dns_label: a, b, vcn_id: <nil>")
+	want := map[string]interface{}{"cidr_block": "10.0.0.0/16", "dns_label": "a, b", "vcn_id": nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseAdditionalInfo() = %v, want %v", got, want)
+	}
+	if got := parseAdditionalInfo(""); len(got) != 0 {
+		t.Errorf("parseAdditionalInfo(\"\") = %v, want empty", got)
+	}
+}
+
+func TestCompareDumps_MixedTabularAndJSON(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	resources := []ResourceInfo{{
+		ResourceType:   "ComputeInstance",
+		ResourceName:   "web",
+		OCID:           "ocid1.instance.oc1..web",
+		CompartmentID:  "ocid1.compartment.oc1..prod",
+		LifecycleState: "RUNNING",
+		AdditionalInfo: map[string]interface{}{"shape": "VM.Standard.E4.Flex", "availability_domain": "AD-1",
+			"fault_domain": "FD-1", "image_id": "ocid1.image.oc1..a", "ocpus": 2, "memory_in_gbs": 16},
+	}}
+
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "old.csv")
+	var buf bytes.Buffer
+	if err := writeCSV(resources, &buf, CSVDialect{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(csvFile, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resources[0].AdditionalInfo["memory_in_gbs"] = 32 // not kept by the CSV dump
+	jsonFile := filepath.Join(dir, "new.json")
+	data, err := json.Marshal(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonFile, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, files := range [][2]string{{csvFile, jsonFile}, {jsonFile, csvFile}} {
+		result, err := CompareDumps(files[0], files[1], DiffConfig{})
+		if err != nil {
+			t.Fatalf("CompareDumps(%s, %s) error = %v", files[0], files[1], err)
+		}
+		if result.Summary.Modified != 0 || result.Summary.Added != 0 || result.Summary.Removed != 0 {
+			t.Errorf("CompareDumps(%s, %s) = %+v, want only the fields of the CSV dump compared", files[0], files[1], result.Summary)
+		}
+	}
+
+	// Changes to the fields the CSV dump keeps are still reported
+	resources[0].AdditionalInfo["shape"] = "VM.Standard.E5.Flex"
+	if data, err = json.Marshal(resources); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonFile, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := CompareDumps(csvFile, jsonFile, DiffConfig{})
+	if err != nil {
+		t.Fatalf("CompareDumps() error = %v", err)
+	}
+	if result.Summary.Modified != 1 {
+		t.Errorf("CompareDumps() = %+v, want the shape change", result.Summary)
+	}
+}
@@ -0,0 +1,226 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// terraformState is the subset of the Terraform state file format (version 4) needed to extract OCIDs
+type terraformState struct {
+	Version   int                      `json:"version"`
+	Resources []terraformStateResource `json:"resources"`
+}
+
+// terraformStateResource is a single resource block of a Terraform state file
+type terraformStateResource struct {
+	Module    string                   `json:"module"`
+	Mode      string                   `json:"mode"`
+	Type      string                   `json:"type"`
+	Name      string                   `json:"name"`
+	Instances []terraformStateInstance `json:"instances"`
+}
+
+// terraformStateInstance is one instance (count/for_each element) of a Terraform resource
+type terraformStateInstance struct {
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// LoadTerraformStateResources extracts the OCI resources managed by a Terraform state file
+// Only managed resources whose id is a single OCID are returned; data sources and
+// composite ids (e.g. attachments identified by two OCIDs) are ignored
+func LoadTerraformStateResources(filename string) ([]ResourceInfo, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return parseTerraformStateResources(data)
+}
+
+// parseTerraformStateResources extracts the OCI resources managed by the content of a Terraform state file
+func parseTerraformStateResources(data []byte) ([]ResourceInfo, error) {
+	var state terraformState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode Terraform state: %w", err)
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("unsupported Terraform state version %d (only version 4 is supported)", state.Version)
+	}
+
+	var resources []ResourceInfo
+	for _, resource := range state.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+		for _, instance := range resource.Instances {
+			ocid, _ := instance.Attributes["id"].(string)
+			if !strings.HasPrefix(ocid, "ocid1.") || strings.Count(ocid, "ocid1.") != 1 {
+				continue
+			}
+
+			name, _ := instance.Attributes["display_name"].(string)
+			if name == "" {
+				name, _ = instance.Attributes["name"].(string)
+			}
+			compartmentID, _ := instance.Attributes["compartment_id"].(string)
+
+			resources = append(resources, ResourceInfo{
+				ResourceType:  resource.Type,
+				ResourceName:  name,
+				OCID:          ocid,
+				CompartmentID: compartmentID,
+				AdditionalInfo: map[string]interface{}{
+					"terraform_address": terraformAddress(resource, instance.IndexKey),
+				},
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+// terraformAddress builds the Terraform resource address (e.g. module.net.oci_core_vcn.main["a"])
+func terraformAddress(resource terraformStateResource, indexKey interface{}) string {
+	address := resource.Type + "." + resource.Name
+	if resource.Module != "" {
+		address = resource.Module + "." + address
+	}
+
+	switch key := indexKey.(type) {
+	case float64:
+		address += fmt.Sprintf("[%d]", int(key))
+	case string:
+		address += fmt.Sprintf("[%q]", key)
+	}
+	return address
+}
+
+// terraformResourceTypes maps Terraform resource types to the resource types of a dump
+var terraformResourceTypes = map[string]string{
+	"oci_apigateway_gateway":                          "APIGateway",
+	"oci_blockchain_blockchain_platform":              "BlockchainPlatform",
+	"oci_containerengine_cluster":                     "OKECluster",
+	"oci_core_boot_volume":                            "BootVolume",
+	"oci_core_boot_volume_backup":                     "BootVolumeBackup",
+	"oci_core_cluster_network":                        "ClusterNetwork",
+	"oci_core_drg":                                    "DRG",
+	"oci_core_instance":                               "ComputeInstance",
+	"oci_core_instance_configuration":                 "InstanceConfiguration",
+	"oci_core_instance_pool":                          "InstancePool",
+	"oci_core_local_peering_gateway":                  "LocalPeeringGateway",
+	"oci_core_private_ip":                             "PrivateIp",
+	"oci_core_subnet":                                 "Subnet",
+	"oci_core_vcn":                                    "VCN",
+	"oci_core_volume":                                 "BlockVolume",
+	"oci_core_volume_backup":                          "BlockVolumeBackup",
+	"oci_core_volume_group":                           "VolumeGroup",
+	"oci_core_volume_group_backup":                    "VolumeGroupBackup",
+	"oci_database_autonomous_database":                "AutonomousDatabase",
+	"oci_database_cloud_exadata_infrastructure":       "CloudExadataInfrastructure",
+	"oci_database_database":                           "Database",
+	"oci_database_db_home":                            "DbHome",
+	"oci_database_exadata_infrastructure":             "ExadataInfrastructure",
+	"oci_database_external_container_database":        "ExternalContainerDatabase",
+	"oci_database_external_non_container_database":    "ExternalNonContainerDatabase",
+	"oci_database_external_pluggable_database":        "ExternalPluggableDatabase",
+	"oci_database_vm_cluster":                         "VmCluster",
+	"oci_file_storage_export":                         "FileStorageExport",
+	"oci_file_storage_file_system":                    "FileStorageSystem",
+	"oci_file_storage_mount_target":                   "MountTarget",
+	"oci_file_storage_snapshot":                       "FileStorageSnapshot",
+	"oci_functions_function":                          "Function",
+	"oci_health_checks_http_monitor":                  "HealthCheck",
+	"oci_health_checks_ping_monitor":                  "HealthCheck",
+	"oci_identity_domain":                             "IdentityDomain",
+	"oci_identity_dynamic_group":                      "DynamicGroup",
+	"oci_identity_group":                              "Group",
+	"oci_identity_policy":                             "Policy",
+	"oci_identity_tag":                                "TagDefinition",
+	"oci_identity_tag_namespace":                      "TagNamespace",
+	"oci_identity_user":                               "User",
+	"oci_limits_quota":                                "Quota",
+	"oci_load_balancer":                               "LoadBalancer",
+	"oci_load_balancer_load_balancer":                 "LoadBalancer",
+	"oci_network_load_balancer_network_load_balancer": "NetworkLoadBalancer",
+	"oci_opensearch_opensearch_cluster":               "OpenSearchCluster",
+	"oci_opsi_database_insight":                       "DatabaseInsight",
+	"oci_opsi_host_insight":                           "HostInsight",
+	"oci_osmanagement_managed_instance_group":         "ManagedInstanceGroup",
+	"oci_streaming_stream":                            "Stream",
+	"oci_streaming_stream_pool":                       "StreamPool",
+}
+
+// CompareTerraformState compares a resource dump with a Terraform state file
+// Resources only in the dump are reported as added (not managed by Terraform) and managed resources
+// as unchanged. Resources only in the state are reported as removed (missing from the tenancy) when
+// the dump covers them: their Terraform type maps to a resource type found in the dump and their
+// compartment is one of the dump's compartments. All other resources only in the state cannot be
+// verified by the dump and are reported as unverified.
+func CompareTerraformState(dumpFile, stateFile string, config DiffConfig) (*DiffResult, error) {
+	logger.Info("Comparing resource dump %s with Terraform state %s", dumpFile, stateFile)
+
+	dumpResources, err := LoadResourcesFromFile(dumpFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dump file %s: %w", dumpFile, err)
+	}
+
+	stateResources, err := LoadTerraformStateResources(stateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Terraform state %s: %w", stateFile, err)
+	}
+
+	logger.Verbose("Loaded %d resources from dump file, %d from Terraform state", len(dumpResources), len(stateResources))
+
+	stateMap := CreateResourceMap(stateResources)
+	dumpMap := CreateResourceMap(dumpResources)
+
+	unmanaged := FindAddedResources(stateMap, dumpMap)
+	removed, unverified := splitTerraformMissing(FindRemovedResources(stateMap, dumpMap), dumpResources)
+
+	var managed []ResourceInfo
+	for ocid, resource := range dumpMap {
+		if _, exists := stateMap[ocid]; exists {
+			managed = append(managed, resource)
+		}
+	}
+	sort.Slice(managed, func(i, j int) bool {
+		if managed[i].ResourceType != managed[j].ResourceType {
+			return managed[i].ResourceType < managed[j].ResourceType
+		}
+		return managed[i].ResourceName < managed[j].ResourceName
+	})
+
+	result := BuildDiffResult(unmanaged, removed, nil, managed, stateFile, dumpFile, config.Detailed)
+	result.addUnverified(unverified)
+
+	logger.Info("Terraform state comparison complete: %d not in state, %d missing from tenancy, %d unverified, %d managed",
+		len(unmanaged), len(removed), len(unverified), len(managed))
+	return result, nil
+}
+
+// splitTerraformMissing separates the state resources missing from the dump into those the dump
+// covers (removed from the tenancy) and those outside its resource types or compartments (unverified)
+func splitTerraformMissing(missing, dumpResources []ResourceInfo) ([]ResourceInfo, []ResourceInfo) {
+	dumpTypes := make(map[string]bool)
+	dumpCompartments := make(map[string]bool)
+	for _, resource := range dumpResources {
+		dumpTypes[resource.ResourceType] = true
+		if resource.CompartmentID != "" {
+			dumpCompartments[resource.CompartmentID] = true
+		}
+	}
+
+	var removed, unverified []ResourceInfo
+	for _, resource := range missing {
+		resourceType, mapped := terraformResourceTypes[resource.ResourceType]
+		if mapped && dumpTypes[resourceType] && dumpCompartments[resource.CompartmentID] {
+			removed = append(removed, resource)
+		} else {
+			unverified = append(unverified, resource)
+		}
+	}
+	return removed, unverified
+}
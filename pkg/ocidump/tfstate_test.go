@@ -0,0 +1,204 @@
+package ocidump
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testTerraformState = `{
+  "version": 4,
+  "terraform_version": "1.8.0",
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "oci_core_vcn",
+      "name": "main",
+      "instances": [
+        {"attributes": {"id": "ocid1.vcn.oc1.ap-tokyo-1.managed", "display_name": "main-vcn", "compartment_id": "ocid1.compartment.oc1..net"}}
+      ]
+    },
+    {
+      "module": "module.app",
+      "mode": "managed",
+      "type": "oci_core_instance",
+      "name": "web",
+      "instances": [
+        {"index_key": 0, "attributes": {"id": "ocid1.instance.oc1.ap-tokyo-1.deleted", "display_name": "web-0", "compartment_id": "ocid1.compartment.oc1..app"}},
+        {"index_key": "blue", "attributes": {"id": "ocid1.instance.oc1.ap-tokyo-1.blue", "display_name": "web-blue", "compartment_id": "ocid1.compartment.oc1..app"}}
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "oci_core_route_table_attachment",
+      "name": "rt",
+      "instances": [
+        {"attributes": {"id": "ocid1.subnet.oc1.ap-tokyo-1.x.ocid1.routetable.oc1.ap-tokyo-1.y"}}
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "oci_identity_availability_domains",
+      "name": "ads",
+      "instances": [
+        {"attributes": {"id": "ocid1.tenancy.oc1..root"}}
+      ]
+    }
+  ]
+}`
+
+func TestLoadTerraformStateResources(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(stateFile, []byte(testTerraformState), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	resources, err := LoadTerraformStateResources(stateFile)
+	if err != nil {
+		t.Fatalf("LoadTerraformStateResources() error = %v, want nil", err)
+	}
+
+	// Data sources and the composite route table attachment id are skipped
+	if len(resources) != 3 {
+		t.Fatalf("LoadTerraformStateResources() returned %d resources, want 3", len(resources))
+	}
+
+	expectedAddresses := map[string]string{
+		"ocid1.vcn.oc1.ap-tokyo-1.managed":      "oci_core_vcn.main",
+		"ocid1.instance.oc1.ap-tokyo-1.deleted": "module.app.oci_core_instance.web[0]",
+		"ocid1.instance.oc1.ap-tokyo-1.blue":    `module.app.oci_core_instance.web["blue"]`,
+	}
+	for _, resource := range resources {
+		expected, ok := expectedAddresses[resource.OCID]
+		if !ok {
+			continue
+		}
+		if got := resource.AdditionalInfo["terraform_address"]; got != expected {
+			t.Errorf("terraform_address for %s = %v, want %s", resource.OCID, got, expected)
+		}
+	}
+
+	if resources[0].ResourceName != "main-vcn" || resources[0].CompartmentID != "ocid1.compartment.oc1..net" {
+		t.Errorf("Unexpected first resource: %+v", resources[0])
+	}
+}
+
+func TestLoadTerraformStateResources_UnsupportedVersion(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(stateFile, []byte(`{"version": 3, "modules": []}`), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	if _, err := LoadTerraformStateResources(stateFile); err == nil {
+		t.Error("LoadTerraformStateResources() error = nil, want error for version 3 state")
+	}
+}
+
+func TestCompareTerraformState(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	tempDir := t.TempDir()
+	stateFile := filepath.Join(tempDir, "terraform.tfstate")
+	dumpFile := filepath.Join(tempDir, "resources.json")
+
+	dump := []ResourceInfo{
+		{ResourceType: "VCN", ResourceName: "main-vcn", OCID: "ocid1.vcn.oc1.ap-tokyo-1.managed", CompartmentID: "ocid1.compartment.oc1..net"},
+		{ResourceType: "ComputeInstance", ResourceName: "web-blue", OCID: "ocid1.instance.oc1.ap-tokyo-1.blue", CompartmentID: "ocid1.compartment.oc1..app"},
+		{ResourceType: "ComputeInstance", ResourceName: "handmade", OCID: "ocid1.instance.oc1.ap-tokyo-1.handmade", CompartmentID: "ocid1.compartment.oc1..app"},
+	}
+	data, err := json.Marshal(dump)
+	if err != nil {
+		t.Fatalf("Failed to marshal test data: %v", err)
+	}
+	if err := os.WriteFile(dumpFile, data, 0644); err != nil {
+		t.Fatalf("Failed to write dump file: %v", err)
+	}
+	if err := os.WriteFile(stateFile, []byte(testTerraformState), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	result, err := CompareTerraformState(dumpFile, stateFile, DiffConfig{Detailed: true})
+	if err != nil {
+		t.Fatalf("CompareTerraformState() error = %v, want nil", err)
+	}
+
+	if len(result.Added) != 1 || result.Added[0].OCID != "ocid1.instance.oc1.ap-tokyo-1.handmade" {
+		t.Errorf("Added = %+v, want only the handmade instance", result.Added)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].OCID != "ocid1.instance.oc1.ap-tokyo-1.deleted" {
+		t.Errorf("Removed = %+v, want only the deleted instance", result.Removed)
+	}
+	if result.Summary.Unchanged != 2 || len(result.Unchanged) != 2 {
+		t.Errorf("Unchanged = %d, want 2 managed resources", result.Summary.Unchanged)
+	}
+	if result.Summary.Modified != 0 {
+		t.Errorf("Modified = %d, want 0", result.Summary.Modified)
+	}
+	if result.Summary.Unverified != 0 || len(result.Unverified) != 0 {
+		t.Errorf("Unverified = %+v, want none", result.Unverified)
+	}
+}
+
+func TestCompareTerraformState_Unverified(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	tempDir := t.TempDir()
+	stateFile := filepath.Join(tempDir, "terraform.tfstate")
+	dumpFile := filepath.Join(tempDir, "resources.json")
+
+	// The dump only covers compute instances of the app compartment
+	dump := []ResourceInfo{
+		{ResourceType: "ComputeInstance", ResourceName: "web", OCID: "ocid1.instance.oc1.ap-tokyo-1.web", CompartmentID: "ocid1.compartment.oc1..app"},
+	}
+	data, err := json.Marshal(dump)
+	if err != nil {
+		t.Fatalf("Failed to marshal test data: %v", err)
+	}
+	if err := os.WriteFile(dumpFile, data, 0644); err != nil {
+		t.Fatalf("Failed to write dump file: %v", err)
+	}
+
+	state := `{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "oci_core_instance", "name": "gone", "instances": [
+      {"attributes": {"id": "ocid1.instance.oc1.ap-tokyo-1.gone", "compartment_id": "ocid1.compartment.oc1..app"}}
+    ]},
+    {"mode": "managed", "type": "oci_core_instance", "name": "other", "instances": [
+      {"attributes": {"id": "ocid1.instance.oc1.ap-tokyo-1.other", "compartment_id": "ocid1.compartment.oc1..other"}}
+    ]},
+    {"mode": "managed", "type": "oci_core_volume", "name": "data", "instances": [
+      {"attributes": {"id": "ocid1.volume.oc1.ap-tokyo-1.data", "compartment_id": "ocid1.compartment.oc1..app"}}
+    ]},
+    {"mode": "managed", "type": "oci_kms_vault", "name": "vault", "instances": [
+      {"attributes": {"id": "ocid1.vault.oc1.ap-tokyo-1.vault", "compartment_id": "ocid1.compartment.oc1..app"}}
+    ]}
+  ]
+}`
+	if err := os.WriteFile(stateFile, []byte(state), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	result, err := CompareTerraformState(dumpFile, stateFile, DiffConfig{})
+	if err != nil {
+		t.Fatalf("CompareTerraformState() error = %v, want nil", err)
+	}
+
+	// Only the instance in a dumped compartment is known to be gone
+	if len(result.Removed) != 1 || result.Removed[0].OCID != "ocid1.instance.oc1.ap-tokyo-1.gone" {
+		t.Errorf("Removed = %+v, want only the instance of the app compartment", result.Removed)
+	}
+
+	// Other compartments, types missing from the dump and unmapped types cannot be verified
+	unverified := make(map[string]bool)
+	for _, resource := range result.Unverified {
+		unverified[resource.OCID] = true
+	}
+	for _, ocid := range []string{"ocid1.instance.oc1.ap-tokyo-1.other", "ocid1.volume.oc1.ap-tokyo-1.data", "ocid1.vault.oc1.ap-tokyo-1.vault"} {
+		if !unverified[ocid] {
+			t.Errorf("Unverified = %+v, want %s", result.Unverified, ocid)
+		}
+	}
+	if result.Summary.Unverified != 3 || !result.Summary.HasChanges() {
+		t.Errorf("Summary = %+v, want 3 unverified and the removed instance as a change", result.Summary)
+	}
+}
@@ -0,0 +1,179 @@
+package ocidump
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// HTTPConfig tunes the HTTP client shared by all OCI clients
+// Every SDK client otherwise opens its own connection pool that keeps only two idle connections
+// per host, so concurrent discoveries close and reopen connections and can exhaust ephemeral ports.
+type HTTPConfig struct {
+	MaxIdleConns        int    `yaml:"max_idle_conns"`          // Idle connections kept across all hosts (default: 100)
+	MaxIdleConnsPerHost int    `yaml:"max_idle_conns_per_host"` // Idle connections kept per host (default: 32)
+	MaxConnsPerHost     int    `yaml:"max_conns_per_host"`      // Connections per host, including active ones (0 = unlimited)
+	IdleConnTimeout     int    `yaml:"idle_conn_timeout"`       // Seconds an idle connection is kept (default: 90)
+	DialTimeout         int    `yaml:"dial_timeout"`            // Seconds to establish a connection (default: 30)
+	TLSHandshakeTimeout int    `yaml:"tls_handshake_timeout"`   // Seconds to complete the TLS handshake (default: 10)
+	RequestTimeout      int    `yaml:"request_timeout"`         // Seconds per request attempt, including reading the response (default: 60)
+	KeepAlive           int    `yaml:"keep_alive"`              // Seconds between TCP keep-alive probes (default: 30, -1 = disabled)
+	Proxy               string `yaml:"proxy"`                   // HTTP proxy URL (empty = HTTPS_PROXY/NO_PROXY from the environment)
+}
+
+// Default HTTP client settings; all but the idle connections per host match the SDK defaults
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultDialTimeout         = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+	defaultRequestTimeout      = 60 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+)
+
+// certRefreshInterval is how often custom CA bundles and client certificates are reloaded,
+// the SDK default for its own transports
+const certRefreshInterval = 30 * time.Minute
+
+// Validate checks the HTTP client settings
+func (c HTTPConfig) Validate() error {
+	settings := []struct {
+		name  string
+		value int
+	}{
+		{"max_idle_conns", c.MaxIdleConns},
+		{"max_idle_conns_per_host", c.MaxIdleConnsPerHost},
+		{"max_conns_per_host", c.MaxConnsPerHost},
+		{"idle_conn_timeout", c.IdleConnTimeout},
+		{"dial_timeout", c.DialTimeout},
+		{"tls_handshake_timeout", c.TLSHandshakeTimeout},
+		{"request_timeout", c.RequestTimeout},
+	}
+	var problems []error
+	for _, setting := range settings {
+		if setting.value < 0 {
+			problems = append(problems, fmt.Errorf("http %s must not be negative, got: %d", setting.name, setting.value))
+		}
+	}
+	if c.KeepAlive < -1 {
+		problems = append(problems, fmt.Errorf("http keep_alive must be -1 (disabled) or more, got: %d", c.KeepAlive))
+	}
+	if _, err := c.proxyURL(); err != nil {
+		problems = append(problems, err)
+	}
+	return errors.Join(problems...)
+}
+
+// proxyURL returns the configured proxy, or nil when the environment decides
+func (c HTTPConfig) proxyURL() (*url.URL, error) {
+	if c.Proxy == "" {
+		return nil, nil
+	}
+	proxy, err := url.Parse(c.Proxy)
+	if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+		return nil, fmt.Errorf("http proxy must be a URL such as http://proxy.example.com:3128, got: %s", c.Proxy)
+	}
+	return proxy, nil
+}
+
+// seconds returns a setting in seconds as a duration, or the default when it is not set
+func seconds(value int, defaultValue time.Duration) time.Duration {
+	if value > 0 {
+		return time.Duration(value) * time.Second
+	}
+	return defaultValue
+}
+
+// newTransport returns the HTTP transport for the settings with the given TLS configuration
+func (c HTTPConfig) newTransport(tlsConfig *tls.Config) (*http.Transport, error) {
+	transport, err := common.DefaultTransport(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	keepAlive := seconds(c.KeepAlive, defaultKeepAlive)
+	if c.KeepAlive < 0 {
+		keepAlive = -1
+	}
+	transport.DialContext = (&net.Dialer{
+		Timeout:   seconds(c.DialTimeout, defaultDialTimeout),
+		KeepAlive: keepAlive,
+	}).DialContext
+	transport.MaxIdleConns = DefaultMaxIdleConns
+	if c.MaxIdleConns > 0 {
+		transport.MaxIdleConns = c.MaxIdleConns
+	}
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if c.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	transport.MaxConnsPerHost = c.MaxConnsPerHost
+	transport.IdleConnTimeout = seconds(c.IdleConnTimeout, defaultIdleConnTimeout)
+	transport.TLSHandshakeTimeout = seconds(c.TLSHandshakeTimeout, defaultTLSHandshakeTimeout)
+
+	proxy, err := c.proxyURL()
+	if err != nil {
+		return nil, err
+	}
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	return transport, nil
+}
+
+// HTTPTransport is the HTTP client shared by the OCI clients
+// Clients of services on the same host, such as compute, networking and block storage, share
+// their idle connections. Custom CA bundles and client certificates configured for the SDK
+// through its environment variables apply as they do to the SDK's own clients.
+type HTTPTransport struct {
+	client *http.Client
+}
+
+// NewHTTPTransport returns a shared HTTP client for the settings
+func NewHTTPTransport(config HTTPConfig) (*HTTPTransport, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	wrapper := &common.OciHTTPTransportWrapper{
+		RefreshRate:       certRefreshInterval,
+		TLSConfigProvider: common.GetTLSConfigTemplateForTransport(),
+		TransportTemplate: func(tlsConfig *tls.Config) (http.RoundTripper, error) {
+			return config.newTransport(tlsConfig)
+		},
+	}
+	// Build the transport once up front so configuration errors surface here
+	if err := wrapper.Refresh(true); err != nil {
+		return nil, err
+	}
+	return &HTTPTransport{client: &http.Client{
+		Timeout:   seconds(config.RequestTimeout, defaultRequestTimeout),
+		Transport: &retryAfterTransport{transport: wrapper},
+	}}, nil
+}
+
+// Apply makes an SDK client send its requests through the shared HTTP client
+// Discoverers that create their own clients call it before RateLimiter.Limit.
+func (t *HTTPTransport) Apply(baseClient *common.BaseClient) {
+	if t == nil {
+		return
+	}
+	baseClient.HTTPClient = t.client
+}
+
+// applyClients makes the SDK service clients of clients use the shared HTTP client
+func (t *HTTPTransport) applyClients(clients *OCIClients) {
+	if t == nil {
+		return
+	}
+	clients.updateBaseClients(func(baseClient *common.BaseClient, service string) {
+		t.Apply(baseClient)
+	})
+}
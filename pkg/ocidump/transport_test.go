@@ -0,0 +1,101 @@
+package ocidump
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+)
+
+func TestHTTPConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  HTTPConfig
+		wantErr bool
+	}{
+		{"empty", HTTPConfig{}, false},
+		{"tuned", HTTPConfig{MaxIdleConnsPerHost: 64, RequestTimeout: 120, KeepAlive: 15, Proxy: "http://proxy.example.com:3128"}, false},
+		{"keep-alive disabled", HTTPConfig{KeepAlive: -1}, false},
+		{"negative idle connections", HTTPConfig{MaxIdleConnsPerHost: -1}, true},
+		{"negative timeout", HTTPConfig{RequestTimeout: -5}, true},
+		{"invalid keep-alive", HTTPConfig{KeepAlive: -2}, true},
+		{"proxy without scheme", HTTPConfig{Proxy: "proxy.example.com:3128"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPConfig_NewTransport(t *testing.T) {
+	transport, err := HTTPConfig{}.newTransport(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || transport.MaxIdleConns != DefaultMaxIdleConns {
+		t.Errorf("default idle connections = %d per host, %d total", transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+	if transport.IdleConnTimeout != defaultIdleConnTimeout || transport.TLSHandshakeTimeout != defaultTLSHandshakeTimeout {
+		t.Errorf("default timeouts = %v idle, %v TLS handshake", transport.IdleConnTimeout, transport.TLSHandshakeTimeout)
+	}
+
+	transport, err = HTTPConfig{MaxIdleConnsPerHost: 64, MaxConnsPerHost: 128, IdleConnTimeout: 30, Proxy: "http://proxy.example.com:3128"}.newTransport(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.MaxIdleConnsPerHost != 64 || transport.MaxConnsPerHost != 128 || transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("configured transport = %d idle per host, %d per host, %v idle timeout",
+			transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, transport.IdleConnTimeout)
+	}
+	request, _ := http.NewRequest(http.MethodGet, "https://iaas.us-ashburn-1.oraclecloud.com", nil)
+	proxy, err := transport.Proxy(request)
+	if err != nil || proxy == nil || proxy.Host != "proxy.example.com:3128" {
+		t.Errorf("proxy = %v, %v", proxy, err)
+	}
+}
+
+func TestHTTPTransport_ApplyClients(t *testing.T) {
+	transport, err := NewHTTPTransport(HTTPConfig{RequestTimeout: 90})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.client.Timeout != 90*time.Second {
+		t.Errorf("request timeout = %v, expected 90s", transport.client.Timeout)
+	}
+
+	networkFake := &fakeVirtualNetwork{}
+	clients := &OCIClients{ComputeClient: core.ComputeClient{}, IdentityClient: identity.IdentityClient{}, VirtualNetworkClient: networkFake}
+	transport.applyClients(clients)
+
+	// Clients of all services share one HTTP client and so one connection pool
+	compute := clients.ComputeClient.(core.ComputeClient)
+	identityClient := clients.IdentityClient.(identity.IdentityClient)
+	if compute.HTTPClient != transport.client || identityClient.HTTPClient != transport.client {
+		t.Errorf("clients do not use the shared HTTP client: %v, %v", compute.HTTPClient, identityClient.HTTPClient)
+	}
+	if clients.VirtualNetworkClient != networkFake {
+		t.Error("clients that are not SDK clients must be left alone")
+	}
+
+	// Apply on a nil transport keeps the SDK default
+	var nilTransport *HTTPTransport
+	baseClient := common.BaseClient{HTTPClient: &http.Client{}}
+	nilTransport.Apply(&baseClient)
+	if baseClient.HTTPClient == transport.client {
+		t.Error("nil transport must not change the client")
+	}
+}
+
+func TestNewHTTPTransport_InvalidConfig(t *testing.T) {
+	if _, err := NewHTTPTransport(HTTPConfig{Proxy: "://"}); err == nil {
+		t.Error("expected an error for an invalid proxy")
+	}
+}
@@ -0,0 +1,118 @@
+package ocidump
+
+import (
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// Config holds the application configuration
+type Config struct {
+	OutputFormat string
+	Timeout      time.Duration
+	MaxWorkers   int
+	LogLevel     LogLevel
+	Logger       *Logger
+	ShowProgress bool
+	Detail       bool
+	SortKeys     []string
+	Filters      FilterConfig
+}
+
+// OCIClients holds all OCI service clients
+type OCIClients struct {
+	ComputeClient              ComputeAPI
+	VirtualNetworkClient       VirtualNetworkAPI
+	BlockStorageClient         BlockStorageAPI
+	IdentityClient             IdentityAPI
+	ObjectStorageClient        ObjectStorageAPI
+	ContainerEngineClient      ContainerEngineAPI
+	LoadBalancerClient         LoadBalancerAPI
+	DatabaseClient             DatabaseAPI
+	APIGatewayClient           APIGatewayAPI
+	FunctionsClient            FunctionsAPI
+	FileStorageClient          FileStorageAPI
+	NetworkLoadBalancerClient  NetworkLoadBalancerAPI
+	StreamingClient            StreamingAPI
+	OpenSearchClient           OpenSearchAPI
+	OpenSearchBackupClient     OpenSearchBackupAPI
+	QuotasClient               QuotasAPI
+	ComputeManagementClient    ComputeManagementAPI
+	HealthChecksClient         HealthChecksAPI
+	ManagedInstanceClient      ManagedInstanceAPI
+	ManagedInstanceGroupClient ManagedInstanceGroupAPI
+	OperationsInsightsClient   OperationsInsightsAPI
+	DbManagementClient         DbManagementAPI
+	BlockchainPlatformClient   BlockchainPlatformAPI
+	CompartmentCache           *ResourceNameCache
+	Options                    DiscoveryOptions
+
+	// ConfigProvider is the authentication provider the clients were created with,
+	// so registered discoverers can create clients for services not listed above
+	ConfigProvider common.ConfigurationProvider
+
+	// IdentityDomainsClient creates the client of the identity domain at the given URL, since each
+	// domain serves its users and groups from its own endpoint. Nil skips identity domains.
+	IdentityDomainsClient func(domainURL string) (IdentityDomainsAPI, error)
+
+	// Transport is the HTTP client shared by the clients above; registered discoverers apply it
+	// to the clients they create with Transport.Apply. Nil when the SDK defaults are used.
+	Transport *HTTPTransport
+
+	// RateLimiter throttles the requests of the clients above; registered discoverers apply it
+	// to the clients they create with RateLimiter.Limit. Nil when no rate limit is configured.
+	RateLimiter *RateLimiter
+}
+
+// DiscoveryOptions holds settings that control how much detail discovery functions fetch
+type DiscoveryOptions struct {
+	Detail   bool   // Fetch per-resource details that require additional API calls
+	Mode     string // DiscoveryModeFull (default) or DiscoveryModeSearch
+	PageSize int    // Items requested per page of list operations (0 = DefaultPageSize), capped at each operation's maximum
+
+	// CircuitBreakerThreshold is the number of discoveries in a row a service may fail before its
+	// resource types are skipped for the rest of the run (0 = DefaultCircuitBreakerThreshold, -1 = never)
+	CircuitBreakerThreshold int
+}
+
+// DefaultPageSize is the page size requested from list operations unless configured otherwise
+const DefaultPageSize = 1000
+
+// maxPageSize is the largest page size most OCI list operations accept
+const maxPageSize = 1000
+
+// ResourceInfo represents a discovered OCI resource
+type ResourceInfo struct {
+	ResourceType    string                 `json:"resource_type"`
+	CompartmentName string                 `json:"compartment_name"`
+	ResourceName    string                 `json:"resource_name"`
+	OCID            string                 `json:"ocid"`
+	CompartmentID   string                 `json:"compartment_id"`
+	AdditionalInfo  map[string]interface{} `json:"additional_info"`
+
+	TimeCreated    string                            `json:"time_created,omitempty"`
+	LifecycleState string                            `json:"lifecycle_state,omitempty"`
+	FreeformTags   map[string]string                 `json:"freeform_tags,omitempty"`
+	DefinedTags    map[string]map[string]interface{} `json:"defined_tags,omitempty"`
+
+	Cost *ResourceCost `json:"cost,omitempty"` // Set by the cost enrichment (--with-cost)
+}
+
+// ResourceNameCache provides thread-safe caching of names by OCID
+// Compartment names are preloaded and fetched on demand; names of discovered resources are added
+// after discovery so references in AdditionalInfo can be resolved
+type ResourceNameCache struct {
+	mu        sync.RWMutex
+	cache     map[string]string // OCID -> Name mapping
+	client    IdentityAPI
+	pageLimit *int // Items per page of the compartment preload (nil = service default)
+
+	// store, when set, persists the preloaded compartment tree of tenancyID between runs
+	store     *CompartmentTreeCache
+	tenancyID string
+	fromStore bool // The names were loaded from store, so a missing compartment means it is stale
+}
+
+// CompartmentNameCache is the former name of ResourceNameCache
+type CompartmentNameCache = ResourceNameCache
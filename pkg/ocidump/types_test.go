@@ -1,4 +1,4 @@
-package main
+package ocidump
 
 import (
 	"encoding/json"
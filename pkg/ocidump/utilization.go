@@ -0,0 +1,247 @@
+package ocidump
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+)
+
+// defaultUtilizationDays is the length of the metrics period when utilization.days is not set
+const defaultUtilizationDays = 7
+
+// utilizationWorkers limits the concurrent Monitoring API queries
+const utilizationWorkers = 5
+
+// UtilizationConfig holds the settings of the Monitoring API utilization enrichment
+type UtilizationConfig struct {
+	Enabled bool `yaml:"enabled"` // Add summary metrics to AdditionalInfo
+	Days    int  `yaml:"days"`    // Length of the metrics period in days (default: 7)
+}
+
+// Validate checks the utilization settings
+func (c UtilizationConfig) Validate() error {
+	// Monitoring keeps metrics for 90 days
+	if c.Days < 0 || c.Days > 90 {
+		return fmt.Errorf("utilization days must be between 1 and 90, got: %d", c.Days)
+	}
+	return nil
+}
+
+// days returns the configured period length, defaulting to 7 days
+func (c UtilizationConfig) days() int {
+	if c.Days == 0 {
+		return defaultUtilizationDays
+	}
+	return c.Days
+}
+
+// utilizationMetric describes a summary metric added to resources of one type
+type utilizationMetric struct {
+	resourceType string // ResourceInfo.ResourceType
+	namespace    string // Monitoring namespace
+	metric       string // Metric name
+	statistic    string // "mean" or "max": MQL statistic of each daily value, and how daily values are combined
+	dimension    string // Dimension identifying the resource
+	byName       bool   // The dimension holds the resource name instead of the OCID
+	key          string // AdditionalInfo key
+}
+
+// utilizationMetrics lists the metrics fetched by --with-metrics
+// Block and boot volumes do not report used capacity, so their I/O throughput is used to spot idle volumes
+var utilizationMetrics = []utilizationMetric{
+	{"ComputeInstance", "oci_computeagent", "CpuUtilization", "mean", "resourceId", false, "cpu_utilization_avg"},
+	{"ComputeInstance", "oci_computeagent", "CpuUtilization", "max", "resourceId", false, "cpu_utilization_max"},
+	{"ComputeInstance", "oci_computeagent", "MemoryUtilization", "mean", "resourceId", false, "memory_utilization_avg"},
+	{"AutonomousDatabase", "oci_autonomous_database", "CpuUtilization", "mean", "resourceId", false, "cpu_utilization_avg"},
+	{"AutonomousDatabase", "oci_autonomous_database", "CpuUtilization", "max", "resourceId", false, "cpu_utilization_max"},
+	{"AutonomousDatabase", "oci_autonomous_database", "StorageUtilization", "max", "resourceId", false, "storage_utilization_max"},
+	{"ObjectStorageBucket", "oci_objectstorage", "StoredBytes", "max", "resourceDisplayName", true, "stored_bytes_max"},
+	{"BlockVolume", "oci_blockstore", "VolumeReadThroughput", "mean", "resourceId", false, "read_throughput_avg"},
+	{"BlockVolume", "oci_blockstore", "VolumeWriteThroughput", "mean", "resourceId", false, "write_throughput_avg"},
+	{"BootVolume", "oci_blockstore", "VolumeReadThroughput", "mean", "resourceId", false, "read_throughput_avg"},
+	{"BootVolume", "oci_blockstore", "VolumeWriteThroughput", "mean", "resourceId", false, "write_throughput_avg"},
+}
+
+// query returns the MQL query of the metric with one value per day
+func (m utilizationMetric) query() string {
+	return fmt.Sprintf("%s[1d].%s()", m.metric, m.statistic)
+}
+
+// resourceKey returns the value of the identifying dimension for a resource
+func (m utilizationMetric) resourceKey(resource ResourceInfo) string {
+	if m.byName {
+		return resource.ResourceName
+	}
+	return resource.OCID
+}
+
+// combine reduces the daily values of a time series to a single value
+func (m utilizationMetric) combine(values []float64) float64 {
+	result := values[0]
+	if m.statistic == "max" {
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+		return result
+	}
+	for _, value := range values[1:] {
+		result += value
+	}
+	return result / float64(len(values))
+}
+
+// utilizationQuery is one Monitoring API query: a metric in a compartment
+type utilizationQuery struct {
+	metric        utilizationMetric
+	compartmentID string
+}
+
+// enrichUtilization adds summary metrics over the configured period to the AdditionalInfo of
+// the resources listed in utilizationMetrics. Failed queries are logged and skipped.
+func enrichUtilization(ctx context.Context, clients *OCIClients, resources []ResourceInfo, config UtilizationConfig) {
+	client, err := monitoring.NewMonitoringClientWithConfigurationProvider(clients.ConfigProvider)
+	if err != nil {
+		logger.Error("Warning: Could not create monitoring client, resources are written without metrics: %v", err)
+		return
+	}
+	clients.Transport.Apply(&client.BaseClient)
+	clients.RateLimiter.Limit(&client.BaseClient, "monitoring")
+
+	queries := utilizationQueries(resources)
+	if len(queries) == 0 {
+		return
+	}
+	end := time.Now().UTC().Truncate(time.Hour)
+	start := end.AddDate(0, 0, -config.days())
+	logger.Verbose("Fetching utilization metrics with %d queries for the last %d days", len(queries), config.days())
+
+	// values[key][resource key] holds the summarized value of each metric
+	values := make(map[string]map[string]float64)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan utilizationQuery)
+	for i := 0; i < utilizationWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for query := range jobs {
+				series, err := summarizeMetric(ctx, client, query, start, end)
+				if err != nil {
+					logger.Verbose("Warning: Could not fetch %s/%s in compartment %s: %v", query.metric.namespace, query.metric.metric, query.compartmentID, err)
+					continue
+				}
+				mu.Lock()
+				metricKey := query.metric.resourceType + "/" + query.metric.key
+				if values[metricKey] == nil {
+					values[metricKey] = make(map[string]float64)
+				}
+				for resourceKey, value := range series {
+					values[metricKey][resourceKey] = value
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, query := range queries {
+		jobs <- query
+	}
+	close(jobs)
+	wg.Wait()
+
+	applyUtilization(resources, values)
+}
+
+// utilizationQueries returns a query per metric and compartment containing resources of its type
+func utilizationQueries(resources []ResourceInfo) []utilizationQuery {
+	compartments := make(map[string]map[string]bool)
+	for _, resource := range resources {
+		if compartments[resource.ResourceType] == nil {
+			compartments[resource.ResourceType] = make(map[string]bool)
+		}
+		compartments[resource.ResourceType][resource.CompartmentID] = true
+	}
+
+	var queries []utilizationQuery
+	for _, metric := range utilizationMetrics {
+		var compartmentIDs []string
+		for compartmentID := range compartments[metric.resourceType] {
+			compartmentIDs = append(compartmentIDs, compartmentID)
+		}
+		sort.Strings(compartmentIDs)
+		for _, compartmentID := range compartmentIDs {
+			queries = append(queries, utilizationQuery{metric: metric, compartmentID: compartmentID})
+		}
+	}
+	return queries
+}
+
+// summarizeMetric runs a query and returns the summarized value keyed by the identifying dimension
+func summarizeMetric(ctx context.Context, client monitoring.MonitoringClient, query utilizationQuery, start, end time.Time) (map[string]float64, error) {
+	var resp monitoring.SummarizeMetricsDataResponse
+	operation := func() error {
+		var err error
+		resp, err = client.SummarizeMetricsData(ctx, monitoring.SummarizeMetricsDataRequest{
+			CompartmentId: common.String(query.compartmentID),
+			SummarizeMetricsDataDetails: monitoring.SummarizeMetricsDataDetails{
+				Namespace: common.String(query.metric.namespace),
+				Query:     common.String(query.metric.query()),
+				StartTime: &common.SDKTime{Time: start},
+				EndTime:   &common.SDKTime{Time: end},
+			},
+		})
+		return err
+	}
+	if err := withRetryAndProgress(ctx, operation, 3, "monitoring", nil); err != nil {
+		return nil, err
+	}
+	return summarizeMetricData(resp.Items, query.metric), nil
+}
+
+// summarizeMetricData combines the datapoints of each time series into one value per resource
+func summarizeMetricData(items []monitoring.MetricData, metric utilizationMetric) map[string]float64 {
+	series := make(map[string][]float64)
+	for _, item := range items {
+		resourceKey := item.Dimensions[metric.dimension]
+		if resourceKey == "" {
+			continue
+		}
+		for _, datapoint := range item.AggregatedDatapoints {
+			if datapoint.Value != nil {
+				series[resourceKey] = append(series[resourceKey], *datapoint.Value)
+			}
+		}
+	}
+
+	result := make(map[string]float64, len(series))
+	for resourceKey, values := range series {
+		if len(values) > 0 {
+			result[resourceKey] = math.Round(metric.combine(values)*100) / 100
+		}
+	}
+	return result
+}
+
+// applyUtilization adds the summarized values to the AdditionalInfo of matching resources
+func applyUtilization(resources []ResourceInfo, values map[string]map[string]float64) {
+	for i := range resources {
+		for _, metric := range utilizationMetrics {
+			if metric.resourceType != resources[i].ResourceType {
+				continue
+			}
+			value, ok := values[metric.resourceType+"/"+metric.key][metric.resourceKey(resources[i])]
+			if !ok {
+				continue
+			}
+			if resources[i].AdditionalInfo == nil {
+				resources[i].AdditionalInfo = make(map[string]interface{})
+			}
+			resources[i].AdditionalInfo[metric.key] = value
+		}
+	}
+}
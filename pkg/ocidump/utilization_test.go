@@ -0,0 +1,64 @@
+package ocidump
+
+import (
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+)
+
+func TestSummarizeMetricData(t *testing.T) {
+	value := func(v float64) monitoring.AggregatedDatapoint {
+		return monitoring.AggregatedDatapoint{Value: &v}
+	}
+	items := []monitoring.MetricData{
+		{Dimensions: map[string]string{"resourceId": "ocid1.instance.oc1..a"}, AggregatedDatapoints: []monitoring.AggregatedDatapoint{value(10), value(20), value(35)}},
+		{Dimensions: map[string]string{"resourceId": "ocid1.instance.oc1..b"}, AggregatedDatapoints: []monitoring.AggregatedDatapoint{value(1.234)}},
+		{Dimensions: map[string]string{"resourceDisplayName": "no-id"}, AggregatedDatapoints: []monitoring.AggregatedDatapoint{value(99)}},
+		{Dimensions: map[string]string{"resourceId": "ocid1.instance.oc1..empty"}},
+	}
+
+	mean := summarizeMetricData(items, utilizationMetric{statistic: "mean", dimension: "resourceId"})
+	if len(mean) != 2 || mean["ocid1.instance.oc1..a"] != 21.67 || mean["ocid1.instance.oc1..b"] != 1.23 {
+		t.Errorf("mean = %v", mean)
+	}
+	peak := summarizeMetricData(items, utilizationMetric{statistic: "max", dimension: "resourceId"})
+	if peak["ocid1.instance.oc1..a"] != 35 {
+		t.Errorf("max = %v", peak)
+	}
+}
+
+func TestUtilizationQueriesAndApply(t *testing.T) {
+	resources := []ResourceInfo{
+		{ResourceType: "ComputeInstance", OCID: "ocid1.instance.oc1..a", CompartmentID: "c1", AdditionalInfo: map[string]interface{}{"shape": "VM.Standard.E4.Flex"}},
+		{ResourceType: "ObjectStorageBucket", ResourceName: "logs", OCID: "bucket:ns:logs", CompartmentID: "c2"},
+		{ResourceType: "VCN", OCID: "ocid1.vcn.oc1..v", CompartmentID: "c1"},
+	}
+
+	queries := utilizationQueries(resources)
+	for _, query := range queries {
+		if query.metric.resourceType == "VCN" || (query.metric.resourceType == "ComputeInstance" && query.compartmentID != "c1") {
+			t.Errorf("unexpected query %+v", query)
+		}
+	}
+	// 3 instance metrics in c1 and 1 bucket metric in c2
+	if len(queries) != 4 {
+		t.Errorf("expected 4 queries, got %d", len(queries))
+	}
+
+	applyUtilization(resources, map[string]map[string]float64{
+		"ComputeInstance/cpu_utilization_avg":  {"ocid1.instance.oc1..a": 12.5},
+		"ObjectStorageBucket/stored_bytes_max": {"logs": 1024},
+	})
+	if resources[0].AdditionalInfo["cpu_utilization_avg"] != 12.5 || resources[0].AdditionalInfo["shape"] == nil {
+		t.Errorf("instance AdditionalInfo = %v", resources[0].AdditionalInfo)
+	}
+	if _, ok := resources[0].AdditionalInfo["memory_utilization_avg"]; ok {
+		t.Errorf("metric without data was added")
+	}
+	if resources[1].AdditionalInfo["stored_bytes_max"] != float64(1024) {
+		t.Errorf("bucket AdditionalInfo = %v, expected metric matched by name", resources[1].AdditionalInfo)
+	}
+	if resources[2].AdditionalInfo != nil {
+		t.Errorf("VCN AdditionalInfo = %v", resources[2].AdditionalInfo)
+	}
+}
@@ -0,0 +1,155 @@
+package ocidump
+
+import (
+	"context"
+	"sync"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// vnicLookupWorkers is the number of concurrent GetVnic calls of a batch
+const vnicLookupWorkers = 5
+
+// vnicResolver resolves the VNICs and private IP addresses of a compartment in bulk
+// Discovery functions collect the VNICs of all their resources and look them up in one batch,
+// with a single ListVnicAttachments for the instances of the compartment and one ListPrivateIps
+// per subnet, instead of a chain of calls per resource. Lookups are cached for the resolver's lifetime.
+type vnicResolver struct {
+	clients *OCIClients
+
+	mu        sync.Mutex
+	vnicCache map[string]core.Vnic
+	subnetIPs map[string]map[string]string // subnet ID -> private IP ID -> address
+}
+
+func newVNICResolver(clients *OCIClients) *vnicResolver {
+	return &vnicResolver{
+		clients:   clients,
+		vnicCache: make(map[string]core.Vnic),
+		subnetIPs: make(map[string]map[string]string),
+	}
+}
+
+// instanceVnics returns the IDs of the attached VNICs of each instance in a compartment
+func (r *vnicResolver) instanceVnics(ctx context.Context, compartmentID string) (map[string][]string, error) {
+	vnics := make(map[string][]string)
+	var page *string
+	for {
+		req := core.ListVnicAttachmentsRequest{
+			CompartmentId: common.String(compartmentID),
+			Page:          page,
+			Limit:         r.clients.pageLimit(maxPageSize),
+		}
+		resp, err := r.clients.ComputeClient.ListVnicAttachments(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, attachment := range resp.Items {
+			if attachment.InstanceId != nil && attachment.VnicId != nil && attachment.LifecycleState == core.VnicAttachmentLifecycleStateAttached {
+				vnics[*attachment.InstanceId] = append(vnics[*attachment.InstanceId], *attachment.VnicId)
+			}
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+	return vnics, nil
+}
+
+// vnics fetches the given VNICs concurrently and returns them by ID
+// VNICs that cannot be fetched are logged and left out.
+func (r *vnicResolver) vnics(ctx context.Context, vnicIDs []string) map[string]core.Vnic {
+	var missing []string
+	r.mu.Lock()
+	for _, id := range vnicIDs {
+		if _, ok := r.vnicCache[id]; !ok && !contains(missing, id) {
+			missing = append(missing, id)
+		}
+	}
+	r.mu.Unlock()
+
+	if len(missing) > 0 {
+		logger.Debug("Fetching %d VNICs", len(missing))
+		forEachConcurrently(len(missing), vnicLookupWorkers, func(i int) {
+			resp, err := r.clients.VirtualNetworkClient.GetVnic(ctx, core.GetVnicRequest{VnicId: common.String(missing[i])})
+			if err != nil {
+				logger.Verbose("Error getting VNIC %s: %v", missing[i], err)
+				return
+			}
+			r.mu.Lock()
+			r.vnicCache[missing[i]] = resp.Vnic
+			r.mu.Unlock()
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	vnics := make(map[string]core.Vnic, len(vnicIDs))
+	for _, id := range vnicIDs {
+		if vnic, ok := r.vnicCache[id]; ok {
+			vnics[id] = vnic
+		}
+	}
+	return vnics
+}
+
+// privateIPAddress returns the address of a private IP in a subnet
+// All private IPs of the subnet are listed on the first lookup; private IPs that are not
+// listed fall back to GetPrivateIp.
+func (r *vnicResolver) privateIPAddress(ctx context.Context, subnetID, privateIPID string) (string, error) {
+	if subnetID != "" {
+		if address, ok := r.subnetPrivateIPs(ctx, subnetID)[privateIPID]; ok {
+			return address, nil
+		}
+	}
+
+	resp, err := r.clients.VirtualNetworkClient.GetPrivateIp(ctx, core.GetPrivateIpRequest{PrivateIpId: common.String(privateIPID)})
+	if err != nil {
+		return "", err
+	}
+	if resp.PrivateIp.IpAddress == nil {
+		return "", nil
+	}
+	return *resp.PrivateIp.IpAddress, nil
+}
+
+// subnetPrivateIPs returns the addresses of the private IPs of a subnet by private IP ID
+func (r *vnicResolver) subnetPrivateIPs(ctx context.Context, subnetID string) map[string]string {
+	r.mu.Lock()
+	addresses, ok := r.subnetIPs[subnetID]
+	r.mu.Unlock()
+	if ok {
+		return addresses
+	}
+
+	addresses = make(map[string]string)
+	var page *string
+	for {
+		req := core.ListPrivateIpsRequest{
+			SubnetId: common.String(subnetID),
+			Page:     page,
+			Limit:    r.clients.pageLimit(maxPageSize),
+		}
+		resp, err := r.clients.VirtualNetworkClient.ListPrivateIps(ctx, req)
+		if err != nil {
+			logger.Verbose("Error listing private IPs for subnet %s: %v", subnetID, err)
+			break
+		}
+		for _, privateIP := range resp.Items {
+			if privateIP.Id != nil && privateIP.IpAddress != nil {
+				addresses[*privateIP.Id] = *privateIP.IpAddress
+			}
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+
+	r.mu.Lock()
+	r.subnetIPs[subnetID] = addresses
+	r.mu.Unlock()
+	return addresses
+}
@@ -0,0 +1,187 @@
+package ocidump
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	"github.com/oracle/oci-go-sdk/v65/filestorage"
+)
+
+func TestVNICResolver_Vnics(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	network := &fakeVirtualNetwork{vnics: map[string]core.Vnic{}}
+	var ids []string
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("ocid1.vnic.oc1..%d", i)
+		network.vnics[id] = core.Vnic{PrivateIp: common.String(fmt.Sprintf("10.0.0.%d", i))}
+		ids = append(ids, id, id)
+	}
+	resolver := newVNICResolver(newFakeOCIClients(&fakeIdentity{}, network, &fakeCompute{}))
+
+	vnics := resolver.vnics(context.Background(), append(ids, "ocid1.vnic.oc1..missing"))
+	if len(vnics) != 12 || *vnics["ocid1.vnic.oc1..7"].PrivateIp != "10.0.0.7" {
+		t.Errorf("resolved %d VNICs: %v", len(vnics), vnics)
+	}
+	if calls := network.count("GetVnic"); calls != 13 {
+		t.Errorf("GetVnic called %d times, want each VNIC once", calls)
+	}
+
+	// Resolved VNICs are cached
+	resolver.vnics(context.Background(), ids[:4])
+	if calls := network.count("GetVnic"); calls != 13 {
+		t.Errorf("GetVnic called %d times after a cached lookup", calls)
+	}
+}
+
+func TestDiscoverDbNodes_PrivateIPs(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	db := &fakeDatabase{
+		dbSystems: []database.DbSystemSummary{
+			{Id: common.String("ocid1.dbsystem.oc1..a"), DisplayName: common.String("db-a"), LifecycleState: database.DbSystemSummaryLifecycleStateAvailable},
+			{Id: common.String("ocid1.dbsystem.oc1..b"), DisplayName: common.String("db-b"), LifecycleState: database.DbSystemSummaryLifecycleStateAvailable},
+		},
+		dbNodes: []database.DbNodeSummary{
+			{Id: common.String("ocid1.dbnode.oc1..a1"), DbSystemId: common.String("ocid1.dbsystem.oc1..a"), Hostname: common.String("node-a1"),
+				VnicId: common.String("ocid1.vnic.oc1..a1"), BackupVnicId: common.String("ocid1.vnic.oc1..a1b"), LifecycleState: database.DbNodeSummaryLifecycleStateAvailable},
+			{Id: common.String("ocid1.dbnode.oc1..b1"), DbSystemId: common.String("ocid1.dbsystem.oc1..b"), Hostname: common.String("node-b1"),
+				VnicId: common.String("ocid1.vnic.oc1..b1"), LifecycleState: database.DbNodeSummaryLifecycleStateAvailable},
+			{Id: common.String("ocid1.dbnode.oc1..b2"), DbSystemId: common.String("ocid1.dbsystem.oc1..b"), Hostname: common.String("node-b2"),
+				VnicId: common.String("ocid1.vnic.oc1..b2"), LifecycleState: database.DbNodeSummaryLifecycleStateTerminated},
+		},
+	}
+	network := &fakeVirtualNetwork{vnics: map[string]core.Vnic{
+		"ocid1.vnic.oc1..a1":  {PrivateIp: common.String("10.0.2.10")},
+		"ocid1.vnic.oc1..a1b": {PrivateIp: common.String("10.0.3.10")},
+		"ocid1.vnic.oc1..b1":  {PrivateIp: common.String("10.0.2.20")},
+	}}
+	clients := newFakeOCIClients(&fakeIdentity{}, network, &fakeCompute{})
+	clients.DatabaseClient = db
+
+	resources, err := discoverDbNodes(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverDbNodes() error = %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("discovered %d nodes, want 2 (terminated skipped)", len(resources))
+	}
+	a1, b1 := resources[0].AdditionalInfo, resources[1].AdditionalInfo
+	if a1["private_ip"] != "10.0.2.10" || a1["backup_private_ip"] != "10.0.3.10" || a1["db_system_name"] != "db-a" {
+		t.Errorf("node-a1 additional info = %v", a1)
+	}
+	if b1["private_ip"] != "10.0.2.20" || b1["backup_private_ip"] != nil {
+		t.Errorf("node-b1 additional info = %v", b1)
+	}
+	// The VNIC of the terminated node is not looked up
+	if calls := network.count("GetVnic"); calls != 3 {
+		t.Errorf("GetVnic called %d times, want 3", calls)
+	}
+}
+
+func TestDiscoverMountTargets_PrivateIPs(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	subnet := common.String("ocid1.subnet.oc1..fss")
+	fss := &fakeFileStorage{mountTargets: []filestorage.MountTargetSummary{
+		{Id: common.String("ocid1.mounttarget.oc1..a"), DisplayName: common.String("mt-a"), AvailabilityDomain: common.String("AD-1"), SubnetId: subnet,
+			PrivateIpIds: []string{"ocid1.privateip.oc1..a"}, LifecycleState: filestorage.MountTargetSummaryLifecycleStateActive},
+		{Id: common.String("ocid1.mounttarget.oc1..b"), DisplayName: common.String("mt-b"), AvailabilityDomain: common.String("AD-2"), SubnetId: subnet,
+			PrivateIpIds: []string{"ocid1.privateip.oc1..b", "ocid1.privateip.oc1..other"}, LifecycleState: filestorage.MountTargetSummaryLifecycleStateActive},
+	}}
+	network := &fakeVirtualNetwork{privateIPs: []core.PrivateIp{
+		{Id: common.String("ocid1.privateip.oc1..a"), SubnetId: subnet, IpAddress: common.String("10.0.4.10")},
+		{Id: common.String("ocid1.privateip.oc1..b"), SubnetId: subnet, IpAddress: common.String("10.0.4.20")},
+		{Id: common.String("ocid1.privateip.oc1..c"), SubnetId: subnet, IpAddress: common.String("10.0.4.30")},
+		{Id: common.String("ocid1.privateip.oc1..other"), SubnetId: common.String("ocid1.subnet.oc1..other"), IpAddress: common.String("10.0.5.10")},
+	}}
+	clients := newFakeOCIClients(&fakeIdentity{availabilityDomains: []string{"AD-1", "AD-2"}}, network, &fakeCompute{})
+	clients.FileStorageClient = fss
+
+	resources, err := discoverMountTargets(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverMountTargets() error = %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("discovered %d mount targets, want 2", len(resources))
+	}
+	if got := fmt.Sprint(resources[0].AdditionalInfo["private_ips"]); got != "[10.0.4.10]" {
+		t.Errorf("mt-a private IPs = %s", got)
+	}
+	if got := fmt.Sprint(resources[1].AdditionalInfo["private_ips"]); got != "[10.0.4.20 10.0.5.10]" {
+		t.Errorf("mt-b private IPs = %s", got)
+	}
+	// The shared subnet is listed once across availability domains (two pages); only the
+	// private IP outside it is fetched on its own
+	if calls := network.count("ListPrivateIps"); calls != 2 {
+		t.Errorf("ListPrivateIps called %d times, want 2 pages of one subnet", calls)
+	}
+	if calls := network.count("GetPrivateIp"); calls != 1 {
+		t.Errorf("GetPrivateIp called %d times, want 1", calls)
+	}
+}
+
+func TestDiscoverVnics(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+	const prod = "ocid1.compartment.oc1..prod"
+	const other = "ocid1.compartment.oc1..other"
+	const subnet = "ocid1.subnet.oc1..app"
+	compute := &fakeCompute{
+		vnicAttachments: []core.VnicAttachment{
+			{InstanceId: common.String("ocid1.instance.oc1..web"), VnicId: common.String("ocid1.vnic.oc1..web"), LifecycleState: core.VnicAttachmentLifecycleStateAttached},
+			{InstanceId: common.String("ocid1.instance.oc1..old"), VnicId: common.String("ocid1.vnic.oc1..detached"), LifecycleState: core.VnicAttachmentLifecycleStateDetached},
+		},
+	}
+	network := &fakeVirtualNetwork{
+		subnets: []core.Subnet{{Id: common.String(subnet), CompartmentId: common.String(prod), LifecycleState: core.SubnetLifecycleStateAvailable}},
+		vnics: map[string]core.Vnic{
+			"ocid1.vnic.oc1..web": {Id: common.String("ocid1.vnic.oc1..web"), DisplayName: common.String("web"), CompartmentId: common.String(prod), SubnetId: common.String(subnet),
+				PrivateIp: common.String("10.0.0.10"), MacAddress: common.String("02:00:17:00:00:01"), IsPrimary: common.Bool(true), LifecycleState: core.VnicLifecycleStateAvailable},
+			"ocid1.vnic.oc1..lb": {Id: common.String("ocid1.vnic.oc1..lb"), DisplayName: common.String("lb"), CompartmentId: common.String(prod), SubnetId: common.String(subnet),
+				PrivateIp: common.String("10.0.0.30"), LifecycleState: core.VnicLifecycleStateAvailable},
+			"ocid1.vnic.oc1..shared": {Id: common.String("ocid1.vnic.oc1..shared"), CompartmentId: common.String(other), SubnetId: common.String(subnet), LifecycleState: core.VnicLifecycleStateAvailable},
+		},
+		privateIPs: []core.PrivateIp{
+			{Id: common.String("ocid1.privateip.oc1..web2"), SubnetId: common.String(subnet), VnicId: common.String("ocid1.vnic.oc1..web"), IpAddress: common.String("10.0.0.11"), IsPrimary: common.Bool(false)},
+			{Id: common.String("ocid1.privateip.oc1..web1"), SubnetId: common.String(subnet), VnicId: common.String("ocid1.vnic.oc1..web"), IpAddress: common.String("10.0.0.10"), IsPrimary: common.Bool(true)},
+			{Id: common.String("ocid1.privateip.oc1..lb"), SubnetId: common.String(subnet), VnicId: common.String("ocid1.vnic.oc1..lb"), IpAddress: common.String("10.0.0.30"), IsPrimary: common.Bool(true)},
+			{Id: common.String("ocid1.privateip.oc1..shared"), SubnetId: common.String(subnet), VnicId: common.String("ocid1.vnic.oc1..shared"), IpAddress: common.String("10.0.0.40"), IsPrimary: common.Bool(true)},
+		},
+	}
+	clients := newFakeOCIClients(&fakeIdentity{}, network, compute)
+
+	resources, err := discoverVnics(context.Background(), clients, prod)
+	if err != nil {
+		t.Fatalf("discoverVnics() error = %v", err)
+	}
+	byName := make(map[string]ResourceInfo)
+	for _, resource := range resources {
+		if resource.ResourceType != "Vnic" {
+			t.Errorf("resource type = %q, want Vnic", resource.ResourceType)
+		}
+		byName[resource.ResourceName] = resource
+	}
+	if len(resources) != 2 {
+		t.Fatalf("discovered %d VNICs, want 2 (VNICs of other compartments skipped): %v", len(resources), resources)
+	}
+
+	web := byName["web"].AdditionalInfo
+	if web["instance_id"] != "ocid1.instance.oc1..web" || web["mac_address"] != "02:00:17:00:00:01" || web["private_ip_count"] != 2 {
+		t.Errorf("web additional info = %v", web)
+	}
+	if ips, _ := web["private_ips"].([]string); len(ips) != 2 || ips[0] != "10.0.0.10" || ips[1] != "10.0.0.11" {
+		t.Errorf("web private IPs = %v, want the primary address first", web["private_ips"])
+	}
+
+	// Service VNICs have no attachment and are found through the private IPs of the subnet
+	lb := byName["lb"]
+	if _, ok := lb.AdditionalInfo["instance_id"]; ok || lb.OCID != "ocid1.vnic.oc1..lb" || lb.LifecycleState != "AVAILABLE" {
+		t.Errorf("lb VNIC = %+v", lb)
+	}
+	if calls := network.count("GetVnic"); calls != 3 {
+		t.Errorf("GetVnic called %d times, want each VNIC once", calls)
+	}
+}
@@ -0,0 +1,48 @@
+package ocidump
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MinWatchInterval is the shortest interval accepted for watch mode
+// Shorter intervals would start a discovery before the previous one has finished on most tenancies.
+const MinWatchInterval = time.Minute
+
+// RunInterval calls run right away and then every interval until ctx is cancelled
+// The interval is measured from the start of a run, so slow runs do not make the watch drift.
+// Errors returned by run are logged and the next run is still scheduled.
+func RunInterval(ctx context.Context, interval time.Duration, run func(ctx context.Context, startedAt time.Time) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	startedAt := time.Now()
+	for {
+		if err := run(ctx, startedAt); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Error("Watch run failed: %v", err)
+		}
+		logger.Info("Next run at %s", startedAt.Add(interval).Format(time.RFC3339))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case startedAt = <-ticker.C:
+		}
+	}
+}
+
+// WatchDiffPath returns the file the changes found by the watch run started at t are written to
+// The UTC timestamp is inserted before the extension so every iteration keeps its own report;
+// stdout is returned unchanged.
+func WatchDiffPath(diffOutput string, t time.Time) string {
+	if IsStdoutPath(diffOutput) {
+		return diffOutput
+	}
+	ext := filepath.Ext(diffOutput)
+	return strings.TrimSuffix(diffOutput, ext) + "-" + t.UTC().Format(dumpTimestampLayout) + ext
+}
@@ -0,0 +1,50 @@
+package ocidump
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunInterval(t *testing.T) {
+	logger = NewLogger(LogLevelSilent)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := 0
+	err := RunInterval(ctx, 10*time.Millisecond, func(ctx context.Context, startedAt time.Time) error {
+		runs++
+		if runs == 3 {
+			cancel()
+		}
+		// Failed runs do not stop the watch
+		return errors.New("discovery failed")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RunInterval() error = %v, expected context.Canceled", err)
+	}
+	if runs != 3 {
+		t.Errorf("RunInterval() ran %d times, expected 3", runs)
+	}
+}
+
+func TestWatchDiffPath(t *testing.T) {
+	at := time.Date(2025, 1, 15, 6, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	tests := []struct {
+		diffOutput string
+		expected   string
+	}{
+		{"", ""},
+		{"-", "-"},
+		{"changes.json", "changes-20250114T210000Z.json"},
+		{"reports/changes", "reports/changes-20250114T210000Z"},
+	}
+	for _, tt := range tests {
+		if got := WatchDiffPath(tt.diffOutput, at); got != tt.expected {
+			t.Errorf("WatchDiffPath(%q) = %q, expected %q", tt.diffOutput, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,1224 @@
+// gRPC service definition for OCI resource discovery and diff.
+//
+// Messages mirror the JSON dump format (pkg/ocidump/oci-resource-dump.schema.json)
+// and the diff result of --compare-files, so the same data is available over typed RPC.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/ocidump/v1/ocidump.proto
+
+package ocidumpv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Filters corresponds to the filters section of the configuration file (FilterConfig);
+// TestProtoFilters_MatchFilterConfig keeps the two in sync.
+type Filters struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	IncludeCompartments   []string               `protobuf:"bytes,1,rep,name=include_compartments,json=includeCompartments,proto3" json:"include_compartments,omitempty"`
+	ExcludeCompartments   []string               `protobuf:"bytes,2,rep,name=exclude_compartments,json=excludeCompartments,proto3" json:"exclude_compartments,omitempty"`
+	IncludeResourceTypes  []string               `protobuf:"bytes,3,rep,name=include_resource_types,json=includeResourceTypes,proto3" json:"include_resource_types,omitempty"` // CLI aliases (e.g. "vcns") or internal names
+	ExcludeResourceTypes  []string               `protobuf:"bytes,4,rep,name=exclude_resource_types,json=excludeResourceTypes,proto3" json:"exclude_resource_types,omitempty"`
+	NamePattern           string                 `protobuf:"bytes,5,opt,name=name_pattern,json=namePattern,proto3" json:"name_pattern,omitempty"`                        // Regular expression
+	ExcludeNamePattern    string                 `protobuf:"bytes,6,opt,name=exclude_name_pattern,json=excludeNamePattern,proto3" json:"exclude_name_pattern,omitempty"` // Regular expression
+	LifecycleStates       []string               `protobuf:"bytes,7,rep,name=lifecycle_states,json=lifecycleStates,proto3" json:"lifecycle_states,omitempty"`
+	CreatedAfter          string                 `protobuf:"bytes,8,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`                                          // RFC3339 or YYYY-MM-DD
+	CreatedBefore         string                 `protobuf:"bytes,9,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`                                       // RFC3339 or YYYY-MM-DD
+	CompartmentSubtrees   []string               `protobuf:"bytes,10,rep,name=compartment_subtrees,json=compartmentSubtrees,proto3" json:"compartment_subtrees,omitempty"`                    // Compartments included with all their descendants
+	Shapes                []string               `protobuf:"bytes,11,rep,name=shapes,proto3" json:"shapes,omitempty"`                                                                         // Shape glob patterns, e.g. VM.Standard.E4.*
+	Expression            string                 `protobuf:"bytes,12,opt,name=expression,proto3" json:"expression,omitempty"`                                                                 // JMESPath expression a resource must satisfy
+	MinSizeGb             float64                `protobuf:"fixed64,13,opt,name=min_size_gb,json=minSizeGb,proto3" json:"min_size_gb,omitempty"`                                              // 0 = no bound
+	MaxSizeGb             float64                `protobuf:"fixed64,14,opt,name=max_size_gb,json=maxSizeGb,proto3" json:"max_size_gb,omitempty"`                                              // 0 = no bound
+	Where                 map[string]string      `protobuf:"bytes,15,rep,name=where,proto3" json:"where,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional info values a resource must have
+	OcidFile              string                 `protobuf:"bytes,16,opt,name=ocid_file,json=ocidFile,proto3" json:"ocid_file,omitempty"`                                                     // Rejected in requests; the server does not read client-chosen files
+	NameGlob              string                 `protobuf:"bytes,17,opt,name=name_glob,json=nameGlob,proto3" json:"name_glob,omitempty"`                                                     // Glob pattern, e.g. prod-*
+	ExcludeNameGlob       string                 `protobuf:"bytes,18,opt,name=exclude_name_glob,json=excludeNameGlob,proto3" json:"exclude_name_glob,omitempty"`                              // Glob pattern
+	IgnoreCase            bool                   `protobuf:"varint,19,opt,name=ignore_case,json=ignoreCase,proto3" json:"ignore_case,omitempty"`                                              // Match name patterns and globs case-insensitively
+	SkipEmptyCompartments bool                   `protobuf:"varint,20,opt,name=skip_empty_compartments,json=skipEmptyCompartments,proto3" json:"skip_empty_compartments,omitempty"`           // Has no effect on the returned resources
+	Ocids                 []string               `protobuf:"bytes,21,rep,name=ocids,proto3" json:"ocids,omitempty"`                                                                           // OCIDs to restrict the resources to
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Filters) Reset() {
+	*x = Filters{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filters) ProtoMessage() {}
+
+func (x *Filters) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filters.ProtoReflect.Descriptor instead.
+func (*Filters) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Filters) GetIncludeCompartments() []string {
+	if x != nil {
+		return x.IncludeCompartments
+	}
+	return nil
+}
+
+func (x *Filters) GetExcludeCompartments() []string {
+	if x != nil {
+		return x.ExcludeCompartments
+	}
+	return nil
+}
+
+func (x *Filters) GetIncludeResourceTypes() []string {
+	if x != nil {
+		return x.IncludeResourceTypes
+	}
+	return nil
+}
+
+func (x *Filters) GetExcludeResourceTypes() []string {
+	if x != nil {
+		return x.ExcludeResourceTypes
+	}
+	return nil
+}
+
+func (x *Filters) GetNamePattern() string {
+	if x != nil {
+		return x.NamePattern
+	}
+	return ""
+}
+
+func (x *Filters) GetExcludeNamePattern() string {
+	if x != nil {
+		return x.ExcludeNamePattern
+	}
+	return ""
+}
+
+func (x *Filters) GetLifecycleStates() []string {
+	if x != nil {
+		return x.LifecycleStates
+	}
+	return nil
+}
+
+func (x *Filters) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *Filters) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *Filters) GetCompartmentSubtrees() []string {
+	if x != nil {
+		return x.CompartmentSubtrees
+	}
+	return nil
+}
+
+func (x *Filters) GetShapes() []string {
+	if x != nil {
+		return x.Shapes
+	}
+	return nil
+}
+
+func (x *Filters) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *Filters) GetMinSizeGb() float64 {
+	if x != nil {
+		return x.MinSizeGb
+	}
+	return 0
+}
+
+func (x *Filters) GetMaxSizeGb() float64 {
+	if x != nil {
+		return x.MaxSizeGb
+	}
+	return 0
+}
+
+func (x *Filters) GetWhere() map[string]string {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *Filters) GetOcidFile() string {
+	if x != nil {
+		return x.OcidFile
+	}
+	return ""
+}
+
+func (x *Filters) GetNameGlob() string {
+	if x != nil {
+		return x.NameGlob
+	}
+	return ""
+}
+
+func (x *Filters) GetExcludeNameGlob() string {
+	if x != nil {
+		return x.ExcludeNameGlob
+	}
+	return ""
+}
+
+func (x *Filters) GetIgnoreCase() bool {
+	if x != nil {
+		return x.IgnoreCase
+	}
+	return false
+}
+
+func (x *Filters) GetSkipEmptyCompartments() bool {
+	if x != nil {
+		return x.SkipEmptyCompartments
+	}
+	return false
+}
+
+func (x *Filters) GetOcids() []string {
+	if x != nil {
+		return x.Ocids
+	}
+	return nil
+}
+
+type DiscoverRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Filters        *Filters               `protobuf:"bytes,1,opt,name=filters,proto3" json:"filters,omitempty"`
+	Detail         bool                   `protobuf:"varint,2,opt,name=detail,proto3" json:"detail,omitempty"`                                       // Fetch per-resource details that require additional API calls
+	TimeoutSeconds int32                  `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // 0 uses the server default, which is also the maximum
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiscoverRequest) Reset() {
+	*x = DiscoverRequest{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverRequest) ProtoMessage() {}
+
+func (x *DiscoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverRequest.ProtoReflect.Descriptor instead.
+func (*DiscoverRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiscoverRequest) GetFilters() *Filters {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *DiscoverRequest) GetDetail() bool {
+	if x != nil {
+		return x.Detail
+	}
+	return false
+}
+
+func (x *DiscoverRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+type DiscoverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resources     []*Resource            `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	DiscoveredAt  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=discovered_at,json=discoveredAt,proto3" json:"discovered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoverResponse) Reset() {
+	*x = DiscoverResponse{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverResponse) ProtoMessage() {}
+
+func (x *DiscoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverResponse.ProtoReflect.Descriptor instead.
+func (*DiscoverResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DiscoverResponse) GetResources() []*Resource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *DiscoverResponse) GetDiscoveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DiscoveredAt
+	}
+	return nil
+}
+
+// Resource is a single discovered OCI resource (ResourceInfo).
+type Resource struct {
+	state           protoimpl.MessageState      `protogen:"open.v1"`
+	ResourceType    string                      `protobuf:"bytes,1,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	CompartmentName string                      `protobuf:"bytes,2,opt,name=compartment_name,json=compartmentName,proto3" json:"compartment_name,omitempty"`
+	ResourceName    string                      `protobuf:"bytes,3,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	Ocid            string                      `protobuf:"bytes,4,opt,name=ocid,proto3" json:"ocid,omitempty"`
+	CompartmentId   string                      `protobuf:"bytes,5,opt,name=compartment_id,json=compartmentId,proto3" json:"compartment_id,omitempty"`
+	AdditionalInfo  *structpb.Struct            `protobuf:"bytes,6,opt,name=additional_info,json=additionalInfo,proto3" json:"additional_info,omitempty"`
+	TimeCreated     string                      `protobuf:"bytes,7,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"` // RFC3339; empty when not reported by the service
+	LifecycleState  string                      `protobuf:"bytes,8,opt,name=lifecycle_state,json=lifecycleState,proto3" json:"lifecycle_state,omitempty"`
+	FreeformTags    map[string]string           `protobuf:"bytes,9,rep,name=freeform_tags,json=freeformTags,proto3" json:"freeform_tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DefinedTags     map[string]*structpb.Struct `protobuf:"bytes,10,rep,name=defined_tags,json=definedTags,proto3" json:"defined_tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Keyed by tag namespace
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Resource) Reset() {
+	*x = Resource{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resource) ProtoMessage() {}
+
+func (x *Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resource.ProtoReflect.Descriptor instead.
+func (*Resource) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Resource) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *Resource) GetCompartmentName() string {
+	if x != nil {
+		return x.CompartmentName
+	}
+	return ""
+}
+
+func (x *Resource) GetResourceName() string {
+	if x != nil {
+		return x.ResourceName
+	}
+	return ""
+}
+
+func (x *Resource) GetOcid() string {
+	if x != nil {
+		return x.Ocid
+	}
+	return ""
+}
+
+func (x *Resource) GetCompartmentId() string {
+	if x != nil {
+		return x.CompartmentId
+	}
+	return ""
+}
+
+func (x *Resource) GetAdditionalInfo() *structpb.Struct {
+	if x != nil {
+		return x.AdditionalInfo
+	}
+	return nil
+}
+
+func (x *Resource) GetTimeCreated() string {
+	if x != nil {
+		return x.TimeCreated
+	}
+	return ""
+}
+
+func (x *Resource) GetLifecycleState() string {
+	if x != nil {
+		return x.LifecycleState
+	}
+	return ""
+}
+
+func (x *Resource) GetFreeformTags() map[string]string {
+	if x != nil {
+		return x.FreeformTags
+	}
+	return nil
+}
+
+func (x *Resource) GetDefinedTags() map[string]*structpb.Struct {
+	if x != nil {
+		return x.DefinedTags
+	}
+	return nil
+}
+
+type DiffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldResources  []*Resource            `protobuf:"bytes,1,rep,name=old_resources,json=oldResources,proto3" json:"old_resources,omitempty"`
+	NewResources  []*Resource            `protobuf:"bytes,2,rep,name=new_resources,json=newResources,proto3" json:"new_resources,omitempty"`
+	Detailed      bool                   `protobuf:"varint,3,opt,name=detailed,proto3" json:"detailed,omitempty"` // Include unchanged resources
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffRequest) Reset() {
+	*x = DiffRequest{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRequest) ProtoMessage() {}
+
+func (x *DiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRequest.ProtoReflect.Descriptor instead.
+func (*DiffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DiffRequest) GetOldResources() []*Resource {
+	if x != nil {
+		return x.OldResources
+	}
+	return nil
+}
+
+func (x *DiffRequest) GetNewResources() []*Resource {
+	if x != nil {
+		return x.NewResources
+	}
+	return nil
+}
+
+func (x *DiffRequest) GetDetailed() bool {
+	if x != nil {
+		return x.Detailed
+	}
+	return false
+}
+
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      *structpb.Value        `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      *structpb.Value        `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOldValue() *structpb.Value {
+	if x != nil {
+		return x.OldValue
+	}
+	return nil
+}
+
+func (x *FieldChange) GetNewValue() *structpb.Value {
+	if x != nil {
+		return x.NewValue
+	}
+	return nil
+}
+
+type ModifiedResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      *Resource              `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModifiedResource) Reset() {
+	*x = ModifiedResource{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifiedResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifiedResource) ProtoMessage() {}
+
+func (x *ModifiedResource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifiedResource.ProtoReflect.Descriptor instead.
+func (*ModifiedResource) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ModifiedResource) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *ModifiedResource) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// MovedResource is a resource moved to another compartment; changes lists the other changed fields.
+type MovedResource struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Resource            *Resource              `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	FromCompartmentId   string                 `protobuf:"bytes,2,opt,name=from_compartment_id,json=fromCompartmentId,proto3" json:"from_compartment_id,omitempty"`
+	FromCompartmentName string                 `protobuf:"bytes,3,opt,name=from_compartment_name,json=fromCompartmentName,proto3" json:"from_compartment_name,omitempty"`
+	ToCompartmentId     string                 `protobuf:"bytes,4,opt,name=to_compartment_id,json=toCompartmentId,proto3" json:"to_compartment_id,omitempty"`
+	ToCompartmentName   string                 `protobuf:"bytes,5,opt,name=to_compartment_name,json=toCompartmentName,proto3" json:"to_compartment_name,omitempty"`
+	Changes             []*FieldChange         `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *MovedResource) Reset() {
+	*x = MovedResource{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovedResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovedResource) ProtoMessage() {}
+
+func (x *MovedResource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovedResource.ProtoReflect.Descriptor instead.
+func (*MovedResource) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MovedResource) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *MovedResource) GetFromCompartmentId() string {
+	if x != nil {
+		return x.FromCompartmentId
+	}
+	return ""
+}
+
+func (x *MovedResource) GetFromCompartmentName() string {
+	if x != nil {
+		return x.FromCompartmentName
+	}
+	return ""
+}
+
+func (x *MovedResource) GetToCompartmentId() string {
+	if x != nil {
+		return x.ToCompartmentId
+	}
+	return ""
+}
+
+func (x *MovedResource) GetToCompartmentName() string {
+	if x != nil {
+		return x.ToCompartmentName
+	}
+	return ""
+}
+
+func (x *MovedResource) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type DiffStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         int32                  `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed       int32                  `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	Modified      int32                  `protobuf:"varint,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,4,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Moved         int32                  `protobuf:"varint,5,opt,name=moved,proto3" json:"moved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffStats) Reset() {
+	*x = DiffStats{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffStats) ProtoMessage() {}
+
+func (x *DiffStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffStats.ProtoReflect.Descriptor instead.
+func (*DiffStats) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DiffStats) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *DiffStats) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+func (x *DiffStats) GetModified() int32 {
+	if x != nil {
+		return x.Modified
+	}
+	return 0
+}
+
+func (x *DiffStats) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *DiffStats) GetMoved() int32 {
+	if x != nil {
+		return x.Moved
+	}
+	return 0
+}
+
+type DiffSummary struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TotalOld       int32                  `protobuf:"varint,1,opt,name=total_old,json=totalOld,proto3" json:"total_old,omitempty"`
+	TotalNew       int32                  `protobuf:"varint,2,opt,name=total_new,json=totalNew,proto3" json:"total_new,omitempty"`
+	Added          int32                  `protobuf:"varint,3,opt,name=added,proto3" json:"added,omitempty"`
+	Removed        int32                  `protobuf:"varint,4,opt,name=removed,proto3" json:"removed,omitempty"`
+	Modified       int32                  `protobuf:"varint,5,opt,name=modified,proto3" json:"modified,omitempty"`
+	Unchanged      int32                  `protobuf:"varint,6,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	ByResourceType map[string]*DiffStats  `protobuf:"bytes,7,rep,name=by_resource_type,json=byResourceType,proto3" json:"by_resource_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ByOriginator   map[string]*DiffStats  `protobuf:"bytes,8,rep,name=by_originator,json=byOriginator,proto3" json:"by_originator,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Unverified     int32                  `protobuf:"varint,9,opt,name=unverified,proto3" json:"unverified,omitempty"` // Terraform state resources the compared dump does not cover
+	Moved          int32                  `protobuf:"varint,10,opt,name=moved,proto3" json:"moved,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiffSummary) Reset() {
+	*x = DiffSummary{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSummary) ProtoMessage() {}
+
+func (x *DiffSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSummary.ProtoReflect.Descriptor instead.
+func (*DiffSummary) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DiffSummary) GetTotalOld() int32 {
+	if x != nil {
+		return x.TotalOld
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetTotalNew() int32 {
+	if x != nil {
+		return x.TotalNew
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetModified() int32 {
+	if x != nil {
+		return x.Modified
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetByResourceType() map[string]*DiffStats {
+	if x != nil {
+		return x.ByResourceType
+	}
+	return nil
+}
+
+func (x *DiffSummary) GetByOriginator() map[string]*DiffStats {
+	if x != nil {
+		return x.ByOriginator
+	}
+	return nil
+}
+
+func (x *DiffSummary) GetUnverified() int32 {
+	if x != nil {
+		return x.Unverified
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetMoved() int32 {
+	if x != nil {
+		return x.Moved
+	}
+	return 0
+}
+
+type DiffResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *DiffSummary           `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	Added         []*Resource            `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`
+	Removed       []*Resource            `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+	Modified      []*ModifiedResource    `protobuf:"bytes,4,rep,name=modified,proto3" json:"modified,omitempty"`
+	Unchanged     []*Resource            `protobuf:"bytes,5,rep,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Unverified    []*Resource            `protobuf:"bytes,6,rep,name=unverified,proto3" json:"unverified,omitempty"`
+	Moved         []*MovedResource       `protobuf:"bytes,7,rep,name=moved,proto3" json:"moved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffResponse) Reset() {
+	*x = DiffResponse{}
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffResponse) ProtoMessage() {}
+
+func (x *DiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ocidump_v1_ocidump_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffResponse.ProtoReflect.Descriptor instead.
+func (*DiffResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ocidump_v1_ocidump_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DiffResponse) GetSummary() *DiffSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetAdded() []*Resource {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetRemoved() []*Resource {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetModified() []*ModifiedResource {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetUnchanged() []*Resource {
+	if x != nil {
+		return x.Unchanged
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetUnverified() []*Resource {
+	if x != nil {
+		return x.Unverified
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetMoved() []*MovedResource {
+	if x != nil {
+		return x.Moved
+	}
+	return nil
+}
+
+var File_proto_ocidump_v1_ocidump_proto protoreflect.FileDescriptor
+
+const file_proto_ocidump_v1_ocidump_proto_rawDesc = "" +
+	"\n" +
+	"\x1eproto/ocidump/v1/ocidump.proto\x12\n" +
+	"ocidump.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x97\a\n" +
+	"\aFilters\x121\n" +
+	"\x14include_compartments\x18\x01 \x03(\tR\x13includeCompartments\x121\n" +
+	"\x14exclude_compartments\x18\x02 \x03(\tR\x13excludeCompartments\x124\n" +
+	"\x16include_resource_types\x18\x03 \x03(\tR\x14includeResourceTypes\x124\n" +
+	"\x16exclude_resource_types\x18\x04 \x03(\tR\x14excludeResourceTypes\x12!\n" +
+	"\fname_pattern\x18\x05 \x01(\tR\vnamePattern\x120\n" +
+	"\x14exclude_name_pattern\x18\x06 \x01(\tR\x12excludeNamePattern\x12)\n" +
+	"\x10lifecycle_states\x18\a \x03(\tR\x0flifecycleStates\x12#\n" +
+	"\rcreated_after\x18\b \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\t \x01(\tR\rcreatedBefore\x121\n" +
+	"\x14compartment_subtrees\x18\n" +
+	" \x03(\tR\x13compartmentSubtrees\x12\x16\n" +
+	"\x06shapes\x18\v \x03(\tR\x06shapes\x12\x1e\n" +
+	"\n" +
+	"expression\x18\f \x01(\tR\n" +
+	"expression\x12\x1e\n" +
+	"\vmin_size_gb\x18\r \x01(\x01R\tminSizeGb\x12\x1e\n" +
+	"\vmax_size_gb\x18\x0e \x01(\x01R\tmaxSizeGb\x124\n" +
+	"\x05where\x18\x0f \x03(\v2\x1e.ocidump.v1.Filters.WhereEntryR\x05where\x12\x1b\n" +
+	"\tocid_file\x18\x10 \x01(\tR\bocidFile\x12\x1b\n" +
+	"\tname_glob\x18\x11 \x01(\tR\bnameGlob\x12*\n" +
+	"\x11exclude_name_glob\x18\x12 \x01(\tR\x0fexcludeNameGlob\x12\x1f\n" +
+	"\vignore_case\x18\x13 \x01(\bR\n" +
+	"ignoreCase\x126\n" +
+	"\x17skip_empty_compartments\x18\x14 \x01(\bR\x15skipEmptyCompartments\x12\x14\n" +
+	"\x05ocids\x18\x15 \x03(\tR\x05ocids\x1a8\n" +
+	"\n" +
+	"WhereEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x81\x01\n" +
+	"\x0fDiscoverRequest\x12-\n" +
+	"\afilters\x18\x01 \x01(\v2\x13.ocidump.v1.FiltersR\afilters\x12\x16\n" +
+	"\x06detail\x18\x02 \x01(\bR\x06detail\x12'\n" +
+	"\x0ftimeout_seconds\x18\x03 \x01(\x05R\x0etimeoutSeconds\"\x87\x01\n" +
+	"\x10DiscoverResponse\x122\n" +
+	"\tresources\x18\x01 \x03(\v2\x14.ocidump.v1.ResourceR\tresources\x12?\n" +
+	"\rdiscovered_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fdiscoveredAt\"\xf9\x04\n" +
+	"\bResource\x12#\n" +
+	"\rresource_type\x18\x01 \x01(\tR\fresourceType\x12)\n" +
+	"\x10compartment_name\x18\x02 \x01(\tR\x0fcompartmentName\x12#\n" +
+	"\rresource_name\x18\x03 \x01(\tR\fresourceName\x12\x12\n" +
+	"\x04ocid\x18\x04 \x01(\tR\x04ocid\x12%\n" +
+	"\x0ecompartment_id\x18\x05 \x01(\tR\rcompartmentId\x12@\n" +
+	"\x0fadditional_info\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x0eadditionalInfo\x12!\n" +
+	"\ftime_created\x18\a \x01(\tR\vtimeCreated\x12'\n" +
+	"\x0flifecycle_state\x18\b \x01(\tR\x0elifecycleState\x12K\n" +
+	"\rfreeform_tags\x18\t \x03(\v2&.ocidump.v1.Resource.FreeformTagsEntryR\ffreeformTags\x12H\n" +
+	"\fdefined_tags\x18\n" +
+	" \x03(\v2%.ocidump.v1.Resource.DefinedTagsEntryR\vdefinedTags\x1a?\n" +
+	"\x11FreeformTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aW\n" +
+	"\x10DefinedTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05value:\x028\x01\"\x9f\x01\n" +
+	"\vDiffRequest\x129\n" +
+	"\rold_resources\x18\x01 \x03(\v2\x14.ocidump.v1.ResourceR\foldResources\x129\n" +
+	"\rnew_resources\x18\x02 \x03(\v2\x14.ocidump.v1.ResourceR\fnewResources\x12\x1a\n" +
+	"\bdetailed\x18\x03 \x01(\bR\bdetailed\"\x8d\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x123\n" +
+	"\told_value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\boldValue\x123\n" +
+	"\tnew_value\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\bnewValue\"w\n" +
+	"\x10ModifiedResource\x120\n" +
+	"\bresource\x18\x01 \x01(\v2\x14.ocidump.v1.ResourceR\bresource\x121\n" +
+	"\achanges\x18\x02 \x03(\v2\x17.ocidump.v1.FieldChangeR\achanges\"\xb4\x02\n" +
+	"\rMovedResource\x120\n" +
+	"\bresource\x18\x01 \x01(\v2\x14.ocidump.v1.ResourceR\bresource\x12.\n" +
+	"\x13from_compartment_id\x18\x02 \x01(\tR\x11fromCompartmentId\x122\n" +
+	"\x15from_compartment_name\x18\x03 \x01(\tR\x13fromCompartmentName\x12*\n" +
+	"\x11to_compartment_id\x18\x04 \x01(\tR\x0ftoCompartmentId\x12.\n" +
+	"\x13to_compartment_name\x18\x05 \x01(\tR\x11toCompartmentName\x121\n" +
+	"\achanges\x18\x06 \x03(\v2\x17.ocidump.v1.FieldChangeR\achanges\"\x8b\x01\n" +
+	"\tDiffStats\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x05R\x05added\x12\x18\n" +
+	"\aremoved\x18\x02 \x01(\x05R\aremoved\x12\x1a\n" +
+	"\bmodified\x18\x03 \x01(\x05R\bmodified\x12\x1c\n" +
+	"\tunchanged\x18\x04 \x01(\x05R\tunchanged\x12\x14\n" +
+	"\x05moved\x18\x05 \x01(\x05R\x05moved\"\xc0\x04\n" +
+	"\vDiffSummary\x12\x1b\n" +
+	"\ttotal_old\x18\x01 \x01(\x05R\btotalOld\x12\x1b\n" +
+	"\ttotal_new\x18\x02 \x01(\x05R\btotalNew\x12\x14\n" +
+	"\x05added\x18\x03 \x01(\x05R\x05added\x12\x18\n" +
+	"\aremoved\x18\x04 \x01(\x05R\aremoved\x12\x1a\n" +
+	"\bmodified\x18\x05 \x01(\x05R\bmodified\x12\x1c\n" +
+	"\tunchanged\x18\x06 \x01(\x05R\tunchanged\x12U\n" +
+	"\x10by_resource_type\x18\a \x03(\v2+.ocidump.v1.DiffSummary.ByResourceTypeEntryR\x0ebyResourceType\x12N\n" +
+	"\rby_originator\x18\b \x03(\v2).ocidump.v1.DiffSummary.ByOriginatorEntryR\fbyOriginator\x12\x1e\n" +
+	"\n" +
+	"unverified\x18\t \x01(\x05R\n" +
+	"unverified\x12\x14\n" +
+	"\x05moved\x18\n" +
+	" \x01(\x05R\x05moved\x1aX\n" +
+	"\x13ByResourceTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.ocidump.v1.DiffStatsR\x05value:\x028\x01\x1aV\n" +
+	"\x11ByOriginatorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.ocidump.v1.DiffStatsR\x05value:\x028\x01\"\xf2\x02\n" +
+	"\fDiffResponse\x121\n" +
+	"\asummary\x18\x01 \x01(\v2\x17.ocidump.v1.DiffSummaryR\asummary\x12*\n" +
+	"\x05added\x18\x02 \x03(\v2\x14.ocidump.v1.ResourceR\x05added\x12.\n" +
+	"\aremoved\x18\x03 \x03(\v2\x14.ocidump.v1.ResourceR\aremoved\x128\n" +
+	"\bmodified\x18\x04 \x03(\v2\x1c.ocidump.v1.ModifiedResourceR\bmodified\x122\n" +
+	"\tunchanged\x18\x05 \x03(\v2\x14.ocidump.v1.ResourceR\tunchanged\x124\n" +
+	"\n" +
+	"unverified\x18\x06 \x03(\v2\x14.ocidump.v1.ResourceR\n" +
+	"unverified\x12/\n" +
+	"\x05moved\x18\a \x03(\v2\x19.ocidump.v1.MovedResourceR\x05moved2\xdf\x01\n" +
+	"\x13ResourceDumpService\x12E\n" +
+	"\bDiscover\x12\x1b.ocidump.v1.DiscoverRequest\x1a\x1c.ocidump.v1.DiscoverResponse\x12F\n" +
+	"\x0fStreamResources\x12\x1b.ocidump.v1.DiscoverRequest\x1a\x14.ocidump.v1.Resource0\x01\x129\n" +
+	"\x04Diff\x12\x17.ocidump.v1.DiffRequest\x1a\x18.ocidump.v1.DiffResponseB.Z,oci-resource-dump/proto/ocidump/v1;ocidumpv1b\x06proto3"
+
+var (
+	file_proto_ocidump_v1_ocidump_proto_rawDescOnce sync.Once
+	file_proto_ocidump_v1_ocidump_proto_rawDescData []byte
+)
+
+func file_proto_ocidump_v1_ocidump_proto_rawDescGZIP() []byte {
+	file_proto_ocidump_v1_ocidump_proto_rawDescOnce.Do(func() {
+		file_proto_ocidump_v1_ocidump_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_ocidump_v1_ocidump_proto_rawDesc), len(file_proto_ocidump_v1_ocidump_proto_rawDesc)))
+	})
+	return file_proto_ocidump_v1_ocidump_proto_rawDescData
+}
+
+var file_proto_ocidump_v1_ocidump_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_ocidump_v1_ocidump_proto_goTypes = []any{
+	(*Filters)(nil),               // 0: ocidump.v1.Filters
+	(*DiscoverRequest)(nil),       // 1: ocidump.v1.DiscoverRequest
+	(*DiscoverResponse)(nil),      // 2: ocidump.v1.DiscoverResponse
+	(*Resource)(nil),              // 3: ocidump.v1.Resource
+	(*DiffRequest)(nil),           // 4: ocidump.v1.DiffRequest
+	(*FieldChange)(nil),           // 5: ocidump.v1.FieldChange
+	(*ModifiedResource)(nil),      // 6: ocidump.v1.ModifiedResource
+	(*MovedResource)(nil),         // 7: ocidump.v1.MovedResource
+	(*DiffStats)(nil),             // 8: ocidump.v1.DiffStats
+	(*DiffSummary)(nil),           // 9: ocidump.v1.DiffSummary
+	(*DiffResponse)(nil),          // 10: ocidump.v1.DiffResponse
+	nil,                           // 11: ocidump.v1.Filters.WhereEntry
+	nil,                           // 12: ocidump.v1.Resource.FreeformTagsEntry
+	nil,                           // 13: ocidump.v1.Resource.DefinedTagsEntry
+	nil,                           // 14: ocidump.v1.DiffSummary.ByResourceTypeEntry
+	nil,                           // 15: ocidump.v1.DiffSummary.ByOriginatorEntry
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 17: google.protobuf.Struct
+	(*structpb.Value)(nil),        // 18: google.protobuf.Value
+}
+var file_proto_ocidump_v1_ocidump_proto_depIdxs = []int32{
+	11, // 0: ocidump.v1.Filters.where:type_name -> ocidump.v1.Filters.WhereEntry
+	0,  // 1: ocidump.v1.DiscoverRequest.filters:type_name -> ocidump.v1.Filters
+	3,  // 2: ocidump.v1.DiscoverResponse.resources:type_name -> ocidump.v1.Resource
+	16, // 3: ocidump.v1.DiscoverResponse.discovered_at:type_name -> google.protobuf.Timestamp
+	17, // 4: ocidump.v1.Resource.additional_info:type_name -> google.protobuf.Struct
+	12, // 5: ocidump.v1.Resource.freeform_tags:type_name -> ocidump.v1.Resource.FreeformTagsEntry
+	13, // 6: ocidump.v1.Resource.defined_tags:type_name -> ocidump.v1.Resource.DefinedTagsEntry
+	3,  // 7: ocidump.v1.DiffRequest.old_resources:type_name -> ocidump.v1.Resource
+	3,  // 8: ocidump.v1.DiffRequest.new_resources:type_name -> ocidump.v1.Resource
+	18, // 9: ocidump.v1.FieldChange.old_value:type_name -> google.protobuf.Value
+	18, // 10: ocidump.v1.FieldChange.new_value:type_name -> google.protobuf.Value
+	3,  // 11: ocidump.v1.ModifiedResource.resource:type_name -> ocidump.v1.Resource
+	5,  // 12: ocidump.v1.ModifiedResource.changes:type_name -> ocidump.v1.FieldChange
+	3,  // 13: ocidump.v1.MovedResource.resource:type_name -> ocidump.v1.Resource
+	5,  // 14: ocidump.v1.MovedResource.changes:type_name -> ocidump.v1.FieldChange
+	14, // 15: ocidump.v1.DiffSummary.by_resource_type:type_name -> ocidump.v1.DiffSummary.ByResourceTypeEntry
+	15, // 16: ocidump.v1.DiffSummary.by_originator:type_name -> ocidump.v1.DiffSummary.ByOriginatorEntry
+	9,  // 17: ocidump.v1.DiffResponse.summary:type_name -> ocidump.v1.DiffSummary
+	3,  // 18: ocidump.v1.DiffResponse.added:type_name -> ocidump.v1.Resource
+	3,  // 19: ocidump.v1.DiffResponse.removed:type_name -> ocidump.v1.Resource
+	6,  // 20: ocidump.v1.DiffResponse.modified:type_name -> ocidump.v1.ModifiedResource
+	3,  // 21: ocidump.v1.DiffResponse.unchanged:type_name -> ocidump.v1.Resource
+	3,  // 22: ocidump.v1.DiffResponse.unverified:type_name -> ocidump.v1.Resource
+	7,  // 23: ocidump.v1.DiffResponse.moved:type_name -> ocidump.v1.MovedResource
+	17, // 24: ocidump.v1.Resource.DefinedTagsEntry.value:type_name -> google.protobuf.Struct
+	8,  // 25: ocidump.v1.DiffSummary.ByResourceTypeEntry.value:type_name -> ocidump.v1.DiffStats
+	8,  // 26: ocidump.v1.DiffSummary.ByOriginatorEntry.value:type_name -> ocidump.v1.DiffStats
+	1,  // 27: ocidump.v1.ResourceDumpService.Discover:input_type -> ocidump.v1.DiscoverRequest
+	1,  // 28: ocidump.v1.ResourceDumpService.StreamResources:input_type -> ocidump.v1.DiscoverRequest
+	4,  // 29: ocidump.v1.ResourceDumpService.Diff:input_type -> ocidump.v1.DiffRequest
+	2,  // 30: ocidump.v1.ResourceDumpService.Discover:output_type -> ocidump.v1.DiscoverResponse
+	3,  // 31: ocidump.v1.ResourceDumpService.StreamResources:output_type -> ocidump.v1.Resource
+	10, // 32: ocidump.v1.ResourceDumpService.Diff:output_type -> ocidump.v1.DiffResponse
+	30, // [30:33] is the sub-list for method output_type
+	27, // [27:30] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_proto_ocidump_v1_ocidump_proto_init() }
+func file_proto_ocidump_v1_ocidump_proto_init() {
+	if File_proto_ocidump_v1_ocidump_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_ocidump_v1_ocidump_proto_rawDesc), len(file_proto_ocidump_v1_ocidump_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_ocidump_v1_ocidump_proto_goTypes,
+		DependencyIndexes: file_proto_ocidump_v1_ocidump_proto_depIdxs,
+		MessageInfos:      file_proto_ocidump_v1_ocidump_proto_msgTypes,
+	}.Build()
+	File_proto_ocidump_v1_ocidump_proto = out.File
+	file_proto_ocidump_v1_ocidump_proto_goTypes = nil
+	file_proto_ocidump_v1_ocidump_proto_depIdxs = nil
+}
@@ -0,0 +1,214 @@
+// gRPC service definition for OCI resource discovery and diff.
+//
+// Messages mirror the JSON dump format (pkg/ocidump/oci-resource-dump.schema.json)
+// and the diff result of --compare-files, so the same data is available over typed RPC.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/ocidump/v1/ocidump.proto
+
+package ocidumpv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ResourceDumpService_Discover_FullMethodName        = "/ocidump.v1.ResourceDumpService/Discover"
+	ResourceDumpService_StreamResources_FullMethodName = "/ocidump.v1.ResourceDumpService/StreamResources"
+	ResourceDumpService_Diff_FullMethodName            = "/ocidump.v1.ResourceDumpService/Diff"
+)
+
+// ResourceDumpServiceClient is the client API for ResourceDumpService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ResourceDumpServiceClient interface {
+	// Discover runs a discovery and returns all matching resources.
+	Discover(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*DiscoverResponse, error)
+	// StreamResources runs a discovery and streams each resource as soon as it is found,
+	// in the same order as the ndjson output format.
+	StreamResources(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Resource], error)
+	// Diff compares two resource lists by OCID.
+	Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error)
+}
+
+type resourceDumpServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResourceDumpServiceClient(cc grpc.ClientConnInterface) ResourceDumpServiceClient {
+	return &resourceDumpServiceClient{cc}
+}
+
+func (c *resourceDumpServiceClient) Discover(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*DiscoverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscoverResponse)
+	err := c.cc.Invoke(ctx, ResourceDumpService_Discover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceDumpServiceClient) StreamResources(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Resource], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResourceDumpService_ServiceDesc.Streams[0], ResourceDumpService_StreamResources_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DiscoverRequest, Resource]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResourceDumpService_StreamResourcesClient = grpc.ServerStreamingClient[Resource]
+
+func (c *resourceDumpServiceClient) Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffResponse)
+	err := c.cc.Invoke(ctx, ResourceDumpService_Diff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResourceDumpServiceServer is the server API for ResourceDumpService service.
+// All implementations must embed UnimplementedResourceDumpServiceServer
+// for forward compatibility.
+type ResourceDumpServiceServer interface {
+	// Discover runs a discovery and returns all matching resources.
+	Discover(context.Context, *DiscoverRequest) (*DiscoverResponse, error)
+	// StreamResources runs a discovery and streams each resource as soon as it is found,
+	// in the same order as the ndjson output format.
+	StreamResources(*DiscoverRequest, grpc.ServerStreamingServer[Resource]) error
+	// Diff compares two resource lists by OCID.
+	Diff(context.Context, *DiffRequest) (*DiffResponse, error)
+	mustEmbedUnimplementedResourceDumpServiceServer()
+}
+
+// UnimplementedResourceDumpServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedResourceDumpServiceServer struct{}
+
+func (UnimplementedResourceDumpServiceServer) Discover(context.Context, *DiscoverRequest) (*DiscoverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Discover not implemented")
+}
+func (UnimplementedResourceDumpServiceServer) StreamResources(*DiscoverRequest, grpc.ServerStreamingServer[Resource]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamResources not implemented")
+}
+func (UnimplementedResourceDumpServiceServer) Diff(context.Context, *DiffRequest) (*DiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
+func (UnimplementedResourceDumpServiceServer) mustEmbedUnimplementedResourceDumpServiceServer() {}
+func (UnimplementedResourceDumpServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeResourceDumpServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResourceDumpServiceServer will
+// result in compilation errors.
+type UnsafeResourceDumpServiceServer interface {
+	mustEmbedUnimplementedResourceDumpServiceServer()
+}
+
+func RegisterResourceDumpServiceServer(s grpc.ServiceRegistrar, srv ResourceDumpServiceServer) {
+	// If the following call pancis, it indicates UnimplementedResourceDumpServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ResourceDumpService_ServiceDesc, srv)
+}
+
+func _ResourceDumpService_Discover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceDumpServiceServer).Discover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceDumpService_Discover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceDumpServiceServer).Discover(ctx, req.(*DiscoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceDumpService_StreamResources_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DiscoverRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourceDumpServiceServer).StreamResources(m, &grpc.GenericServerStream[DiscoverRequest, Resource]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResourceDumpService_StreamResourcesServer = grpc.ServerStreamingServer[Resource]
+
+func _ResourceDumpService_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceDumpServiceServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceDumpService_Diff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceDumpServiceServer).Diff(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResourceDumpService_ServiceDesc is the grpc.ServiceDesc for ResourceDumpService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResourceDumpService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ocidump.v1.ResourceDumpService",
+	HandlerType: (*ResourceDumpServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Discover",
+			Handler:    _ResourceDumpService_Discover_Handler,
+		},
+		{
+			MethodName: "Diff",
+			Handler:    _ResourceDumpService_Diff_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamResources",
+			Handler:       _ResourceDumpService_StreamResources_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/ocidump/v1/ocidump.proto",
+}